@@ -13,17 +13,20 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/dbng/dbngfakes"
 
 	"github.com/concourse/atc/api/buildserver/buildserverfakes"
 	"github.com/concourse/atc/api/containerserver/containerserverfakes"
+	"github.com/concourse/atc/api/eventserver/eventserverfakes"
 	"github.com/concourse/atc/api/jobserver/jobserverfakes"
 	"github.com/concourse/atc/api/pipes/pipesfakes"
 	"github.com/concourse/atc/api/resourceserver/resourceserverfakes"
 	"github.com/concourse/atc/api/teamserver/teamserverfakes"
 	"github.com/concourse/atc/api/workerserver/workerserverfakes"
+	"github.com/concourse/atc/artifactstore/artifactstorefakes"
 	"github.com/concourse/atc/auth/authfakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
@@ -44,12 +47,14 @@ var (
 	providerFactory               *authfakes.FakeProviderFactory
 	fakeEngine                    *enginefakes.FakeEngine
 	fakeWorkerClient              *workerfakes.FakeClient
+	fakeArtifactStore             *artifactstorefakes.FakeStore
 	teamServerDB                  *teamserverfakes.FakeTeamsDB
 	fakeVolumeFactory             *dbngfakes.FakeVolumeFactory
 	fakeContainerFactory          *dbngfakes.FakeContainerFactory
 	workerDB                      *workerserverfakes.FakeWorkerDB
 	containerDB                   *containerserverfakes.FakeContainerDB
 	pipeDB                        *pipesfakes.FakePipeDB
+	clusterEventsDB               *eventserverfakes.FakeClusterEventsDB
 	pipelineDBFactory             *dbfakes.FakePipelineDBFactory
 	teamDBFactory                 *dbfakes.FakeTeamDBFactory
 	dbTeamFactory                 *dbngfakes.FakeTeamFactory
@@ -111,6 +116,7 @@ var _ = BeforeEach(func() {
 	buildServerDB = new(buildserverfakes.FakeBuildsDB)
 	containerDB = new(containerserverfakes.FakeContainerDB)
 	pipeDB = new(pipesfakes.FakePipeDB)
+	clusterEventsDB = new(eventserverfakes.FakeClusterEventsDB)
 	pipelinesDB = new(dbfakes.FakePipelinesDB)
 	buildsDB = new(authfakes.FakeBuildsDB)
 
@@ -124,6 +130,7 @@ var _ = BeforeEach(func() {
 
 	fakeEngine = new(enginefakes.FakeEngine)
 	fakeWorkerClient = new(workerfakes.FakeClient)
+	fakeArtifactStore = new(artifactstorefakes.FakeStore)
 
 	fakeSchedulerFactory = new(jobserverfakes.FakeSchedulerFactory)
 	fakeScannerFactory = new(resourceserverfakes.FakeScannerFactory)
@@ -188,6 +195,7 @@ var _ = BeforeEach(func() {
 		containerDB,
 		pipeDB,
 		pipelinesDB,
+		clusterEventsDB,
 
 		peerAddr,
 		constructedEventHandler.Construct,
@@ -195,6 +203,8 @@ var _ = BeforeEach(func() {
 
 		fakeEngine,
 		fakeWorkerClient,
+		fakeArtifactStore,
+		atc.BaseResourceTypeVersions{"some-resource": "some-version"},
 
 		fakeSchedulerFactory,
 		fakeScannerFactory,
@@ -69,10 +69,23 @@ var _ = Describe("Auth API", func() {
 
 						Expect(body).To(MatchJSON(`{"type":"some type","value":"some value"}`))
 
-						expiration, teamName, isAdmin := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+						expiration, teamName, isAdmin, userName := fakeTokenGenerator.GenerateTokenArgsForCall(0)
 						Expect(expiration).To(BeTemporally("~", time.Now().Add(24*time.Hour), time.Minute))
 						Expect(teamName).To(Equal(savedTeam.Name))
 						Expect(isAdmin).To(Equal(savedTeam.Admin))
+						Expect(userName).To(BeEmpty())
+					})
+
+					Context("when the request uses basic auth", func() {
+						BeforeEach(func() {
+							request.Header.Del("Authorization")
+							request.SetBasicAuth("some-user", "some-password")
+						})
+
+						It("names the user in the token", func() {
+							_, _, _, userName := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+							Expect(userName).To(Equal("some-user"))
+						})
 					})
 				})
 
@@ -33,7 +33,10 @@ func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.Admin)
+	// basic auth identifies the user; a token exchanged for another doesn't
+	userName, _, _ := r.BasicAuth()
+
+	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(time.Now().Add(s.expire), team.Name, team.Admin, userName)
 	if err != nil {
 		logger.Error("generate-token", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/event"
 )
 
 var _ = Describe("Builds API", func() {
@@ -1329,6 +1331,171 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/builds/:build_id/artifacts", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/artifacts")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildByIDReturns(build, true, nil)
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+			})
+
+			Context("when not authenticated and the job is private", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+					build.GetPipelineReturns(db.SavedPipeline{Public: true}, nil)
+					build.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "job1", Public: false},
+						},
+					}, 1, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", false, true)
+				})
+
+				Context("when listing the artifacts succeeds", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.ListReturns([]string{"binary", "report"}, nil)
+					})
+
+					It("lists the build's artifacts", func() {
+						Expect(fakeArtifactStore.ListCallCount()).To(Equal(1))
+						Expect(fakeArtifactStore.ListArgsForCall(0)).To(Equal(42))
+					})
+
+					It("returns 200 with the artifact names", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{"name": "binary"},
+							{"name": "report"}
+						]`))
+					})
+				})
+
+				Context("when listing the artifacts fails", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.ListReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when build is not found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildByIDReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/artifacts/:artifact_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/artifacts/binary")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildByIDReturns(build, true, nil)
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+			})
+
+			Context("when authenticated, but not authorized", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-other-team", false, true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", false, true)
+				})
+
+				Context("when the artifact exists", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.OpenReturns(ioutil.NopCloser(bytes.NewBufferString("some-tar-bytes")), true, nil)
+					})
+
+					It("opens the named artifact of the build", func() {
+						Expect(fakeArtifactStore.OpenCallCount()).To(Equal(1))
+						buildID, name := fakeArtifactStore.OpenArgsForCall(0)
+						Expect(buildID).To(Equal(42))
+						Expect(name).To(Equal("binary"))
+					})
+
+					It("streams the tarball", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/x-tar"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("some-tar-bytes"))
+					})
+				})
+
+				Context("when the artifact does not exist", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.OpenReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when opening the artifact fails", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.OpenReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/plan", func() {
 		var publicPlan atc.PublicBuildPlan
 
@@ -1490,4 +1657,776 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
-})
+
+	Describe("GET /api/v1/builds/:build_id/timings", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/timings")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			var engineBuild *enginefakes.FakeBuild
+
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				buildsDB.GetBuildByIDReturns(build, true, nil)
+
+				engineBuild = new(enginefakes.FakeBuild)
+				fakeEngine.LookupBuildReturns(engineBuild, nil)
+			})
+
+			Context("when not authenticated and the job is private", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+					build.GetPipelineReturns(db.SavedPipeline{Public: true}, nil)
+					build.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "job1", Public: false},
+						},
+					}, 1, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", false, true)
+
+					plan := atc.Plan{
+						ID: "do-id",
+						Do: &atc.DoPlan{
+							{
+								ID:  "get-id",
+								Get: &atc.GetPlan{Name: "some-input", Resource: "some-resource"},
+							},
+							{
+								ID:   "task-id",
+								Task: &atc.TaskPlan{Name: "some-task"},
+							},
+						},
+					}
+
+					engineBuild.PublicPlanReturns(atc.PublicBuildPlan{
+						Schema: "exec.v2",
+						Plan:   plan.Public(),
+					}, nil)
+
+					build.GetEventsReturns([]event.Envelope{
+						envelope(event.StartStep{Time: 100, Origin: event.Origin{ID: "do-id"}, Step: "do"}),
+						envelope(event.StartStep{Time: 100, Origin: event.Origin{ID: "get-id"}, Step: "get"}),
+						envelope(event.ImageFetchPhase{StartTime: 100, EndTime: 103, Origin: event.Origin{ID: "get-id"}, Phase: "get"}),
+						envelope(event.StartGet{Time: 105, Origin: event.Origin{ID: "get-id"}}),
+						envelope(event.FinishStep{Time: 110, Origin: event.Origin{ID: "get-id"}, Step: "get", Status: atc.StatusSucceeded}),
+						envelope(event.StartStep{Time: 110, Origin: event.Origin{ID: "task-id"}, Step: "task"}),
+						envelope(event.StreamPhase{StartTime: 115.3, EndTime: 116, Origin: event.Origin{ID: "get-id"}, Direction: "out", Artifact: "some-input"}),
+						envelope(event.StreamPhase{StartTime: 116, EndTime: 117.6, Origin: event.Origin{ID: "get-id"}, Direction: "out", Artifact: "some-input"}),
+						envelope(event.StreamPhase{StartTime: 115, EndTime: 117, Origin: event.Origin{ID: "task-id"}, Direction: "in", Artifact: "some-input"}),
+						envelope(event.StartTask{Time: 120, Origin: event.Origin{ID: "task-id"}}),
+						envelope(event.FinishTask{Time: 150, Origin: event.Origin{ID: "task-id"}}),
+						envelope(event.FinishStep{Time: 151, Origin: event.Origin{ID: "task-id"}, Step: "task", Status: atc.StatusFailed}),
+						envelope(event.FinishStep{Time: 151, Origin: event.Origin{ID: "do-id"}, Step: "do", Status: atc.StatusFailed}),
+					}, nil)
+
+					build.CreateTimeReturns(time.Unix(93, 0))
+					build.StartTimeReturns(time.Unix(100, 0))
+
+					teamDB.FindContainersByDescriptorsReturns([]db.SavedContainer{
+						{
+							Container: db.Container{
+								ContainerIdentifier: db.ContainerIdentifier{PlanID: "task-id", Stage: db.ContainerStageCheck},
+								ContainerMetadata:   db.ContainerMetadata{WorkerName: "some-image-worker"},
+							},
+						},
+						{
+							Container: db.Container{
+								ContainerIdentifier: db.ContainerIdentifier{PlanID: "task-id", Stage: db.ContainerStageRun},
+								ContainerMetadata:   db.ContainerMetadata{WorkerName: "some-worker"},
+							},
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("only loads the events relevant to timings", func() {
+					Expect(build.GetEventsArgsForCall(0)).To(ContainElement(event.EventTypeStartStep))
+					Expect(build.GetEventsArgsForCall(0)).NotTo(ContainElement(event.EventTypeLog))
+				})
+
+				It("looks up the build's containers", func() {
+					Expect(teamDBFactory.GetTeamDBArgsForCall(teamDBFactory.GetTeamDBCallCount() - 1)).To(Equal("some-team"))
+					Expect(teamDB.FindContainersByDescriptorsArgsForCall(0).BuildID).To(Equal(42))
+				})
+
+				It("returns the timings of each step", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"build_id": 42,
+						"queued": 7,
+						"plan": {
+							"id": "do-id",
+							"step": "do",
+							"status": "failed",
+							"start_time": 100,
+							"end_time": 151,
+							"durations": {"total": 51, "queued": 0, "image_fetch": 0, "input_stream": 0, "run": 0, "output_stream": 0},
+							"steps": [
+								{
+									"id": "get-id",
+									"step": "get",
+									"name": "some-input",
+									"status": "succeeded",
+									"start_time": 100,
+									"end_time": 110,
+									"durations": {"total": 10, "queued": 2, "image_fetch": 3, "input_stream": 0, "run": 5, "output_stream": 2}
+								},
+								{
+									"id": "task-id",
+									"step": "task",
+									"name": "some-task",
+									"worker": "some-worker",
+									"status": "failed",
+									"start_time": 110,
+									"end_time": 151,
+									"durations": {"total": 41, "queued": 8, "image_fetch": 0, "input_stream": 2, "run": 30, "output_stream": 0}
+								}
+							]
+						}
+					}`))
+				})
+
+				Context("when getting the events fails", func() {
+					BeforeEach(func() {
+						build.GetEventsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildByIDReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/log", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/log" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			var fakeEventSource *dbfakes.FakeEventSource
+			var envelopes []event.Envelope
+
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				buildsDB.GetBuildByIDReturns(build, true, nil)
+
+				stdout := event.Origin{ID: "task-id", Source: event.OriginSourceStdout}
+				stderr := event.Origin{ID: "task-id", Source: event.OriginSourceStderr}
+				getStdout := event.Origin{ID: "get-id", Source: event.OriginSourceStdout}
+
+				envelopes = []event.Envelope{
+					envelope(event.Log{Origin: getStdout, Payload: "fetching\n", Lines: []event.LogLine{{Offset: 0, Time: 90}}}),
+					envelope(event.StartTask{Time: 100, Origin: event.Origin{ID: "task-id"}}),
+					envelope(event.Log{Origin: stdout, Payload: "\x1b[1mcompil", Lines: []event.LogLine{{Offset: 0, Time: 100}}}),
+					envelope(event.Log{Origin: stdout, Payload: "ing\x1b[0"}),
+					envelope(event.Log{Origin: stdout, Payload: "m...\nrunning\n", Lines: []event.LogLine{{Offset: 5, Time: 101.25}}}),
+					envelope(event.Log{Origin: stderr, Payload: "\x1b[31mFAIL\x1b[0m\n", Lines: []event.LogLine{{Offset: 0, Time: 103}}}),
+				}
+
+				fakeEventSource = new(dbfakes.FakeEventSource)
+				fakeEventSource.NextStub = func() (event.Envelope, error) {
+					call := fakeEventSource.NextCallCount() - 1
+					if call >= len(envelopes) {
+						return event.Envelope{}, db.ErrEndOfBuildEventStream
+					}
+
+					return envelopes[call], nil
+				}
+
+				build.EventsReturns(fakeEventSource, nil)
+			})
+
+			Context("when not authenticated and the job is private", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+					build.GetPipelineReturns(db.SavedPipeline{Public: true}, nil)
+					build.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "job1", Public: false},
+						},
+					}, 1, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", false, true)
+				})
+
+				It("returns 200 with the build's output as plain text", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("text/plain; charset=utf-8"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(string(body)).To(Equal("fetching\n\x1b[1mcompiling\x1b[0m...\nrunning\n\x1b[31mFAIL\x1b[0m\n"))
+				})
+
+				It("streams the events from the start and closes the source", func() {
+					_, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(build.EventsCallCount()).To(Equal(1))
+					Expect(build.EventsArgsForCall(0)).To(BeZero())
+
+					Eventually(fakeEventSource.CloseCallCount).Should(Equal(1))
+				})
+
+				Context("when filtered to a step's origin", func() {
+					BeforeEach(func() {
+						query = "?origin=task-id"
+					})
+
+					It("only returns that step's output", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(string(body)).To(Equal("\x1b[1mcompiling\x1b[0m...\nrunning\n\x1b[31mFAIL\x1b[0m\n"))
+					})
+				})
+
+				Context("when stripping ANSI escape sequences", func() {
+					BeforeEach(func() {
+						query = "?strip_ansi=true"
+					})
+
+					It("strips them, even when split across events", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(string(body)).To(Equal("fetching\ncompiling...\nrunning\nFAIL\n"))
+					})
+				})
+
+				Context("when prefixing timestamps", func() {
+					BeforeEach(func() {
+						query = "?timestamps=true&strip_ansi=true"
+					})
+
+					It("prefixes each line with the time it started being written", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(string(body)).To(Equal(
+							"[1970-01-01T00:01:30.000Z] fetching\n" +
+								"[1970-01-01T00:01:40.000Z] compiling...\n" +
+								"[1970-01-01T00:01:41.250Z] running\n" +
+								"[1970-01-01T00:01:43.000Z] FAIL\n",
+						))
+					})
+				})
+
+				Context("when steps running in parallel write partial lines", func() {
+					BeforeEach(func() {
+						getStdout := event.Origin{ID: "get-id", Source: event.OriginSourceStdout}
+						taskStdout := event.Origin{ID: "task-id", Source: event.OriginSourceStdout}
+						taskStderr := event.Origin{ID: "task-id", Source: event.OriginSourceStderr}
+
+						envelopes = []event.Envelope{
+							envelope(event.Log{Origin: getStdout, Payload: "downloading", Lines: []event.LogLine{{Offset: 0, Time: 90}}}),
+							envelope(event.Log{Origin: taskStdout, Payload: "compil", Lines: []event.LogLine{{Offset: 0, Time: 91}}}),
+							envelope(event.Log{Origin: taskStderr, Payload: "warn", Lines: []event.LogLine{{Offset: 0, Time: 92}}}),
+							envelope(event.Log{Origin: taskStdout, Payload: "ing\n"}),
+							envelope(event.Log{Origin: getStdout, Payload: "... done\nfetched", Lines: []event.LogLine{{Offset: 9, Time: 93}}}),
+							envelope(event.Log{Origin: taskStderr, Payload: "ing\n"}),
+						}
+
+						query = "?timestamps=true"
+					})
+
+					It("keeps each step's stdout and stderr lines whole, writing out the unfinished ones at the end", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(string(body)).To(Equal(
+							"[1970-01-01T00:01:31.000Z] compiling\n" +
+								"[1970-01-01T00:01:30.000Z] downloading... done\n" +
+								"[1970-01-01T00:01:32.000Z] warning\n" +
+								"[1970-01-01T00:01:33.000Z] fetched",
+						))
+					})
+				})
+
+				Context("when a line goes on for long without a newline", func() {
+					BeforeEach(func() {
+						stdout := event.Origin{ID: "task-id", Source: event.OriginSourceStdout}
+						progress := strings.Repeat("10%\r", 20*1024)
+
+						envelopes = []event.Envelope{
+							envelope(event.Log{Origin: stdout, Payload: progress, Lines: []event.LogLine{{Offset: 0, Time: 100}}}),
+							envelope(event.Log{Origin: stdout, Payload: progress}),
+							envelope(event.Log{Origin: stdout, Payload: "done\n"}),
+						}
+
+						query = "?timestamps=true"
+					})
+
+					It("writes it out in pieces, only prefixing its start", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(string(body)).To(Equal(
+							"[1970-01-01T00:01:40.000Z] " + strings.Repeat("10%\r", 40*1024) + "done\n",
+						))
+					})
+				})
+
+				Context("when the build's output was saved as an older version of the event", func() {
+					BeforeEach(func() {
+						envelopes = []event.Envelope{
+							envelope(event.LogV50{Origin: event.Origin{ID: "task-id", Source: event.OriginSourceStdout}, Payload: "old\noutput\n"}),
+						}
+
+						query = "?timestamps=true"
+					})
+
+					It("returns it without timestamps", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(string(body)).To(Equal("old\noutput\n"))
+					})
+				})
+
+				Context("when getting the events fails", func() {
+					BeforeEach(func() {
+						build.EventsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildByIDReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/comments", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/comments")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				buildsDB.GetBuildByIDReturns(build, true, nil)
+			})
+
+			Context("when not authenticated and the job is private", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+					build.GetPipelineReturns(db.SavedPipeline{Public: true}, nil)
+					build.GetConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "job1", Public: false},
+						},
+					}, 1, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", false, true)
+				})
+
+				Context("when getting the comments succeeds", func() {
+					BeforeEach(func() {
+						build.GetCommentsReturns([]db.BuildComment{
+							{ID: 1, BuildID: 42, TeamName: "some-team", Comment: "looks like a *flake*", CreatedAt: time.Unix(100, 0)},
+							{ID: 2, BuildID: 42, TeamName: "some-other-team", Comment: "it is not", CreatedAt: time.Unix(200, 0)},
+						}, nil)
+					})
+
+					It("returns 200 with the comments", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{"id": 1, "team_name": "some-team", "comment": "looks like a *flake*", "created_at": 100},
+							{"id": 2, "team_name": "some-other-team", "comment": "it is not", "created_at": 200}
+						]`))
+					})
+				})
+
+				Context("when getting the comments fails", func() {
+					BeforeEach(func() {
+						build.GetCommentsReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				buildsDB.GetBuildByIDReturns(nil, false, nil)
+			})
+
+			It("returns Not Found", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/builds/:build_id/comments", func() {
+		var (
+			requestBody string
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			requestBody = `{"comment":"looks like a *flake*"}`
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("POST", server.URL+"/api/v1/builds/42/comments", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build can be found", func() {
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.TeamNameReturns("some-team")
+				buildsDB.GetBuildByIDReturns(build, true, nil)
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+
+				It("does not save the comment", func() {
+					Expect(build.SaveCommentCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when authenticated as another team", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-other-team", false, true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authenticated as the build's team", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", false, true)
+
+					build.SaveCommentReturns(db.BuildComment{
+						ID:        3,
+						BuildID:   42,
+						TeamName:  "some-team",
+						Comment:   "looks like a *flake*",
+						CreatedAt: time.Unix(100, 0),
+					}, nil)
+				})
+
+				It("saves the comment as left by the team", func() {
+					Expect(build.SaveCommentCallCount()).To(Equal(1))
+
+					teamName, author, comment := build.SaveCommentArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(author).To(BeEmpty())
+					Expect(comment).To(Equal("looks like a *flake*"))
+				})
+
+				Context("when the token names the user", func() {
+					BeforeEach(func() {
+						userContextReader.GetUserNameReturns("some-user", true)
+
+						build.SaveCommentReturns(db.BuildComment{
+							ID:        3,
+							BuildID:   42,
+							TeamName:  "some-team",
+							Author:    "some-user",
+							Comment:   "looks like a *flake*",
+							CreatedAt: time.Unix(100, 0),
+						}, nil)
+					})
+
+					It("saves the comment as left by the user", func() {
+						Expect(build.SaveCommentCallCount()).To(Equal(1))
+
+						_, author, _ := build.SaveCommentArgsForCall(0)
+						Expect(author).To(Equal("some-user"))
+					})
+
+					It("returns the author with the saved comment", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{"id": 3, "team_name": "some-team", "author": "some-user", "comment": "looks like a *flake*", "created_at": 100}`))
+					})
+				})
+
+				It("returns 201 with the saved comment", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{"id": 3, "team_name": "some-team", "comment": "looks like a *flake*", "created_at": 100}`))
+				})
+
+				Context("when the comment is blank", func() {
+					BeforeEach(func() {
+						requestBody = `{"comment":"  "}`
+					})
+
+					It("returns 400 without saving it", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(build.SaveCommentCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the request is malformed", func() {
+					BeforeEach(func() {
+						requestBody = `{`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when saving the comment fails", func() {
+					BeforeEach(func() {
+						build.SaveCommentReturns(db.BuildComment{}, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/annotations", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/annotations")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and the build is found", func() {
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				buildsDB.GetBuildByIDReturns(build, true, nil)
+
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when getting the annotations succeeds", func() {
+				BeforeEach(func() {
+					build.GetAnnotationsReturns([]db.BuildAnnotation{
+						{PlanID: "some-plan-id", StepName: "unit", Name: "failed test", Value: "TestSomething"},
+					}, nil)
+				})
+
+				It("returns 200 with the annotations", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{"plan_id": "some-plan-id", "step": "unit", "name": "failed test", "value": "TestSomething"}
+					]`))
+				})
+			})
+
+			Context("when getting the annotations fails", func() {
+				BeforeEach(func() {
+					build.GetAnnotationsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/tests", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/tests")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and the build is found", func() {
+			BeforeEach(func() {
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				buildsDB.GetBuildByIDReturns(build, true, nil)
+
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when getting the test results succeeds", func() {
+				BeforeEach(func() {
+					build.GetTestResultsReturns([]db.BuildTestResult{
+						{
+							PlanID:   "some-plan-id",
+							StepName: "unit",
+							TestResult: atc.TestResult{
+								Suite:     "unit",
+								ClassName: "pkg.Widget",
+								Name:      "resizes",
+								Status:    atc.TestStatusFailed,
+								Duration:  1.5,
+								Message:   "expected 2, got 3",
+							},
+						},
+					}, nil)
+				})
+
+				It("returns 200 with the test results", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"plan_id": "some-plan-id",
+							"step": "unit",
+							"suite": "unit",
+							"class_name": "pkg.Widget",
+							"name": "resizes",
+							"status": "failed",
+							"duration": 1.5,
+							"message": "expected 2, got 3"
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the test results fails", func() {
+				BeforeEach(func() {
+					build.GetTestResultsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
+
+func envelope(ev atc.Event) event.Envelope {
+	payload, err := json.Marshal(ev)
+	Expect(err).NotTo(HaveOccurred())
+
+	data := json.RawMessage(payload)
+
+	return event.Envelope{
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+		Data:    &data,
+	}
+}
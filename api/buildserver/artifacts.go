@@ -0,0 +1,64 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListBuildArtifacts(build db.Build) http.Handler {
+	log := s.logger.Session("list-build-artifacts", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		names, err := s.artifactStore.List(build.ID())
+		if err != nil {
+			log.Error("failed-to-list-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		artifacts := make([]atc.BuildArtifact, len(names))
+		for i, name := range names {
+			artifacts[i] = atc.BuildArtifact{Name: name}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(artifacts)
+	})
+}
+
+func (s *Server) GetBuildArtifact(build db.Build) http.Handler {
+	log := s.logger.Session("get-build-artifact", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":artifact_name")
+
+		artifact, found, err := s.artifactStore.Open(build.ID(), name)
+		if err != nil {
+			log.Error("failed-to-open-artifact", err, lager.Data{"artifact": name})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		defer artifact.Close()
+
+		w.Header().Set("Content-Type", "application/x-tar")
+		w.WriteHeader(http.StatusOK)
+
+		_, err = io.Copy(w, artifact)
+		if err != nil {
+			log.Error("failed-to-stream-artifact", err, lager.Data{"artifact": name})
+		}
+	})
+}
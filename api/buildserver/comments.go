@@ -0,0 +1,102 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListBuildComments(build db.Build) http.Handler {
+	hLog := s.logger.Session("list-build-comments", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		comments, err := build.GetComments()
+		if err != nil {
+			hLog.Error("failed-to-get-comments", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedComments := []atc.BuildComment{}
+		for _, comment := range comments {
+			presentedComments = append(presentedComments, present.BuildComment(comment))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(presentedComments)
+	})
+}
+
+func (s *Server) CreateBuildComment(build db.Build) http.Handler {
+	hLog := s.logger.Session("create-build-comment", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authTeam, authTeamFound := auth.GetTeam(r)
+		if !authTeamFound {
+			hLog.Error("failed-to-get-team-from-auth", errors.New("failed-to-get-team-from-auth"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var comment atc.BuildComment
+		err := json.NewDecoder(r.Body).Decode(&comment)
+		if err != nil {
+			hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if strings.TrimSpace(comment.Comment) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// only some auth methods name the user in the token, so the author
+		// may be unknown; the team always is
+		author, _ := auth.GetUserName(r)
+
+		savedComment, err := build.SaveComment(authTeam.Name(), author, comment.Comment)
+		if err != nil {
+			hLog.Error("failed-to-save-comment", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		json.NewEncoder(w).Encode(present.BuildComment(savedComment))
+	})
+}
+
+func (s *Server) ListBuildAnnotations(build db.Build) http.Handler {
+	hLog := s.logger.Session("list-build-annotations", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		annotations, err := build.GetAnnotations()
+		if err != nil {
+			hLog.Error("failed-to-get-annotations", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedAnnotations := []atc.BuildAnnotation{}
+		for _, annotation := range annotations {
+			presentedAnnotations = append(presentedAnnotations, present.BuildAnnotation(annotation))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(presentedAnnotations)
+	})
+}
@@ -10,12 +10,18 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
 	"github.com/vito/go-sse/sse"
 )
 
 const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
+// Log events are sent as version 5.0, without their per-line times, unless
+// the client asks for them with ?log_lines=true, as clients that don't know
+// of version 5.1 fail to parse it.
+const LogLinesParam = "log_lines"
+
 func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientNotifier := w.(http.CloseNotifier)
@@ -55,6 +61,8 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			writer.writeFlusher = gz
 		}
 
+		logLines := r.FormValue(LogLinesParam) == "true"
+
 		events, err := build.Events(eventID)
 		if err != nil {
 			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
@@ -85,6 +93,14 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 				return
 			}
 
+			if !logLines {
+				ev, err = withoutLogLines(ev)
+				if err != nil {
+					logger.Error("failed-to-downgrade-log-event", err)
+					return
+				}
+			}
+
 			err = writer.WriteEvent(eventID, ev)
 			if err != nil {
 				logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
@@ -96,6 +112,35 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 	})
 }
 
+func withoutLogLines(envelope event.Envelope) (event.Envelope, error) {
+	if envelope.Event != event.EventTypeLog || envelope.Version != (event.Log{}).Version() || envelope.Data == nil {
+		return envelope, nil
+	}
+
+	var log event.Log
+	err := json.Unmarshal(*envelope.Data, &log)
+	if err != nil {
+		return event.Envelope{}, err
+	}
+
+	payload, err := json.Marshal(event.LogV50{
+		Origin:  log.Origin,
+		Payload: log.Payload,
+		Time:    log.Time,
+	})
+	if err != nil {
+		return event.Envelope{}, err
+	}
+
+	data := json.RawMessage(payload)
+
+	return event.Envelope{
+		Data:    &data,
+		Event:   envelope.Event,
+		Version: event.LogV50{}.Version(),
+	}, nil
+}
+
 type flusher interface {
 	Flush() error
 }
@@ -28,6 +28,15 @@ func fakeEvent(payload string) event.Envelope {
 	}
 }
 
+func logEvent(payload string) event.Envelope {
+	msg := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &msg,
+		Event:   event.EventTypeLog,
+		Version: "5.1",
+	}
+}
+
 var _ = Describe("Handler", func() {
 	var (
 		build *dbfakes.FakeBuild
@@ -166,6 +175,49 @@ var _ = Describe("Handler", func() {
 					Expect(actualFrom).To(Equal(uint(2)))
 				})
 			})
+
+			Context("when there are Log events", func() {
+				BeforeEach(func() {
+					returnedEvents = []event.Envelope{
+						fakeEvent(`{"event":1}`),
+						logEvent(`{"origin":{"id":"some-id"},"payload":"some output\n","lines":[{"offset":0,"time":100.5}]}`),
+					}
+				})
+
+				It("emits them as the version older clients know of, without the line times", func() {
+					defer response.Body.Close()
+					reader := sse.NewReadCloser(response.Body)
+
+					_, err := reader.Next()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(reader.Next()).To(Equal(sse.Event{
+						ID:   "1",
+						Name: "event",
+						Data: []byte(`{"data":{"origin":{"id":"some-id"},"payload":"some output\n"},"event":"log","version":"5.0"}`),
+					}))
+				})
+
+				Context("when the line times are asked for", func() {
+					BeforeEach(func() {
+						request.URL.RawQuery = "log_lines=true"
+					})
+
+					It("emits them as they are", func() {
+						defer response.Body.Close()
+						reader := sse.NewReadCloser(response.Body)
+
+						_, err := reader.Next()
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(reader.Next()).To(Equal(sse.Event{
+							ID:   "1",
+							Name: "event",
+							Data: []byte(`{"data":{"origin":{"id":"some-id"},"payload":"some output\n","lines":[{"offset":0,"time":100.5}]},"event":"log","version":"5.1"}`),
+						}))
+					})
+				})
+			})
 		})
 
 		Context("when the eventsource returns an error", func() {
@@ -0,0 +1,312 @@
+package buildserver
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+func (s *Server) GetBuildLog(build db.Build) http.Handler {
+	hLog := s.logger.Session("get-build-log", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writer := newLogWriter(w)
+		writer.origin = event.OriginID(r.FormValue("origin"))
+		writer.stripANSI = r.FormValue("strip_ansi") == "true"
+		writer.timestamps = r.FormValue("timestamps") == "true"
+
+		events, err := build.Events(0)
+		if err != nil {
+			hLog.Error("failed-to-get-build-events", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		done := make(chan struct{})
+		defer close(done)
+
+		go func() {
+			select {
+			case <-w.(http.CloseNotifier).CloseNotify():
+			case <-s.drain:
+			case <-done:
+			}
+
+			events.Close()
+		}()
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		flusher := w.(http.Flusher)
+
+		for {
+			envelope, err := events.Next()
+			if err != nil {
+				if err == db.ErrEndOfBuildEventStream {
+					_, err := writer.Close()
+					if err != nil {
+						hLog.Info("failed-to-write-log", lager.Data{"error": err.Error()})
+					}
+				} else if err != db.ErrBuildEventStreamClosed {
+					hLog.Error("failed-to-get-next-build-event", err)
+				}
+
+				return
+			}
+
+			if envelope.Event != event.EventTypeLog || envelope.Data == nil {
+				continue
+			}
+
+			ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+			if err != nil {
+				hLog.Error("failed-to-parse-event", err)
+				return
+			}
+
+			var log event.Log
+			switch ev := ev.(type) {
+			case event.Log:
+				log = ev
+			case event.LogV50:
+				log = event.Log{Origin: ev.Origin, Payload: ev.Payload, Time: ev.Time}
+			default:
+				// older deprecated versions
+				continue
+			}
+
+			written, err := writer.WriteLog(log)
+			if err != nil {
+				hLog.Info("failed-to-write-log", lager.Data{"error": err.Error()})
+				return
+			}
+
+			if written {
+				flusher.Flush()
+			}
+		}
+	})
+}
+
+// logWriter renders Log events as plain text. As the events' payloads are
+// chunked arbitrarily, and the output of steps running in parallel is
+// interleaved, it holds on to each step's partial stdout and stderr lines
+// until they are complete, and keeps track of escape sequences spanning
+// several events.
+type logWriter struct {
+	w io.Writer
+
+	origin     event.OriginID
+	stripANSI  bool
+	timestamps bool
+
+	lines      map[event.Origin]*partialLine
+	lineOrder  []event.Origin
+	ansiStates map[event.Origin]ansiState
+}
+
+// maxPartialLineLength bounds how much of a line is held on to, as output
+// such as progress bars redrawn with \r can go on for long without a newline.
+// Longer lines are written out in pieces, which may be interleaved with the
+// output of other steps.
+const maxPartialLineLength = 64 * 1024
+
+// partialLine is the part of a step's current line held on to, and the time
+// it started being written. The line is continued if its start was already
+// written out.
+type partialLine struct {
+	text      string
+	time      float64
+	continued bool
+}
+
+func newLogWriter(w io.Writer) *logWriter {
+	return &logWriter{
+		w:          w,
+		lines:      map[event.Origin]*partialLine{},
+		ansiStates: map[event.Origin]ansiState{},
+	}
+}
+
+func (writer *logWriter) WriteLog(log event.Log) (bool, error) {
+	if writer.origin != "" && log.Origin.ID != writer.origin {
+		return false, nil
+	}
+
+	line, found := writer.lines[log.Origin]
+	if !found {
+		line = &partialLine{}
+		writer.lines[log.Origin] = line
+		writer.lineOrder = append(writer.lineOrder, log.Origin)
+	}
+
+	var buf bytes.Buffer
+
+	state := writer.ansiStates[log.Origin]
+
+	payload := log.Payload
+	offset := 0
+
+	// render line by line, as the payload's line offsets are those of the
+	// unstripped payload
+	for payload != "" {
+		end := strings.IndexByte(payload, '\n') + 1
+		if end == 0 {
+			end = len(payload)
+		}
+
+		segment := payload[:end]
+		if writer.stripANSI {
+			segment = stripANSI(&state, segment)
+		}
+
+		if line.text == "" && line.time == 0 && !line.continued {
+			line.time = lineTime(log, offset)
+		}
+
+		line.text += segment
+
+		if strings.HasSuffix(line.text, "\n") || len(line.text) >= maxPartialLineLength {
+			writer.writeLine(&buf, line)
+		}
+
+		payload = payload[end:]
+		offset += end
+	}
+
+	writer.ansiStates[log.Origin] = state
+
+	return writer.flush(&buf)
+}
+
+// Close writes out the lines that were never completed, as happens when a
+// step's output doesn't end in a newline.
+func (writer *logWriter) Close() (bool, error) {
+	var buf bytes.Buffer
+
+	for _, origin := range writer.lineOrder {
+		line := writer.lines[origin]
+		if line.text != "" {
+			writer.writeLine(&buf, line)
+		}
+	}
+
+	return writer.flush(&buf)
+}
+
+func (writer *logWriter) writeLine(buf *bytes.Buffer, line *partialLine) {
+	if writer.timestamps && !line.continued {
+		buf.WriteString(timestampPrefix(line.time))
+	}
+
+	buf.WriteString(line.text)
+
+	line.continued = !strings.HasSuffix(line.text, "\n")
+	line.text = ""
+	line.time = 0
+}
+
+func (writer *logWriter) flush(buf *bytes.Buffer) (bool, error) {
+	if buf.Len() == 0 {
+		return false, nil
+	}
+
+	_, err := buf.WriteTo(writer.w)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// lineTime is the time the line starting at the offset of the payload was
+// written. Older events only carry the time the payload was written, if any.
+func lineTime(log event.Log, offset int) float64 {
+	for _, line := range log.Lines {
+		if line.Offset == offset {
+			return line.Time
+		}
+	}
+
+	return float64(log.Time)
+}
+
+// timestampLayout is RFC 3339 with milliseconds, as lines are often written
+// within the same second.
+const timestampLayout = "2006-01-02T15:04:05.000Z07:00"
+
+func timestampPrefix(t float64) string {
+	if t == 0 {
+		return ""
+	}
+
+	return "[" + time.Unix(0, int64(t*float64(time.Second))).UTC().Format(timestampLayout) + "] "
+}
+
+type ansiState int
+
+const (
+	ansiText ansiState = iota
+	ansiEscape
+	ansiCSI
+	ansiOSC
+	ansiOSCEscape
+)
+
+// stripANSI removes the ANSI escape sequences from the text, starting in the
+// given state and leaving it in the state the text ended in.
+func stripANSI(state *ansiState, text string) string {
+	stripped := make([]byte, 0, len(text))
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+
+		switch *state {
+		case ansiText:
+			if c == '\x1b' {
+				*state = ansiEscape
+			} else {
+				stripped = append(stripped, c)
+			}
+
+		case ansiEscape:
+			switch c {
+			case '[':
+				*state = ansiCSI
+			case ']':
+				*state = ansiOSC
+			default:
+				// two-character sequence
+				*state = ansiText
+			}
+
+		case ansiCSI:
+			// parameters and intermediates until the final byte
+			if c >= 0x40 && c <= 0x7e {
+				*state = ansiText
+			}
+
+		case ansiOSC:
+			// terminated by BEL or ST (ESC \)
+			if c == '\a' {
+				*state = ansiText
+			} else if c == '\x1b' {
+				*state = ansiOSCEscape
+			}
+
+		case ansiOSCEscape:
+			*state = ansiText
+		}
+	}
+
+	return string(stripped)
+}
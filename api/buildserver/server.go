@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/artifactstore"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
@@ -29,6 +30,7 @@ type Server struct {
 	teamDBFactory       db.TeamDBFactory
 	buildsDB            BuildsDB
 	eventHandlerFactory EventHandlerFactory
+	artifactStore       artifactstore.Store
 	drain               <-chan struct{}
 	rejector            auth.Rejector
 
@@ -43,6 +45,7 @@ func NewServer(
 	teamDBFactory db.TeamDBFactory,
 	buildsDB BuildsDB,
 	eventHandlerFactory EventHandlerFactory,
+	artifactStore artifactstore.Store,
 	drain <-chan struct{},
 ) *Server {
 	return &Server{
@@ -55,6 +58,7 @@ func NewServer(
 		teamDBFactory:       teamDBFactory,
 		buildsDB:            buildsDB,
 		eventHandlerFactory: eventHandlerFactory,
+		artifactStore:       artifactStore,
 		drain:               drain,
 
 		rejector: auth.UnauthorizedRejector{},
@@ -0,0 +1,34 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) ListBuildTestResults(build db.Build) http.Handler {
+	hLog := s.logger.Session("list-build-test-results", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results, err := build.GetTestResults()
+		if err != nil {
+			hLog.Error("failed-to-get-test-results", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presentedResults := []atc.BuildTestResult{}
+		for _, result := range results {
+			presentedResults = append(presentedResults, present.BuildTestResult(result))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(presentedResults)
+	})
+}
@@ -0,0 +1,70 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+)
+
+func (s *Server) GetBuildTimings(build db.Build) http.Handler {
+	hLog := s.logger.Session("get-build-timings", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		engineBuild, err := s.engine.LookupBuild(hLog, build)
+		if err != nil {
+			hLog.Error("failed-to-lookup-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		plan, err := engineBuild.PublicPlan(hLog)
+		if err != nil {
+			hLog.Error("failed-to-generate-plan", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		events, err := build.GetEvents(present.BuildTimingEventTypes)
+		if err != nil {
+			hLog.Error("failed-to-get-events", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// the containers, and with them the workers, are only known until
+		// the containers are reaped
+		containers, err := s.teamDBFactory.GetTeamDB(build.TeamName()).FindContainersByDescriptors(db.Container{
+			ContainerIdentifier: db.ContainerIdentifier{
+				BuildID: build.ID(),
+			},
+		})
+		if err != nil {
+			hLog.Error("failed-to-find-containers", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		workers := map[atc.PlanID]string{}
+		for _, container := range containers {
+			if container.Stage == db.ContainerStageRun || workers[container.PlanID] == "" {
+				workers[container.PlanID] = container.WorkerName
+			}
+		}
+
+		timings, err := present.BuildTimings(build, plan, events, workers)
+		if err != nil {
+			hLog.Error("failed-to-present-timings", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(timings)
+	})
+}
@@ -483,6 +483,49 @@ jobs:
 							})
 						})
 
+						Context("when the payload configures attempts", func() {
+							BeforeEach(func() {
+								payload := `---
+jobs:
+- name: some-job
+  plan:
+  - task: some-task
+    file: some/task.yml
+    attempts: 2
+  - task: some-other-task
+    file: some/other-task.yml
+    attempts:
+      count: 3
+      backoff: 30s
+      on: [errored]`
+
+								request.Header.Set("Content-Type", "application/x-yaml")
+								request.Body = ioutil.NopCloser(bytes.NewBufferString(payload))
+							})
+
+							It("saves both forms of attempts", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+								_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+								Expect(savedConfig.Jobs[0].Plan).To(Equal(atc.PlanSequence{
+									{
+										Task:           "some-task",
+										TaskConfigPath: "some/task.yml",
+										Attempts:       &atc.AttemptsConfig{Count: 2},
+									},
+									{
+										Task:           "some-other-task",
+										TaskConfigPath: "some/other-task.yml",
+										Attempts: &atc.AttemptsConfig{
+											Count:   3,
+											Backoff: "30s",
+											On:      []string{"errored"},
+										},
+									},
+								}))
+							})
+						})
+
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbngfakes.FakePipeline)
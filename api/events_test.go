@@ -0,0 +1,209 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/event"
+	"github.com/vito/go-sse/sse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Events API", func() {
+	Describe("GET /api/v1/events", func() {
+		var (
+			request  *http.Request
+			response *http.Response
+
+			fakeEventSource *dbfakes.FakeClusterEventSource
+		)
+
+		BeforeEach(func() {
+			var err error
+			request, err = http.NewRequest("GET", server.URL+"/api/v1/events?pipeline=some-pipeline&type=build-started&type=build-finished", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			pending := []db.ClusterEvent{
+				{
+					ID:       43,
+					Envelope: clusterEvent(event.EventTypeBuildStarted, `{"build_id":1}`),
+				},
+				{
+					ID:       44,
+					Envelope: clusterEvent(event.EventTypeBuildFinished, `{"build_id":1,"status":"succeeded"}`),
+				},
+			}
+
+			closed := make(chan struct{})
+			closeOnce := new(sync.Once)
+
+			fakeEventSource = new(dbfakes.FakeClusterEventSource)
+			fakeEventSource.NextStub = func() (db.ClusterEvent, error) {
+				if len(pending) > 0 {
+					ev := pending[0]
+					pending = pending[1:]
+					return ev, nil
+				}
+
+				<-closed
+
+				return db.ClusterEvent{}, db.ErrClusterEventStreamClosed
+			}
+			fakeEventSource.CloseStub = func() error {
+				closeOnce.Do(func() { close(closed) })
+				return nil
+			}
+
+			clusterEventsDB.LatestClusterEventIDReturns(42, nil)
+			clusterEventsDB.ClusterEventsReturns(fakeEventSource, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			client := &http.Client{
+				Transport: &http.Transport{},
+			}
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			response.Body.Close()
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not subscribe to any events", func() {
+				Expect(clusterEventsDB.ClusterEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 200 with an event stream", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("text/event-stream; charset=utf-8"))
+			})
+
+			It("subscribes to the requester's team's events matching the filter", func() {
+				Expect(clusterEventsDB.ClusterEventsCallCount()).To(Equal(1))
+
+				filter, _ := clusterEventsDB.ClusterEventsArgsForCall(0)
+				Expect(filter).To(Equal(db.ClusterEventFilter{
+					Teams:    []string{"some-team"},
+					Pipeline: "some-pipeline",
+					Types:    []atc.EventType{event.EventTypeBuildStarted, event.EventTypeBuildFinished},
+				}))
+			})
+
+			It("only streams events from after the request", func() {
+				_, since := clusterEventsDB.ClusterEventsArgsForCall(0)
+				Expect(since).To(Equal(42))
+			})
+
+			It("streams the events with their IDs", func() {
+				reader := sse.NewReadCloser(response.Body)
+
+				ev, err := reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev.ID).To(Equal("43"))
+				Expect(ev.Name).To(Equal("event"))
+				Expect(ev.Data).To(MatchJSON(`{
+					"event": "build-started",
+					"version": "1.0",
+					"team": "some-team",
+					"pipeline": "some-pipeline",
+					"job": "some-job",
+					"data": {"build_id": 1}
+				}`))
+
+				ev, err = reader.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev.ID).To(Equal("44"))
+			})
+
+			It("closes the event source when the client goes away", func() {
+				response.Body.Close()
+				Eventually(fakeEventSource.CloseCallCount, 30*time.Second).Should(BeNumerically(">=", 1))
+			})
+
+			Context("when resuming from a Last-Event-ID", func() {
+				BeforeEach(func() {
+					request.Header.Set("Last-Event-ID", "12")
+				})
+
+				It("streams events from after that event", func() {
+					Expect(clusterEventsDB.LatestClusterEventIDCallCount()).To(BeZero())
+
+					_, since := clusterEventsDB.ClusterEventsArgsForCall(0)
+					Expect(since).To(Equal(12))
+				})
+			})
+
+			Context("when the Last-Event-ID is malformed", func() {
+				BeforeEach(func() {
+					request.Header.Set("Last-Event-ID", "nope")
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the requester is an admin", func() {
+				BeforeEach(func() {
+					userContextReader.GetTeamReturns("main", true, true)
+				})
+
+				It("does not limit the events to any team", func() {
+					filter, _ := clusterEventsDB.ClusterEventsArgsForCall(0)
+					Expect(filter.Teams).To(BeNil())
+				})
+			})
+
+			Context("when subscribing to the events fails", func() {
+				BeforeEach(func() {
+					clusterEventsDB.ClusterEventsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
+
+func clusterEvent(eventType atc.EventType, payload string) event.ClusterEnvelope {
+	data := json.RawMessage(payload)
+	return event.ClusterEnvelope{
+		Envelope: event.Envelope{
+			Data:    &data,
+			Event:   eventType,
+			Version: "1.0",
+		},
+		Team:     "some-team",
+		Pipeline: "some-pipeline",
+		Job:      "some-job",
+	}
+}
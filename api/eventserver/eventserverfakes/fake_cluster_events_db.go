@@ -0,0 +1,116 @@
+// This file was generated by counterfeiter
+package eventserverfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/api/eventserver"
+	"github.com/concourse/atc/db"
+)
+
+type FakeClusterEventsDB struct {
+	LatestClusterEventIDStub        func() (int, error)
+	latestClusterEventIDMutex       sync.RWMutex
+	latestClusterEventIDArgsForCall []struct{}
+	latestClusterEventIDReturns     struct {
+		result1 int
+		result2 error
+	}
+	ClusterEventsStub        func(filter db.ClusterEventFilter, since int) (db.ClusterEventSource, error)
+	clusterEventsMutex       sync.RWMutex
+	clusterEventsArgsForCall []struct {
+		filter db.ClusterEventFilter
+		since  int
+	}
+	clusterEventsReturns struct {
+		result1 db.ClusterEventSource
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClusterEventsDB) LatestClusterEventID() (int, error) {
+	fake.latestClusterEventIDMutex.Lock()
+	fake.latestClusterEventIDArgsForCall = append(fake.latestClusterEventIDArgsForCall, struct{}{})
+	fake.recordInvocation("LatestClusterEventID", []interface{}{})
+	fake.latestClusterEventIDMutex.Unlock()
+	if fake.LatestClusterEventIDStub != nil {
+		return fake.LatestClusterEventIDStub()
+	} else {
+		return fake.latestClusterEventIDReturns.result1, fake.latestClusterEventIDReturns.result2
+	}
+}
+
+func (fake *FakeClusterEventsDB) LatestClusterEventIDCallCount() int {
+	fake.latestClusterEventIDMutex.RLock()
+	defer fake.latestClusterEventIDMutex.RUnlock()
+	return len(fake.latestClusterEventIDArgsForCall)
+}
+
+func (fake *FakeClusterEventsDB) LatestClusterEventIDReturns(result1 int, result2 error) {
+	fake.LatestClusterEventIDStub = nil
+	fake.latestClusterEventIDReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterEventsDB) ClusterEvents(filter db.ClusterEventFilter, since int) (db.ClusterEventSource, error) {
+	fake.clusterEventsMutex.Lock()
+	fake.clusterEventsArgsForCall = append(fake.clusterEventsArgsForCall, struct {
+		filter db.ClusterEventFilter
+		since  int
+	}{filter, since})
+	fake.recordInvocation("ClusterEvents", []interface{}{filter, since})
+	fake.clusterEventsMutex.Unlock()
+	if fake.ClusterEventsStub != nil {
+		return fake.ClusterEventsStub(filter, since)
+	} else {
+		return fake.clusterEventsReturns.result1, fake.clusterEventsReturns.result2
+	}
+}
+
+func (fake *FakeClusterEventsDB) ClusterEventsCallCount() int {
+	fake.clusterEventsMutex.RLock()
+	defer fake.clusterEventsMutex.RUnlock()
+	return len(fake.clusterEventsArgsForCall)
+}
+
+func (fake *FakeClusterEventsDB) ClusterEventsArgsForCall(i int) (db.ClusterEventFilter, int) {
+	fake.clusterEventsMutex.RLock()
+	defer fake.clusterEventsMutex.RUnlock()
+	return fake.clusterEventsArgsForCall[i].filter, fake.clusterEventsArgsForCall[i].since
+}
+
+func (fake *FakeClusterEventsDB) ClusterEventsReturns(result1 db.ClusterEventSource, result2 error) {
+	fake.ClusterEventsStub = nil
+	fake.clusterEventsReturns = struct {
+		result1 db.ClusterEventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClusterEventsDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.latestClusterEventIDMutex.RLock()
+	defer fake.latestClusterEventIDMutex.RUnlock()
+	fake.clusterEventsMutex.RLock()
+	defer fake.clusterEventsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeClusterEventsDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ eventserver.ClusterEventsDB = new(FakeClusterEventsDB)
@@ -0,0 +1,32 @@
+package eventserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	db    ClusterEventsDB
+	drain <-chan struct{}
+}
+
+//go:generate counterfeiter . ClusterEventsDB
+
+type ClusterEventsDB interface {
+	LatestClusterEventID() (int, error)
+	ClusterEvents(filter db.ClusterEventFilter, since int) (db.ClusterEventSource, error)
+}
+
+func NewServer(
+	logger lager.Logger,
+	db ClusterEventsDB,
+	drain <-chan struct{},
+) *Server {
+	return &Server{
+		logger: logger,
+		db:     db,
+		drain:  drain,
+	}
+}
@@ -0,0 +1,118 @@
+package eventserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/vito/go-sse/sse"
+)
+
+// StreamEvents serves the cluster-wide firehose as server-sent events. Only
+// events from after the request (or after the Last-Event-ID) are sent.
+func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("stream-events")
+
+	filter := db.ClusterEventFilter{
+		Team:     r.FormValue("team"),
+		Pipeline: r.FormValue("pipeline"),
+		Job:      r.FormValue("job"),
+	}
+
+	for _, t := range r.Form["type"] {
+		filter.Types = append(filter.Types, atc.EventType(t))
+	}
+
+	authTeam, authTeamFound := auth.GetTeam(r)
+	if !authTeamFound {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !authTeam.IsAdmin() {
+		filter.Teams = []string{authTeam.Name()}
+	}
+
+	var since int
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		var err error
+		since, err = strconv.Atoi(lastEventID)
+		if err != nil {
+			logger.Info("failed-to-parse-last-event-id", lager.Data{"last-event-id": lastEventID})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	} else {
+		var err error
+		since, err = s.db.LatestClusterEventID()
+		if err != nil {
+			logger.Error("failed-to-get-latest-event-id", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	events, err := s.db.ClusterEvents(filter, since)
+	if err != nil {
+		logger.Error("failed-to-get-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.Header().Add("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	flusher := w.(http.Flusher)
+	flusher.Flush()
+
+	streamDone := make(chan struct{})
+	defer close(streamDone)
+
+	// Next blocks until there is a matching event, which may be never, so the
+	// source is closed from here once nobody is listening anymore
+	go func() {
+		select {
+		case <-w.(http.CloseNotifier).CloseNotify():
+		case <-s.drain:
+		case <-streamDone:
+		}
+
+		events.Close()
+	}()
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err != db.ErrClusterEventStreamClosed {
+				logger.Error("failed-to-get-next-event", err)
+			}
+
+			return
+		}
+
+		payload, err := json.Marshal(ev.Envelope)
+		if err != nil {
+			logger.Error("failed-to-marshal-event", err)
+			return
+		}
+
+		err = sse.Event{
+			ID:   fmt.Sprintf("%d", ev.ID),
+			Name: "event",
+			Data: payload,
+		}.Write(w)
+		if err != nil {
+			logger.Info("failed-to-write-event", lager.Data{"error": err.Error()})
+			return
+		}
+
+		flusher.Flush()
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc/api/cliserver"
 	"github.com/concourse/atc/api/configserver"
 	"github.com/concourse/atc/api/containerserver"
+	"github.com/concourse/atc/api/eventserver"
 	"github.com/concourse/atc/api/infoserver"
 	"github.com/concourse/atc/api/jobserver"
 	"github.com/concourse/atc/api/loglevelserver"
@@ -24,6 +25,7 @@ import (
 	"github.com/concourse/atc/api/teamserver"
 	"github.com/concourse/atc/api/volumeserver"
 	"github.com/concourse/atc/api/workerserver"
+	"github.com/concourse/atc/artifactstore"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
@@ -58,6 +60,7 @@ func NewHandler(
 	containerDB containerserver.ContainerDB,
 	pipeDB pipes.PipeDB,
 	pipelinesDB db.PipelinesDB,
+	clusterEventsDB eventserver.ClusterEventsDB,
 
 	peerURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
@@ -65,6 +68,8 @@ func NewHandler(
 
 	engine engine.Engine,
 	workerClient worker.Client,
+	artifactStore artifactstore.Store,
+	baseResourceTypeVersions atc.BaseResourceTypeVersions,
 
 	schedulerFactory jobserver.SchedulerFactory,
 	scannerFactory resourceserver.ScannerFactory,
@@ -103,10 +108,11 @@ func NewHandler(
 		teamDBFactory,
 		buildsDB,
 		eventHandlerFactory,
+		artifactStore,
 		drain,
 	)
 
-	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
+	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, engine)
 	resourceServer := resourceserver.NewServer(logger, scannerFactory)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, pipeDB)
@@ -115,7 +121,7 @@ func NewHandler(
 
 	configServer := configserver.NewServer(logger, teamDBFactory, dbTeamFactory)
 
-	workerServer := workerserver.NewServer(logger, workerDB, teamDBFactory, dbTeamFactory, dbWorkerFactory)
+	workerServer := workerserver.NewServer(logger, workerDB, teamDBFactory, dbTeamFactory, dbWorkerFactory, baseResourceTypeVersions)
 
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 
@@ -129,6 +135,8 @@ func NewHandler(
 
 	infoServer := infoserver.NewServer(logger, version)
 
+	eventServer := eventserver.NewServer(logger, clusterEventsDB, drain)
+
 	handlers := map[string]http.Handler{
 		atc.ListAuthMethods: http.HandlerFunc(authServer.ListAuthMethods),
 		atc.GetAuthToken:    http.HandlerFunc(authServer.GetAuthToken),
@@ -144,11 +152,22 @@ func NewHandler(
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
+		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.ListBuildArtifacts),
+		atc.GetBuildArtifact:    buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifact),
+		atc.GetBuildTimings:     buildHandlerFactory.HandlerFor(buildServer.GetBuildTimings),
+		atc.GetBuildLog:         buildHandlerFactory.HandlerFor(buildServer.GetBuildLog),
+
+		atc.ListBuildComments:    buildHandlerFactory.HandlerFor(buildServer.ListBuildComments),
+		atc.CreateBuildComment:   buildHandlerFactory.HandlerFor(buildServer.CreateBuildComment),
+		atc.ListBuildAnnotations: buildHandlerFactory.HandlerFor(buildServer.ListBuildAnnotations),
+		atc.ListBuildTestResults: buildHandlerFactory.HandlerFor(buildServer.ListBuildTestResults),
 
 		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
 		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
 		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
 		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.ExplainJob:     pipelineHandlerFactory.HandlerFor(jobServer.ExplainJob),
+		atc.GetJobTimings:  pipelineHandlerFactory.HandlerFor(jobServer.GetJobTimings),
 		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
 		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
 		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
@@ -156,6 +175,9 @@ func NewHandler(
 		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
 		atc.MainJobBadge:   mainredirect.Handler{atc.Routes, atc.JobBadge},
 
+		atc.SearchJobBuildLogs: pipelineHandlerFactory.HandlerFor(jobServer.SearchJobBuildLogs),
+		atc.GetJobFlakyTests:   pipelineHandlerFactory.HandlerFor(jobServer.GetJobFlakyTests),
+
 		atc.ListAllPipelines: http.HandlerFunc(pipelineServer.ListAllPipelines),
 		atc.ListPipelines:    http.HandlerFunc(pipelineServer.ListPipelines),
 		atc.GetPipeline:      pipelineHandlerFactory.HandlerFor(pipelineServer.GetPipeline),
@@ -188,6 +210,7 @@ func NewHandler(
 		atc.RegisterWorker:  http.HandlerFunc(workerServer.RegisterWorker),
 		atc.LandWorker:      http.HandlerFunc(workerServer.LandWorker),
 		atc.RetireWorker:    http.HandlerFunc(workerServer.RetireWorker),
+		atc.ListWorkerPins:  http.HandlerFunc(workerServer.ListWorkerPins),
 		atc.PruneWorker:     http.HandlerFunc(workerServer.PruneWorker),
 		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
 		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
@@ -205,6 +228,8 @@ func NewHandler(
 
 		atc.ListVolumes: teamHandlerFactory.HandlerFor(volumesServer.ListVolumes),
 
+		atc.StreamEvents: http.HandlerFunc(eventServer.StreamEvents),
+
 		atc.ListTeams:   http.HandlerFunc(teamServer.ListTeams),
 		atc.SetTeam:     http.HandlerFunc(teamServer.SetTeam),
 		atc.DestroyTeam: http.HandlerFunc(teamServer.DestroyTeam),
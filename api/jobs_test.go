@@ -13,7 +13,11 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/event"
+	"github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
 )
 
@@ -1472,6 +1476,150 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/explain", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/explain")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", true, true)
+			})
+
+			Context("when the pipeline contains the requested job", func() {
+				someJob := atc.JobConfig{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{
+							Get:      "some-input",
+							Resource: "some-resource",
+							Passed:   []string{"upstream-job"},
+						},
+					},
+				}
+
+				var fakeScheduler *schedulerfakes.FakeBuildScheduler
+
+				BeforeEach(func() {
+					fakeScheduler = new(schedulerfakes.FakeBuildScheduler)
+					fakeSchedulerFactory.BuildSchedulerReturns(fakeScheduler)
+
+					pipelineDB.ConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{someJob},
+					})
+
+					pipelineDB.GetResourceReturns(db.SavedResource{Paused: true}, true, nil)
+				})
+
+				Context("when the job can be explained", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainReturns(scheduler.Explanation{
+							Inputs: algorithm.Explanation{
+								Resolved: true,
+								Mapping: algorithm.InputMapping{
+									"some-input": algorithm.InputVersion{VersionID: 2},
+								},
+								Inputs: []algorithm.InputExplanation{
+									{
+										Input:      "some-input",
+										ResourceID: 11,
+										Candidates: []algorithm.CandidateExplanation{
+											{VersionID: 3, EliminatedBy: algorithm.EliminatedNotPassed, JobID: 1},
+											{VersionID: 2},
+										},
+									},
+								},
+								JobNames: map[int]string{1: "upstream-job"},
+							},
+							Blocker: scheduler.BlockerMaxInFlightReached,
+						}, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("explained the requested job", func() {
+						Expect(fakeScheduler.ExplainCallCount()).To(Equal(1))
+						_, actualJobConfig := fakeScheduler.ExplainArgsForCall(0)
+						Expect(actualJobConfig).To(Equal(someJob))
+
+						Expect(pipelineDB.GetResourceCallCount()).To(Equal(1))
+						Expect(pipelineDB.GetResourceArgsForCall(0)).To(Equal("some-resource"))
+					})
+
+					It("returns the explanation", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"inputs_satisfied": true,
+							"inputs": [
+								{
+									"name": "some-input",
+									"resource": "some-resource",
+									"resource_paused": true,
+									"version_id": 2,
+									"candidates": [
+										{"version_id": 3, "eliminated_by": "not-passed", "job": "upstream-job"},
+										{"version_id": 2}
+									]
+								}
+							],
+							"blocker": "max-in-flight-reached"
+						}`))
+					})
+				})
+
+				Context("when explaining the job fails", func() {
+					BeforeEach(func() {
+						fakeScheduler.ExplainReturns(scheduler.Explanation{}, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when looking up a resource fails", func() {
+					BeforeEach(func() {
+						pipelineDB.GetResourceReturns(db.SavedResource{}, false, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the pipeline does not contain the requested job", func() {
+				BeforeEach(func() {
+					pipelineDB.ConfigReturns(atc.Config{})
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
@@ -1707,4 +1855,499 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/timings", func() {
+		var response *http.Response
+		var requestURL string
+
+		BeforeEach(func() {
+			requestURL = server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/timings"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(requestURL)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", true, true)
+			})
+
+			Context("when the job has builds", func() {
+				var engineBuild *enginefakes.FakeBuild
+
+				BeforeEach(func() {
+					plan := atc.Plan{
+						ID:  "get-id",
+						Get: &atc.GetPlan{Name: "some-input", Resource: "some-resource"},
+					}
+
+					engineBuild = new(enginefakes.FakeBuild)
+					engineBuild.PublicPlanReturns(atc.PublicBuildPlan{
+						Schema: "exec.v2",
+						Plan:   plan.Public(),
+					}, nil)
+					fakeEngine.LookupBuildReturns(engineBuild, nil)
+
+					pipelineDB.GetJobReturns(db.SavedJob{}, true, nil)
+
+					runningBuild := new(dbfakes.FakeBuild)
+					runningBuild.IDReturns(5)
+					runningBuild.EngineReturns("exec.v2")
+					runningBuild.IsRunningReturns(true)
+
+					neverStartedBuild := new(dbfakes.FakeBuild)
+					neverStartedBuild.IDReturns(4)
+					neverStartedBuild.StatusReturns(db.StatusAborted)
+
+					builds := []db.Build{runningBuild, neverStartedBuild}
+					buildsEvents := map[int][]event.Envelope{}
+					for i, total := range []int64{30, 10, 20} {
+						build := new(dbfakes.FakeBuild)
+						build.IDReturns(3 - i)
+						build.EngineReturns("exec.v2")
+						build.CreateTimeReturns(time.Unix(100-total/2, 0))
+						build.StartTimeReturns(time.Unix(100, 0))
+
+						buildsEvents[3-i] = []event.Envelope{
+							envelope(event.StartStep{Time: 100, Origin: event.Origin{ID: "get-id"}, Step: "get"}),
+							envelope(event.StartGet{Time: 102, Origin: event.Origin{ID: "get-id"}}),
+							envelope(event.FinishStep{Time: 100 + total, Origin: event.Origin{ID: "get-id"}, Step: "get", Status: atc.StatusSucceeded}),
+						}
+
+						builds = append(builds, build)
+					}
+
+					pipelineDB.GetJobBuildsReturns(builds, db.Pagination{}, nil)
+					pipelineDB.GetBuildsEventsReturns(buildsEvents, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("looks up the job", func() {
+					Expect(pipelineDB.GetJobArgsForCall(0)).To(Equal("some-job"))
+				})
+
+				It("skips builds that never started", func() {
+					Expect(fakeEngine.LookupBuildCallCount()).To(Equal(3))
+				})
+
+				It("gets the events of the finished builds at once", func() {
+					Expect(pipelineDB.GetBuildsEventsCallCount()).To(Equal(1))
+
+					buildIDs, _ := pipelineDB.GetBuildsEventsArgsForCall(0)
+					Expect(buildIDs).To(Equal([]int{3, 2, 1}))
+				})
+
+				It("looks at the last 25 builds of the job by default", func() {
+					jobName, page := pipelineDB.GetJobBuildsArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(page).To(Equal(db.Page{Limit: 25}))
+				})
+
+				It("returns the percentiles of each step's durations across the finished builds", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"builds": [3, 2, 1],
+						"queued": {"samples": 3, "p50": 10, "p95": 15},
+						"steps": [
+							{
+								"step": "get",
+								"name": "some-input",
+								"samples": 3,
+								"p50": {"total": 20, "queued": 2, "image_fetch": 0, "input_stream": 0, "run": 18, "output_stream": 0},
+								"p95": {"total": 30, "queued": 2, "image_fetch": 0, "input_stream": 0, "run": 28, "output_stream": 0}
+							}
+						]
+					}`))
+				})
+
+				Context("when a limit is given", func() {
+					BeforeEach(func() {
+						requestURL += "?limit=5"
+					})
+
+					It("looks at that many builds", func() {
+						_, page := pipelineDB.GetJobBuildsArgsForCall(0)
+						Expect(page).To(Equal(db.Page{Limit: 5}))
+					})
+				})
+
+				Context("when the limit given is too high", func() {
+					BeforeEach(func() {
+						requestURL += "?limit=5000"
+					})
+
+					It("looks at no more than 100 builds", func() {
+						_, page := pipelineDB.GetJobBuildsArgsForCall(0)
+						Expect(page).To(Equal(db.Page{Limit: 100}))
+					})
+				})
+
+				Context("when getting the builds' events fails", func() {
+					BeforeEach(func() {
+						pipelineDB.GetBuildsEventsReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when looking up a build's plan fails", func() {
+					BeforeEach(func() {
+						fakeEngine.LookupBuildReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job cannot be found", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobReturns(db.SavedJob{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when looking up the job fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobReturns(db.SavedJob{}, false, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when looking up the job's builds fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobReturns(db.SavedJob{}, true, nil)
+					pipelineDB.GetJobBuildsReturns(nil, db.Pagination{}, errors.New("oh no!"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/logs/search", func() {
+		var response *http.Response
+		var requestURL string
+
+		BeforeEach(func() {
+			requestURL = server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/logs/search?q=connection+refused"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(requestURL)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", true, true)
+			})
+
+			Context("when the job exists", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobReturns(db.SavedJob{}, true, nil)
+
+					pipelineDB.SearchJobBuildLogsReturns([]db.LogMatch{
+						{
+							BuildID:   42,
+							BuildName: "7",
+							Origin:    event.Origin{ID: "some-task", Source: event.OriginSourceStderr},
+							Line:      12,
+							Text:      "dial tcp: connection refused",
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("searches the last 25 builds of the job by default", func() {
+					Expect(pipelineDB.SearchJobBuildLogsCallCount()).To(Equal(1))
+
+					jobName, query, limit := pipelineDB.SearchJobBuildLogsArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(query).To(Equal("connection refused"))
+					Expect(limit).To(Equal(25))
+				})
+
+				It("returns the matching lines", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"build_id": 42,
+							"build_name": "7",
+							"origin": {"id": "some-task", "source": "stderr"},
+							"line": 12,
+							"text": "dial tcp: connection refused"
+						}
+					]`))
+				})
+
+				Context("when a limit is given", func() {
+					BeforeEach(func() {
+						requestURL += "&limit=5"
+					})
+
+					It("searches that many builds", func() {
+						_, _, limit := pipelineDB.SearchJobBuildLogsArgsForCall(0)
+						Expect(limit).To(Equal(5))
+					})
+				})
+
+				Context("when the limit given is too large", func() {
+					BeforeEach(func() {
+						requestURL += "&limit=100000"
+					})
+
+					It("searches no more than 100 builds", func() {
+						_, _, limit := pipelineDB.SearchJobBuildLogsArgsForCall(0)
+						Expect(limit).To(Equal(100))
+					})
+				})
+
+				Context("when no query is given", func() {
+					BeforeEach(func() {
+						requestURL = server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/logs/search"
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not search", func() {
+						Expect(pipelineDB.SearchJobBuildLogsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when searching fails", func() {
+					BeforeEach(func() {
+						pipelineDB.SearchJobBuildLogsReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job does not exist", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobReturns(db.SavedJob{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					pipelineDB.IsPublicReturns(false)
+				})
+
+				It("returns Unauthorized", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					pipelineDB.IsPublicReturns(true)
+				})
+
+				Context("and the job is private", func() {
+					BeforeEach(func() {
+						pipelineDB.GetJobReturns(db.SavedJob{}, true, nil)
+					})
+
+					It("returns Unauthorized", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					})
+
+					It("does not search", func() {
+						Expect(pipelineDB.SearchJobBuildLogsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("and the job is public", func() {
+					BeforeEach(func() {
+						pipelineDB.GetJobReturns(db.SavedJob{
+							Config: atc.JobConfig{Name: "some-job", Public: true},
+						}, true, nil)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("searches the job's builds", func() {
+						Expect(pipelineDB.SearchJobBuildLogsCallCount()).To(Equal(1))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/flaky_tests", func() {
+		var response *http.Response
+		var requestURL string
+
+		BeforeEach(func() {
+			requestURL = server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/flaky_tests"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(requestURL)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", true, true)
+			})
+
+			Context("when the job exists", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobReturns(db.SavedJob{}, true, nil)
+
+					pipelineDB.GetJobFlakyTestsReturns([]db.FlakyTest{
+						{
+							Suite:               "unit",
+							ClassName:           "pkg.Widget",
+							Name:                "resizes",
+							Passes:              3,
+							Failures:            2,
+							LastFailedBuildName: "7",
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("looks at the last 25 builds of the job by default", func() {
+					Expect(pipelineDB.GetJobFlakyTestsCallCount()).To(Equal(1))
+
+					jobName, limit := pipelineDB.GetJobFlakyTestsArgsForCall(0)
+					Expect(jobName).To(Equal("some-job"))
+					Expect(limit).To(Equal(25))
+				})
+
+				It("returns the flaky tests", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"suite": "unit",
+							"class_name": "pkg.Widget",
+							"name": "resizes",
+							"passes": 3,
+							"failures": 2,
+							"last_failed_build": "7"
+						}
+					]`))
+				})
+
+				Context("when a limit is given", func() {
+					BeforeEach(func() {
+						requestURL += "?limit=5"
+					})
+
+					It("looks at that many builds", func() {
+						_, limit := pipelineDB.GetJobFlakyTestsArgsForCall(0)
+						Expect(limit).To(Equal(5))
+					})
+				})
+
+				Context("when the limit given is too large", func() {
+					BeforeEach(func() {
+						requestURL += "?limit=100000"
+					})
+
+					It("looks at no more than 100 builds", func() {
+						_, limit := pipelineDB.GetJobFlakyTestsArgsForCall(0)
+						Expect(limit).To(Equal(100))
+					})
+				})
+
+				Context("when getting the flaky tests fails", func() {
+					BeforeEach(func() {
+						pipelineDB.GetJobFlakyTestsReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job does not exist", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobReturns(db.SavedJob{}, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
@@ -0,0 +1,54 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+)
+
+func (s *Server) ExplainJob(pipelineDB db.PipelineDB, dbPipeline dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("explain-job")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		jobConfig, found := pipelineDB.Config().Jobs.Lookup(jobName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		scheduler := s.schedulerFactory.BuildScheduler(pipelineDB, dbPipeline, s.externalURL)
+
+		explanation, err := scheduler.Explain(logger, jobConfig)
+		if err != nil {
+			logger.Error("failed-to-explain-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		jobInputs := config.JobInputs(jobConfig)
+
+		pausedResources := map[string]bool{}
+		for _, input := range jobInputs {
+			resource, found, err := pipelineDB.GetResource(input.Resource)
+			if err != nil {
+				logger.Error("failed-to-get-resource", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if found {
+				pausedResources[input.Resource] = resource.Paused
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(present.JobExplanation(explanation, jobInputs, pausedResources))
+	})
+}
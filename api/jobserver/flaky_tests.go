@@ -0,0 +1,61 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+)
+
+const (
+	defaultFlakyTestsLimit = 25
+	maxFlakyTestsLimit     = 100
+)
+
+func (s *Server) GetJobFlakyTests(pipelineDB db.PipelineDB, _ dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("get-job-flaky-tests")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit <= 0 {
+			limit = defaultFlakyTestsLimit
+		} else if limit > maxFlakyTestsLimit {
+			limit = maxFlakyTestsLimit
+		}
+
+		_, found, err := pipelineDB.GetJob(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		flakyTests, err := pipelineDB.GetJobFlakyTests(jobName, limit)
+		if err != nil {
+			logger.Error("failed-to-get-flaky-tests", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := make([]atc.FlakyTest, len(flakyTests))
+		for i, flakyTest := range flakyTests {
+			presented[i] = present.FlakyTest(flakyTest)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(presented)
+	})
+}
@@ -0,0 +1,80 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+)
+
+const (
+	defaultLogSearchLimit = 25
+	maxLogSearchLimit     = 100
+)
+
+func (s *Server) SearchJobBuildLogs(pipelineDB db.PipelineDB, _ dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("search-job-build-logs")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		query := r.FormValue("q")
+		if query == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit <= 0 {
+			limit = defaultLogSearchLimit
+		} else if limit > maxLogSearchLimit {
+			limit = maxLogSearchLimit
+		}
+
+		job, found, err := pipelineDB.GetJob(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// the pipeline may be public, but as with the build log endpoints the
+		// job's output is only readable if the job is public too
+		if !job.Config.Public && !auth.IsAuthorized(r) {
+			if auth.IsAuthenticated(r) {
+				s.rejector.Forbidden(w, r)
+				return
+			}
+
+			s.rejector.Unauthorized(w, r)
+			return
+		}
+
+		matches, err := pipelineDB.SearchJobBuildLogs(jobName, query, limit)
+		if err != nil {
+			logger.Error("failed-to-search-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := make([]atc.LogMatch, len(matches))
+		for i, match := range matches {
+			presented[i] = present.LogMatch(match)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(presented)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/scheduler"
 )
 
@@ -19,6 +20,7 @@ type Server struct {
 
 	schedulerFactory SchedulerFactory
 	externalURL      string
+	engine           engine.Engine
 	rejector         auth.Rejector
 }
 
@@ -26,11 +28,13 @@ func NewServer(
 	logger lager.Logger,
 	schedulerFactory SchedulerFactory,
 	externalURL string,
+	engine engine.Engine,
 ) *Server {
 	return &Server{
 		logger:           logger,
 		schedulerFactory: schedulerFactory,
 		externalURL:      externalURL,
+		engine:           engine,
 		rejector:         auth.UnauthorizedRejector{},
 	}
 }
@@ -0,0 +1,119 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/event"
+)
+
+const (
+	defaultTimingsLimit = 25
+	maxTimingsLimit     = 100
+)
+
+func (s *Server) GetJobTimings(pipelineDB db.PipelineDB, _ dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("get-job-timings")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit <= 0 {
+			limit = defaultTimingsLimit
+		}
+
+		if limit > maxTimingsLimit {
+			limit = maxTimingsLimit
+		}
+
+		_, found, err := pipelineDB.GetJob(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		builds, _, err := pipelineDB.GetJobBuilds(jobName, db.Page{Limit: limit})
+		if err != nil {
+			logger.Error("failed-to-get-job-builds", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		timedBuilds := []db.Build{}
+		buildIDs := []int{}
+		for _, build := range builds {
+			if build.IsRunning() {
+				continue
+			}
+
+			// builds aborted before they were started have no plan to time
+			if build.Engine() == "" {
+				continue
+			}
+
+			timedBuilds = append(timedBuilds, build)
+			buildIDs = append(buildIDs, build.ID())
+		}
+
+		buildsEvents, err := pipelineDB.GetBuildsEvents(buildIDs, present.BuildTimingEventTypes)
+		if err != nil {
+			logger.Error("failed-to-get-builds-events", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		buildTimings := []atc.BuildTimings{}
+		for _, build := range timedBuilds {
+			timings, err := s.buildTimings(logger.Session("build", lager.Data{"build-id": build.ID()}), build, buildsEvents[build.ID()])
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			buildTimings = append(buildTimings, timings)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(present.JobTimings(buildTimings))
+	})
+}
+
+// buildTimings presents the timings of the build given its events, which
+// are fetched for all of the builds at once. Looking the build up to get its
+// plan decodes its engine metadata, rather than querying for it.
+func (s *Server) buildTimings(logger lager.Logger, build db.Build, events []event.Envelope) (atc.BuildTimings, error) {
+	engineBuild, err := s.engine.LookupBuild(logger, build)
+	if err != nil {
+		logger.Error("failed-to-lookup-build", err)
+		return atc.BuildTimings{}, err
+	}
+
+	plan, err := engineBuild.PublicPlan(logger)
+	if err != nil {
+		logger.Error("failed-to-generate-plan", err)
+		return atc.BuildTimings{}, err
+	}
+
+	timings, err := present.BuildTimings(build, plan, events, nil)
+	if err != nil {
+		logger.Error("failed-to-present-timings", err)
+		return atc.BuildTimings{}, err
+	}
+
+	return timings, nil
+}
@@ -0,0 +1,25 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func BuildComment(comment db.BuildComment) atc.BuildComment {
+	return atc.BuildComment{
+		ID:        comment.ID,
+		TeamName:  comment.TeamName,
+		Author:    comment.Author,
+		Comment:   comment.Comment,
+		CreatedAt: comment.CreatedAt.Unix(),
+	}
+}
+
+func BuildAnnotation(annotation db.BuildAnnotation) atc.BuildAnnotation {
+	return atc.BuildAnnotation{
+		PlanID: annotation.PlanID,
+		Step:   annotation.StepName,
+		Name:   annotation.Name,
+		Value:  annotation.Value,
+	}
+}
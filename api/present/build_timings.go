@@ -0,0 +1,414 @@
+package present
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/event"
+)
+
+// BuildTimingEventTypes are the only events needed to present a build's
+// timings.
+var BuildTimingEventTypes = []atc.EventType{
+	event.EventTypeStartStep,
+	event.EventTypeFinishStep,
+	event.EventTypeImageFetchPhase,
+	event.EventTypeStreamPhase,
+	event.EventTypeStartTask,
+	event.EventTypeFinishTask,
+	event.EventTypeStartGet,
+	event.EventTypeStartPut,
+}
+
+type publicPlan struct {
+	ID atc.PlanID `json:"id"`
+
+	Aggregate []publicPlan `json:"aggregate"`
+	Do        []publicPlan `json:"do"`
+	Retry     []publicPlan `json:"retry"`
+
+	Get          *publicNamedStep `json:"get"`
+	Put          *publicNamedStep `json:"put"`
+	DependentGet *publicNamedStep `json:"dependent_get"`
+	Task         *publicNamedStep `json:"task"`
+
+	SaveArtifacts *struct{} `json:"save_artifacts"`
+
+	Timeout   *publicHookedStep `json:"timeout"`
+	Try       *publicHookedStep `json:"try"`
+	OnSuccess *publicHookedStep `json:"on_success"`
+	OnFailure *publicHookedStep `json:"on_failure"`
+	Ensure    *publicHookedStep `json:"ensure"`
+}
+
+type publicNamedStep struct {
+	Name     string `json:"name"`
+	Resource string `json:"resource"`
+}
+
+type publicHookedStep struct {
+	Step      *publicPlan `json:"step"`
+	OnSuccess *publicPlan `json:"on_success"`
+	OnFailure *publicPlan `json:"on_failure"`
+	Ensure    *publicPlan `json:"ensure"`
+}
+
+type stepEvents struct {
+	start  *event.StartStep
+	finish *event.FinishStep
+
+	taskStart  int64
+	taskFinish int64
+
+	// when a get or put's script started, or the resource was found in the
+	// cache
+	resourceStart int64
+
+	// fractional seconds, summed over every phase
+	imageFetch   float64
+	inputStream  float64
+	outputStream float64
+}
+
+// BuildTimings breaks down the build's time per step of its public plan,
+// using the events saved by the build. Workers maps each step to the name of
+// the worker that ran it, as far as it is still known.
+func BuildTimings(build db.Build, plan atc.PublicBuildPlan, events []event.Envelope, workers map[atc.PlanID]string) (atc.BuildTimings, error) {
+	timings := atc.BuildTimings{
+		BuildID: build.ID(),
+	}
+
+	if !build.CreateTime().IsZero() && !build.StartTime().IsZero() {
+		queued := nonNegative(build.StartTime().Unix() - build.CreateTime().Unix())
+		timings.Queued = &queued
+	}
+
+	if plan.Plan == nil {
+		return timings, nil
+	}
+
+	var root publicPlan
+	err := json.Unmarshal(*plan.Plan, &root)
+	if err != nil {
+		return atc.BuildTimings{}, err
+	}
+
+	byOrigin, err := collectStepEvents(events)
+	if err != nil {
+		return atc.BuildTimings{}, err
+	}
+
+	timings.Plan = stepTiming(root, byOrigin, workers)
+
+	return timings, nil
+}
+
+// JobTimings aggregates the timings of the given builds per step type and
+// name.
+func JobTimings(builds []atc.BuildTimings) atc.JobTimings {
+	type stepKey struct {
+		step string
+		name string
+	}
+
+	samples := map[stepKey][]atc.StepDurations{}
+	keys := []stepKey{}
+
+	var collect func(atc.BuildStepTiming)
+	collect = func(timing atc.BuildStepTiming) {
+		if timing.StartTime != 0 && timing.EndTime != 0 {
+			key := stepKey{step: timing.Step, name: timing.Name}
+			if _, found := samples[key]; !found {
+				keys = append(keys, key)
+			}
+
+			samples[key] = append(samples[key], timing.Durations)
+		}
+
+		for _, child := range timing.Steps {
+			collect(child)
+		}
+	}
+
+	jobTimings := atc.JobTimings{
+		Builds: []int{},
+		Steps:  []atc.JobStepTiming{},
+	}
+
+	queued := []int64{}
+
+	for _, build := range builds {
+		jobTimings.Builds = append(jobTimings.Builds, build.BuildID)
+		collect(build.Plan)
+
+		if build.Queued != nil {
+			queued = append(queued, *build.Queued)
+		}
+	}
+
+	if len(queued) > 0 {
+		jobTimings.Queued = atc.JobQueuedTiming{
+			Samples: len(queued),
+			P50:     percentile(queued, 50),
+			P95:     percentile(queued, 95),
+		}
+	}
+
+	for _, key := range keys {
+		durations := samples[key]
+
+		jobTimings.Steps = append(jobTimings.Steps, atc.JobStepTiming{
+			Step:    key.step,
+			Name:    key.name,
+			Samples: len(durations),
+			P50:     durationsPercentile(durations, 50),
+			P95:     durationsPercentile(durations, 95),
+		})
+	}
+
+	return jobTimings
+}
+
+func collectStepEvents(envelopes []event.Envelope) (map[event.OriginID]*stepEvents, error) {
+	byOrigin := map[event.OriginID]*stepEvents{}
+
+	eventsFor := func(id event.OriginID) *stepEvents {
+		events, found := byOrigin[id]
+		if !found {
+			events = &stepEvents{}
+			byOrigin[id] = events
+		}
+
+		return events
+	}
+
+	for _, envelope := range envelopes {
+		if envelope.Data == nil {
+			continue
+		}
+
+		ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		switch e := ev.(type) {
+		case event.StartStep:
+			eventsFor(e.Origin.ID).start = &e
+		case event.FinishStep:
+			eventsFor(e.Origin.ID).finish = &e
+		case event.StartTask:
+			eventsFor(e.Origin.ID).taskStart = e.Time
+		case event.FinishTask:
+			eventsFor(e.Origin.ID).taskFinish = e.Time
+		case event.StartGet:
+			eventsFor(e.Origin.ID).startResource(e.Time)
+		case event.StartPut:
+			eventsFor(e.Origin.ID).startResource(e.Time)
+		case event.ImageFetchPhase:
+			eventsFor(e.Origin.ID).imageFetch += e.EndTime - e.StartTime
+		case event.StreamPhase:
+			if e.Direction == "in" {
+				eventsFor(e.Origin.ID).inputStream += e.EndTime - e.StartTime
+			} else {
+				eventsFor(e.Origin.ID).outputStream += e.EndTime - e.StartTime
+			}
+		}
+	}
+
+	return byOrigin, nil
+}
+
+func stepTiming(plan publicPlan, byOrigin map[event.OriginID]*stepEvents, workers map[atc.PlanID]string) atc.BuildStepTiming {
+	timing := atc.BuildStepTiming{
+		ID:     plan.ID,
+		Worker: workers[plan.ID],
+	}
+
+	var children []publicPlan
+
+	switch {
+	case plan.Aggregate != nil:
+		timing.Step = "aggregate"
+		children = plan.Aggregate
+	case plan.Do != nil:
+		timing.Step = "do"
+		children = plan.Do
+	case plan.Retry != nil:
+		timing.Step = "retry"
+		children = plan.Retry
+	case plan.Get != nil:
+		timing.Step = "get"
+		timing.Name = stepName(plan.Get)
+	case plan.Put != nil:
+		timing.Step = "put"
+		timing.Name = stepName(plan.Put)
+	case plan.DependentGet != nil:
+		timing.Step = "dependent_get"
+		timing.Name = stepName(plan.DependentGet)
+	case plan.Task != nil:
+		timing.Step = "task"
+		timing.Name = plan.Task.Name
+	case plan.SaveArtifacts != nil:
+		timing.Step = "save_artifacts"
+	case plan.Timeout != nil:
+		timing.Step = "timeout"
+		children = plan.Timeout.steps()
+	case plan.Try != nil:
+		timing.Step = "try"
+		children = plan.Try.steps()
+	case plan.OnSuccess != nil:
+		timing.Step = "on_success"
+		children = plan.OnSuccess.steps()
+	case plan.OnFailure != nil:
+		timing.Step = "on_failure"
+		children = plan.OnFailure.steps()
+	case plan.Ensure != nil:
+		timing.Step = "ensure"
+		children = plan.Ensure.steps()
+	}
+
+	for _, child := range children {
+		timing.Steps = append(timing.Steps, stepTiming(child, byOrigin, workers))
+	}
+
+	events, found := byOrigin[event.OriginID(plan.ID)]
+	if !found {
+		return timing
+	}
+
+	if events.start != nil {
+		timing.StartTime = events.start.Time
+	}
+
+	if events.finish != nil {
+		timing.EndTime = events.finish.Time
+		timing.Status = events.finish.Status
+	}
+
+	durations := atc.StepDurations{
+		ImageFetch:   roundSeconds(events.imageFetch),
+		InputStream:  roundSeconds(events.inputStream),
+		OutputStream: roundSeconds(events.outputStream),
+	}
+
+	if timing.StartTime != 0 && timing.EndTime != 0 {
+		durations.Total = timing.EndTime - timing.StartTime
+	}
+
+	switch {
+	case events.taskStart != 0:
+		durations.Queued = queuedDuration(timing, durations, events.taskStart)
+
+		if events.taskFinish != 0 {
+			durations.Run = nonNegative(events.taskFinish - events.taskStart)
+		}
+
+	case events.resourceStart != 0:
+		durations.Queued = queuedDuration(timing, durations, events.resourceStart)
+
+		if timing.EndTime != 0 {
+			durations.Run = nonNegative(timing.EndTime - events.resourceStart)
+		}
+
+	case len(children) == 0:
+		// older builds don't record when a get or put's script started, so
+		// the time spent queued is counted as running
+		durations.Run = nonNegative(durations.Total - durations.ImageFetch - durations.InputStream)
+	}
+
+	timing.Durations = durations
+
+	return timing
+}
+
+// startResource keeps the first start of a get or put, as a get is fetched
+// again when its build is resumed.
+func (events *stepEvents) startResource(startTime int64) {
+	if events.resourceStart == 0 {
+		events.resourceStart = startTime
+	}
+}
+
+// queuedDuration is everything before the step started running that wasn't
+// spent fetching its image or streaming in its inputs, which was spent
+// waiting for a worker and creating the container.
+func queuedDuration(timing atc.BuildStepTiming, durations atc.StepDurations, runStart int64) int64 {
+	if timing.StartTime == 0 {
+		return 0
+	}
+
+	return nonNegative(runStart - timing.StartTime - durations.ImageFetch - durations.InputStream)
+}
+
+func (step publicHookedStep) steps() []publicPlan {
+	steps := []publicPlan{}
+
+	for _, s := range []*publicPlan{step.Step, step.OnSuccess, step.OnFailure, step.Ensure} {
+		if s != nil {
+			steps = append(steps, *s)
+		}
+	}
+
+	return steps
+}
+
+func stepName(step *publicNamedStep) string {
+	if step.Name != "" {
+		return step.Name
+	}
+
+	return step.Resource
+}
+
+func durationsPercentile(samples []atc.StepDurations, p int) atc.StepDurations {
+	field := func(get func(atc.StepDurations) int64) int64 {
+		values := make([]int64, len(samples))
+		for i, sample := range samples {
+			values[i] = get(sample)
+		}
+
+		return percentile(values, p)
+	}
+
+	return atc.StepDurations{
+		Total:        field(func(d atc.StepDurations) int64 { return d.Total }),
+		Queued:       field(func(d atc.StepDurations) int64 { return d.Queued }),
+		ImageFetch:   field(func(d atc.StepDurations) int64 { return d.ImageFetch }),
+		InputStream:  field(func(d atc.StepDurations) int64 { return d.InputStream }),
+		Run:          field(func(d atc.StepDurations) int64 { return d.Run }),
+		OutputStream: field(func(d atc.StepDurations) int64 { return d.OutputStream }),
+	}
+}
+
+// percentile is the nearest-rank percentile of the values, which it sorts.
+func percentile(values []int64, p int) int64 {
+	sort.Sort(int64s(values))
+
+	rank := (p*len(values) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return values[rank-1]
+}
+
+func roundSeconds(seconds float64) int64 {
+	return int64(seconds + 0.5)
+}
+
+func nonNegative(duration int64) int64 {
+	if duration < 0 {
+		return 0
+	}
+
+	return duration
+}
+
+type int64s []int64
+
+func (s int64s) Len() int           { return len(s) }
+func (s int64s) Less(i, j int) bool { return s[i] < s[j] }
+func (s int64s) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
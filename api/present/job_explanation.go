@@ -0,0 +1,53 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/scheduler"
+)
+
+func JobExplanation(
+	explanation scheduler.Explanation,
+	inputs []config.JobInput,
+	pausedResources map[string]bool,
+) atc.JobExplanation {
+	resources := map[string]string{}
+	for _, input := range inputs {
+		resources[input.Name] = input.Resource
+	}
+
+	presentedInputs := []atc.InputExplanation{}
+	for _, input := range explanation.Inputs.Inputs {
+		candidates := []atc.CandidateExplanation{}
+		for _, candidate := range input.Candidates {
+			candidates = append(candidates, atc.CandidateExplanation{
+				VersionID:    candidate.VersionID,
+				EliminatedBy: string(candidate.EliminatedBy),
+				Job:          explanation.Inputs.JobNames[candidate.JobID],
+			})
+		}
+
+		resource := resources[input.Input]
+
+		presentedInputs = append(presentedInputs, atc.InputExplanation{
+			Name:           input.Input,
+			Resource:       resource,
+			ResourcePaused: pausedResources[resource],
+			VersionID:      explanation.Inputs.Mapping[input.Input].VersionID,
+			Candidates:     candidates,
+		})
+	}
+
+	presented := atc.JobExplanation{
+		InputsSatisfied: explanation.Inputs.Resolved,
+		Inputs:          presentedInputs,
+		Blocker:         string(explanation.Blocker),
+	}
+
+	if explanation.NextPendingBuild != nil {
+		build := Build(explanation.NextPendingBuild)
+		presented.NextPendingBuild = &build
+	}
+
+	return presented
+}
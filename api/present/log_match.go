@@ -0,0 +1,19 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func LogMatch(match db.LogMatch) atc.LogMatch {
+	return atc.LogMatch{
+		BuildID:   match.BuildID,
+		BuildName: match.BuildName,
+		Origin: atc.LogOrigin{
+			ID:     string(match.Origin.ID),
+			Source: string(match.Origin.Source),
+		},
+		Line: match.Line,
+		Text: match.Text,
+	}
+}
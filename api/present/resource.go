@@ -1,6 +1,8 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web"
@@ -33,8 +35,19 @@ func Resource(resource db.SavedResource, groups atc.GroupConfigs, showCheckError
 	}
 
 	var checkErrString string
+	var checkFailures int
+	var backoffSeconds, nextCheck int64
 	if resource.CheckError != nil && showCheckError {
 		checkErrString = resource.CheckError.Error()
+		checkFailures = resource.CheckFailures
+
+		if resource.CheckBackoffInterval > 0 {
+			backoffSeconds = int64(resource.CheckBackoffInterval / time.Second)
+
+			if !resource.LastChecked.IsZero() {
+				nextCheck = resource.LastChecked.Add(resource.CheckBackoffInterval).Unix()
+			}
+		}
 	}
 
 	return atc.Resource{
@@ -47,5 +60,8 @@ func Resource(resource db.SavedResource, groups atc.GroupConfigs, showCheckError
 
 		FailingToCheck: resource.FailingToCheck(),
 		CheckError:     checkErrString,
+		CheckFailures:  checkFailures,
+		CheckBackoff:   backoffSeconds,
+		NextCheck:      nextCheck,
 	}
 }
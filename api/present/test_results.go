@@ -0,0 +1,25 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+func BuildTestResult(result db.BuildTestResult) atc.BuildTestResult {
+	return atc.BuildTestResult{
+		PlanID:     result.PlanID,
+		Step:       result.StepName,
+		TestResult: result.TestResult,
+	}
+}
+
+func FlakyTest(flakyTest db.FlakyTest) atc.FlakyTest {
+	return atc.FlakyTest{
+		Suite:           flakyTest.Suite,
+		ClassName:       flakyTest.ClassName,
+		Name:            flakyTest.Name,
+		Passes:          flakyTest.Passes,
+		Failures:        flakyTest.Failures,
+		LastFailedBuild: flakyTest.LastFailedBuildName,
+	}
+}
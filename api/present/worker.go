@@ -22,6 +22,8 @@ func Worker(workerInfo dbng.Worker) atc.Worker {
 		HTTPSProxyURL:    workerInfo.HTTPSProxyURL,
 		NoProxy:          workerInfo.NoProxy,
 		ActiveContainers: workerInfo.ActiveContainers,
+		MaxContainers:    workerInfo.MaxContainers,
+		UnhealthyReason:  workerInfo.UnhealthyReason,
 		ResourceTypes:    workerInfo.ResourceTypes,
 		Platform:         workerInfo.Platform,
 		Tags:             workerInfo.Tags,
@@ -30,3 +32,14 @@ func Worker(workerInfo dbng.Worker) atc.Worker {
 		State:            string(workerInfo.State),
 	}
 }
+
+func WorkerPin(pin dbng.WorkerPin) atc.WorkerPin {
+	return atc.WorkerPin{
+		BuildID:      pin.BuildID,
+		BuildName:    pin.BuildName,
+		JobName:      pin.JobName,
+		PipelineName: pin.PipelineName,
+		TeamName:     pin.TeamName,
+		Containers:   pin.ContainerHandles,
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -320,16 +321,19 @@ var _ = Describe("Resources API", func() {
 			Context("when the call to get a resource succeeds", func() {
 				BeforeEach(func() {
 					fakePipelineDB.GetResourceReturns(db.SavedResource{
-						ID:           1,
-						CheckError:   errors.New("sup"),
-						Paused:       true,
-						PipelineName: "a-pipeline",
+						ID:            1,
+						CheckError:    errors.New("sup"),
+						CheckFailures: 3,
+						LastChecked:   time.Unix(1000, 0),
+						Paused:        true,
+						PipelineName:  "a-pipeline",
 						Resource: db.Resource{
 							Name: "resource-1",
 						},
 						Config: atc.ResourceConfig{
 							Type: "type-1",
 						},
+						CheckBackoffInterval: 8 * time.Minute,
 					}, true, nil)
 					fakePipelineDB.ConfigReturns(atc.Config{
 						Groups: []atc.GroupConfig{
@@ -361,9 +365,39 @@ var _ = Describe("Resources API", func() {
 								"url": "/teams/a-team/pipelines/a-pipeline/resources/resource-1",
 								"paused": true,
 								"failing_to_check": true,
-								"check_error": "sup"
+								"check_error": "sup",
+								"check_failures": 3,
+								"check_backoff": 480,
+								"next_check": 1480
 							}`))
 				})
+
+				Context("when the resource's checks are not backing off", func() {
+					BeforeEach(func() {
+						fakePipelineDB.GetResourceReturns(db.SavedResource{
+							ID:            1,
+							CheckError:    errors.New("sup"),
+							CheckFailures: 1,
+							LastChecked:   time.Unix(1000, 0),
+							PipelineName:  "a-pipeline",
+							Resource: db.Resource{
+								Name: "resource-1",
+							},
+							Config: atc.ResourceConfig{
+								Type: "type-1",
+							},
+						}, true, nil)
+					})
+
+					It("does not report a backoff or the next check", func() {
+						var resource atc.Resource
+						err := json.NewDecoder(response.Body).Decode(&resource)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(resource.CheckBackoff).To(BeZero())
+						Expect(resource.NextCheck).To(BeZero())
+					})
+				})
 			})
 		})
 	})
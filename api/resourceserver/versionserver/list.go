@@ -2,10 +2,15 @@ package versionserver
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
@@ -46,7 +51,14 @@ func (s *Server) ListResourceVersions(pipelineDB db.PipelineDB, _ dbng.Pipeline)
 			limit = atc.PaginationAPIDefaultLimit
 		}
 
-		versions, pagination, found, err := pipelineDB.GetResourceVersions(resourceName, db.Page{
+		filter, err := parseVersionFilter(r.URL.Query())
+		if err != nil {
+			logger.Info("malformed-filter", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		versions, pagination, found, err := pipelineDB.GetResourceVersions(resourceName, filter, db.Page{
 			Until: until,
 			Since: since,
 			From:  from,
@@ -64,12 +76,14 @@ func (s *Server) ListResourceVersions(pipelineDB db.PipelineDB, _ dbng.Pipeline)
 			return
 		}
 
+		filterQuery := versionFilterQuery(r.URL.Query())
+
 		if pagination.Next != nil {
-			s.addNextLink(w, teamName, pipelineDB.GetPipelineName(), resourceName, *pagination.Next)
+			s.addNextLink(w, teamName, pipelineDB.GetPipelineName(), resourceName, *pagination.Next, filterQuery)
 		}
 
 		if pagination.Previous != nil {
-			s.addPreviousLink(w, teamName, pipelineDB.GetPipelineName(), resourceName, *pagination.Previous)
+			s.addPreviousLink(w, teamName, pipelineDB.GetPipelineName(), resourceName, *pagination.Previous, filterQuery)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -84,9 +98,9 @@ func (s *Server) ListResourceVersions(pipelineDB db.PipelineDB, _ dbng.Pipeline)
 	})
 }
 
-func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, page db.Page) {
+func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, page db.Page, filterQuery string) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/versions?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/versions?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		teamName,
 		pipelineName,
@@ -95,13 +109,14 @@ func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName, reso
 		page.Since,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		filterQuery,
 		atc.LinkRelNext,
 	))
 }
 
-func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, page db.Page) {
+func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName, resourceName string, page db.Page, filterQuery string) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/versions?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/teams/%s/pipelines/%s/resources/%s/versions?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		teamName,
 		pipelineName,
@@ -110,6 +125,98 @@ func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName,
 		page.Until,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		filterQuery,
 		atc.LinkRelPrevious,
 	))
 }
+
+var versionFilterParams = []string{
+	atc.VersionQueryFilter,
+	atc.VersionQueryMetadata,
+	atc.VersionQueryEnabled,
+	atc.VersionQueryAfter,
+	atc.VersionQueryBefore,
+}
+
+func parseVersionFilter(query url.Values) (db.VersionFilter, error) {
+	var filter db.VersionFilter
+
+	for _, field := range query[atc.VersionQueryFilter] {
+		name, value, err := splitField(field)
+		if err != nil {
+			return db.VersionFilter{}, err
+		}
+
+		if filter.Version == nil {
+			filter.Version = db.Version{}
+		}
+
+		filter.Version[name] = value
+	}
+
+	for _, field := range query[atc.VersionQueryMetadata] {
+		name, value, err := splitField(field)
+		if err != nil {
+			return db.VersionFilter{}, err
+		}
+
+		filter.Metadata = append(filter.Metadata, db.MetadataField{
+			Name:  name,
+			Value: value,
+		})
+	}
+
+	if rawEnabled := query.Get(atc.VersionQueryEnabled); rawEnabled != "" {
+		enabled, err := strconv.ParseBool(rawEnabled)
+		if err != nil {
+			return db.VersionFilter{}, err
+		}
+
+		filter.Enabled = &enabled
+	}
+
+	if rawAfter := query.Get(atc.VersionQueryAfter); rawAfter != "" {
+		after, err := time.Parse(time.RFC3339, rawAfter)
+		if err != nil {
+			return db.VersionFilter{}, err
+		}
+
+		filter.ModifiedAfter = after
+	}
+
+	if rawBefore := query.Get(atc.VersionQueryBefore); rawBefore != "" {
+		before, err := time.Parse(time.RFC3339, rawBefore)
+		if err != nil {
+			return db.VersionFilter{}, err
+		}
+
+		filter.ModifiedBefore = before
+	}
+
+	return filter, nil
+}
+
+func splitField(field string) (string, string, error) {
+	segs := strings.SplitN(field, ":", 2)
+	if len(segs) != 2 || segs[0] == "" {
+		return "", "", errors.New("expected name:value, got '" + field + "'")
+	}
+
+	return segs[0], segs[1], nil
+}
+
+// versionFilterQuery preserves the filter across pagination links.
+func versionFilterQuery(query url.Values) string {
+	filterQuery := url.Values{}
+	for _, param := range versionFilterParams {
+		if values, ok := query[param]; ok {
+			filterQuery[param] = values
+		}
+	}
+
+	if len(filterQuery) == 0 {
+		return ""
+	}
+
+	return "&" + filterQuery.Encode()
+}
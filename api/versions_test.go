@@ -81,8 +81,9 @@ var _ = Describe("Versions API", func() {
 				It("does not set defaults for since and until", func() {
 					Expect(pipelineDB.GetResourceVersionsCallCount()).To(Equal(1))
 
-					resourceName, page := pipelineDB.GetResourceVersionsArgsForCall(0)
+					resourceName, filter, page := pipelineDB.GetResourceVersionsArgsForCall(0)
 					Expect(resourceName).To(Equal("some-resource"))
+					Expect(filter).To(Equal(db.VersionFilter{}))
 					Expect(page).To(Equal(db.Page{
 						Since: 0,
 						Until: 0,
@@ -101,7 +102,7 @@ var _ = Describe("Versions API", func() {
 				It("passes them through", func() {
 					Expect(pipelineDB.GetResourceVersionsCallCount()).To(Equal(1))
 
-					resourceName, page := pipelineDB.GetResourceVersionsArgsForCall(0)
+					resourceName, _, page := pipelineDB.GetResourceVersionsArgsForCall(0)
 					Expect(resourceName).To(Equal("some-resource"))
 					Expect(page).To(Equal(db.Page{
 						Since: 2,
@@ -113,6 +114,75 @@ var _ = Describe("Versions API", func() {
 				})
 			})
 
+			Context("when filter params are passed", func() {
+				BeforeEach(func() {
+					queryParams = "?filter=ref:abc123&filter=branch:master&metadata=author:alice&enabled=false&after=2016-11-01T00:00:00Z&before=2016-12-01T00:00:00Z"
+				})
+
+				It("passes them through as a filter", func() {
+					Expect(pipelineDB.GetResourceVersionsCallCount()).To(Equal(1))
+
+					disabled := false
+
+					_, filter, _ := pipelineDB.GetResourceVersionsArgsForCall(0)
+					Expect(filter).To(Equal(db.VersionFilter{
+						Version: db.Version{
+							"ref":    "abc123",
+							"branch": "master",
+						},
+						Metadata: []db.MetadataField{
+							{Name: "author", Value: "alice"},
+						},
+						Enabled:        &disabled,
+						ModifiedAfter:  time.Date(2016, 11, 1, 0, 0, 0, 0, time.UTC),
+						ModifiedBefore: time.Date(2016, 12, 1, 0, 0, 0, 0, time.UTC),
+					}))
+				})
+
+				Context("when next/previous pages are available", func() {
+					BeforeEach(func() {
+						queryParams = "?filter=ref:abc123&limit=2"
+
+						pipelineDB.GetPipelineNameReturns("some-pipeline")
+						pipelineDB.GetResourceVersionsReturns([]db.SavedVersionedResource{}, db.Pagination{
+							Previous: &db.Page{Until: 4, Limit: 2},
+							Next:     &db.Page{Since: 2, Limit: 2},
+						}, true, nil)
+					})
+
+					It("preserves the filter in the Link headers", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/some-pipeline/resources/some-resource/versions?until=4&limit=2&filter=ref%%3Aabc123>; rel="previous"`, externalURL),
+							fmt.Sprintf(`<%s/api/v1/teams/a-team/pipelines/some-pipeline/resources/some-resource/versions?since=2&limit=2&filter=ref%%3Aabc123>; rel="next"`, externalURL),
+						}))
+					})
+				})
+			})
+
+			Context("when a filter param is malformed", func() {
+				BeforeEach(func() {
+					queryParams = "?filter=nope"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("does not look up versions", func() {
+					Expect(pipelineDB.GetResourceVersionsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the time range is malformed", func() {
+				BeforeEach(func() {
+					queryParams = "?after=yesterday"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
 			Context("when getting the versions succeeds", func() {
 				var returnedVersions []db.SavedVersionedResource
 
@@ -58,8 +58,9 @@ var _ = Describe("Workers API", func() {
 							HTTPSProxyURL:    "https://some-proxy.com",
 							NoProxy:          "no,proxy",
 							ActiveContainers: 1,
+							UnhealthyReason:  "garden: connection refused",
 							ResourceTypes: []atc.WorkerResourceType{
-								{Type: "some-resource", Image: "some-resource-image"},
+								{Type: "some-resource", Image: "some-resource-image", Version: "some-version"},
 							},
 							Platform: "freebsd",
 							Tags:     []string{"demon"},
@@ -70,7 +71,7 @@ var _ = Describe("Workers API", func() {
 							BaggageclaimURL:  &bcURL2,
 							ActiveContainers: 2,
 							ResourceTypes: []atc.WorkerResourceType{
-								{Type: "some-resource", Image: "some-resource-image"},
+								{Type: "some-resource", Image: "some-resource-image", Version: "old-version"},
 							},
 							Platform: "beos",
 							Tags:     []string{"best", "os", "ever", "rip"},
@@ -96,8 +97,9 @@ var _ = Describe("Workers API", func() {
 							HTTPSProxyURL:    "https://some-proxy.com",
 							NoProxy:          "no,proxy",
 							ActiveContainers: 1,
+							UnhealthyReason:  "garden: connection refused",
 							ResourceTypes: []atc.WorkerResourceType{
-								{Type: "some-resource", Image: "some-resource-image"},
+								{Type: "some-resource", Image: "some-resource-image", Version: "some-version"},
 							},
 							Platform: "freebsd",
 							Tags:     []string{"demon"},
@@ -108,11 +110,12 @@ var _ = Describe("Workers API", func() {
 							BaggageclaimURL:  "5.6.7.8:8888",
 							ActiveContainers: 2,
 							ResourceTypes: []atc.WorkerResourceType{
-								{Type: "some-resource", Image: "some-resource-image"},
+								{Type: "some-resource", Image: "some-resource-image", Version: "old-version"},
 							},
-							Platform: "beos",
-							Tags:     []string{"best", "os", "ever", "rip"},
-							State:    "stalled",
+							Platform:             "beos",
+							Tags:                 []string{"best", "os", "ever", "rip"},
+							State:                "stalled",
+							DriftedResourceTypes: []string{"some-resource"},
 						},
 					}))
 
@@ -361,6 +364,24 @@ var _ = Describe("Workers API", func() {
 					Expect(dbWorkerFactory.SaveWorkerCallCount()).To(BeZero())
 				})
 			})
+
+			Context("when the worker has a negative max containers", func() {
+				BeforeEach(func() {
+					worker.MaxContainers = -1
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+
+				It("returns the validation error in the response body", func() {
+					Expect(ioutil.ReadAll(response.Body)).To(Equal([]byte("invalid max containers")))
+				})
+
+				It("does not save it", func() {
+					Expect(dbWorkerFactory.SaveWorkerCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		Context("when not authenticated", func() {
@@ -472,6 +493,125 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/workers/:worker_name/pins", func() {
+		var (
+			response   *http.Response
+			workerName string
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers/"+workerName+"/pins", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			workerName = "some-worker"
+			authValidator.IsAuthenticatedReturns(true)
+			dbWorkerFactory.GetWorkerReturns(&dbng.Worker{
+				Name:     workerName,
+				TeamName: "some-team",
+				State:    dbng.WorkerStateLanding,
+			}, true, nil)
+			dbWorkerFactory.WorkerPinsReturns([]dbng.WorkerPin{
+				{
+					BuildID:          42,
+					BuildName:        "3",
+					JobName:          "some-job",
+					PipelineName:     "some-pipeline",
+					TeamName:         "some-team",
+					ContainerHandles: []string{"handle-1", "handle-2"},
+				},
+				{
+					BuildID:          43,
+					BuildName:        "1234",
+					TeamName:         "some-team",
+					ContainerHandles: []string{"handle-3"},
+				},
+			}, nil)
+		})
+
+		Context("when the request is authenticated as the worker's owner", func() {
+			BeforeEach(func() {
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns application/json", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("looks up the pins for the worker", func() {
+				Expect(dbWorkerFactory.WorkerPinsCallCount()).To(Equal(1))
+				Expect(dbWorkerFactory.WorkerPinsArgsForCall(0)).To(Equal(workerName))
+			})
+
+			It("returns the builds and containers pinning the worker", func() {
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{
+						"build_id": 42,
+						"build_name": "3",
+						"job_name": "some-job",
+						"pipeline_name": "some-pipeline",
+						"team_name": "some-team",
+						"containers": ["handle-1", "handle-2"]
+					},
+					{
+						"build_id": 43,
+						"build_name": "1234",
+						"team_name": "some-team",
+						"containers": ["handle-3"]
+					}
+				]`))
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when looking up the pins fails", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.WorkerPinsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the request is authenticated as the wrong team", func() {
+			BeforeEach(func() {
+				userContextReader.GetTeamReturns("some-other-team", false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/workers/:worker_name/retire", func() {
 		var (
 			response   *http.Response
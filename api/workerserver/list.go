@@ -33,6 +33,11 @@ func (s *Server) ListWorkers(teamDB db.TeamDB, team dbng.Team) http.Handler {
 		workers := make([]atc.Worker, len(savedWorkers))
 		for i, savedWorker := range savedWorkers {
 			workers[i] = present.Worker(*savedWorker)
+
+			drifted := s.baseResourceTypeVersions.Drift(savedWorker.ResourceTypes)
+			if len(drifted) > 0 {
+				workers[i].DriftedResourceTypes = drifted
+			}
 		}
 
 		json.NewEncoder(w).Encode(workers)
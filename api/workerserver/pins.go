@@ -0,0 +1,43 @@
+package workerserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+)
+
+func (s *Server) ListWorkerPins(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-worker-pins")
+	workerName := r.FormValue(":worker_name")
+
+	_, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	if err != nil {
+		logger.Error("failed-to-get-worker", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	savedPins, err := s.dbWorkerFactory.WorkerPins(workerName)
+	if err != nil {
+		logger.Error("failed-to-get-worker-pins", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	pins := make([]atc.WorkerPin, len(savedPins))
+	for i, savedPin := range savedPins {
+		pins[i] = present.WorkerPin(savedPin)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(pins)
+}
@@ -41,6 +41,12 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if registration.MaxContainers < 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid max containers")
+		return
+	}
+
 	var ttl time.Duration
 
 	ttlStr := r.URL.Query().Get("ttl")
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
 )
@@ -15,6 +16,8 @@ type Server struct {
 	teamDBFactory   db.TeamDBFactory
 	dbTeamFactory   dbng.TeamFactory
 	dbWorkerFactory dbng.WorkerFactory
+
+	baseResourceTypeVersions atc.BaseResourceTypeVersions
 }
 
 //go:generate counterfeiter . WorkerDB
@@ -30,6 +33,7 @@ func NewServer(
 	teamDBFactory db.TeamDBFactory,
 	dbTeamFactory dbng.TeamFactory,
 	dbWorkerFactory dbng.WorkerFactory,
+	baseResourceTypeVersions atc.BaseResourceTypeVersions,
 ) *Server {
 	return &Server{
 		logger:          logger,
@@ -37,5 +41,7 @@ func NewServer(
 		teamDBFactory:   teamDBFactory,
 		dbTeamFactory:   dbTeamFactory,
 		dbWorkerFactory: dbWorkerFactory,
+
+		baseResourceTypeVersions: baseResourceTypeVersions,
 	}
 }
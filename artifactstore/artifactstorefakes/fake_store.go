@@ -0,0 +1,258 @@
+// This file was generated by counterfeiter
+package artifactstorefakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/artifactstore"
+	"io"
+)
+
+type FakeStore struct {
+	SaveStub        func(buildID int, name string, tarStream io.Reader) error
+	saveMutex       sync.RWMutex
+	saveArgsForCall []struct {
+		buildID   int
+		name      string
+		tarStream io.Reader
+	}
+	saveReturns struct {
+		result1 error
+	}
+	OpenStub        func(buildID int, name string) (io.ReadCloser, bool, error)
+	openMutex       sync.RWMutex
+	openArgsForCall []struct {
+		buildID int
+		name    string
+	}
+	openReturns struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}
+	ListStub        func(buildID int) ([]string, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+		buildID int
+	}
+	listReturns struct {
+		result1 []string
+		result2 error
+	}
+	DeleteForBuildsStub        func(buildIDs []int) error
+	deleteForBuildsMutex       sync.RWMutex
+	deleteForBuildsArgsForCall []struct {
+		buildIDs []int
+	}
+	deleteForBuildsReturns struct {
+		result1 error
+	}
+	BuildIDsStub        func() ([]int, error)
+	buildIDsMutex       sync.RWMutex
+	buildIDsArgsForCall []struct{}
+	buildIDsReturns     struct {
+		result1 []int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStore) Save(buildID int, name string, tarStream io.Reader) error {
+	fake.saveMutex.Lock()
+	fake.saveArgsForCall = append(fake.saveArgsForCall, struct {
+		buildID   int
+		name      string
+		tarStream io.Reader
+	}{buildID, name, tarStream})
+	fake.recordInvocation("Save", []interface{}{buildID, name, tarStream})
+	fake.saveMutex.Unlock()
+	if fake.SaveStub != nil {
+		return fake.SaveStub(buildID, name, tarStream)
+	} else {
+		return fake.saveReturns.result1
+	}
+}
+
+func (fake *FakeStore) SaveCallCount() int {
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	return len(fake.saveArgsForCall)
+}
+
+func (fake *FakeStore) SaveArgsForCall(i int) (int, string, io.Reader) {
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	return fake.saveArgsForCall[i].buildID, fake.saveArgsForCall[i].name, fake.saveArgsForCall[i].tarStream
+}
+
+func (fake *FakeStore) SaveReturns(result1 error) {
+	fake.SaveStub = nil
+	fake.saveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) Open(buildID int, name string) (io.ReadCloser, bool, error) {
+	fake.openMutex.Lock()
+	fake.openArgsForCall = append(fake.openArgsForCall, struct {
+		buildID int
+		name    string
+	}{buildID, name})
+	fake.recordInvocation("Open", []interface{}{buildID, name})
+	fake.openMutex.Unlock()
+	if fake.OpenStub != nil {
+		return fake.OpenStub(buildID, name)
+	} else {
+		return fake.openReturns.result1, fake.openReturns.result2, fake.openReturns.result3
+	}
+}
+
+func (fake *FakeStore) OpenCallCount() int {
+	fake.openMutex.RLock()
+	defer fake.openMutex.RUnlock()
+	return len(fake.openArgsForCall)
+}
+
+func (fake *FakeStore) OpenArgsForCall(i int) (int, string) {
+	fake.openMutex.RLock()
+	defer fake.openMutex.RUnlock()
+	return fake.openArgsForCall[i].buildID, fake.openArgsForCall[i].name
+}
+
+func (fake *FakeStore) OpenReturns(result1 io.ReadCloser, result2 bool, result3 error) {
+	fake.OpenStub = nil
+	fake.openReturns = struct {
+		result1 io.ReadCloser
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeStore) List(buildID int) ([]string, error) {
+	fake.listMutex.Lock()
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.recordInvocation("List", []interface{}{buildID})
+	fake.listMutex.Unlock()
+	if fake.ListStub != nil {
+		return fake.ListStub(buildID)
+	} else {
+		return fake.listReturns.result1, fake.listReturns.result2
+	}
+}
+
+func (fake *FakeStore) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeStore) ListArgsForCall(i int) int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return fake.listArgsForCall[i].buildID
+}
+
+func (fake *FakeStore) ListReturns(result1 []string, result2 error) {
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) DeleteForBuilds(buildIDs []int) error {
+	var buildIDsCopy []int
+	if buildIDs != nil {
+		buildIDsCopy = make([]int, len(buildIDs))
+		copy(buildIDsCopy, buildIDs)
+	}
+	fake.deleteForBuildsMutex.Lock()
+	fake.deleteForBuildsArgsForCall = append(fake.deleteForBuildsArgsForCall, struct {
+		buildIDs []int
+	}{buildIDsCopy})
+	fake.recordInvocation("DeleteForBuilds", []interface{}{buildIDsCopy})
+	fake.deleteForBuildsMutex.Unlock()
+	if fake.DeleteForBuildsStub != nil {
+		return fake.DeleteForBuildsStub(buildIDs)
+	} else {
+		return fake.deleteForBuildsReturns.result1
+	}
+}
+
+func (fake *FakeStore) DeleteForBuildsCallCount() int {
+	fake.deleteForBuildsMutex.RLock()
+	defer fake.deleteForBuildsMutex.RUnlock()
+	return len(fake.deleteForBuildsArgsForCall)
+}
+
+func (fake *FakeStore) DeleteForBuildsArgsForCall(i int) []int {
+	fake.deleteForBuildsMutex.RLock()
+	defer fake.deleteForBuildsMutex.RUnlock()
+	return fake.deleteForBuildsArgsForCall[i].buildIDs
+}
+
+func (fake *FakeStore) DeleteForBuildsReturns(result1 error) {
+	fake.DeleteForBuildsStub = nil
+	fake.deleteForBuildsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) BuildIDs() ([]int, error) {
+	fake.buildIDsMutex.Lock()
+	fake.buildIDsArgsForCall = append(fake.buildIDsArgsForCall, struct{}{})
+	fake.recordInvocation("BuildIDs", []interface{}{})
+	fake.buildIDsMutex.Unlock()
+	if fake.BuildIDsStub != nil {
+		return fake.BuildIDsStub()
+	} else {
+		return fake.buildIDsReturns.result1, fake.buildIDsReturns.result2
+	}
+}
+
+func (fake *FakeStore) BuildIDsCallCount() int {
+	fake.buildIDsMutex.RLock()
+	defer fake.buildIDsMutex.RUnlock()
+	return len(fake.buildIDsArgsForCall)
+}
+
+func (fake *FakeStore) BuildIDsReturns(result1 []int, result2 error) {
+	fake.BuildIDsStub = nil
+	fake.buildIDsReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	fake.openMutex.RLock()
+	defer fake.openMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	fake.deleteForBuildsMutex.RLock()
+	defer fake.deleteForBuildsMutex.RUnlock()
+	fake.buildIDsMutex.RLock()
+	defer fake.buildIDsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ artifactstore.Store = new(FakeStore)
@@ -0,0 +1,195 @@
+package artifactstore
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+var ErrInvalidArtifactName = errors.New("invalid artifact name")
+
+var ErrArtifactsDisabled = errors.New("build artifacts are disabled; the ATC must be given a --build-artifacts-dir to keep them in")
+
+const tarballExtension = ".tar"
+
+//go:generate counterfeiter . Store
+
+// Store holds the artifacts saved by builds, as tarballs keyed by the build's
+// ID and the artifact's name.
+type Store interface {
+	Save(buildID int, name string, tarStream io.Reader) error
+	Open(buildID int, name string) (io.ReadCloser, bool, error)
+	List(buildID int) ([]string, error)
+	DeleteForBuilds(buildIDs []int) error
+
+	// BuildIDs returns the IDs of the builds that have artifacts in the store.
+	BuildIDs() ([]int, error)
+}
+
+type localStore struct {
+	dir string
+}
+
+// NewLocalStore returns a Store that keeps each build's artifacts in its own
+// directory under dir. Other ATCs can't reach the directory, so it must only
+// be used when there is a single ATC.
+func NewLocalStore(dir string) Store {
+	return &localStore{
+		dir: dir,
+	}
+}
+
+func (store *localStore) Save(buildID int, name string, tarStream io.Reader) error {
+	if !atc.ValidArtifactName(name) {
+		return ErrInvalidArtifactName
+	}
+
+	buildDir := store.buildDir(buildID)
+
+	err := os.MkdirAll(buildDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(buildDir, ".saving-")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tmp, tarStream)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(buildDir, name+tarballExtension))
+}
+
+func (store *localStore) Open(buildID int, name string) (io.ReadCloser, bool, error) {
+	if !atc.ValidArtifactName(name) {
+		return nil, false, nil
+	}
+
+	file, err := os.Open(filepath.Join(store.buildDir(buildID), name+tarballExtension))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
+
+func (store *localStore) List(buildID int) ([]string, error) {
+	infos, err := ioutil.ReadDir(store.buildDir(buildID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+
+		return nil, err
+	}
+
+	names := []string{}
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, tarballExtension) || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		names = append(names, strings.TrimSuffix(name, tarballExtension))
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (store *localStore) DeleteForBuilds(buildIDs []int) error {
+	for _, buildID := range buildIDs {
+		err := os.RemoveAll(store.buildDir(buildID))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (store *localStore) BuildIDs() ([]int, error) {
+	infos, err := ioutil.ReadDir(store.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []int{}, nil
+		}
+
+		return nil, err
+	}
+
+	buildIDs := []int{}
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+
+		buildID, err := strconv.Atoi(info.Name())
+		if err != nil {
+			continue
+		}
+
+		buildIDs = append(buildIDs, buildID)
+	}
+
+	sort.Ints(buildIDs)
+
+	return buildIDs, nil
+}
+
+func (store *localStore) buildDir(buildID int) string {
+	return filepath.Join(store.dir, strconv.Itoa(buildID))
+}
+
+type disabledStore struct{}
+
+// NewDisabledStore returns a Store for when there is nowhere durable to keep
+// artifacts. Saving fails, so that jobs configured with artifacts don't
+// quietly lose them, and there is never anything to open.
+func NewDisabledStore() Store {
+	return disabledStore{}
+}
+
+func (disabledStore) Save(int, string, io.Reader) error {
+	return ErrArtifactsDisabled
+}
+
+func (disabledStore) Open(int, string) (io.ReadCloser, bool, error) {
+	return nil, false, nil
+}
+
+func (disabledStore) List(int) ([]string, error) {
+	return []string{}, nil
+}
+
+func (disabledStore) DeleteForBuilds([]int) error {
+	return nil
+}
+
+func (disabledStore) BuildIDs() ([]int, error) {
+	return []int{}, nil
+}
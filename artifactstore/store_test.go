@@ -0,0 +1,147 @@
+package artifactstore_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/atc/artifactstore"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalStore", func() {
+	var (
+		dir   string
+		store artifactstore.Store
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "artifact-store")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = artifactstore.NewLocalStore(dir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("Save", func() {
+		It("can be opened afterwards", func() {
+			err := store.Save(42, "some-output", bytes.NewBufferString("some-tarball"))
+			Expect(err).NotTo(HaveOccurred())
+
+			tarball, found, err := store.Open(42, "some-output")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			defer tarball.Close()
+
+			contents, err := ioutil.ReadAll(tarball)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-tarball"))
+		})
+
+		It("rejects names that would escape the build's directory", func() {
+			err := store.Save(42, "../some-output", bytes.NewBufferString("some-tarball"))
+			Expect(err).To(Equal(artifactstore.ErrInvalidArtifactName))
+		})
+	})
+
+	Describe("Open", func() {
+		It("returns false for an artifact that was never saved", func() {
+			_, found, err := store.Open(42, "some-output")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("List", func() {
+		It("returns the names of the build's artifacts", func() {
+			Expect(store.Save(42, "some-output", bytes.NewBufferString("a"))).To(Succeed())
+			Expect(store.Save(42, "another-output", bytes.NewBufferString("b"))).To(Succeed())
+			Expect(store.Save(43, "other-build-output", bytes.NewBufferString("c"))).To(Succeed())
+
+			names, err := store.List(42)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(Equal([]string{"another-output", "some-output"}))
+		})
+
+		It("returns an empty list for a build without artifacts", func() {
+			names, err := store.List(42)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(BeEmpty())
+		})
+	})
+
+	Describe("DeleteForBuilds", func() {
+		It("removes only the given builds' artifacts", func() {
+			Expect(store.Save(42, "some-output", bytes.NewBufferString("a"))).To(Succeed())
+			Expect(store.Save(43, "some-output", bytes.NewBufferString("b"))).To(Succeed())
+
+			err := store.DeleteForBuilds([]int{42})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := store.Open(42, "some-output")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = store.Open(43, "some-output")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Describe("BuildIDs", func() {
+		It("returns the IDs of the builds with artifacts", func() {
+			Expect(store.Save(43, "some-output", bytes.NewBufferString("a"))).To(Succeed())
+			Expect(store.Save(42, "some-output", bytes.NewBufferString("b"))).To(Succeed())
+			Expect(store.Save(42, "another-output", bytes.NewBufferString("c"))).To(Succeed())
+
+			Expect(ioutil.WriteFile(filepath.Join(dir, "not-a-build"), []byte("d"), 0644)).To(Succeed())
+
+			buildIDs, err := store.BuildIDs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buildIDs).To(Equal([]int{42, 43}))
+		})
+
+		It("returns an empty list when nothing has been saved", func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+
+			buildIDs, err := store.BuildIDs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buildIDs).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("DisabledStore", func() {
+	var store artifactstore.Store
+
+	BeforeEach(func() {
+		store = artifactstore.NewDisabledStore()
+	})
+
+	It("refuses to save artifacts", func() {
+		err := store.Save(42, "some-output", bytes.NewBufferString("some-tarball"))
+		Expect(err).To(Equal(artifactstore.ErrArtifactsDisabled))
+	})
+
+	It("has no artifacts", func() {
+		_, found, err := store.Open(42, "some-output")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		names, err := store.List(42)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(BeEmpty())
+
+		buildIDs, err := store.BuildIDs()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buildIDs).To(BeEmpty())
+	})
+})
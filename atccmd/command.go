@@ -19,6 +19,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/buildserver"
+	"github.com/concourse/atc/artifactstore"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/builds"
@@ -40,6 +41,7 @@ import (
 	"github.com/concourse/atc/web/publichandler"
 	"github.com/concourse/atc/web/robotstxt"
 	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/health"
 	"github.com/concourse/atc/worker/image"
 	"github.com/concourse/atc/wrappa"
 	"github.com/concourse/retryhttp"
@@ -81,11 +83,31 @@ type ATCCommand struct {
 	SessionSigningKey FileFlag `long:"session-signing-key" description:"File containing an RSA private key, used to sign session tokens."`
 
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
+	ResourceCheckingMaxBackoff   time.Duration `long:"resource-checking-max-backoff" default:"1h" description:"Maximum interval to back off to when a resource's checks keep failing."`
 	OldResourceGracePeriod       time.Duration `long:"old-resource-grace-period" default:"5m" description:"How long to cache the result of a get step after a newer version of the resource is found."`
 	ResourceCacheCleanupInterval time.Duration `long:"resource-cache-cleanup-interval" default:"30s" description:"Interval on which to cleanup old caches of resources."`
 
+	WorkerHealthCheckInterval     time.Duration `long:"worker-health-check-interval"     default:"30s" description:"Interval on which to probe the Garden and Baggageclaim servers of each worker."`
+	WorkerHealthCheckTimeout      time.Duration `long:"worker-health-check-timeout"      default:"5s"  description:"How long to wait for a worker to respond to a health probe before no longer placing containers on it."`
+	WorkerCapacityPollingInterval time.Duration `long:"worker-capacity-polling-interval" default:"10s" description:"Interval on which steps waiting for every compatible worker to be full check again for room."`
+
+	BaseResourceTypeVersions atc.BaseResourceTypeVersions `long:"base-resource-type-version" value-name:"TYPE:VERSION" description:"Version of a base resource type that workers are expected to provide. Only workers providing it run steps of that type, and others are reported as drifted. Can be specified multiple times."`
+
+	ImageResourceCheckTimeout time.Duration `long:"image-resource-check-timeout" description:"How long to wait for the check of a step's image resource before failing the step. Defaults to no timeout."`
+	ImageResourceGetTimeout   time.Duration `long:"image-resource-get-timeout"   description:"How long to wait for the get of a step's image resource before failing the step. Defaults to no timeout."`
+
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	ClusterEventRetention time.Duration `long:"cluster-event-retention" default:"168h" description:"How long to keep the events of the cluster-wide event stream. Zero keeps them forever."`
+
+	BuildArtifactsDir      DirFlag `long:"build-artifacts-dir"       description:"Durable directory in which to keep the artifacts saved by job builds, e.g. a persistent disk. Jobs can't save artifacts unless it is set. Artifacts are only kept on this ATC's disk, so only a single ATC in a cluster may be given one."`
+	BuildArtifactsToRetain int     `long:"build-artifacts-to-retain" default:"10" description:"Number of most recent builds of a job to keep the artifacts of when the job keeps all of its build logs."`
+
+	DefaultTaskLimits struct {
+		CPU    uint64 `long:"default-task-cpu-limit"    description:"CPU shares given to task containers that do not configure their own."`
+		Memory uint64 `long:"default-task-memory-limit" description:"Memory limit, in bytes, of task containers that do not configure their own."`
+	}
+
 	Developer struct {
 		Noop bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
 	} `group:"Developer Options"`
@@ -163,9 +185,11 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	dbTeamFactory := dbng.NewTeamFactory(dbngConn, lockFactory)
 	dbPipelineFactory := dbng.NewPipelineFactory(dbngConn, lockFactory)
 	dbWorkerFactory := dbng.NewWorkerFactory(dbngConn)
-	dbResourceCacheFactory := dbng.NewResourceCacheFactory(dbngConn, lockFactory)
-	dbResourceConfigFactory := dbng.NewResourceConfigFactory(dbngConn, lockFactory)
+	dbResourceCacheFactory := dbng.NewResourceCacheFactory(dbngConn, lockFactory, cmd.BaseResourceTypeVersions)
+	dbTaskCacheFactory := dbng.NewTaskCacheFactory(dbngConn)
+	dbResourceConfigFactory := dbng.NewResourceConfigFactory(dbngConn, lockFactory, cmd.BaseResourceTypeVersions)
 	dbBaseResourceTypeFactory := dbng.NewBaseResourceTypeFactory(dbngConn)
+	dbWorkerTaskCacheFactory := dbng.NewWorkerTaskCacheFactory(dbngConn)
 	workerClient := cmd.constructWorkerPool(
 		logger,
 		sqlDB,
@@ -175,6 +199,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
 		dbBaseResourceTypeFactory,
+		dbWorkerTaskCacheFactory,
 		dbVolumeFactory,
 		dbWorkerFactory,
 		dbTeamFactory,
@@ -183,17 +208,26 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	resourceFactory := resourceFactoryFactory.FactoryFor(workerClient)
 	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, teamDBFactory)
+
+	artifactStore, err := cmd.constructArtifactStore(logger, lockFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, dbTaskCacheFactory, teamDBFactory, artifactStore)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		resourceFactory,
 		cmd.ResourceCheckingInterval,
+		cmd.ResourceCheckingMaxBackoff,
 		engine,
+		workerClient,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		resourceFactory,
 		cmd.ResourceCheckingInterval,
+		radar.Backoff{Max: cmd.ResourceCheckingMaxBackoff},
 		cmd.ExternalURL.String(),
 	)
 
@@ -239,6 +273,7 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		pipelineDBFactory,
 		engine,
 		workerClient,
+		artifactStore,
 		drain,
 		radarSchedulerFactory,
 		radarScannerFactory,
@@ -367,6 +402,14 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 					logger.Session("resource-cache-collector"),
 					dbResourceCacheFactory,
 				),
+				gcng.NewTaskCacheCollector(
+					logger.Session("task-cache-collector"),
+					dbTaskCacheFactory,
+				),
+				gcng.NewWorkerTaskCacheCollector(
+					logger.Session("worker-task-cache-collector"),
+					dbWorkerTaskCacheFactory,
+				),
 				gcng.NewVolumeCollector(
 					logger.Session("volume-collector"),
 					dbVolumeFactory,
@@ -391,13 +434,47 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 				logger.Session("build-reaper"),
 				sqlDB,
 				pipelineDBFactory,
+				artifactStore,
 				500,
+				cmd.BuildArtifactsToRetain,
+				cmd.ClusterEventRetention,
 			),
 			"build-reaper",
 			sqlDB,
 			clock.NewClock(),
 			30*time.Second,
 		)},
+
+		{"build-log-indexer", lockrunner.NewRunner(
+			logger.Session("build-log-indexer-runner"),
+			builds.NewLogIndexer(
+				logger.Session("build-log-indexer"),
+				sqlDB,
+				50,
+			),
+			"build-log-indexer",
+			sqlDB,
+			clock.NewClock(),
+			time.Minute,
+		)},
+
+		{"worker-health-prober", lockrunner.NewRunner(
+			logger.Session("worker-health-prober-runner"),
+			health.NewProber(
+				logger.Session("worker-health-prober"),
+				dbWorkerFactory,
+				health.NewClientFactory(
+					logger.Session("worker-health-client"),
+					dbWorkerFactory,
+					cmd.WorkerHealthCheckTimeout,
+				),
+				clock.NewClock(),
+			),
+			"worker-health-prober",
+			sqlDB,
+			clock.NewClock(),
+			cmd.WorkerHealthCheckInterval,
+		)},
 	}
 
 	if cmd.Worker.GardenURL.URL() != nil {
@@ -652,6 +729,7 @@ func (cmd *ATCCommand) constructWorkerPool(
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
 	dbResourceConfigFactory dbng.ResourceConfigFactory,
 	dbBaseResourceTypeFactory dbng.BaseResourceTypeFactory,
+	dbWorkerTaskCacheFactory dbng.WorkerTaskCacheFactory,
 	dbVolumeFactory dbng.VolumeFactory,
 	dbWorkerFactory dbng.WorkerFactory,
 	dbTeamFactory dbng.TeamFactory,
@@ -660,6 +738,7 @@ func (cmd *ATCCommand) constructWorkerPool(
 		resourceFetcherFactory,
 		resourceFactoryFactory,
 		dbResourceCacheFactory,
+		clock.NewClock(),
 	)
 	return worker.NewPool(
 		worker.NewDBWorkerProvider(
@@ -671,14 +750,42 @@ func (cmd *ATCCommand) constructWorkerPool(
 			dbResourceCacheFactory,
 			dbResourceConfigFactory,
 			dbBaseResourceTypeFactory,
+			dbWorkerTaskCacheFactory,
 			dbVolumeFactory,
 			dbTeamFactory,
 			pipelineDBFactory,
 			dbWorkerFactory,
 		),
+		clock.NewClock(),
+		cmd.BaseResourceTypeVersions,
+		cmd.WorkerCapacityPollingInterval,
 	)
 }
 
+func (cmd *ATCCommand) constructArtifactStore(logger lager.Logger, lockFactory lock.LockFactory) (artifactstore.Store, error) {
+	dir := cmd.BuildArtifactsDir.Path()
+	if dir == "" {
+		logger.Info("build-artifacts-disabled")
+		return artifactstore.NewDisabledStore(), nil
+	}
+
+	// the artifacts are only on this ATC's disk, so builds running on or
+	// requests handled by any other ATC would not find them. the lock is held
+	// for as long as this ATC runs, so that a second one fails to start.
+	storeLock := lockFactory.NewLock(logger.Session("build-artifacts-store"), lock.NewBuildArtifactsStoreLockID())
+
+	acquired, err := storeLock.Acquire()
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock build artifacts store: %s", err)
+	}
+
+	if !acquired {
+		return nil, errors.New("another ATC is already keeping build artifacts; --build-artifacts-dir can only be given to a single ATC")
+	}
+
+	return artifactstore.NewLocalStore(dir), nil
+}
+
 func (cmd *ATCCommand) loadOrGenerateSigningKey() (*rsa.PrivateKey, error) {
 	var signingKey *rsa.PrivateKey
 
@@ -799,19 +906,30 @@ func (cmd *ATCCommand) constructEngine(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
+	dbTaskCacheFactory dbng.TaskCacheFactory,
 	teamDBFactory db.TeamDBFactory,
+	artifactStore artifactstore.Store,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
 		workerClient,
 		resourceFetcher,
 		resourceFactory,
 		dbResourceCacheFactory,
+		dbTaskCacheFactory,
+		atc.ContainerLimits{
+			CPU:    cmd.DefaultTaskLimits.CPU,
+			Memory: cmd.DefaultTaskLimits.Memory,
+		},
 	)
 
 	execV2Engine := engine.NewExecEngine(
 		gardenFactory,
-		engine.NewBuildDelegateFactory(),
+		engine.NewBuildDelegateFactory(worker.ImageFetchTimeouts{
+			Check: cmd.ImageResourceCheckTimeout,
+			Get:   cmd.ImageResourceGetTimeout,
+		}),
 		teamDBFactory,
+		artifactStore,
 		cmd.ExternalURL.String(),
 	)
 
@@ -857,6 +975,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 	pipelineDBFactory db.PipelineDBFactory,
 	engine engine.Engine,
 	workerClient worker.Client,
+	artifactStore artifactstore.Store,
 	drain <-chan struct{},
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
@@ -915,6 +1034,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 		sqlDB, // containerserver.ContainerDB
 		sqlDB, // pipes.PipeDB
 		sqlDB, // db.PipelinesDB
+		sqlDB, // eventserver.ClusterEventsDB
 
 		cmd.PeerURL.String(),
 		buildserver.NewEventHandler,
@@ -922,6 +1042,8 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 		engine,
 		workerClient,
+		artifactStore,
+		cmd.BaseResourceTypeVersions,
 		radarSchedulerFactory,
 		radarScannerFactory,
 
@@ -9,12 +9,13 @@ import (
 )
 
 type FakeTokenGenerator struct {
-	GenerateTokenStub        func(expiration time.Time, teamName string, isAdmin bool) (auth.TokenType, auth.TokenValue, error)
+	GenerateTokenStub        func(expiration time.Time, teamName string, isAdmin bool, userName string) (auth.TokenType, auth.TokenValue, error)
 	generateTokenMutex       sync.RWMutex
 	generateTokenArgsForCall []struct {
 		expiration time.Time
 		teamName   string
 		isAdmin    bool
+		userName   string
 	}
 	generateTokenReturns struct {
 		result1 auth.TokenType
@@ -25,17 +26,18 @@ type FakeTokenGenerator struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeTokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool) (auth.TokenType, auth.TokenValue, error) {
+func (fake *FakeTokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool, userName string) (auth.TokenType, auth.TokenValue, error) {
 	fake.generateTokenMutex.Lock()
 	fake.generateTokenArgsForCall = append(fake.generateTokenArgsForCall, struct {
 		expiration time.Time
 		teamName   string
 		isAdmin    bool
-	}{expiration, teamName, isAdmin})
-	fake.recordInvocation("GenerateToken", []interface{}{expiration, teamName, isAdmin})
+		userName   string
+	}{expiration, teamName, isAdmin, userName})
+	fake.recordInvocation("GenerateToken", []interface{}{expiration, teamName, isAdmin, userName})
 	fake.generateTokenMutex.Unlock()
 	if fake.GenerateTokenStub != nil {
-		return fake.GenerateTokenStub(expiration, teamName, isAdmin)
+		return fake.GenerateTokenStub(expiration, teamName, isAdmin, userName)
 	} else {
 		return fake.generateTokenReturns.result1, fake.generateTokenReturns.result2, fake.generateTokenReturns.result3
 	}
@@ -47,10 +49,10 @@ func (fake *FakeTokenGenerator) GenerateTokenCallCount() int {
 	return len(fake.generateTokenArgsForCall)
 }
 
-func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (time.Time, string, bool) {
+func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (time.Time, string, bool, string) {
 	fake.generateTokenMutex.RLock()
 	defer fake.generateTokenMutex.RUnlock()
-	return fake.generateTokenArgsForCall[i].expiration, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].isAdmin
+	return fake.generateTokenArgsForCall[i].expiration, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].isAdmin, fake.generateTokenArgsForCall[i].userName
 }
 
 func (fake *FakeTokenGenerator) GenerateTokenReturns(result1 auth.TokenType, result2 auth.TokenValue, result3 error) {
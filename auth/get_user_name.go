@@ -0,0 +1,11 @@
+package auth
+
+import "net/http"
+
+// GetUserName returns the name of the user the request's token was issued
+// to. Only tokens from auth methods which identify the user carry one, e.g.
+// basic auth; OAuth tokens only identify the team.
+func GetUserName(r *http.Request) (string, bool) {
+	userName, present := r.Context().Value(userNameKey).(string)
+	return userName, present
+}
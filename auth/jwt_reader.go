@@ -31,6 +31,26 @@ func (jr JWTReader) GetTeam(r *http.Request) (string, bool, bool) {
 	return teamName, isAdmin, true
 }
 
+func (jr JWTReader) GetUserName(r *http.Request) (string, bool) {
+	token, err := getJWT(r, jr.PublicKey)
+	if err != nil {
+		return "", false
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	userNameInterface, userNameOK := claims[userNameClaimKey]
+	if !userNameOK {
+		return "", false
+	}
+
+	userName, ok := userNameInterface.(string)
+	if !ok || userName == "" {
+		return "", false
+	}
+
+	return userName, true
+}
+
 func (jr JWTReader) GetSystem(r *http.Request) (bool, bool) {
 	token, err := getJWT(r, jr.PublicKey)
 	if err != nil {
@@ -161,7 +161,8 @@ func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 
 	exp := time.Now().Add(handler.expire)
 
-	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.Admin)
+	// providers only verify membership, so the token doesn't name the user
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(exp, team.Name, team.Admin, "")
 	if err != nil {
 		hLog.Error("failed-to-sign-token", err)
 		http.Error(w, "failed to sign token", http.StatusInternalServerError)
@@ -16,9 +16,10 @@ const TokenTypeBearer = "Bearer"
 const expClaimKey = "exp"
 const teamNameClaimKey = "teamName"
 const isAdminClaimKey = "isAdmin"
+const userNameClaimKey = "userName"
 
 type TokenGenerator interface {
-	GenerateToken(expiration time.Time, teamName string, isAdmin bool) (TokenType, TokenValue, error)
+	GenerateToken(expiration time.Time, teamName string, isAdmin bool, userName string) (TokenType, TokenValue, error)
 }
 
 type tokenGenerator struct {
@@ -31,12 +32,20 @@ func NewTokenGenerator(privateKey *rsa.PrivateKey) TokenGenerator {
 	}
 }
 
-func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool) (TokenType, TokenValue, error) {
-	jwtToken := jwt.NewWithClaims(SigningMethod, jwt.MapClaims{
+// GenerateToken signs a token for the team. The user name identifies who
+// logged in, if the auth method knows; it is left out of the token otherwise.
+func (generator *tokenGenerator) GenerateToken(expiration time.Time, teamName string, isAdmin bool, userName string) (TokenType, TokenValue, error) {
+	claims := jwt.MapClaims{
 		expClaimKey:      expiration.Unix(),
 		teamNameClaimKey: teamName,
 		isAdminClaimKey:  isAdmin,
-	})
+	}
+
+	if userName != "" {
+		claims[userNameClaimKey] = userName
+	}
+
+	jwtToken := jwt.NewWithClaims(SigningMethod, claims)
 
 	signed, err := jwtToken.SignedString(generator.privateKey)
 	if err != nil {
@@ -6,5 +6,6 @@ import "net/http"
 
 type UserContextReader interface {
 	GetTeam(r *http.Request) (string, bool, bool)
+	GetUserName(r *http.Request) (string, bool)
 	GetSystem(r *http.Request) (bool, bool)
 }
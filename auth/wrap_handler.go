@@ -8,6 +8,7 @@ import (
 var authenticated = "authenticated"
 var teamNameKey = "teamName"
 var isAdminKey = "isAdmin"
+var userNameKey = "userName"
 var isSystemKey = "system"
 
 func WrapHandler(
@@ -36,6 +37,11 @@ func (h authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = context.WithValue(ctx, isAdminKey, isAdmin)
 	}
 
+	userName, found := h.userContextReader.GetUserName(r)
+	if found {
+		ctx = context.WithValue(ctx, userNameKey, userName)
+	}
+
 	isSystem, found := h.userContextReader.GetSystem(r)
 	if found {
 		ctx = context.WithValue(ctx, isSystemKey, isSystem)
@@ -61,3 +61,7 @@ type BuildPreparation struct {
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
 }
+
+type BuildArtifact struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,21 @@
+package atc
+
+// BuildComment is a comment on a build, in markdown, left by a team. Author
+// is the user who left it, for tokens naming their user (e.g. from basic
+// auth); it is omitted for those only naming the team (e.g. from OAuth).
+type BuildComment struct {
+	ID        int    `json:"id"`
+	TeamName  string `json:"team_name"`
+	Author    string `json:"author,omitempty"`
+	Comment   string `json:"comment"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// BuildAnnotation is a piece of metadata attached to a build by one of its
+// steps, e.g. the name of a failed test.
+type BuildAnnotation struct {
+	PlanID PlanID `json:"plan_id"`
+	Step   string `json:"step"`
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+}
@@ -0,0 +1,17 @@
+package atc
+
+// LogMatch is a line of a build's output matching a log search.
+type LogMatch struct {
+	BuildID   int       `json:"build_id"`
+	BuildName string    `json:"build_name"`
+	Origin    LogOrigin `json:"origin"`
+	Line      int       `json:"line"`
+	Text      string    `json:"text"`
+}
+
+// LogOrigin identifies the step, and which of its streams, a line of output
+// came from.
+type LogOrigin struct {
+	ID     string `json:"id"`
+	Source string `json:"source,omitempty"`
+}
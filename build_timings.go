@@ -0,0 +1,63 @@
+package atc
+
+// BuildTimings breaks down where a build's wall-clock time went, following
+// the shape of its public plan.
+type BuildTimings struct {
+	BuildID int `json:"build_id"`
+
+	// Queued is how long the build was pending before it started, in
+	// seconds. It is not known for builds created before it was recorded.
+	Queued *int64 `json:"queued,omitempty"`
+
+	Plan BuildStepTiming `json:"plan"`
+}
+
+type BuildStepTiming struct {
+	ID     PlanID      `json:"id"`
+	Step   string      `json:"step"`
+	Name   string      `json:"name,omitempty"`
+	Worker string      `json:"worker,omitempty"`
+	Status BuildStatus `json:"status,omitempty"`
+
+	StartTime int64 `json:"start_time,omitempty"`
+	EndTime   int64 `json:"end_time,omitempty"`
+
+	Durations StepDurations `json:"durations"`
+
+	Steps []BuildStepTiming `json:"steps,omitempty"`
+}
+
+// StepDurations are all in seconds.
+type StepDurations struct {
+	Total        int64 `json:"total"`
+	Queued       int64 `json:"queued"`
+	ImageFetch   int64 `json:"image_fetch"`
+	InputStream  int64 `json:"input_stream"`
+	Run          int64 `json:"run"`
+	OutputStream int64 `json:"output_stream"`
+}
+
+// JobTimings aggregates the step timings of a job's most recent builds.
+type JobTimings struct {
+	Builds []int           `json:"builds"`
+	Queued JobQueuedTiming `json:"queued"`
+	Steps  []JobStepTiming `json:"steps"`
+}
+
+// JobQueuedTiming aggregates how long the builds were pending before they
+// started, over the builds for which it is known.
+type JobQueuedTiming struct {
+	Samples int   `json:"samples"`
+	P50     int64 `json:"p50"`
+	P95     int64 `json:"p95"`
+}
+
+// JobStepTiming aggregates the timings of the steps with the same type and
+// name across builds.
+type JobStepTiming struct {
+	Step    string        `json:"step"`
+	Name    string        `json:"name,omitempty"`
+	Samples int           `json:"samples"`
+	P50     StepDurations `json:"p50"`
+	P95     StepDurations `json:"p95"`
+}
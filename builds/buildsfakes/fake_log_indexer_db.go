@@ -0,0 +1,79 @@
+// This file was generated by counterfeiter
+package buildsfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/builds"
+	"github.com/concourse/atc/db"
+)
+
+type FakeLogIndexerDB struct {
+	GetBuildsWithUnindexedLogsStub        func(limit int) ([]db.Build, error)
+	getBuildsWithUnindexedLogsMutex       sync.RWMutex
+	getBuildsWithUnindexedLogsArgsForCall []struct {
+		limit int
+	}
+	getBuildsWithUnindexedLogsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLogIndexerDB) GetBuildsWithUnindexedLogs(limit int) ([]db.Build, error) {
+	fake.getBuildsWithUnindexedLogsMutex.Lock()
+	fake.getBuildsWithUnindexedLogsArgsForCall = append(fake.getBuildsWithUnindexedLogsArgsForCall, struct {
+		limit int
+	}{limit})
+	fake.recordInvocation("GetBuildsWithUnindexedLogs", []interface{}{limit})
+	fake.getBuildsWithUnindexedLogsMutex.Unlock()
+	if fake.GetBuildsWithUnindexedLogsStub != nil {
+		return fake.GetBuildsWithUnindexedLogsStub(limit)
+	} else {
+		return fake.getBuildsWithUnindexedLogsReturns.result1, fake.getBuildsWithUnindexedLogsReturns.result2
+	}
+}
+
+func (fake *FakeLogIndexerDB) GetBuildsWithUnindexedLogsCallCount() int {
+	fake.getBuildsWithUnindexedLogsMutex.RLock()
+	defer fake.getBuildsWithUnindexedLogsMutex.RUnlock()
+	return len(fake.getBuildsWithUnindexedLogsArgsForCall)
+}
+
+func (fake *FakeLogIndexerDB) GetBuildsWithUnindexedLogsArgsForCall(i int) int {
+	fake.getBuildsWithUnindexedLogsMutex.RLock()
+	defer fake.getBuildsWithUnindexedLogsMutex.RUnlock()
+	return fake.getBuildsWithUnindexedLogsArgsForCall[i].limit
+}
+
+func (fake *FakeLogIndexerDB) GetBuildsWithUnindexedLogsReturns(result1 []db.Build, result2 error) {
+	fake.GetBuildsWithUnindexedLogsStub = nil
+	fake.getBuildsWithUnindexedLogsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLogIndexerDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getBuildsWithUnindexedLogsMutex.RLock()
+	defer fake.getBuildsWithUnindexedLogsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLogIndexerDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ builds.LogIndexerDB = new(FakeLogIndexerDB)
@@ -0,0 +1,55 @@
+package builds
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/db"
+)
+
+//go:generate counterfeiter . LogIndexerDB
+
+type LogIndexerDB interface {
+	GetBuildsWithUnindexedLogs(limit int) ([]db.Build, error)
+}
+
+// LogIndexer indexes the output of finished builds which weren't indexed as
+// they finished, either because they predate the index or because indexing
+// them failed, so that searching them needn't read their events.
+type LogIndexer struct {
+	logger lager.Logger
+
+	logIndexerDB LogIndexerDB
+	batchSize    int
+}
+
+func NewLogIndexer(
+	logger lager.Logger,
+	logIndexerDB LogIndexerDB,
+	batchSize int,
+) *LogIndexer {
+	return &LogIndexer{
+		logger: logger,
+
+		logIndexerDB: logIndexerDB,
+		batchSize:    batchSize,
+	}
+}
+
+func (indexer *LogIndexer) Run() error {
+	builds, err := indexer.logIndexerDB.GetBuildsWithUnindexedLogs(indexer.batchSize)
+	if err != nil {
+		indexer.logger.Error("failed-to-get-builds-with-unindexed-logs", err)
+		return err
+	}
+
+	for _, build := range builds {
+		err := build.IndexLogs()
+		if err != nil {
+			// counted by the build, so it's retried a limited number of times
+			indexer.logger.Error("failed-to-index-build-logs", err, lager.Data{
+				"build": build.ID(),
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package builds_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/builds"
+	"github.com/concourse/atc/builds/buildsfakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/dbfakes"
+)
+
+var _ = Describe("LogIndexer", func() {
+	var (
+		fakeLogIndexerDB *buildsfakes.FakeLogIndexerDB
+
+		indexer *builds.LogIndexer
+	)
+
+	BeforeEach(func() {
+		fakeLogIndexerDB = new(buildsfakes.FakeLogIndexerDB)
+
+		indexer = builds.NewLogIndexer(
+			lagertest.NewTestLogger("test"),
+			fakeLogIndexerDB,
+			50,
+		)
+	})
+
+	Describe("Run", func() {
+		var unindexedBuilds []*dbfakes.FakeBuild
+		var runErr error
+
+		BeforeEach(func() {
+			unindexedBuilds = []*dbfakes.FakeBuild{
+				new(dbfakes.FakeBuild),
+				new(dbfakes.FakeBuild),
+			}
+
+			fakeLogIndexerDB.GetBuildsWithUnindexedLogsReturns([]db.Build{
+				unindexedBuilds[0],
+				unindexedBuilds[1],
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			runErr = indexer.Run()
+		})
+
+		It("looks up a batch of builds with unindexed logs", func() {
+			Expect(fakeLogIndexerDB.GetBuildsWithUnindexedLogsCallCount()).To(Equal(1))
+			Expect(fakeLogIndexerDB.GetBuildsWithUnindexedLogsArgsForCall(0)).To(Equal(50))
+		})
+
+		It("indexes each build's logs", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(unindexedBuilds[0].IndexLogsCallCount()).To(Equal(1))
+			Expect(unindexedBuilds[1].IndexLogsCallCount()).To(Equal(1))
+		})
+
+		Context("when indexing a build fails", func() {
+			BeforeEach(func() {
+				unindexedBuilds[0].IndexLogsReturns(errors.New("nope"))
+			})
+
+			It("carries on with the other builds, to retry it on the next run", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(unindexedBuilds[1].IndexLogsCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when looking up the builds fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeLogIndexerDB.GetBuildsWithUnindexedLogsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(runErr).To(Equal(disaster))
+			})
+		})
+	})
+})
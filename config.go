@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 )
 
@@ -53,17 +54,19 @@ func (groups GroupConfigs) Lookup(name string) (GroupConfig, bool) {
 type ResourceConfig struct {
 	Name string `yaml:"name" json:"name" mapstructure:"name"`
 
-	Type       string `yaml:"type" json:"type" mapstructure:"type"`
-	Source     Source `yaml:"source" json:"source" mapstructure:"source"`
-	CheckEvery string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
-	Tags       Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
+	Type         string `yaml:"type" json:"type" mapstructure:"type"`
+	Source       Source `yaml:"source" json:"source" mapstructure:"source"`
+	CheckEvery   string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+	CheckTimeout string `yaml:"check_timeout,omitempty" json:"check_timeout,omitempty" mapstructure:"check_timeout"`
+	Tags         Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
 }
 
 type ResourceType struct {
-	Name   string `yaml:"name" json:"name" mapstructure:"name"`
-	Type   string `yaml:"type" json:"type" mapstructure:"type"`
-	Source Source `yaml:"source" json:"source" mapstructure:"source"`
-	Tags   Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
+	Name         string `yaml:"name" json:"name" mapstructure:"name"`
+	Type         string `yaml:"type" json:"type" mapstructure:"type"`
+	Source       Source `yaml:"source" json:"source" mapstructure:"source"`
+	CheckTimeout string `yaml:"check_timeout,omitempty" json:"check_timeout,omitempty" mapstructure:"check_timeout"`
+	Tags         Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
 }
 
 type ResourceTypes []ResourceType
@@ -106,6 +109,14 @@ type JobConfig struct {
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
+	// How long the build's tasks are given to exit once the build is aborted,
+	// before they are killed. Steps may override it.
+	AbortGracePeriod string `yaml:"abort_grace_period,omitempty" json:"abort_grace_period,omitempty" mapstructure:"abort_grace_period"`
+
+	// Outputs of the build to keep once it succeeds, downloadable for as long as
+	// the build's logs are retained.
+	Artifacts []string `yaml:"artifacts,omitempty" json:"artifacts,omitempty" mapstructure:"artifacts"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
 	Failure *PlanConfig `yaml:"on_failure,omitempty" json:"on_failure,omitempty" mapstructure:"on_failure"`
@@ -312,6 +323,99 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal("")
 }
 
+// TaskReports locates the test reports a task writes. JUnit is a path
+// relative to the task's working directory, and may be a glob matching
+// several files.
+type TaskReports struct {
+	JUnit string `yaml:"junit,omitempty" json:"junit,omitempty" mapstructure:"junit"`
+}
+
+// JUnitDir is the deepest directory containing every file the JUnit path may
+// match. It is "." if the path may match files at the root of the working
+// directory.
+func (reports TaskReports) JUnitDir() string {
+	dir := path.Dir(path.Clean(reports.JUnit))
+
+	dirs := strings.Split(dir, "/")
+	for i, d := range dirs {
+		if strings.ContainsAny(d, `*?[\`) {
+			dir = path.Join(append([]string{"."}, dirs[:i]...)...)
+			break
+		}
+	}
+
+	return dir
+}
+
+// An AttemptsConfig represents how many times to run a step until it works,
+// how long to wait between attempts, and which outcomes ('errored' and/or
+// 'failed') are worth another attempt. It may be configured as a plain count.
+type AttemptsConfig struct {
+	Count   int      `yaml:"count,omitempty" json:"count,omitempty" mapstructure:"count"`
+	Backoff string   `yaml:"backoff,omitempty" json:"backoff,omitempty" mapstructure:"backoff"`
+	On      []string `yaml:"on,omitempty" json:"on,omitempty" mapstructure:"on"`
+}
+
+// attemptsConfig has the same fields as AttemptsConfig without its
+// (un)marshaling methods.
+type attemptsConfig AttemptsConfig
+
+func (c *AttemptsConfig) UnmarshalJSON(attempts []byte) error {
+	var count int
+	if err := json.Unmarshal(attempts, &count); err == nil {
+		*c = AttemptsConfig{Count: count}
+		return nil
+	}
+
+	var config attemptsConfig
+	err := json.Unmarshal(attempts, &config)
+	if err != nil {
+		return errors.New("unknown type for attempts")
+	}
+
+	*c = AttemptsConfig(config)
+
+	return nil
+}
+
+func (c *AttemptsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var count int
+	if err := unmarshal(&count); err == nil {
+		*c = AttemptsConfig{Count: count}
+		return nil
+	}
+
+	var config attemptsConfig
+	err := unmarshal(&config)
+	if err != nil {
+		return errors.New("unknown type for attempts")
+	}
+
+	*c = AttemptsConfig(config)
+
+	return nil
+}
+
+func (c *AttemptsConfig) isCount() bool {
+	return c.Backoff == "" && len(c.On) == 0
+}
+
+func (c *AttemptsConfig) MarshalYAML() (interface{}, error) {
+	if c.isCount() {
+		return c.Count, nil
+	}
+
+	return attemptsConfig(*c), nil
+}
+
+func (c *AttemptsConfig) MarshalJSON() ([]byte, error) {
+	if c.isCount() {
+		return json.Marshal(c.Count)
+	}
+
+	return json.Marshal(attemptsConfig(*c))
+}
+
 // A PlanConfig is a flattened set of configuration corresponding to
 // a particular Plan, where Source and Version are populated lazily.
 type PlanConfig struct {
@@ -351,6 +455,14 @@ type PlanConfig struct {
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
+	// resource limits for the task's container, overriding the task config's
+	ContainerLimits *ContainerLimits `yaml:"container_limits,omitempty" json:"container_limits,omitempty" mapstructure:"container_limits"`
+	// reuse the task's outputs from a previous run with the same config and inputs
+	CacheOutputs bool `yaml:"cache_outputs,omitempty" json:"cache_outputs,omitempty" mapstructure:"cache_outputs"`
+	// test reports written by the task, to be collected once it has run
+	Reports *TaskReports `yaml:"reports,omitempty" json:"reports,omitempty" mapstructure:"reports"`
+	// time the task is given to exit once the build is aborted, before it is killed
+	AbortGracePeriod string `yaml:"abort_grace_period,omitempty" json:"abort_grace_period,omitempty" mapstructure:"abort_grace_period"`
 
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
@@ -387,7 +499,7 @@ type PlanConfig struct {
 	DependentGet string `yaml:"-" json:"-"`
 
 	// repeat the step up to N times, until it works
-	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
+	Attempts *AttemptsConfig `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
 
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
@@ -435,6 +547,37 @@ func (config PlanConfig) Hooks() Hooks {
 	return Hooks{config.Failure, config.Ensure, config.Success}
 }
 
+// A PassedJob is a job referred to by a `passed` constraint. Jobs in other
+// pipelines of the same team are referred to as `pipeline/job`.
+type PassedJob struct {
+	PipelineName string
+	JobName      string
+}
+
+func ParsePassedJob(passed string) PassedJob {
+	segs := strings.SplitN(passed, "/", 2)
+	if len(segs) == 1 {
+		return PassedJob{JobName: passed}
+	}
+
+	return PassedJob{
+		PipelineName: segs[0],
+		JobName:      segs[1],
+	}
+}
+
+func (job PassedJob) IsInOtherPipeline() bool {
+	return job.PipelineName != ""
+}
+
+func (job PassedJob) String() string {
+	if job.IsInOtherPipeline() {
+		return job.PipelineName + "/" + job.JobName
+	}
+
+	return job.JobName
+}
+
 type ResourceConfigs []ResourceConfig
 
 func (resources ResourceConfigs) Lookup(name string) (ResourceConfig, bool) {
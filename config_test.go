@@ -139,4 +139,76 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("AttemptsConfig", func() {
+		Context("when unmarshaling a count from YAML", func() {
+			It("produces an attempts config with only a count", func() {
+				var attemptsConfig AttemptsConfig
+				err := yaml.Unmarshal([]byte(`3`), &attemptsConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(attemptsConfig).To(Equal(AttemptsConfig{Count: 3}))
+			})
+		})
+
+		Context("when unmarshaling a full config from YAML", func() {
+			It("produces the correct attempts config without error", func() {
+				var attemptsConfig AttemptsConfig
+				bs := []byte("count: 3\nbackoff: 30s\non: [errored]")
+				err := yaml.Unmarshal(bs, &attemptsConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(attemptsConfig).To(Equal(AttemptsConfig{
+					Count:   3,
+					Backoff: "30s",
+					On:      []string{"errored"},
+				}))
+			})
+		})
+
+		Context("when unmarshaling a full config from JSON", func() {
+			It("produces the correct attempts config without error", func() {
+				var attemptsConfig AttemptsConfig
+				bs := []byte(`{ "count": 3, "backoff": "30s", "on": ["errored", "failed"] }`)
+				err := json.Unmarshal(bs, &attemptsConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(attemptsConfig).To(Equal(AttemptsConfig{
+					Count:   3,
+					Backoff: "30s",
+					On:      []string{"errored", "failed"},
+				}))
+			})
+		})
+
+		Context("when marshaling to JSON", func() {
+			It("marshals a plain count as a number", func() {
+				bs, err := json.Marshal(&AttemptsConfig{Count: 3})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bs).To(MatchJSON(`3`))
+			})
+
+			It("marshals a full config as an object", func() {
+				bs, err := json.Marshal(&AttemptsConfig{Count: 3, Backoff: "30s"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bs).To(MatchJSON(`{"count":3,"backoff":"30s"}`))
+			})
+		})
+	})
+
+	Describe("ParsePassedJob", func() {
+		It("parses a job in the same pipeline", func() {
+			passedJob := ParsePassedJob("some-job")
+			Expect(passedJob).To(Equal(PassedJob{JobName: "some-job"}))
+			Expect(passedJob.IsInOtherPipeline()).To(BeFalse())
+			Expect(passedJob.String()).To(Equal("some-job"))
+		})
+
+		It("parses a job in another pipeline", func() {
+			passedJob := ParsePassedJob("some-pipeline/some-job")
+			Expect(passedJob).To(Equal(PassedJob{PipelineName: "some-pipeline", JobName: "some-job"}))
+			Expect(passedJob.IsInOtherPipeline()).To(BeTrue())
+			Expect(passedJob.String()).To(Equal("some-pipeline/some-job"))
+		})
+	})
 })
@@ -0,0 +1,76 @@
+package algorithm
+
+// VersionsDBDelta holds what changed in a pipeline since its VersionsDB was
+// loaded.
+type VersionsDBDelta struct {
+	ResourceVersions []ResourceVersion
+	BuildOutputs     []BuildOutput
+	BuildInputs      []BuildInput
+
+	// new check orders of versions that were already loaded, by version ID
+	CheckOrders map[int]int
+
+	// replace the loaded names when non-nil
+	JobIDs      map[string]int
+	ResourceIDs map[string]int
+}
+
+func (delta VersionsDBDelta) IsEmpty() bool {
+	return len(delta.ResourceVersions) == 0 &&
+		len(delta.BuildOutputs) == 0 &&
+		len(delta.BuildInputs) == 0 &&
+		len(delta.CheckOrders) == 0 &&
+		delta.JobIDs == nil &&
+		delta.ResourceIDs == nil
+}
+
+// Apply returns a new VersionsDB with the delta applied. The receiver is left
+// untouched, as it may still be in use by whoever loaded it before.
+func (db *VersionsDB) Apply(delta VersionsDBDelta) *VersionsDB {
+	newDB := &VersionsDB{
+		ResourceVersions: make([]ResourceVersion, len(db.ResourceVersions), len(db.ResourceVersions)+len(delta.ResourceVersions)),
+		BuildOutputs:     make([]BuildOutput, len(db.BuildOutputs), len(db.BuildOutputs)+len(delta.BuildOutputs)),
+		BuildInputs:      make([]BuildInput, len(db.BuildInputs), len(db.BuildInputs)+len(delta.BuildInputs)),
+		JobIDs:           db.JobIDs,
+		ResourceIDs:      db.ResourceIDs,
+		CachedAt:         db.CachedAt,
+	}
+
+	copy(newDB.ResourceVersions, db.ResourceVersions)
+	copy(newDB.BuildOutputs, db.BuildOutputs)
+	copy(newDB.BuildInputs, db.BuildInputs)
+
+	if len(delta.CheckOrders) > 0 {
+		for i, version := range newDB.ResourceVersions {
+			if checkOrder, found := delta.CheckOrders[version.VersionID]; found {
+				newDB.ResourceVersions[i].CheckOrder = checkOrder
+			}
+		}
+
+		for i, output := range newDB.BuildOutputs {
+			if checkOrder, found := delta.CheckOrders[output.VersionID]; found {
+				newDB.BuildOutputs[i].CheckOrder = checkOrder
+			}
+		}
+
+		for i, input := range newDB.BuildInputs {
+			if checkOrder, found := delta.CheckOrders[input.VersionID]; found {
+				newDB.BuildInputs[i].CheckOrder = checkOrder
+			}
+		}
+	}
+
+	newDB.ResourceVersions = append(newDB.ResourceVersions, delta.ResourceVersions...)
+	newDB.BuildOutputs = append(newDB.BuildOutputs, delta.BuildOutputs...)
+	newDB.BuildInputs = append(newDB.BuildInputs, delta.BuildInputs...)
+
+	if delta.JobIDs != nil {
+		newDB.JobIDs = delta.JobIDs
+	}
+
+	if delta.ResourceIDs != nil {
+		newDB.ResourceIDs = delta.ResourceIDs
+	}
+
+	return newDB
+}
@@ -0,0 +1,155 @@
+package algorithm_test
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"os"
+
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Apply", func() {
+	var versionsDB *algorithm.VersionsDB
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         31,
+					JobID:           11,
+				},
+			},
+			BuildInputs: []algorithm.BuildInput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         32,
+					JobID:           12,
+					InputName:       "some-input",
+				},
+			},
+			JobIDs:      map[string]int{"j1": 11, "j2": 12},
+			ResourceIDs: map[string]int{"r1": 21},
+		}
+	})
+
+	It("appends the new rows", func() {
+		newDB := versionsDB.Apply(algorithm.VersionsDBDelta{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         33,
+					JobID:           11,
+				},
+			},
+		})
+
+		Expect(newDB.ResourceVersions).To(Equal([]algorithm.ResourceVersion{
+			{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+			{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+		}))
+		Expect(newDB.BuildOutputs).To(HaveLen(2))
+		Expect(newDB.BuildInputs).To(Equal(versionsDB.BuildInputs))
+		Expect(newDB.JobIDs).To(Equal(versionsDB.JobIDs))
+	})
+
+	It("updates the check order of existing versions everywhere", func() {
+		newDB := versionsDB.Apply(algorithm.VersionsDBDelta{
+			CheckOrders: map[int]int{1: 3},
+		})
+
+		Expect(newDB.ResourceVersions[0].CheckOrder).To(Equal(3))
+		Expect(newDB.BuildOutputs[0].CheckOrder).To(Equal(3))
+		Expect(newDB.BuildInputs[0].CheckOrder).To(Equal(3))
+	})
+
+	It("replaces the job and resource IDs when given", func() {
+		newDB := versionsDB.Apply(algorithm.VersionsDBDelta{
+			JobIDs: map[string]int{"j3": 13},
+		})
+
+		Expect(newDB.JobIDs).To(Equal(map[string]int{"j3": 13}))
+		Expect(newDB.ResourceIDs).To(Equal(map[string]int{"r1": 21}))
+	})
+
+	It("leaves the original VersionsDB untouched", func() {
+		versionsDB.Apply(algorithm.VersionsDBDelta{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+			},
+			CheckOrders: map[int]int{1: 3},
+		})
+
+		Expect(versionsDB.ResourceVersions).To(Equal([]algorithm.ResourceVersion{
+			{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+		}))
+		Expect(versionsDB.BuildOutputs[0].CheckOrder).To(Equal(1))
+		Expect(versionsDB.BuildInputs[0].CheckOrder).To(Equal(1))
+	})
+
+	for _, fixture := range []string{
+		"testdata/bosh-versions.json.gz",
+		"testdata/concourse-versions-high-cpu-deploy.json.gz",
+		"testdata/relint-versions-2.json.gz",
+	} {
+		fixture := fixture
+
+		Measure("applying a delta to "+fixture+" compared to loading every row", func(b Benchmarker) {
+			fixtureDB := loadFixture(fixture)
+
+			delta := algorithm.VersionsDBDelta{}
+			for i, version := range fixtureDB.ResourceVersions[:10] {
+				version.VersionID = -(i + 1)
+				delta.ResourceVersions = append(delta.ResourceVersions, version)
+				delta.BuildOutputs = append(delta.BuildOutputs, algorithm.BuildOutput{
+					ResourceVersion: version,
+					BuildID:         -(i + 1),
+					JobID:           fixtureDB.BuildOutputs[0].JobID,
+				})
+			}
+
+			b.Time("incremental", func() {
+				fixtureDB.Apply(delta)
+			})
+
+			b.Time("full", func() {
+				full := &algorithm.VersionsDB{
+					JobIDs:      fixtureDB.JobIDs,
+					ResourceIDs: fixtureDB.ResourceIDs,
+				}
+
+				full.ResourceVersions = append(full.ResourceVersions, fixtureDB.ResourceVersions...)
+				full.ResourceVersions = append(full.ResourceVersions, delta.ResourceVersions...)
+
+				full.BuildOutputs = append(full.BuildOutputs, fixtureDB.BuildOutputs...)
+				full.BuildOutputs = append(full.BuildOutputs, delta.BuildOutputs...)
+
+				full.BuildInputs = append(full.BuildInputs, fixtureDB.BuildInputs...)
+			})
+		}, 10)
+	}
+})
+
+func loadFixture(path string) *algorithm.VersionsDB {
+	dbFile, err := os.Open(path)
+	Expect(err).ToNot(HaveOccurred())
+
+	defer dbFile.Close()
+
+	gr, err := gzip.NewReader(dbFile)
+	Expect(err).ToNot(HaveOccurred())
+
+	db := &algorithm.VersionsDB{}
+	err = json.NewDecoder(gr).Decode(db)
+	Expect(err).ToNot(HaveOccurred())
+
+	return db
+}
@@ -0,0 +1,216 @@
+package algorithm
+
+import "sort"
+
+type Elimination string
+
+const (
+	EliminatedNotPassed     Elimination = "not-passed"
+	EliminatedNoCommonBuild Elimination = "no-common-build"
+)
+
+type Explanation struct {
+	Resolved bool
+	Mapping  InputMapping
+	Inputs   []InputExplanation
+	JobNames map[int]string
+}
+
+type InputExplanation struct {
+	Input      string
+	ResourceID int
+	Candidates []CandidateExplanation
+}
+
+type CandidateExplanation struct {
+	VersionID    int
+	EliminatedBy Elimination
+	JobID        int
+}
+
+func (explanation InputExplanation) Viable() bool {
+	for _, candidate := range explanation.Candidates {
+		if candidate.EliminatedBy == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Explain resolves the input configs the same way Resolve does, while also
+// reporting, for each version Resolve considers for an input, the constraint
+// that ruled it out (if any).
+func (configs InputConfigs) Explain(db *VersionsDB) Explanation {
+	mapping, resolved := configs.Resolve(db)
+
+	explanation := Explanation{
+		Resolved: resolved,
+		Mapping:  mapping,
+		Inputs:   make([]InputExplanation, len(configs)),
+		JobNames: map[int]string{},
+	}
+
+	for name, id := range db.JobIDs {
+		explanation.JobNames[id] = name
+	}
+
+	for i, inputConfig := range configs {
+		considered := db.consideredVersionsOfInput(inputConfig)
+		passed := db.passedBuildsOfResource(inputConfig.ResourceID, inputConfig.Passed)
+
+		input := InputExplanation{
+			Input:      inputConfig.Name,
+			ResourceID: inputConfig.ResourceID,
+		}
+
+		for _, version := range considered.versions {
+			candidate := CandidateExplanation{VersionID: version.id}
+
+			for _, jobID := range inputConfig.Passed.sorted() {
+				if len(passed[jobID][version.id]) == 0 {
+					candidate.EliminatedBy = EliminatedNotPassed
+					candidate.JobID = jobID
+					break
+				}
+			}
+
+			input.Candidates = append(input.Candidates, candidate)
+		}
+
+		explanation.Inputs[i] = input
+	}
+
+	explanation.eliminateUncommonBuilds(db, configs)
+
+	return explanation
+}
+
+// consideredVersionsOfInput returns the versions of the input's resource that
+// Resolve picks from: the pinned version, the latest version, every version,
+// or the versions that came out of the jobs the input must have passed. A
+// version that came out of only some of those jobs is still returned, so that
+// the job it didn't pass can be reported.
+func (db VersionsDB) consideredVersionsOfInput(inputConfig InputConfig) VersionCandidates {
+	candidates := VersionCandidates{}
+
+	switch {
+	case inputConfig.PinnedVersionID != 0:
+		candidate, found := db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
+		if found {
+			candidates.Add(candidate)
+		}
+
+	case len(inputConfig.Passed) != 0:
+		for _, output := range db.BuildOutputs {
+			if output.ResourceID == inputConfig.ResourceID && inputConfig.Passed.Contains(output.JobID) {
+				candidates.Add(VersionCandidate{
+					VersionID:  output.VersionID,
+					CheckOrder: output.CheckOrder,
+				})
+			}
+		}
+
+	case inputConfig.UseEveryVersion:
+		candidates = db.AllVersionsOfResource(inputConfig.ResourceID)
+
+	default:
+		candidate, found := db.LatestVersionOfResource(inputConfig.ResourceID)
+		if found {
+			candidates.Add(candidate)
+		}
+	}
+
+	return candidates
+}
+
+// eliminateUncommonBuilds rules out candidates whose builds of a shared
+// upstream job do not overlap with the builds of every other input passing
+// through the same job.
+func (explanation Explanation) eliminateUncommonBuilds(db *VersionsDB, configs InputConfigs) {
+	jobs := JobSet{}
+	passed := make([]map[int]map[int]BuildSet, len(configs))
+	for i, inputConfig := range configs {
+		jobs = jobs.Union(inputConfig.Passed)
+		passed[i] = db.passedBuildsOfResource(inputConfig.ResourceID, inputConfig.Passed)
+	}
+
+	for _, jobID := range jobs.sorted() {
+		firstTick := true
+		commonBuildIDs := BuildSet{}
+
+		for i, input := range explanation.Inputs {
+			if !configs[i].Passed.Contains(jobID) {
+				continue
+			}
+
+			inputBuildIDs := BuildSet{}
+			for _, candidate := range input.Candidates {
+				if candidate.EliminatedBy == "" {
+					inputBuildIDs = inputBuildIDs.Union(passed[i][jobID][candidate.VersionID])
+				}
+			}
+
+			if firstTick {
+				commonBuildIDs = inputBuildIDs
+				firstTick = false
+			} else {
+				commonBuildIDs = commonBuildIDs.Intersect(inputBuildIDs)
+			}
+		}
+
+		for i, input := range explanation.Inputs {
+			if !configs[i].Passed.Contains(jobID) {
+				continue
+			}
+
+			for j, candidate := range input.Candidates {
+				if candidate.EliminatedBy != "" {
+					continue
+				}
+
+				if !passed[i][jobID][candidate.VersionID].Overlaps(commonBuildIDs) {
+					input.Candidates[j].EliminatedBy = EliminatedNoCommonBuild
+					input.Candidates[j].JobID = jobID
+				}
+			}
+		}
+	}
+}
+
+func (db VersionsDB) passedBuildsOfResource(resourceID int, jobs JobSet) map[int]map[int]BuildSet {
+	passed := map[int]map[int]BuildSet{}
+
+	for _, output := range db.BuildOutputs {
+		if output.ResourceID != resourceID || !jobs.Contains(output.JobID) {
+			continue
+		}
+
+		versions, found := passed[output.JobID]
+		if !found {
+			versions = map[int]BuildSet{}
+			passed[output.JobID] = versions
+		}
+
+		builds, found := versions[output.VersionID]
+		if !found {
+			builds = BuildSet{}
+			versions[output.VersionID] = builds
+		}
+
+		builds[output.BuildID] = struct{}{}
+	}
+
+	return passed
+}
+
+func (set JobSet) sorted() []int {
+	ids := []int{}
+	for id := range set {
+		ids = append(ids, id)
+	}
+
+	sort.Ints(ids)
+
+	return ids
+}
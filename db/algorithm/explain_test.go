@@ -0,0 +1,239 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/atc/db/algorithm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	var (
+		versionsDB   *algorithm.VersionsDB
+		inputConfigs algorithm.InputConfigs
+		explanation  algorithm.Explanation
+	)
+
+	BeforeEach(func() {
+		versionsDB = &algorithm.VersionsDB{
+			ResourceVersions: []algorithm.ResourceVersion{
+				{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+				{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+				{VersionID: 3, ResourceID: 21, CheckOrder: 3},
+				{VersionID: 4, ResourceID: 22, CheckOrder: 1},
+				{VersionID: 5, ResourceID: 22, CheckOrder: 2},
+			},
+			BuildOutputs: []algorithm.BuildOutput{
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 1, ResourceID: 21, CheckOrder: 1},
+					BuildID:         31,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 4, ResourceID: 22, CheckOrder: 1},
+					BuildID:         31,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 2, ResourceID: 21, CheckOrder: 2},
+					BuildID:         32,
+					JobID:           11,
+				},
+				{
+					ResourceVersion: algorithm.ResourceVersion{VersionID: 5, ResourceID: 22, CheckOrder: 2},
+					BuildID:         33,
+					JobID:           11,
+				},
+			},
+			BuildInputs: []algorithm.BuildInput{},
+			JobIDs:      map[string]int{"j1": 11, "j2": 12},
+			ResourceIDs: map[string]int{"r1": 21, "r2": 22},
+		}
+	})
+
+	JustBeforeEach(func() {
+		explanation = inputConfigs.Explain(versionsDB)
+	})
+
+	Context("with an input without constraints", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					JobName:    "j2",
+					Passed:     algorithm.JobSet{},
+					ResourceID: 21,
+					JobID:      12,
+				},
+			}
+		})
+
+		It("resolves to the latest version, which is all it considers", func() {
+			Expect(explanation.Resolved).To(BeTrue())
+			Expect(explanation.Mapping).To(Equal(algorithm.InputMapping{
+				"some-input": algorithm.InputVersion{VersionID: 3, FirstOccurrence: true},
+			}))
+
+			Expect(explanation.Inputs).To(Equal([]algorithm.InputExplanation{
+				{
+					Input:      "some-input",
+					ResourceID: 21,
+					Candidates: []algorithm.CandidateExplanation{
+						{VersionID: 3},
+					},
+				},
+			}))
+		})
+	})
+
+	Context("with an input using every version", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:            "some-input",
+					JobName:         "j2",
+					Passed:          algorithm.JobSet{},
+					UseEveryVersion: true,
+					ResourceID:      21,
+					JobID:           12,
+				},
+			}
+		})
+
+		It("considers every version", func() {
+			Expect(explanation.Inputs[0].Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 3},
+				{VersionID: 2},
+				{VersionID: 1},
+			}))
+		})
+	})
+
+	Context("with a pinned version", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:            "some-input",
+					JobName:         "j2",
+					Passed:          algorithm.JobSet{},
+					PinnedVersionID: 2,
+					ResourceID:      21,
+					JobID:           12,
+				},
+			}
+		})
+
+		It("only considers the pinned version", func() {
+			Expect(explanation.Inputs[0].Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 2},
+			}))
+		})
+	})
+
+	Context("when the resource has no versions", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					JobName:    "j2",
+					Passed:     algorithm.JobSet{},
+					ResourceID: 23,
+					JobID:      12,
+				},
+			}
+		})
+
+		It("does not resolve and reports no candidates", func() {
+			Expect(explanation.Resolved).To(BeFalse())
+			Expect(explanation.Inputs[0].Candidates).To(BeEmpty())
+			Expect(explanation.Inputs[0].Viable()).To(BeFalse())
+		})
+	})
+
+	Context("with inputs passed through the same job", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "input-1",
+					JobName:    "j2",
+					Passed:     algorithm.JobSet{11: struct{}{}},
+					ResourceID: 21,
+					JobID:      12,
+				},
+				{
+					Name:       "input-2",
+					JobName:    "j2",
+					Passed:     algorithm.JobSet{11: struct{}{}},
+					ResourceID: 22,
+					JobID:      12,
+				},
+			}
+		})
+
+		It("reports the versions that came out of the job without a common build", func() {
+			Expect(explanation.Resolved).To(BeTrue())
+			Expect(explanation.Mapping["input-1"].VersionID).To(Equal(1))
+			Expect(explanation.Mapping["input-2"].VersionID).To(Equal(4))
+
+			Expect(explanation.Inputs).To(Equal([]algorithm.InputExplanation{
+				{
+					Input:      "input-1",
+					ResourceID: 21,
+					Candidates: []algorithm.CandidateExplanation{
+						{VersionID: 2, EliminatedBy: algorithm.EliminatedNoCommonBuild, JobID: 11},
+						{VersionID: 1},
+					},
+				},
+				{
+					Input:      "input-2",
+					ResourceID: 22,
+					Candidates: []algorithm.CandidateExplanation{
+						{VersionID: 5, EliminatedBy: algorithm.EliminatedNoCommonBuild, JobID: 11},
+						{VersionID: 4},
+					},
+				},
+			}))
+		})
+	})
+
+	Context("when the passed job has never produced the resource", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					JobName:    "j2",
+					Passed:     algorithm.JobSet{12: struct{}{}},
+					ResourceID: 21,
+					JobID:      12,
+				},
+			}
+		})
+
+		It("does not resolve and considers no versions", func() {
+			Expect(explanation.Resolved).To(BeFalse())
+			Expect(explanation.Inputs[0].Viable()).To(BeFalse())
+			Expect(explanation.Inputs[0].Candidates).To(BeEmpty())
+		})
+	})
+
+	Context("when an input must have passed several jobs", func() {
+		BeforeEach(func() {
+			inputConfigs = algorithm.InputConfigs{
+				{
+					Name:       "some-input",
+					JobName:    "j2",
+					Passed:     algorithm.JobSet{11: struct{}{}, 12: struct{}{}},
+					ResourceID: 21,
+					JobID:      13,
+				},
+			}
+		})
+
+		It("blames the job that the versions out of the other did not pass", func() {
+			Expect(explanation.Resolved).To(BeFalse())
+			Expect(explanation.Inputs[0].Candidates).To(Equal([]algorithm.CandidateExplanation{
+				{VersionID: 2, EliminatedBy: algorithm.EliminatedNotPassed, JobID: 12},
+				{VersionID: 1, EliminatedBy: algorithm.EliminatedNotPassed, JobID: 12},
+			}))
+		})
+	})
+})
@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/event"
 )
 
@@ -24,8 +26,8 @@ const (
 	StatusErrored   Status = "errored"
 )
 
-const buildColumns = "id, name, job_id, team_id, status, manually_triggered, scheduled, engine, engine_metadata, start_time, end_time, reap_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
+const buildColumns = "id, name, job_id, team_id, status, manually_triggered, scheduled, engine, engine_metadata, start_time, end_time, reap_time, create_time"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.create_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
 
 //go:generate counterfeiter . Build
 
@@ -42,6 +44,7 @@ type Build interface {
 	StartTime() time.Time
 	EndTime() time.Time
 	ReapTime() time.Time
+	CreateTime() time.Time
 	IsOneOff() bool
 	IsScheduled() bool
 	IsRunning() bool
@@ -52,11 +55,20 @@ type Build interface {
 	Events(from uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
 
+	// GetEvents returns the events of the given types saved so far, in the
+	// order they were saved, without waiting for the build to finish. An
+	// empty list of types returns every event.
+	GetEvents(types []atc.EventType) ([]event.Envelope, error)
+
 	GetVersionedResources() (SavedVersionedResources, error)
 	GetResources() ([]BuildInput, []BuildOutput, error)
 
 	Start(string, string) (bool, error)
 	Finish(status Status) error
+
+	// IndexLogs saves the output of a finished pipeline build for full-text
+	// search. Builds that aren't indexed are still searched, just slower.
+	IndexLogs() error
 	MarkAsFailed(cause error) error
 	Abort() error
 	AbortNotifier() (Notifier, error)
@@ -73,6 +85,18 @@ type Build interface {
 	SaveImageResourceVersion(planID atc.PlanID, identifier ResourceCacheIdentifier) error
 	GetImageResourceCacheIdentifiers() ([]ResourceCacheIdentifier, error)
 
+	SaveComment(teamName string, author string, comment string) (BuildComment, error)
+	GetComments() ([]BuildComment, error)
+
+	SaveAnnotations(planID atc.PlanID, stepName string, annotations []atc.MetadataField) error
+	GetAnnotations() ([]BuildAnnotation, error)
+
+	SaveTestResults(planID atc.PlanID, stepName string, results []atc.TestResult) error
+	GetTestResults() ([]BuildTestResult, error)
+
+	SaveStepCheckpoint(planID atc.PlanID, checkpoint StepCheckpoint) error
+	GetStepCheckpoint(planID atc.PlanID) (StepCheckpoint, bool, error)
+
 	GetConfig() (atc.Config, ConfigVersion, error)
 
 	GetPipeline() (SavedPipeline, error)
@@ -95,9 +119,10 @@ type build struct {
 	engine         string
 	engineMetadata string
 
-	startTime time.Time
-	endTime   time.Time
-	reapTime  time.Time
+	startTime  time.Time
+	endTime    time.Time
+	reapTime   time.Time
+	createTime time.Time
 
 	conn Conn
 	bus  *notificationsBus
@@ -153,6 +178,11 @@ func (b *build) ReapTime() time.Time {
 	return b.reapTime
 }
 
+// CreateTime is zero for builds created before it was recorded.
+func (b *build) CreateTime() time.Time {
+	return b.createTime
+}
+
 func (b *build) Status() Status {
 	return b.status
 }
@@ -217,20 +247,72 @@ func (b *build) Events(from uint) (EventSource, error) {
 		return nil, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-
 	return newSQLDBBuildEventSource(
 		b.id,
-		table,
+		b.eventsTable(),
 		b.conn,
 		notifier,
 		from,
 	), nil
 }
 
+func (b *build) GetEvents(types []atc.EventType) ([]event.Envelope, error) {
+	query := sq.Select("type, version, payload").
+		From(b.eventsTable()).
+		Where(sq.Eq{"build_id": b.id})
+
+	if len(types) > 0 {
+		typeNames := make([]string, len(types))
+		for i, t := range types {
+			typeNames[i] = string(t)
+		}
+
+		query = query.Where(sq.Eq{"type": typeNames})
+	}
+
+	sqlQuery, args, err := query.
+		OrderBy("event_id ASC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := b.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	envelopes := []event.Envelope{}
+	for rows.Next() {
+		var t, v, p string
+		err := rows.Scan(&t, &v, &p)
+		if err != nil {
+			return nil, err
+		}
+
+		data := json.RawMessage(p)
+
+		envelopes = append(envelopes, event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+		})
+	}
+
+	return envelopes, rows.Err()
+}
+
+func (b *build) eventsTable() string {
+	if b.pipelineID != 0 {
+		return fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	}
+
+	return fmt.Sprintf("team_build_events_%d", b.teamID)
+}
+
 func (b *build) Start(engine, metadata string) (bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -264,6 +346,15 @@ func (b *build) Start(engine, metadata string) (bool, error) {
 		return false, err
 	}
 
+	err = dbng.SaveClusterEvent(tx, b.clusterEventScope(), event.BuildStarted{
+		BuildID:   b.id,
+		BuildName: b.name,
+		Time:      startTime.Unix(),
+	})
+	if err != nil {
+		return false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return false, err
@@ -336,6 +427,16 @@ func (b *build) Finish(status Status) error {
 		return err
 	}
 
+	err = dbng.SaveClusterEvent(tx, b.clusterEventScope(), event.BuildFinished{
+		BuildID:   b.id,
+		BuildName: b.name,
+		Status:    atc.BuildStatus(status),
+		Time:      endTime.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(fmt.Sprintf(`
 		DROP SEQUENCE %s
 	`, buildEventSeq(b.id)))
@@ -951,15 +1052,10 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 		return err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
-	}
-
 	_, err = tx.Exec(fmt.Sprintf(`
 		INSERT INTO %s (event_id, build_id, type, version, payload)
 		VALUES (nextval('%s'), $1, $2, $3, $4)
-	`, table, buildEventSeq(b.id)), b.id, string(event.EventType()), string(event.Version()), payload)
+	`, b.eventsTable(), buildEventSeq(b.id)), b.id, string(event.EventType()), string(event.Version()), payload)
 	if err != nil {
 		return err
 	}
@@ -967,6 +1063,14 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 	return nil
 }
 
+func (b *build) clusterEventScope() dbng.ClusterEventScope {
+	return dbng.ClusterEventScope{
+		TeamID:       b.teamID,
+		PipelineName: b.pipelineName,
+		JobName:      b.jobName,
+	}
+}
+
 func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
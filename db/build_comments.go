@@ -0,0 +1,127 @@
+package db
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// BuildComment is a comment on a build, in markdown, left by a team. Author
+// names the user who left it, if their token identified them.
+type BuildComment struct {
+	ID        int
+	BuildID   int
+	TeamName  string
+	Author    string
+	Comment   string
+	CreatedAt time.Time
+}
+
+// BuildAnnotation is a piece of metadata attached to a build by one of its
+// steps.
+type BuildAnnotation struct {
+	PlanID   atc.PlanID
+	StepName string
+	Name     string
+	Value    string
+}
+
+func (b *build) SaveComment(teamName string, author string, comment string) (BuildComment, error) {
+	savedComment := BuildComment{
+		BuildID:  b.id,
+		TeamName: teamName,
+		Author:   author,
+		Comment:  comment,
+	}
+
+	err := b.conn.QueryRow(`
+		INSERT INTO build_comments (build_id, team_name, author, comment)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, b.id, teamName, author, comment).Scan(&savedComment.ID, &savedComment.CreatedAt)
+	if err != nil {
+		return BuildComment{}, err
+	}
+
+	return savedComment, nil
+}
+
+func (b *build) GetComments() ([]BuildComment, error) {
+	rows, err := b.conn.Query(`
+		SELECT id, team_name, author, comment, created_at
+		FROM build_comments
+		WHERE build_id = $1
+		ORDER BY id ASC
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	comments := []BuildComment{}
+	for rows.Next() {
+		comment := BuildComment{BuildID: b.id}
+
+		err := rows.Scan(&comment.ID, &comment.TeamName, &comment.Author, &comment.Comment, &comment.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+func (b *build) SaveAnnotations(planID atc.PlanID, stepName string, annotations []atc.MetadataField) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	for _, annotation := range annotations {
+		_, err := tx.Exec(`
+			INSERT INTO build_annotations (build_id, plan_id, step_name, name, value)
+			VALUES ($1, $2, $3, $4, $5)
+		`, b.id, string(planID), stepName, annotation.Name, annotation.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (b *build) GetAnnotations() ([]BuildAnnotation, error) {
+	rows, err := b.conn.Query(`
+		SELECT plan_id, step_name, name, value
+		FROM build_annotations
+		WHERE build_id = $1
+		ORDER BY id ASC
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	annotations := []BuildAnnotation{}
+	for rows.Next() {
+		var annotation BuildAnnotation
+		var planID string
+
+		err := rows.Scan(&planID, &annotation.StepName, &annotation.Name, &annotation.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		annotation.PlanID = atc.PlanID(planID)
+
+		annotations = append(annotations, annotation)
+	}
+
+	return annotations, rows.Err()
+}
@@ -32,10 +32,11 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 	var startTime pq.NullTime
 	var endTime pq.NullTime
 	var reapTime pq.NullTime
+	var createTime pq.NullTime
 	var teamName string
 	var isManuallyTriggered bool
 
-	err := row.Scan(&id, &name, &jobID, &teamID, &status, &isManuallyTriggered, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &teamName)
+	err := row.Scan(&id, &name, &jobID, &teamID, &status, &isManuallyTriggered, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &createTime, &jobName, &pipelineID, &pipelineName, &teamName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, false, nil
@@ -58,9 +59,10 @@ func (f *buildFactory) ScanBuild(row scannable) (Build, bool, error) {
 		engine:         engine.String,
 		engineMetadata: engineMetadata.String,
 
-		startTime: startTime.Time,
-		endTime:   endTime.Time,
-		reapTime:  reapTime.Time,
+		startTime:  startTime.Time,
+		endTime:    endTime.Time,
+		reapTime:   reapTime.Time,
+		createTime: createTime.Time,
 
 		teamName: teamName,
 	}
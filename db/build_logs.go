@@ -0,0 +1,465 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/event"
+)
+
+// maxIndexedLineLength bounds the part of a line that is full-text indexed,
+// as tsvectors are limited in size.
+const maxIndexedLineLength = 64 * 1024
+
+// logMatchBatchSize bounds the lines matched per query when searching the
+// output of builds that haven't been indexed, keeping under Postgres' limit on
+// query parameters.
+const logMatchBatchSize = 1000
+
+// maxLogMatches bounds the lines returned by a log search.
+const maxLogMatches = 1000
+
+// maxLogIndexFailures is how many times indexing a build's output is retried
+// in the background before the build is left to be searched unindexed.
+const maxLogIndexFailures = 5
+
+// LogMatch is a line of a build's output matching a log search.
+type LogMatch struct {
+	BuildID   int
+	BuildName string
+	Origin    event.Origin
+
+	// Line is the line's offset in the output of the step it originated
+	// from, starting at 0.
+	Line int
+	Text string
+}
+
+type logLine struct {
+	origin event.Origin
+	number int
+	text   string
+}
+
+type logBufferKey struct {
+	id     event.OriginID
+	source event.OriginSource
+}
+
+// splitLogLines reassembles the lines of each step's output from the Log
+// events, which are chunked arbitrarily. Lines are numbered per step, across
+// its stdout and stderr.
+func splitLogLines(envelopes []event.Envelope) ([]logLine, error) {
+	lines := []logLine{}
+
+	buffers := map[logBufferKey]string{}
+	bufferOrder := []logBufferKey{}
+	lineNumbers := map[event.OriginID]int{}
+
+	addLine := func(origin event.Origin, text string) {
+		lines = append(lines, logLine{
+			origin: origin,
+			number: lineNumbers[origin.ID],
+			text:   strings.TrimSuffix(text, "\r"),
+		})
+
+		lineNumbers[origin.ID]++
+	}
+
+	for _, envelope := range envelopes {
+		if envelope.Event != event.EventTypeLog || envelope.Data == nil {
+			continue
+		}
+
+		ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		var log event.Log
+		switch ev := ev.(type) {
+		case event.Log:
+			log = ev
+		case event.LogV50:
+			log = event.Log{Origin: ev.Origin, Payload: ev.Payload}
+		default:
+			// older deprecated versions
+			continue
+		}
+
+		key := logBufferKey{id: log.Origin.ID, source: log.Origin.Source}
+		if _, found := buffers[key]; !found {
+			bufferOrder = append(bufferOrder, key)
+		}
+
+		chunks := strings.Split(buffers[key]+log.Payload, "\n")
+		for _, text := range chunks[:len(chunks)-1] {
+			addLine(log.Origin, text)
+		}
+
+		buffers[key] = chunks[len(chunks)-1]
+	}
+
+	for _, key := range bufferOrder {
+		if buffers[key] != "" {
+			addLine(event.Origin{ID: key.id, Source: key.source}, buffers[key])
+		}
+	}
+
+	return lines, nil
+}
+
+// IndexLogs saves the lines of the build's output for full-text search. It is
+// done once the build has finished, as running builds' output is incomplete.
+// One-off builds can't be searched, so they aren't indexed. Builds that are
+// already indexed are left alone, and failures are counted so that builds
+// which keep failing to index stop being retried.
+func (b *build) IndexLogs() error {
+	if b.pipelineID == 0 {
+		return nil
+	}
+
+	err := b.indexLogs()
+	if err != nil {
+		_, countErr := b.conn.Exec(`
+			UPDATE builds
+			SET logs_index_failures = logs_index_failures + 1
+			WHERE id = $1
+		`, b.id)
+		if countErr != nil {
+			return countErr
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (b *build) indexLogs() error {
+	events, err := b.GetEvents([]atc.EventType{event.EventTypeLog})
+	if err != nil {
+		return err
+	}
+
+	lines, err := splitLogLines(events)
+	if err != nil {
+		return err
+	}
+
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var indexed bool
+	err = tx.QueryRow(`
+		SELECT logs_indexed
+		FROM builds
+		WHERE id = $1
+		FOR UPDATE
+	`, b.id).Scan(&indexed)
+	if err != nil {
+		return err
+	}
+
+	if indexed {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO build_log_lines (build_id, origin_id, origin_source, line_number, line, tsv)
+		VALUES ($1, $2, $3, $4, $5, to_tsvector('simple', left($5, $6)))
+	`)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	for _, line := range lines {
+		_, err := stmt.Exec(b.id, string(line.origin.ID), string(line.origin.Source), line.number, line.text, maxIndexedLineLength)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE builds
+		SET logs_indexed = true
+		WHERE id = $1
+	`, b.id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SearchJobBuildLogs finds the lines of output of the job's most recent builds
+// containing every word of the query, up to maxLogMatches of them, most recent
+// builds first. Words are matched whole, as split by the 'simple' text search
+// configuration. Indexed builds are searched through their full-text index;
+// the output of the others is matched as it is read.
+func (pdb *pipelineDB) SearchJobBuildLogs(jobName string, query string, limit int) ([]LogMatch, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT b.id, b.name, b.logs_indexed
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		WHERE j.name = $1
+			AND j.pipeline_id = $2
+		ORDER BY b.id DESC
+		LIMIT $3
+	`, jobName, pdb.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	type searchedBuild struct {
+		id      int
+		name    string
+		indexed bool
+	}
+
+	builds := []searchedBuild{}
+	indexedIDs := []int{}
+	for rows.Next() {
+		var build searchedBuild
+		err := rows.Scan(&build.id, &build.name, &build.indexed)
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, build)
+
+		if build.indexed {
+			indexedIDs = append(indexedIDs, build.id)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	indexedMatches, err := pdb.searchIndexedLogs(indexedIDs, query)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []LogMatch{}
+	for _, build := range builds {
+		var buildMatches []LogMatch
+
+		if build.indexed {
+			buildMatches = indexedMatches[build.id]
+		} else {
+			buildMatches, err = pdb.scanLogs(build.id, query, maxLogMatches-len(matches))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, match := range buildMatches {
+			if len(matches) == maxLogMatches {
+				return matches, nil
+			}
+
+			match.BuildName = build.name
+			matches = append(matches, match)
+		}
+	}
+
+	return matches, nil
+}
+
+func (pdb *pipelineDB) searchIndexedLogs(buildIDs []int, query string) (map[int][]LogMatch, error) {
+	matches := map[int][]LogMatch{}
+
+	if len(buildIDs) == 0 {
+		return matches, nil
+	}
+
+	sqlQuery, args, err := sq.Select("build_id, origin_id, origin_source, line_number, line").
+		From("build_log_lines").
+		Where(sq.Eq{"build_id": buildIDs}).
+		Where(sq.Expr("tsv @@ plainto_tsquery('simple', ?)", query)).
+		OrderBy("build_id DESC", "id ASC").
+		Limit(maxLogMatches).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pdb.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var match LogMatch
+		var originID, originSource string
+
+		err := rows.Scan(&match.BuildID, &originID, &originSource, &match.Line, &match.Text)
+		if err != nil {
+			return nil, err
+		}
+
+		match.Origin = event.Origin{
+			ID:     event.OriginID(originID),
+			Source: event.OriginSource(originSource),
+		}
+
+		matches[match.BuildID] = append(matches[match.BuildID], match)
+	}
+
+	return matches, rows.Err()
+}
+
+func (pdb *pipelineDB) scanLogs(buildID int, query string, limit int) ([]LogMatch, error) {
+	words := logQueryWords(query)
+	if len(words) == 0 || limit <= 0 {
+		return []LogMatch{}, nil
+	}
+
+	rows, err := pdb.conn.Query(fmt.Sprintf(`
+		SELECT type, version, payload
+		FROM pipeline_build_events_%d
+		WHERE build_id = $1
+			AND type = $2
+		ORDER BY event_id ASC
+	`, pdb.ID), buildID, string(event.EventTypeLog))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	envelopes := []event.Envelope{}
+	for rows.Next() {
+		var t, v, p string
+		err := rows.Scan(&t, &v, &p)
+		if err != nil {
+			return nil, err
+		}
+
+		data := json.RawMessage(p)
+
+		envelopes = append(envelopes, event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+		})
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err := splitLogLines(envelopes)
+	if err != nil {
+		return nil, err
+	}
+
+	// only lines containing every word of the query can match it, so the
+	// others needn't be sent to Postgres
+	candidates := []logLine{}
+	for _, line := range lines {
+		if containsLogQueryWords(line.text, words) {
+			candidates = append(candidates, line)
+		}
+	}
+
+	matches := []LogMatch{}
+	for start := 0; start < len(candidates) && len(matches) < limit; start += logMatchBatchSize {
+		end := start + logMatchBatchSize
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+
+		matched, err := pdb.matchLogLines(candidates[start:end], query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, line := range matched {
+			matches = append(matches, LogMatch{
+				BuildID: buildID,
+				Origin:  line.origin,
+				Line:    line.number,
+				Text:    line.text,
+			})
+		}
+	}
+
+	return matches, nil
+}
+
+// logQueryWords splits a query into the runs of letters and digits which
+// every word of its tsquery contains, lower-cased as the 'simple'
+// configuration does.
+func logQueryWords(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsLogQueryWords(text string, words []string) bool {
+	lower := strings.ToLower(text)
+	for _, word := range words {
+		if !strings.Contains(lower, word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchLogLines has Postgres match the lines against the query the same way
+// the full-text index is, so that a build's matches don't change once it has
+// been indexed.
+func (pdb *pipelineDB) matchLogLines(lines []logLine, query string) ([]logLine, error) {
+	args := []interface{}{query, maxIndexedLineLength}
+	values := make([]string, len(lines))
+	for i, line := range lines {
+		values[i] = fmt.Sprintf("($%d::int, $%d::text)", len(args)+1, len(args)+2)
+		args = append(args, i, line.text)
+	}
+
+	rows, err := pdb.conn.Query(`
+		SELECT l.n
+		FROM (VALUES `+strings.Join(values, ", ")+`) AS l(n, line)
+		WHERE to_tsvector('simple', left(l.line, $2)) @@ plainto_tsquery('simple', $1)
+		ORDER BY l.n ASC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	matched := []logLine{}
+	for rows.Next() {
+		var n int
+		err := rows.Scan(&n)
+		if err != nil {
+			return nil, err
+		}
+
+		matched = append(matched, lines[n])
+	}
+
+	return matched, rows.Err()
+}
@@ -0,0 +1,248 @@
+package db_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Build log search", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var sqlDB *db.SQLDB
+	var pipelineDB db.PipelineDB
+
+	var build db.Build
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(lockfakes.FakeConnector)
+		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := lock.NewLockFactory(retryableConn)
+
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDB := teamDBFactory.GetTeamDB(atc.DefaultTeamName)
+
+		pipeline, _, err := teamDB.SaveConfigToBeDeprecated("some-pipeline", atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+			},
+		}, db.ConfigVersion(1), db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDB = pipelineDBFactory.Build(pipeline)
+
+		build, err = pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		stdout := event.Origin{ID: "some-task", Source: event.OriginSourceStdout}
+		stderr := event.Origin{ID: "some-task", Source: event.OriginSourceStderr}
+
+		for _, ev := range []atc.Event{
+			event.LogV50{Origin: stdout, Payload: "compiling...\nrunning tes"},
+			event.Log{Origin: stderr, Payload: "warning: deprecated\n"},
+			event.Log{Origin: stdout, Payload: "ts\nFAIL: connection refused\n"},
+			event.Log{Origin: stdout, Payload: "Connection was Refused again"},
+		} {
+			err := build.SaveEvent(ev)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	expectedMatches := func() []db.LogMatch {
+		return []db.LogMatch{
+			{
+				BuildID:   build.ID(),
+				BuildName: build.Name(),
+				Origin:    event.Origin{ID: "some-task", Source: event.OriginSourceStdout},
+				Line:      3,
+				Text:      "FAIL: connection refused",
+			},
+			{
+				BuildID:   build.ID(),
+				BuildName: build.Name(),
+				Origin:    event.Origin{ID: "some-task", Source: event.OriginSourceStdout},
+				Line:      4,
+				Text:      "Connection was Refused again",
+			},
+		}
+	}
+
+	Context("while the build is running", func() {
+		It("finds the matching lines in the build's output", func() {
+			matches, err := pipelineDB.SearchJobBuildLogs("some-job", "refused connection", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(Equal(expectedMatches()))
+		})
+	})
+
+	Context("once the build has finished", func() {
+		BeforeEach(func() {
+			err := build.Finish(db.StatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.IndexLogs()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("finds the matching lines through the full-text index", func() {
+			matches, err := pipelineDB.SearchJobBuildLogs("some-job", "refused connection", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(Equal(expectedMatches()))
+		})
+
+		It("finds nothing once the build's events have been reaped", func() {
+			err := sqlDB.DeleteBuildEventsByBuildIDs([]int{build.ID()})
+			Expect(err).NotTo(HaveOccurred())
+
+			matches, err := pipelineDB.SearchJobBuildLogs("some-job", "refused", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+	})
+
+	Context("when the query is only part of a word", func() {
+		It("finds nothing while the build is running", func() {
+			matches, err := pipelineDB.SearchJobBuildLogs("some-job", "refuse", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+
+		It("finds nothing once the build has been indexed", func() {
+			err := build.Finish(db.StatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.IndexLogs()
+			Expect(err).NotTo(HaveOccurred())
+
+			matches, err := pipelineDB.SearchJobBuildLogs("some-job", "refuse", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(BeEmpty())
+		})
+	})
+
+	It("finds nothing when no line matches", func() {
+		matches, err := pipelineDB.SearchJobBuildLogs("some-job", "segfault", 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeEmpty())
+	})
+
+	It("returns no more than 1000 matches", func() {
+		err := build.SaveEvent(event.Log{
+			Origin:  event.Origin{ID: "some-task", Source: event.OriginSourceStdout},
+			Payload: "\n" + strings.Repeat("refused\n", 1500),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		matches, err := pipelineDB.SearchJobBuildLogs("some-job", "refused", 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(1000))
+	})
+
+	Describe("IndexLogs", func() {
+		BeforeEach(func() {
+			err := build.Finish(db.StatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not index the build's output twice", func() {
+			err := build.IndexLogs()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.IndexLogs()
+			Expect(err).NotTo(HaveOccurred())
+
+			matches, err := pipelineDB.SearchJobBuildLogs("some-job", "refused connection", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(Equal(expectedMatches()))
+		})
+	})
+
+	Describe("GetBuildsWithUnindexedLogs", func() {
+		It("returns finished pipeline builds which haven't been indexed, most recent first", func() {
+			runningBuild, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			indexedBuild, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			unindexedBuild, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, b := range []db.Build{build, indexedBuild, unindexedBuild} {
+				err := b.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			err = indexedBuild.IndexLogs()
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := sqlDB.GetBuildsWithUnindexedLogs(10)
+			Expect(err).NotTo(HaveOccurred())
+
+			ids := []int{}
+			for _, b := range builds {
+				ids = append(ids, b.ID())
+			}
+
+			Expect(ids).To(Equal([]int{unindexedBuild.ID(), build.ID()}))
+			Expect(ids).NotTo(ContainElement(runningBuild.ID()))
+
+			builds, err = sqlDB.GetBuildsWithUnindexedLogs(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+		})
+
+		It("skips builds which have failed to be indexed too many times", func() {
+			err := build.Finish(db.StatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE builds SET logs_index_failures = 5 WHERE id = $1`, build.ID())
+			Expect(err).NotTo(HaveOccurred())
+
+			builds, err := sqlDB.GetBuildsWithUnindexedLogs(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(BeEmpty())
+		})
+	})
+
+	It("only searches the most recent builds", func() {
+		_, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		matches, err := pipelineDB.SearchJobBuildLogs("some-job", "refused", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeEmpty())
+	})
+})
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/concourse/atc"
+)
+
+// StepCheckpoint records that a get or put step of a build has completed, so
+// that it is not run again when the build is resumed.
+type StepCheckpoint struct {
+	ExitStatus int
+	Version    atc.Version
+	Metadata   []atc.MetadataField
+}
+
+func (b *build) SaveStepCheckpoint(planID atc.PlanID, checkpoint StepCheckpoint) error {
+	version, err := json.Marshal(checkpoint.Version)
+	if err != nil {
+		return err
+	}
+
+	metadata, err := json.Marshal(checkpoint.Metadata)
+	if err != nil {
+		return err
+	}
+
+	result, err := b.conn.Exec(`
+		UPDATE build_step_checkpoints
+		SET exit_status = $3, version = $4, metadata = $5
+		WHERE build_id = $1 AND plan_id = $2
+	`, b.id, string(planID), checkpoint.ExitStatus, version, metadata)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err := b.conn.Exec(`
+			INSERT INTO build_step_checkpoints (build_id, plan_id, exit_status, version, metadata)
+			VALUES ($1, $2, $3, $4, $5)
+		`, b.id, string(planID), checkpoint.ExitStatus, version, metadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *build) GetStepCheckpoint(planID atc.PlanID) (StepCheckpoint, bool, error) {
+	var checkpoint StepCheckpoint
+	var version, metadata []byte
+
+	err := b.conn.QueryRow(`
+		SELECT exit_status, version, metadata
+		FROM build_step_checkpoints
+		WHERE build_id = $1 AND plan_id = $2
+	`, b.id, string(planID)).Scan(&checkpoint.ExitStatus, &version, &metadata)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return StepCheckpoint{}, false, nil
+		}
+
+		return StepCheckpoint{}, false, err
+	}
+
+	err = json.Unmarshal(version, &checkpoint.Version)
+	if err != nil {
+		return StepCheckpoint{}, false, err
+	}
+
+	err = json.Unmarshal(metadata, &checkpoint.Metadata)
+	if err != nil {
+		return StepCheckpoint{}, false, err
+	}
+
+	return checkpoint, true, nil
+}
@@ -240,6 +240,267 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("GetEvents", func() {
+		It("returns the events saved so far, optionally filtered by type", func() {
+			build, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.StartStep{Time: 1, Step: "task"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.Log{Payload: "some log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveEvent(event.FinishStep{Time: 2, Step: "task", Status: atc.StatusSucceeded})
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := build.GetEvents(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]event.Envelope{
+				envelope(event.StartStep{Time: 1, Step: "task"}),
+				envelope(event.Log{Payload: "some log"}),
+				envelope(event.FinishStep{Time: 2, Step: "task", Status: atc.StatusSucceeded}),
+			}))
+
+			events, err = build.GetEvents([]atc.EventType{event.EventTypeStartStep, event.EventTypeFinishStep})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]event.Envelope{
+				envelope(event.StartStep{Time: 1, Step: "task"}),
+				envelope(event.FinishStep{Time: 2, Step: "task", Status: atc.StatusSucceeded}),
+			}))
+		})
+	})
+
+	Describe("SaveComment", func() {
+		It("saves the comment, which can then be listed", func() {
+			build, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			otherBuild, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			comments, err := build.GetComments()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comments).To(BeEmpty())
+
+			firstComment, err := build.SaveComment("some-team", "some-user", "looks like a *flake*")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(firstComment.BuildID).To(Equal(build.ID()))
+			Expect(firstComment.TeamName).To(Equal("some-team"))
+			Expect(firstComment.Author).To(Equal("some-user"))
+			Expect(firstComment.Comment).To(Equal("looks like a *flake*"))
+			Expect(firstComment.CreatedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			secondComment, err := build.SaveComment("some-other-team", "", "it is not")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = otherBuild.SaveComment("some-team", "some-user", "unrelated")
+			Expect(err).NotTo(HaveOccurred())
+
+			comments, err = build.GetComments()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(comments).To(HaveLen(2))
+			Expect(comments[0].ID).To(Equal(firstComment.ID))
+			Expect(comments[0].Author).To(Equal("some-user"))
+			Expect(comments[0].Comment).To(Equal("looks like a *flake*"))
+			Expect(comments[1].ID).To(Equal(secondComment.ID))
+			Expect(comments[1].TeamName).To(Equal("some-other-team"))
+			Expect(comments[1].Author).To(BeEmpty())
+		})
+	})
+
+	Describe("SaveAnnotations", func() {
+		It("saves the annotations, which can then be listed", func() {
+			build, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			annotations, err := build.GetAnnotations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(annotations).To(BeEmpty())
+
+			err = build.SaveAnnotations("some-plan-id", "unit", []atc.MetadataField{
+				{Name: "failed test", Value: "TestSomething"},
+				{Name: "coverage", Value: "42%"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveAnnotations("some-other-plan-id", "integration", []atc.MetadataField{
+				{Name: "failed test", Value: "TestSomethingElse"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			annotations, err = build.GetAnnotations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(annotations).To(Equal([]db.BuildAnnotation{
+				{PlanID: "some-plan-id", StepName: "unit", Name: "failed test", Value: "TestSomething"},
+				{PlanID: "some-plan-id", StepName: "unit", Name: "coverage", Value: "42%"},
+				{PlanID: "some-other-plan-id", StepName: "integration", Name: "failed test", Value: "TestSomethingElse"},
+			}))
+		})
+	})
+
+	Describe("SaveTestResults", func() {
+		It("saves the results, which can then be listed", func() {
+			build, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			results, err := build.GetTestResults()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(BeEmpty())
+
+			err = build.SaveTestResults("some-plan-id", "unit", []atc.TestResult{
+				{Suite: "unit", ClassName: "pkg.Widget", Name: "renders", Status: atc.TestStatusPassed, Duration: 0.25},
+				{Suite: "unit", ClassName: "pkg.Widget", Name: "resizes", Status: atc.TestStatusFailed, Duration: 1.5, Message: "expected 2, got 3"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			results, err = build.GetTestResults()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]db.BuildTestResult{
+				{
+					PlanID:     "some-plan-id",
+					StepName:   "unit",
+					TestResult: atc.TestResult{Suite: "unit", ClassName: "pkg.Widget", Name: "renders", Status: atc.TestStatusPassed, Duration: 0.25},
+				},
+				{
+					PlanID:     "some-plan-id",
+					StepName:   "unit",
+					TestResult: atc.TestResult{Suite: "unit", ClassName: "pkg.Widget", Name: "resizes", Status: atc.TestStatusFailed, Duration: 1.5, Message: "expected 2, got 3"},
+				},
+			}))
+		})
+
+		It("replaces the results already saved for the same plan ID", func() {
+			build, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveTestResults("some-plan-id", "unit", []atc.TestResult{
+				{Suite: "unit", ClassName: "pkg.Widget", Name: "renders", Status: atc.TestStatusFailed, Duration: 0.25},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveTestResults("some-other-plan-id", "integration", []atc.TestResult{
+				{Suite: "integration", ClassName: "pkg.App", Name: "boots", Status: atc.TestStatusPassed, Duration: 3},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveTestResults("some-plan-id", "unit", []atc.TestResult{
+				{Suite: "unit", ClassName: "pkg.Widget", Name: "renders", Status: atc.TestStatusPassed, Duration: 0.5},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			results, err := build.GetTestResults()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(Equal([]db.BuildTestResult{
+				{
+					PlanID:     "some-other-plan-id",
+					StepName:   "integration",
+					TestResult: atc.TestResult{Suite: "integration", ClassName: "pkg.App", Name: "boots", Status: atc.TestStatusPassed, Duration: 3},
+				},
+				{
+					PlanID:     "some-plan-id",
+					StepName:   "unit",
+					TestResult: atc.TestResult{Suite: "unit", ClassName: "pkg.Widget", Name: "renders", Status: atc.TestStatusPassed, Duration: 0.5},
+				},
+			}))
+		})
+	})
+
+	Describe("SaveStepCheckpoint", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the checkpoint, which can then be found by plan ID", func() {
+			_, found, err := build.GetStepCheckpoint("some-plan-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			err = build.SaveStepCheckpoint("some-plan-id", db.StepCheckpoint{
+				ExitStatus: 0,
+				Version:    atc.Version{"ref": "abc"},
+				Metadata:   []atc.MetadataField{{Name: "commit", Value: "abc"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			checkpoint, found, err := build.GetStepCheckpoint("some-plan-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(checkpoint).To(Equal(db.StepCheckpoint{
+				ExitStatus: 0,
+				Version:    atc.Version{"ref": "abc"},
+				Metadata:   []atc.MetadataField{{Name: "commit", Value: "abc"}},
+			}))
+
+			_, found, err = build.GetStepCheckpoint("some-other-plan-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("replaces an existing checkpoint for the same plan ID", func() {
+			err := build.SaveStepCheckpoint("some-plan-id", db.StepCheckpoint{ExitStatus: 1})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveStepCheckpoint("some-plan-id", db.StepCheckpoint{
+				ExitStatus: 0,
+				Version:    atc.Version{"ref": "def"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			checkpoint, found, err := build.GetStepCheckpoint("some-plan-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(checkpoint.ExitStatus).To(Equal(0))
+			Expect(checkpoint.Version).To(Equal(atc.Version{"ref": "def"}))
+		})
+	})
+
+	Describe("GetJobFlakyTests", func() {
+		var builds []db.Build
+
+		BeforeEach(func() {
+			builds = nil
+
+			for _, status := range []atc.TestStatus{atc.TestStatusFailed, atc.TestStatusPassed, atc.TestStatusErrored} {
+				build, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveTestResults("some-plan-id", "unit", []atc.TestResult{
+					{Suite: "unit", Name: "flaky", Status: status},
+					{Suite: "unit", Name: "stable", Status: atc.TestStatusPassed},
+					{Suite: "unit", Name: "broken", Status: atc.TestStatusFailed},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				builds = append(builds, build)
+			}
+		})
+
+		It("finds the tests which both passed and failed in the job's recent builds", func() {
+			flakyTests, err := pipelineDB.GetJobFlakyTests("some-job", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flakyTests).To(Equal([]db.FlakyTest{
+				{
+					Suite:               "unit",
+					Name:                "flaky",
+					Passes:              1,
+					Failures:            2,
+					LastFailedBuildName: builds[2].Name(),
+				},
+			}))
+		})
+
+		It("only considers the most recent builds", func() {
+			flakyTests, err := pipelineDB.GetJobFlakyTests("some-job", 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(flakyTests).To(BeEmpty())
+		})
+	})
+
 	Describe("SaveInput", func() {
 		It("can get a build's input", func() {
 			build, err := pipelineDB.CreateJobBuild("some-job")
@@ -536,7 +797,7 @@ var _ = Describe("Build", func() {
 					)
 					Expect(err).NotTo(HaveOccurred())
 
-					versions, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Limit: 1})
+					versions, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Limit: 1})
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(versions).To(HaveLen(1))
@@ -712,7 +973,7 @@ var _ = Describe("Build", func() {
 					)
 					Expect(err).NotTo(HaveOccurred())
 
-					versions, _, found, err := pipelineDB.GetResourceVersions("input1", db.Page{Limit: 1})
+					versions, _, found, err := pipelineDB.GetResourceVersions("input1", db.VersionFilter{}, db.Page{Limit: 1})
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(versions).To(HaveLen(1))
@@ -0,0 +1,142 @@
+package db
+
+import "github.com/concourse/atc"
+
+// BuildTestResult is a test result reported by one of a build's steps.
+type BuildTestResult struct {
+	PlanID   atc.PlanID
+	StepName string
+
+	atc.TestResult
+}
+
+// FlakyTest is a test which both passed and failed across a job's recent
+// builds. Errors count as failures.
+type FlakyTest struct {
+	Suite     string
+	ClassName string
+	Name      string
+
+	Passes   int
+	Failures int
+
+	LastFailedBuildName string
+}
+
+// SaveTestResults replaces any results already saved for the step, so that a
+// resumed step reporting its results again doesn't duplicate them.
+func (b *build) SaveTestResults(planID atc.PlanID, stepName string, results []atc.TestResult) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		DELETE FROM build_test_results
+		WHERE build_id = $1
+			AND plan_id = $2
+	`, b.id, string(planID))
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO build_test_results (build_id, plan_id, step_name, suite, class_name, name, status, duration, message)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`)
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	for _, result := range results {
+		_, err := stmt.Exec(b.id, string(planID), stepName, result.Suite, result.ClassName, result.Name, string(result.Status), result.Duration, result.Message)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (b *build) GetTestResults() ([]BuildTestResult, error) {
+	rows, err := b.conn.Query(`
+		SELECT plan_id, step_name, suite, class_name, name, status, duration, message
+		FROM build_test_results
+		WHERE build_id = $1
+		ORDER BY id ASC
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := []BuildTestResult{}
+	for rows.Next() {
+		var result BuildTestResult
+		var planID, status string
+
+		err := rows.Scan(&planID, &result.StepName, &result.Suite, &result.ClassName, &result.Name, &status, &result.Duration, &result.Message)
+		if err != nil {
+			return nil, err
+		}
+
+		result.PlanID = atc.PlanID(planID)
+		result.Status = atc.TestStatus(status)
+
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// GetJobFlakyTests finds the tests which both passed and failed in the job's
+// most recent builds, most frequently failing first.
+func (pdb *pipelineDB) GetJobFlakyTests(jobName string, limit int) ([]FlakyTest, error) {
+	rows, err := pdb.conn.Query(`
+		SELECT t.suite, t.class_name, t.name, t.passes, t.failures, fb.name
+		FROM (
+			SELECT r.suite, r.class_name, r.name,
+				SUM(CASE WHEN r.status = $4 THEN 1 ELSE 0 END) AS passes,
+				SUM(CASE WHEN r.status IN ($5, $6) THEN 1 ELSE 0 END) AS failures,
+				MAX(CASE WHEN r.status IN ($5, $6) THEN r.build_id END) AS last_failed_build_id
+			FROM build_test_results r
+			INNER JOIN (
+				SELECT b.id
+				FROM builds b
+				INNER JOIN jobs j ON b.job_id = j.id
+				WHERE j.name = $1
+					AND j.pipeline_id = $2
+				ORDER BY b.id DESC
+				LIMIT $3
+			) recent ON r.build_id = recent.id
+			GROUP BY r.suite, r.class_name, r.name
+		) t
+		INNER JOIN builds fb ON fb.id = t.last_failed_build_id
+		WHERE t.passes > 0
+		ORDER BY t.failures DESC, t.suite ASC, t.class_name ASC, t.name ASC
+	`, jobName, pdb.ID, limit, string(atc.TestStatusPassed), string(atc.TestStatusFailed), string(atc.TestStatusErrored))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	flakyTests := []FlakyTest{}
+	for rows.Next() {
+		var flakyTest FlakyTest
+
+		err := rows.Scan(&flakyTest.Suite, &flakyTest.ClassName, &flakyTest.Name, &flakyTest.Passes, &flakyTest.Failures, &flakyTest.LastFailedBuildName)
+		if err != nil {
+			return nil, err
+		}
+
+		flakyTests = append(flakyTests, flakyTest)
+	}
+
+	return flakyTests, rows.Err()
+}
@@ -0,0 +1,331 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/event"
+)
+
+var ErrClusterEventStreamClosed = errors.New("cluster event stream closed")
+
+// ClusterEventFilter narrows down the events read from the firehose. Zero
+// values match everything.
+type ClusterEventFilter struct {
+	// Teams limits the events to those of the given teams, e.g. the teams the
+	// requester is allowed to see. A nil slice allows every team.
+	Teams []string
+
+	Team     string
+	Pipeline string
+	Job      string
+	Types    []atc.EventType
+}
+
+type ClusterEvent struct {
+	ID       int
+	Envelope event.ClusterEnvelope
+}
+
+//go:generate counterfeiter . ClusterEventSource
+
+type ClusterEventSource interface {
+	Next() (ClusterEvent, error)
+	Close() error
+}
+
+// DeleteClusterEventsOlderThan removes the events that were saved longer ago
+// than the retention period, so that the firehose doesn't grow forever.
+func (db *SQLDB) DeleteClusterEventsOlderThan(retention time.Duration) error {
+	_, err := db.conn.Exec(`
+		DELETE FROM cluster_events
+		WHERE created_at < now() - $1 * interval '1 second'
+	`, retention.Seconds())
+	return err
+}
+
+// LatestClusterEventID returns the ID of the last event that can no longer be
+// preceded by events of transactions that are still in flight.
+func (db *SQLDB) LatestClusterEventID() (int, error) {
+	var id int
+	err := db.conn.QueryRow(`
+		SELECT COALESCE((
+			SELECT id
+			FROM cluster_events
+			WHERE txid < txid_snapshot_xmin(txid_current_snapshot())
+			ORDER BY txid DESC, id DESC
+			LIMIT 1
+		), 0)
+	`).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// ClusterEvents streams the events matching the filter that were saved after
+// the event with the given ID.
+//
+// Event IDs are assigned before the saving transaction commits, so they don't
+// commit in order. Events are therefore streamed in the order of the
+// transactions that saved them, and only once every older transaction has
+// finished, so that none are skipped.
+func (db *SQLDB) ClusterEvents(filter ClusterEventFilter, since int) (ClusterEventSource, error) {
+	cursor := clusterEventCursor{ID: since}
+	err := db.conn.QueryRow(`
+		SELECT txid
+		FROM cluster_events
+		WHERE id <= $1
+		ORDER BY id DESC
+		LIMIT 1
+	`, since).Scan(&cursor.Txid)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	notifier, err := newConditionNotifier(db.bus, dbng.ClusterEventsChannel, func() (bool, error) {
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSQLDBClusterEventSource(db.conn, notifier, filter, cursor), nil
+}
+
+func newSQLDBClusterEventSource(
+	conn Conn,
+	notifier Notifier,
+	filter ClusterEventFilter,
+	cursor clusterEventCursor,
+) *sqldbClusterEventSource {
+	wg := new(sync.WaitGroup)
+
+	source := &sqldbClusterEventSource{
+		conn:     conn,
+		notifier: notifier,
+		filter:   filter,
+
+		events: make(chan ClusterEvent, 2000),
+		stop:   make(chan struct{}),
+		wg:     wg,
+	}
+
+	wg.Add(1)
+	go source.collectEvents(cursor)
+
+	return source
+}
+
+// clusterEventUnsettledPollInterval is how often the source checks for events
+// that are waiting on older transactions, as those finishing aren't notified.
+const clusterEventUnsettledPollInterval = time.Second
+
+// clusterEventCursor is the position of an event in the order of the
+// transactions that saved them.
+type clusterEventCursor struct {
+	Txid int64
+	ID   int
+}
+
+type sqldbClusterEventSource struct {
+	conn     Conn
+	notifier Notifier
+	filter   ClusterEventFilter
+
+	events chan ClusterEvent
+	stop   chan struct{}
+	err    error
+	wg     *sync.WaitGroup
+}
+
+func (source *sqldbClusterEventSource) Next() (ClusterEvent, error) {
+	e, ok := <-source.events
+	if !ok {
+		return ClusterEvent{}, source.err
+	}
+
+	return e, nil
+}
+
+func (source *sqldbClusterEventSource) Close() error {
+	select {
+	case <-source.stop:
+		return nil
+	default:
+		close(source.stop)
+	}
+
+	source.wg.Wait()
+
+	return source.notifier.Close()
+}
+
+func (source *sqldbClusterEventSource) collectEvents(cursor clusterEventCursor) {
+	defer source.wg.Done()
+
+	var batchSize = cap(source.events)
+
+	for {
+		select {
+		case <-source.stop:
+			source.err = ErrClusterEventStreamClosed
+			close(source.events)
+			return
+		default:
+		}
+
+		query, args, err := source.query(cursor, batchSize)
+		if err != nil {
+			source.err = err
+			close(source.events)
+			return
+		}
+
+		rows, err := source.conn.Query(query, args...)
+		if err != nil {
+			source.err = err
+			close(source.events)
+			return
+		}
+
+		rowsReturned := 0
+		unsettled := false
+
+		for rows.Next() {
+			rowsReturned++
+
+			ev, txid, settled, err := scanClusterEventRow(rows)
+			if err != nil {
+				rows.Close()
+
+				source.err = err
+				close(source.events)
+				return
+			}
+
+			if !settled {
+				// an older transaction may still save events that belong before
+				// this one; wait for it to finish
+				unsettled = true
+				break
+			}
+
+			cursor = clusterEventCursor{Txid: txid, ID: ev.ID}
+
+			select {
+			case source.events <- ev:
+			case <-source.stop:
+				rows.Close()
+
+				source.err = ErrClusterEventStreamClosed
+				close(source.events)
+				return
+			}
+		}
+
+		err = rows.Close()
+		if err != nil {
+			source.err = err
+			close(source.events)
+			return
+		}
+
+		if rowsReturned == batchSize && !unsettled {
+			// still more events
+			continue
+		}
+
+		var poll <-chan time.Time
+		if unsettled {
+			poll = time.After(clusterEventUnsettledPollInterval)
+		}
+
+		select {
+		case <-source.notifier.Notify():
+		case <-poll:
+		case <-source.stop:
+			source.err = ErrClusterEventStreamClosed
+			close(source.events)
+			return
+		}
+	}
+}
+
+func (source *sqldbClusterEventSource) query(cursor clusterEventCursor, limit int) (string, []interface{}, error) {
+	query := sq.Select("id, type, version, payload, team_name, pipeline_name, job_name, txid").
+		Column("txid < txid_snapshot_xmin(txid_current_snapshot())").
+		From("cluster_events").
+		Where(sq.Expr("(txid, id) > (?, ?)", cursor.Txid, cursor.ID))
+
+	if source.filter.Teams != nil {
+		if len(source.filter.Teams) == 0 {
+			query = query.Where(sq.Expr("false"))
+		} else {
+			query = query.Where(sq.Eq{"team_name": source.filter.Teams})
+		}
+	}
+
+	if source.filter.Team != "" {
+		query = query.Where(sq.Eq{"team_name": source.filter.Team})
+	}
+
+	if source.filter.Pipeline != "" {
+		query = query.Where(sq.Eq{"pipeline_name": source.filter.Pipeline})
+	}
+
+	if source.filter.Job != "" {
+		query = query.Where(sq.Eq{"job_name": source.filter.Job})
+	}
+
+	if len(source.filter.Types) > 0 {
+		types := make([]string, len(source.filter.Types))
+		for i, t := range source.filter.Types {
+			types[i] = string(t)
+		}
+
+		query = query.Where(sq.Eq{"type": types})
+	}
+
+	return query.
+		OrderBy("txid ASC", "id ASC").
+		Limit(uint64(limit)).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+}
+
+func scanClusterEventRow(row scannable) (ClusterEvent, int64, bool, error) {
+	var id int
+	var t, v, p, teamName string
+	var pipelineName, jobName sql.NullString
+	var txid int64
+	var settled bool
+
+	err := row.Scan(&id, &t, &v, &p, &teamName, &pipelineName, &jobName, &txid, &settled)
+	if err != nil {
+		return ClusterEvent{}, 0, false, err
+	}
+
+	data := json.RawMessage(p)
+
+	return ClusterEvent{
+		ID: id,
+		Envelope: event.ClusterEnvelope{
+			Envelope: event.Envelope{
+				Data:    &data,
+				Event:   atc.EventType(t),
+				Version: atc.EventVersion(v),
+			},
+			Team:     teamName,
+			Pipeline: pipelineName.String,
+			Job:      jobName.String,
+		},
+	}, txid, settled, nil
+}
@@ -0,0 +1,274 @@
+package db_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/atc/event"
+	"github.com/lib/pq"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster events", func() {
+	var dbConn db.Conn
+	var listener *pq.Listener
+
+	var sqlDB *db.SQLDB
+	var teamDB db.TeamDB
+	var pipelineDB db.PipelineDB
+
+	BeforeEach(func() {
+		postgresRunner.Truncate()
+
+		dbConn = db.Wrap(postgresRunner.Open())
+
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener, dbConn)
+
+		pgxConn := postgresRunner.OpenPgx()
+		fakeConnector := new(lockfakes.FakeConnector)
+		retryableConn := &lock.RetryableConn{Connector: fakeConnector, Conn: pgxConn}
+
+		lockFactory := lock.NewLockFactory(retryableConn)
+
+		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
+
+		teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
+		teamDB = teamDBFactory.GetTeamDB(atc.DefaultTeamName)
+
+		pipeline, _, err := teamDB.SaveConfigToBeDeprecated("some-pipeline", atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+			},
+			Resources: atc.ResourceConfigs{
+				{Name: "some-resource", Type: "some-type"},
+			},
+		}, db.ConfigVersion(1), db.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		pipelineDBFactory := db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		pipelineDB = pipelineDBFactory.Build(pipeline)
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = listener.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	nextEvent := func(source db.ClusterEventSource) db.ClusterEvent {
+		events := make(chan db.ClusterEvent, 1)
+
+		go func() {
+			defer GinkgoRecover()
+
+			ev, err := source.Next()
+			Expect(err).NotTo(HaveOccurred())
+
+			events <- ev
+		}()
+
+		var ev db.ClusterEvent
+		Eventually(events, 5*time.Second).Should(Receive(&ev))
+
+		return ev
+	}
+
+	It("streams build lifecycle events as they happen", func() {
+		since, err := sqlDB.LatestClusterEventID()
+		Expect(err).NotTo(HaveOccurred())
+
+		source, err := sqlDB.ClusterEvents(db.ClusterEventFilter{}, since)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer source.Close()
+
+		build, err := pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		ev := nextEvent(source)
+		Expect(ev.ID).To(BeNumerically(">", since))
+		Expect(ev.Envelope.Event).To(Equal(event.EventTypeBuildCreated))
+		Expect(ev.Envelope.Team).To(Equal(atc.DefaultTeamName))
+		Expect(ev.Envelope.Pipeline).To(Equal("some-pipeline"))
+		Expect(ev.Envelope.Job).To(Equal("some-job"))
+
+		started, err := build.Start("engine", "metadata")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(started).To(BeTrue())
+
+		Expect(nextEvent(source).Envelope.Event).To(Equal(event.EventTypeBuildStarted))
+
+		err = build.Finish(db.StatusSucceeded)
+		Expect(err).NotTo(HaveOccurred())
+
+		ev = nextEvent(source)
+		Expect(ev.Envelope.Event).To(Equal(event.EventTypeBuildFinished))
+
+		var finished event.BuildFinished
+		err = json.Unmarshal(*ev.Envelope.Data, &finished)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(finished.BuildID).To(Equal(build.ID()))
+		Expect(finished.BuildName).To(Equal(build.Name()))
+		Expect(finished.Status).To(Equal(atc.StatusSucceeded))
+	})
+
+	It("only streams newly discovered resource versions", func() {
+		resourceConfig := atc.ResourceConfig{Name: "some-resource", Type: "some-type"}
+
+		err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		since, err := sqlDB.LatestClusterEventID()
+		Expect(err).NotTo(HaveOccurred())
+
+		source, err := sqlDB.ClusterEvents(db.ClusterEventFilter{}, since)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer source.Close()
+
+		err = pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "1"}, {"version": "2"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		ev := nextEvent(source)
+		Expect(ev.Envelope.Event).To(Equal(event.EventTypeResourceVersionsDiscovered))
+		Expect(*ev.Envelope.Data).To(MatchJSON(`{
+			"resource": "some-resource",
+			"versions": [{"version": "2"}]
+		}`))
+	})
+
+	It("filters events by type, team, and pipeline", func() {
+		since, err := sqlDB.LatestClusterEventID()
+		Expect(err).NotTo(HaveOccurred())
+
+		source, err := sqlDB.ClusterEvents(db.ClusterEventFilter{
+			Teams:    []string{atc.DefaultTeamName},
+			Pipeline: "some-pipeline",
+			Types:    []atc.EventType{event.EventTypePipelinePaused},
+		}, since)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer source.Close()
+
+		_, err = teamDB.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = pipelineDB.Pause()
+		Expect(err).NotTo(HaveOccurred())
+
+		ev := nextEvent(source)
+		Expect(ev.Envelope.Event).To(Equal(event.EventTypePipelinePaused))
+		Expect(ev.Envelope.Pipeline).To(Equal("some-pipeline"))
+	})
+
+	It("streams pipelines being unpaused", func() {
+		since, err := sqlDB.LatestClusterEventID()
+		Expect(err).NotTo(HaveOccurred())
+
+		source, err := sqlDB.ClusterEvents(db.ClusterEventFilter{}, since)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer source.Close()
+
+		err = pipelineDB.Unpause()
+		Expect(err).NotTo(HaveOccurred())
+
+		ev := nextEvent(source)
+		Expect(ev.Envelope.Event).To(Equal(event.EventTypePipelineUnpaused))
+		Expect(ev.Envelope.Team).To(Equal(atc.DefaultTeamName))
+		Expect(ev.Envelope.Pipeline).To(Equal("some-pipeline"))
+	})
+
+	It("streams events whose transactions commit out of order without skipping any", func() {
+		since, err := sqlDB.LatestClusterEventID()
+		Expect(err).NotTo(HaveOccurred())
+
+		source, err := sqlDB.ClusterEvents(db.ClusterEventFilter{}, since)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer source.Close()
+
+		tx, err := dbConn.Begin()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = tx.Exec(`
+			INSERT INTO cluster_events (type, version, payload, team_name)
+			VALUES ($1, '1.0', '{}', $2)
+		`, string(event.EventTypeBuildCreated), atc.DefaultTeamName)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = pipelineDB.Pause()
+		Expect(err).NotTo(HaveOccurred())
+
+		err = tx.Commit()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(nextEvent(source).Envelope.Event).To(Equal(event.EventTypeBuildCreated))
+		Expect(nextEvent(source).Envelope.Event).To(Equal(event.EventTypePipelinePaused))
+	})
+
+	Describe("DeleteClusterEventsOlderThan", func() {
+		countEvents := func() int {
+			var count int
+			err := dbConn.QueryRow(`SELECT COUNT(*) FROM cluster_events`).Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			return count
+		}
+
+		BeforeEach(func() {
+			err := pipelineDB.Pause()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE cluster_events SET created_at = now() - interval '2 hours'`)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = pipelineDB.Unpause()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the events saved before the retention period", func() {
+			Expect(countEvents()).To(BeNumerically(">", 1))
+
+			err := sqlDB.DeleteClusterEventsOlderThan(time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(countEvents()).To(Equal(1))
+
+			since, err := sqlDB.LatestClusterEventID()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(since).NotTo(BeZero())
+		})
+	})
+
+	It("streams nothing for an empty set of visible teams", func() {
+		source, err := sqlDB.ClusterEvents(db.ClusterEventFilter{Teams: []string{}}, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = pipelineDB.CreateJobBuild("some-job")
+		Expect(err).NotTo(HaveOccurred())
+
+		events := make(chan db.ClusterEvent, 1)
+		go func() {
+			ev, err := source.Next()
+			if err == nil {
+				events <- ev
+			}
+		}()
+
+		Consistently(events).ShouldNot(Receive())
+
+		err = source.Close()
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -80,6 +80,7 @@ type DB interface {
 	GetPublicBuilds(page Page) ([]Build, Pagination, error)
 
 	FindJobIDForBuild(buildID int) (int, bool, error)
+	FindJobConfigsForBuilds(buildIDs []int) (map[int]atc.JobConfig, error)
 
 	CreatePipe(pipeGUID string, url string, teamName string) error
 	GetPipe(pipeGUID string) (Pipe, error)
@@ -95,6 +96,7 @@ type DB interface {
 	GetContainer(string) (SavedContainer, bool, error)
 	CreateContainerToBeRemoved(container Container, maxLifetime time.Duration, volumeHandles []string) (SavedContainer, error)
 	FindContainerByIdentifier(ContainerIdentifier) (SavedContainer, bool, error)
+	CountContainersOnWorker(workerName string) (int, error)
 	FindLatestSuccessfulBuildsPerJob() (map[int]int, error)
 	FindJobContainersFromUnsuccessfulBuilds() ([]SavedContainer, error)
 	ReapContainer(handle string) error
@@ -161,6 +161,46 @@ var _ = Describe("Builds", func() {
 		})
 	})
 
+	Describe("FindJobConfigsForBuilds", func() {
+		It("finds the job config of each build still in its pipeline", func() {
+			someJobBuild := createAndFinishBuild(database, pipelineDB, "some-job", db.StatusSucceeded)
+			removedJobBuild := createAndFinishBuild(database, pipelineDB, "some-random-job", db.StatusSucceeded)
+
+			oneOffBuild, err := teamDB.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = teamDB.SaveConfigToBeDeprecated("some-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:      "some-job",
+						Artifacts: []string{"some-output"},
+					},
+				},
+			}, pipelineDB.ConfigVersion(), db.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			jobConfigs, err := database.FindJobConfigsForBuilds([]int{
+				someJobBuild.ID(),
+				removedJobBuild.ID(),
+				oneOffBuild.ID(),
+				oneOffBuild.ID() + 1,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobConfigs).To(Equal(map[int]atc.JobConfig{
+				someJobBuild.ID(): {
+					Name:      "some-job",
+					Artifacts: []string{"some-output"},
+				},
+			}))
+		})
+
+		It("returns nothing for no builds", func() {
+			jobConfigs, err := database.FindJobConfigsForBuilds([]int{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobConfigs).To(BeEmpty())
+		})
+	})
+
 	Describe("GetPublicBuilds", func() {
 		var publicBuild db.Build
 
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/lib/pq"
@@ -512,6 +513,36 @@ var _ = Describe("Keeping track of containers", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	It("can count the containers on a worker", func() {
+		someBuild, err := teamDB.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		for i, workerName := range []string{"some-worker", "some-worker", "updated-resource-type-worker"} {
+			_, err := database.CreateContainerToBeRemoved(db.Container{
+				ContainerIdentifier: db.ContainerIdentifier{
+					BuildID: someBuild.ID(),
+					PlanID:  atc.PlanID(fmt.Sprintf("some-task-%d", i)),
+					Stage:   db.ContainerStageRun,
+				},
+				ContainerMetadata: db.ContainerMetadata{
+					Handle:     fmt.Sprintf("some-handle-%d", i),
+					WorkerName: workerName,
+					Type:       db.ContainerTypeTask,
+					TeamID:     teamID,
+				},
+			}, time.Duration(0), []string{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		count, err := database.CountContainersOnWorker("some-worker")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(2))
+
+		count, err = database.CountContainersOnWorker("some-unknown-worker")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(BeZero())
+	})
+
 	It("differentiates between a single step's containers with different stages", func() {
 		someBuild, err := teamDB.CreateOneOffBuild()
 		Expect(err).ToNot(HaveOccurred())
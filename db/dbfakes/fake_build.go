@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
 )
 
 type FakeBuild struct {
@@ -263,6 +264,98 @@ type FakeBuild struct {
 		result1 db.SavedPipeline
 		result2 error
 	}
+	GetEventsStub        func(types []atc.EventType) ([]event.Envelope, error)
+	getEventsMutex       sync.RWMutex
+	getEventsArgsForCall []struct {
+		types []atc.EventType
+	}
+	getEventsReturns struct {
+		result1 []event.Envelope
+		result2 error
+	}
+	SaveCommentStub        func(teamName string, author string, comment string) (db.BuildComment, error)
+	saveCommentMutex       sync.RWMutex
+	saveCommentArgsForCall []struct {
+		teamName string
+		author   string
+		comment  string
+	}
+	saveCommentReturns struct {
+		result1 db.BuildComment
+		result2 error
+	}
+	GetCommentsStub        func() ([]db.BuildComment, error)
+	getCommentsMutex       sync.RWMutex
+	getCommentsArgsForCall []struct{}
+	getCommentsReturns     struct {
+		result1 []db.BuildComment
+		result2 error
+	}
+	SaveAnnotationsStub        func(planID atc.PlanID, stepName string, annotations []atc.MetadataField) error
+	saveAnnotationsMutex       sync.RWMutex
+	saveAnnotationsArgsForCall []struct {
+		planID      atc.PlanID
+		stepName    string
+		annotations []atc.MetadataField
+	}
+	saveAnnotationsReturns struct {
+		result1 error
+	}
+	GetAnnotationsStub        func() ([]db.BuildAnnotation, error)
+	getAnnotationsMutex       sync.RWMutex
+	getAnnotationsArgsForCall []struct{}
+	getAnnotationsReturns     struct {
+		result1 []db.BuildAnnotation
+		result2 error
+	}
+	SaveTestResultsStub        func(planID atc.PlanID, stepName string, results []atc.TestResult) error
+	saveTestResultsMutex       sync.RWMutex
+	saveTestResultsArgsForCall []struct {
+		planID   atc.PlanID
+		stepName string
+		results  []atc.TestResult
+	}
+	saveTestResultsReturns struct {
+		result1 error
+	}
+	GetTestResultsStub        func() ([]db.BuildTestResult, error)
+	getTestResultsMutex       sync.RWMutex
+	getTestResultsArgsForCall []struct{}
+	getTestResultsReturns     struct {
+		result1 []db.BuildTestResult
+		result2 error
+	}
+	SaveStepCheckpointStub        func(planID atc.PlanID, checkpoint db.StepCheckpoint) error
+	saveStepCheckpointMutex       sync.RWMutex
+	saveStepCheckpointArgsForCall []struct {
+		planID     atc.PlanID
+		checkpoint db.StepCheckpoint
+	}
+	saveStepCheckpointReturns struct {
+		result1 error
+	}
+	GetStepCheckpointStub        func(planID atc.PlanID) (db.StepCheckpoint, bool, error)
+	getStepCheckpointMutex       sync.RWMutex
+	getStepCheckpointArgsForCall []struct {
+		planID atc.PlanID
+	}
+	getStepCheckpointReturns struct {
+		result1 db.StepCheckpoint
+		result2 bool
+		result3 error
+	}
+	IndexLogsStub        func() error
+	indexLogsMutex       sync.RWMutex
+	indexLogsArgsForCall []struct{}
+	indexLogsReturns     struct {
+		result1 error
+	}
+	CreateTimeStub        func() time.Time
+	createTimeMutex       sync.RWMutex
+	createTimeArgsForCall []struct{}
+	createTimeReturns     struct {
+		result1 time.Time
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1243,6 +1336,358 @@ func (fake *FakeBuild) GetPipelineReturns(result1 db.SavedPipeline, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) GetEvents(types []atc.EventType) ([]event.Envelope, error) {
+	var typesCopy []atc.EventType
+	if types != nil {
+		typesCopy = make([]atc.EventType, len(types))
+		copy(typesCopy, types)
+	}
+	fake.getEventsMutex.Lock()
+	fake.getEventsArgsForCall = append(fake.getEventsArgsForCall, struct {
+		types []atc.EventType
+	}{typesCopy})
+	fake.recordInvocation("GetEvents", []interface{}{typesCopy})
+	fake.getEventsMutex.Unlock()
+	if fake.GetEventsStub != nil {
+		return fake.GetEventsStub(types)
+	} else {
+		return fake.getEventsReturns.result1, fake.getEventsReturns.result2
+	}
+}
+
+func (fake *FakeBuild) GetEventsCallCount() int {
+	fake.getEventsMutex.RLock()
+	defer fake.getEventsMutex.RUnlock()
+	return len(fake.getEventsArgsForCall)
+}
+
+func (fake *FakeBuild) GetEventsArgsForCall(i int) []atc.EventType {
+	fake.getEventsMutex.RLock()
+	defer fake.getEventsMutex.RUnlock()
+	return fake.getEventsArgsForCall[i].types
+}
+
+func (fake *FakeBuild) GetEventsReturns(result1 []event.Envelope, result2 error) {
+	fake.GetEventsStub = nil
+	fake.getEventsReturns = struct {
+		result1 []event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveComment(teamName string, author string, comment string) (db.BuildComment, error) {
+	fake.saveCommentMutex.Lock()
+	fake.saveCommentArgsForCall = append(fake.saveCommentArgsForCall, struct {
+		teamName string
+		author   string
+		comment  string
+	}{teamName, author, comment})
+	fake.recordInvocation("SaveComment", []interface{}{teamName, author, comment})
+	fake.saveCommentMutex.Unlock()
+	if fake.SaveCommentStub != nil {
+		return fake.SaveCommentStub(teamName, author, comment)
+	} else {
+		return fake.saveCommentReturns.result1, fake.saveCommentReturns.result2
+	}
+}
+
+func (fake *FakeBuild) SaveCommentCallCount() int {
+	fake.saveCommentMutex.RLock()
+	defer fake.saveCommentMutex.RUnlock()
+	return len(fake.saveCommentArgsForCall)
+}
+
+func (fake *FakeBuild) SaveCommentArgsForCall(i int) (string, string, string) {
+	fake.saveCommentMutex.RLock()
+	defer fake.saveCommentMutex.RUnlock()
+	return fake.saveCommentArgsForCall[i].teamName, fake.saveCommentArgsForCall[i].author, fake.saveCommentArgsForCall[i].comment
+}
+
+func (fake *FakeBuild) SaveCommentReturns(result1 db.BuildComment, result2 error) {
+	fake.SaveCommentStub = nil
+	fake.saveCommentReturns = struct {
+		result1 db.BuildComment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) GetComments() ([]db.BuildComment, error) {
+	fake.getCommentsMutex.Lock()
+	fake.getCommentsArgsForCall = append(fake.getCommentsArgsForCall, struct{}{})
+	fake.recordInvocation("GetComments", []interface{}{})
+	fake.getCommentsMutex.Unlock()
+	if fake.GetCommentsStub != nil {
+		return fake.GetCommentsStub()
+	} else {
+		return fake.getCommentsReturns.result1, fake.getCommentsReturns.result2
+	}
+}
+
+func (fake *FakeBuild) GetCommentsCallCount() int {
+	fake.getCommentsMutex.RLock()
+	defer fake.getCommentsMutex.RUnlock()
+	return len(fake.getCommentsArgsForCall)
+}
+
+func (fake *FakeBuild) GetCommentsReturns(result1 []db.BuildComment, result2 error) {
+	fake.GetCommentsStub = nil
+	fake.getCommentsReturns = struct {
+		result1 []db.BuildComment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveAnnotations(planID atc.PlanID, stepName string, annotations []atc.MetadataField) error {
+	var annotationsCopy []atc.MetadataField
+	if annotations != nil {
+		annotationsCopy = make([]atc.MetadataField, len(annotations))
+		copy(annotationsCopy, annotations)
+	}
+	fake.saveAnnotationsMutex.Lock()
+	fake.saveAnnotationsArgsForCall = append(fake.saveAnnotationsArgsForCall, struct {
+		planID      atc.PlanID
+		stepName    string
+		annotations []atc.MetadataField
+	}{planID, stepName, annotationsCopy})
+	fake.recordInvocation("SaveAnnotations", []interface{}{planID, stepName, annotationsCopy})
+	fake.saveAnnotationsMutex.Unlock()
+	if fake.SaveAnnotationsStub != nil {
+		return fake.SaveAnnotationsStub(planID, stepName, annotations)
+	} else {
+		return fake.saveAnnotationsReturns.result1
+	}
+}
+
+func (fake *FakeBuild) SaveAnnotationsCallCount() int {
+	fake.saveAnnotationsMutex.RLock()
+	defer fake.saveAnnotationsMutex.RUnlock()
+	return len(fake.saveAnnotationsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveAnnotationsArgsForCall(i int) (atc.PlanID, string, []atc.MetadataField) {
+	fake.saveAnnotationsMutex.RLock()
+	defer fake.saveAnnotationsMutex.RUnlock()
+	return fake.saveAnnotationsArgsForCall[i].planID, fake.saveAnnotationsArgsForCall[i].stepName, fake.saveAnnotationsArgsForCall[i].annotations
+}
+
+func (fake *FakeBuild) SaveAnnotationsReturns(result1 error) {
+	fake.SaveAnnotationsStub = nil
+	fake.saveAnnotationsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) GetAnnotations() ([]db.BuildAnnotation, error) {
+	fake.getAnnotationsMutex.Lock()
+	fake.getAnnotationsArgsForCall = append(fake.getAnnotationsArgsForCall, struct{}{})
+	fake.recordInvocation("GetAnnotations", []interface{}{})
+	fake.getAnnotationsMutex.Unlock()
+	if fake.GetAnnotationsStub != nil {
+		return fake.GetAnnotationsStub()
+	} else {
+		return fake.getAnnotationsReturns.result1, fake.getAnnotationsReturns.result2
+	}
+}
+
+func (fake *FakeBuild) GetAnnotationsCallCount() int {
+	fake.getAnnotationsMutex.RLock()
+	defer fake.getAnnotationsMutex.RUnlock()
+	return len(fake.getAnnotationsArgsForCall)
+}
+
+func (fake *FakeBuild) GetAnnotationsReturns(result1 []db.BuildAnnotation, result2 error) {
+	fake.GetAnnotationsStub = nil
+	fake.getAnnotationsReturns = struct {
+		result1 []db.BuildAnnotation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveTestResults(planID atc.PlanID, stepName string, results []atc.TestResult) error {
+	var resultsCopy []atc.TestResult
+	if results != nil {
+		resultsCopy = make([]atc.TestResult, len(results))
+		copy(resultsCopy, results)
+	}
+	fake.saveTestResultsMutex.Lock()
+	fake.saveTestResultsArgsForCall = append(fake.saveTestResultsArgsForCall, struct {
+		planID   atc.PlanID
+		stepName string
+		results  []atc.TestResult
+	}{planID, stepName, resultsCopy})
+	fake.recordInvocation("SaveTestResults", []interface{}{planID, stepName, resultsCopy})
+	fake.saveTestResultsMutex.Unlock()
+	if fake.SaveTestResultsStub != nil {
+		return fake.SaveTestResultsStub(planID, stepName, results)
+	} else {
+		return fake.saveTestResultsReturns.result1
+	}
+}
+
+func (fake *FakeBuild) SaveTestResultsCallCount() int {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	return len(fake.saveTestResultsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveTestResultsArgsForCall(i int) (atc.PlanID, string, []atc.TestResult) {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	return fake.saveTestResultsArgsForCall[i].planID, fake.saveTestResultsArgsForCall[i].stepName, fake.saveTestResultsArgsForCall[i].results
+}
+
+func (fake *FakeBuild) SaveTestResultsReturns(result1 error) {
+	fake.SaveTestResultsStub = nil
+	fake.saveTestResultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) GetTestResults() ([]db.BuildTestResult, error) {
+	fake.getTestResultsMutex.Lock()
+	fake.getTestResultsArgsForCall = append(fake.getTestResultsArgsForCall, struct{}{})
+	fake.recordInvocation("GetTestResults", []interface{}{})
+	fake.getTestResultsMutex.Unlock()
+	if fake.GetTestResultsStub != nil {
+		return fake.GetTestResultsStub()
+	} else {
+		return fake.getTestResultsReturns.result1, fake.getTestResultsReturns.result2
+	}
+}
+
+func (fake *FakeBuild) GetTestResultsCallCount() int {
+	fake.getTestResultsMutex.RLock()
+	defer fake.getTestResultsMutex.RUnlock()
+	return len(fake.getTestResultsArgsForCall)
+}
+
+func (fake *FakeBuild) GetTestResultsReturns(result1 []db.BuildTestResult, result2 error) {
+	fake.GetTestResultsStub = nil
+	fake.getTestResultsReturns = struct {
+		result1 []db.BuildTestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SaveStepCheckpoint(planID atc.PlanID, checkpoint db.StepCheckpoint) error {
+	fake.saveStepCheckpointMutex.Lock()
+	fake.saveStepCheckpointArgsForCall = append(fake.saveStepCheckpointArgsForCall, struct {
+		planID     atc.PlanID
+		checkpoint db.StepCheckpoint
+	}{planID, checkpoint})
+	fake.recordInvocation("SaveStepCheckpoint", []interface{}{planID, checkpoint})
+	fake.saveStepCheckpointMutex.Unlock()
+	if fake.SaveStepCheckpointStub != nil {
+		return fake.SaveStepCheckpointStub(planID, checkpoint)
+	} else {
+		return fake.saveStepCheckpointReturns.result1
+	}
+}
+
+func (fake *FakeBuild) SaveStepCheckpointCallCount() int {
+	fake.saveStepCheckpointMutex.RLock()
+	defer fake.saveStepCheckpointMutex.RUnlock()
+	return len(fake.saveStepCheckpointArgsForCall)
+}
+
+func (fake *FakeBuild) SaveStepCheckpointArgsForCall(i int) (atc.PlanID, db.StepCheckpoint) {
+	fake.saveStepCheckpointMutex.RLock()
+	defer fake.saveStepCheckpointMutex.RUnlock()
+	return fake.saveStepCheckpointArgsForCall[i].planID, fake.saveStepCheckpointArgsForCall[i].checkpoint
+}
+
+func (fake *FakeBuild) SaveStepCheckpointReturns(result1 error) {
+	fake.SaveStepCheckpointStub = nil
+	fake.saveStepCheckpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) GetStepCheckpoint(planID atc.PlanID) (db.StepCheckpoint, bool, error) {
+	fake.getStepCheckpointMutex.Lock()
+	fake.getStepCheckpointArgsForCall = append(fake.getStepCheckpointArgsForCall, struct {
+		planID atc.PlanID
+	}{planID})
+	fake.recordInvocation("GetStepCheckpoint", []interface{}{planID})
+	fake.getStepCheckpointMutex.Unlock()
+	if fake.GetStepCheckpointStub != nil {
+		return fake.GetStepCheckpointStub(planID)
+	} else {
+		return fake.getStepCheckpointReturns.result1, fake.getStepCheckpointReturns.result2, fake.getStepCheckpointReturns.result3
+	}
+}
+
+func (fake *FakeBuild) GetStepCheckpointCallCount() int {
+	fake.getStepCheckpointMutex.RLock()
+	defer fake.getStepCheckpointMutex.RUnlock()
+	return len(fake.getStepCheckpointArgsForCall)
+}
+
+func (fake *FakeBuild) GetStepCheckpointArgsForCall(i int) atc.PlanID {
+	fake.getStepCheckpointMutex.RLock()
+	defer fake.getStepCheckpointMutex.RUnlock()
+	return fake.getStepCheckpointArgsForCall[i].planID
+}
+
+func (fake *FakeBuild) GetStepCheckpointReturns(result1 db.StepCheckpoint, result2 bool, result3 error) {
+	fake.GetStepCheckpointStub = nil
+	fake.getStepCheckpointReturns = struct {
+		result1 db.StepCheckpoint
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) IndexLogs() error {
+	fake.indexLogsMutex.Lock()
+	fake.indexLogsArgsForCall = append(fake.indexLogsArgsForCall, struct{}{})
+	fake.recordInvocation("IndexLogs", []interface{}{})
+	fake.indexLogsMutex.Unlock()
+	if fake.IndexLogsStub != nil {
+		return fake.IndexLogsStub()
+	} else {
+		return fake.indexLogsReturns.result1
+	}
+}
+
+func (fake *FakeBuild) IndexLogsCallCount() int {
+	fake.indexLogsMutex.RLock()
+	defer fake.indexLogsMutex.RUnlock()
+	return len(fake.indexLogsArgsForCall)
+}
+
+func (fake *FakeBuild) IndexLogsReturns(result1 error) {
+	fake.IndexLogsStub = nil
+	fake.indexLogsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) CreateTime() time.Time {
+	fake.createTimeMutex.Lock()
+	fake.createTimeArgsForCall = append(fake.createTimeArgsForCall, struct{}{})
+	fake.recordInvocation("CreateTime", []interface{}{})
+	fake.createTimeMutex.Unlock()
+	if fake.CreateTimeStub != nil {
+		return fake.CreateTimeStub()
+	} else {
+		return fake.createTimeReturns.result1
+	}
+}
+
+func (fake *FakeBuild) CreateTimeCallCount() int {
+	fake.createTimeMutex.RLock()
+	defer fake.createTimeMutex.RUnlock()
+	return len(fake.createTimeArgsForCall)
+}
+
+func (fake *FakeBuild) CreateTimeReturns(result1 time.Time) {
+	fake.CreateTimeStub = nil
+	fake.createTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1316,6 +1761,28 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.getConfigMutex.RUnlock()
 	fake.getPipelineMutex.RLock()
 	defer fake.getPipelineMutex.RUnlock()
+	fake.getEventsMutex.RLock()
+	defer fake.getEventsMutex.RUnlock()
+	fake.saveCommentMutex.RLock()
+	defer fake.saveCommentMutex.RUnlock()
+	fake.getCommentsMutex.RLock()
+	defer fake.getCommentsMutex.RUnlock()
+	fake.saveAnnotationsMutex.RLock()
+	defer fake.saveAnnotationsMutex.RUnlock()
+	fake.getAnnotationsMutex.RLock()
+	defer fake.getAnnotationsMutex.RUnlock()
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	fake.getTestResultsMutex.RLock()
+	defer fake.getTestResultsMutex.RUnlock()
+	fake.saveStepCheckpointMutex.RLock()
+	defer fake.saveStepCheckpointMutex.RUnlock()
+	fake.getStepCheckpointMutex.RLock()
+	defer fake.getStepCheckpointMutex.RUnlock()
+	fake.indexLogsMutex.RLock()
+	defer fake.indexLogsMutex.RUnlock()
+	fake.createTimeMutex.RLock()
+	defer fake.createTimeMutex.RUnlock()
 	return fake.invocations
 }
 
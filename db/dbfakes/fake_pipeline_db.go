@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
 )
 
 type FakePipelineDB struct {
@@ -142,10 +143,11 @@ type FakePipelineDB struct {
 		result2 bool
 		result3 error
 	}
-	GetResourceVersionsStub        func(resourceName string, page db.Page) ([]db.SavedVersionedResource, db.Pagination, bool, error)
+	GetResourceVersionsStub        func(resourceName string, filter db.VersionFilter, page db.Page) ([]db.SavedVersionedResource, db.Pagination, bool, error)
 	getResourceVersionsMutex       sync.RWMutex
 	getResourceVersionsArgsForCall []struct {
 		resourceName string
+		filter       db.VersionFilter
 		page         db.Page
 	}
 	getResourceVersionsReturns struct {
@@ -532,6 +534,46 @@ type FakePipelineDB struct {
 	hideReturns     struct {
 		result1 error
 	}
+	SearchJobBuildLogsStub        func(job string, query string, limit int) ([]db.LogMatch, error)
+	searchJobBuildLogsMutex       sync.RWMutex
+	searchJobBuildLogsArgsForCall []struct {
+		job   string
+		query string
+		limit int
+	}
+	searchJobBuildLogsReturns struct {
+		result1 []db.LogMatch
+		result2 error
+	}
+	GetJobFlakyTestsStub        func(job string, limit int) ([]db.FlakyTest, error)
+	getJobFlakyTestsMutex       sync.RWMutex
+	getJobFlakyTestsArgsForCall []struct {
+		job   string
+		limit int
+	}
+	getJobFlakyTestsReturns struct {
+		result1 []db.FlakyTest
+		result2 error
+	}
+	SetResourceCheckBackoffIntervalStub        func(resource db.SavedResource, interval time.Duration) error
+	setResourceCheckBackoffIntervalMutex       sync.RWMutex
+	setResourceCheckBackoffIntervalArgsForCall []struct {
+		resource db.SavedResource
+		interval time.Duration
+	}
+	setResourceCheckBackoffIntervalReturns struct {
+		result1 error
+	}
+	GetBuildsEventsStub        func(buildIDs []int, types []atc.EventType) (map[int][]event.Envelope, error)
+	getBuildsEventsMutex       sync.RWMutex
+	getBuildsEventsArgsForCall []struct {
+		buildIDs []int
+		types    []atc.EventType
+	}
+	getBuildsEventsReturns struct {
+		result1 map[int][]event.Envelope
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1037,16 +1079,17 @@ func (fake *FakePipelineDB) GetResourceTypeReturns(result1 db.SavedResourceType,
 	}{result1, result2, result3}
 }
 
-func (fake *FakePipelineDB) GetResourceVersions(resourceName string, page db.Page) ([]db.SavedVersionedResource, db.Pagination, bool, error) {
+func (fake *FakePipelineDB) GetResourceVersions(resourceName string, filter db.VersionFilter, page db.Page) ([]db.SavedVersionedResource, db.Pagination, bool, error) {
 	fake.getResourceVersionsMutex.Lock()
 	fake.getResourceVersionsArgsForCall = append(fake.getResourceVersionsArgsForCall, struct {
 		resourceName string
+		filter       db.VersionFilter
 		page         db.Page
-	}{resourceName, page})
-	fake.recordInvocation("GetResourceVersions", []interface{}{resourceName, page})
+	}{resourceName, filter, page})
+	fake.recordInvocation("GetResourceVersions", []interface{}{resourceName, filter, page})
 	fake.getResourceVersionsMutex.Unlock()
 	if fake.GetResourceVersionsStub != nil {
-		return fake.GetResourceVersionsStub(resourceName, page)
+		return fake.GetResourceVersionsStub(resourceName, filter, page)
 	} else {
 		return fake.getResourceVersionsReturns.result1, fake.getResourceVersionsReturns.result2, fake.getResourceVersionsReturns.result3, fake.getResourceVersionsReturns.result4
 	}
@@ -2486,6 +2529,156 @@ func (fake *FakePipelineDB) HideReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) SearchJobBuildLogs(job string, query string, limit int) ([]db.LogMatch, error) {
+	fake.searchJobBuildLogsMutex.Lock()
+	fake.searchJobBuildLogsArgsForCall = append(fake.searchJobBuildLogsArgsForCall, struct {
+		job   string
+		query string
+		limit int
+	}{job, query, limit})
+	fake.recordInvocation("SearchJobBuildLogs", []interface{}{job, query, limit})
+	fake.searchJobBuildLogsMutex.Unlock()
+	if fake.SearchJobBuildLogsStub != nil {
+		return fake.SearchJobBuildLogsStub(job, query, limit)
+	} else {
+		return fake.searchJobBuildLogsReturns.result1, fake.searchJobBuildLogsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) SearchJobBuildLogsCallCount() int {
+	fake.searchJobBuildLogsMutex.RLock()
+	defer fake.searchJobBuildLogsMutex.RUnlock()
+	return len(fake.searchJobBuildLogsArgsForCall)
+}
+
+func (fake *FakePipelineDB) SearchJobBuildLogsArgsForCall(i int) (string, string, int) {
+	fake.searchJobBuildLogsMutex.RLock()
+	defer fake.searchJobBuildLogsMutex.RUnlock()
+	return fake.searchJobBuildLogsArgsForCall[i].job, fake.searchJobBuildLogsArgsForCall[i].query, fake.searchJobBuildLogsArgsForCall[i].limit
+}
+
+func (fake *FakePipelineDB) SearchJobBuildLogsReturns(result1 []db.LogMatch, result2 error) {
+	fake.SearchJobBuildLogsStub = nil
+	fake.searchJobBuildLogsReturns = struct {
+		result1 []db.LogMatch
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetJobFlakyTests(job string, limit int) ([]db.FlakyTest, error) {
+	fake.getJobFlakyTestsMutex.Lock()
+	fake.getJobFlakyTestsArgsForCall = append(fake.getJobFlakyTestsArgsForCall, struct {
+		job   string
+		limit int
+	}{job, limit})
+	fake.recordInvocation("GetJobFlakyTests", []interface{}{job, limit})
+	fake.getJobFlakyTestsMutex.Unlock()
+	if fake.GetJobFlakyTestsStub != nil {
+		return fake.GetJobFlakyTestsStub(job, limit)
+	} else {
+		return fake.getJobFlakyTestsReturns.result1, fake.getJobFlakyTestsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetJobFlakyTestsCallCount() int {
+	fake.getJobFlakyTestsMutex.RLock()
+	defer fake.getJobFlakyTestsMutex.RUnlock()
+	return len(fake.getJobFlakyTestsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetJobFlakyTestsArgsForCall(i int) (string, int) {
+	fake.getJobFlakyTestsMutex.RLock()
+	defer fake.getJobFlakyTestsMutex.RUnlock()
+	return fake.getJobFlakyTestsArgsForCall[i].job, fake.getJobFlakyTestsArgsForCall[i].limit
+}
+
+func (fake *FakePipelineDB) GetJobFlakyTestsReturns(result1 []db.FlakyTest, result2 error) {
+	fake.GetJobFlakyTestsStub = nil
+	fake.getJobFlakyTestsReturns = struct {
+		result1 []db.FlakyTest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) SetResourceCheckBackoffInterval(resource db.SavedResource, interval time.Duration) error {
+	fake.setResourceCheckBackoffIntervalMutex.Lock()
+	fake.setResourceCheckBackoffIntervalArgsForCall = append(fake.setResourceCheckBackoffIntervalArgsForCall, struct {
+		resource db.SavedResource
+		interval time.Duration
+	}{resource, interval})
+	fake.recordInvocation("SetResourceCheckBackoffInterval", []interface{}{resource, interval})
+	fake.setResourceCheckBackoffIntervalMutex.Unlock()
+	if fake.SetResourceCheckBackoffIntervalStub != nil {
+		return fake.SetResourceCheckBackoffIntervalStub(resource, interval)
+	} else {
+		return fake.setResourceCheckBackoffIntervalReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) SetResourceCheckBackoffIntervalCallCount() int {
+	fake.setResourceCheckBackoffIntervalMutex.RLock()
+	defer fake.setResourceCheckBackoffIntervalMutex.RUnlock()
+	return len(fake.setResourceCheckBackoffIntervalArgsForCall)
+}
+
+func (fake *FakePipelineDB) SetResourceCheckBackoffIntervalArgsForCall(i int) (db.SavedResource, time.Duration) {
+	fake.setResourceCheckBackoffIntervalMutex.RLock()
+	defer fake.setResourceCheckBackoffIntervalMutex.RUnlock()
+	return fake.setResourceCheckBackoffIntervalArgsForCall[i].resource, fake.setResourceCheckBackoffIntervalArgsForCall[i].interval
+}
+
+func (fake *FakePipelineDB) SetResourceCheckBackoffIntervalReturns(result1 error) {
+	fake.SetResourceCheckBackoffIntervalStub = nil
+	fake.setResourceCheckBackoffIntervalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) GetBuildsEvents(buildIDs []int, types []atc.EventType) (map[int][]event.Envelope, error) {
+	var buildIDsCopy []int
+	if buildIDs != nil {
+		buildIDsCopy = make([]int, len(buildIDs))
+		copy(buildIDsCopy, buildIDs)
+	}
+	var typesCopy []atc.EventType
+	if types != nil {
+		typesCopy = make([]atc.EventType, len(types))
+		copy(typesCopy, types)
+	}
+	fake.getBuildsEventsMutex.Lock()
+	fake.getBuildsEventsArgsForCall = append(fake.getBuildsEventsArgsForCall, struct {
+		buildIDs []int
+		types    []atc.EventType
+	}{buildIDsCopy, typesCopy})
+	fake.recordInvocation("GetBuildsEvents", []interface{}{buildIDsCopy, typesCopy})
+	fake.getBuildsEventsMutex.Unlock()
+	if fake.GetBuildsEventsStub != nil {
+		return fake.GetBuildsEventsStub(buildIDs, types)
+	} else {
+		return fake.getBuildsEventsReturns.result1, fake.getBuildsEventsReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetBuildsEventsCallCount() int {
+	fake.getBuildsEventsMutex.RLock()
+	defer fake.getBuildsEventsMutex.RUnlock()
+	return len(fake.getBuildsEventsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetBuildsEventsArgsForCall(i int) ([]int, []atc.EventType) {
+	fake.getBuildsEventsMutex.RLock()
+	defer fake.getBuildsEventsMutex.RUnlock()
+	return fake.getBuildsEventsArgsForCall[i].buildIDs, fake.getBuildsEventsArgsForCall[i].types
+}
+
+func (fake *FakePipelineDB) GetBuildsEventsReturns(result1 map[int][]event.Envelope, result2 error) {
+	fake.GetBuildsEventsStub = nil
+	fake.getBuildsEventsReturns = struct {
+		result1 map[int][]event.Envelope
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2611,6 +2804,14 @@ func (fake *FakePipelineDB) Invocations() map[string][][]interface{} {
 	defer fake.exposeMutex.RUnlock()
 	fake.hideMutex.RLock()
 	defer fake.hideMutex.RUnlock()
+	fake.searchJobBuildLogsMutex.RLock()
+	defer fake.searchJobBuildLogsMutex.RUnlock()
+	fake.getJobFlakyTestsMutex.RLock()
+	defer fake.getJobFlakyTestsMutex.RUnlock()
+	fake.setResourceCheckBackoffIntervalMutex.RLock()
+	defer fake.setResourceCheckBackoffIntervalMutex.RUnlock()
+	fake.getBuildsEventsMutex.RLock()
+	defer fake.getBuildsEventsMutex.RUnlock()
 	return fake.invocations
 }
 
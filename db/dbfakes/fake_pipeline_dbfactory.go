@@ -16,6 +16,14 @@ type FakePipelineDBFactory struct {
 	buildReturns struct {
 		result1 db.PipelineDB
 	}
+	ReleaseStub        func(pipelineID int) error
+	releaseMutex       sync.RWMutex
+	releaseArgsForCall []struct {
+		pipelineID int
+	}
+	releaseReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -53,11 +61,46 @@ func (fake *FakePipelineDBFactory) BuildReturns(result1 db.PipelineDB) {
 	}{result1}
 }
 
+func (fake *FakePipelineDBFactory) Release(pipelineID int) error {
+	fake.releaseMutex.Lock()
+	fake.releaseArgsForCall = append(fake.releaseArgsForCall, struct {
+		pipelineID int
+	}{pipelineID})
+	fake.recordInvocation("Release", []interface{}{pipelineID})
+	fake.releaseMutex.Unlock()
+	if fake.ReleaseStub != nil {
+		return fake.ReleaseStub(pipelineID)
+	} else {
+		return fake.releaseReturns.result1
+	}
+}
+
+func (fake *FakePipelineDBFactory) ReleaseCallCount() int {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return len(fake.releaseArgsForCall)
+}
+
+func (fake *FakePipelineDBFactory) ReleaseArgsForCall(i int) int {
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
+	return fake.releaseArgsForCall[i].pipelineID
+}
+
+func (fake *FakePipelineDBFactory) ReleaseReturns(result1 error) {
+	fake.ReleaseStub = nil
+	fake.releaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDBFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.releaseMutex.RLock()
+	defer fake.releaseMutex.RUnlock()
 	return fake.invocations
 }
 
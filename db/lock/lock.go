@@ -19,6 +19,7 @@ const (
 	LockTypeBatch
 	LockTypeVolumeCreating
 	LockTypeContainerCreating
+	LockTypeBuildArtifactsStore
 )
 
 func NewBuildTrackingLockID(buildID int) LockID {
@@ -53,6 +54,10 @@ func NewContainerCreatingLockID(containerID int) LockID {
 	return LockID{LockTypeContainerCreating, containerID}
 }
 
+func NewBuildArtifactsStoreLockID() LockID {
+	return LockID{LockTypeBuildArtifactsStore}
+}
+
 //go:generate counterfeiter . LockFactory
 
 type LockFactory interface {
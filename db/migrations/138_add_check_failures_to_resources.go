@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddCheckFailuresToResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE resources
+			ADD COLUMN check_failures integer NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddJSONBIndexesToVersionedResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE INDEX versioned_resources_version_jsonb
+		ON versioned_resources USING gin ((version::jsonb) jsonb_path_ops)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX versioned_resources_metadata_jsonb
+		ON versioned_resources USING gin ((metadata::jsonb) jsonb_path_ops)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX versioned_resources_resource_id_check_order
+		ON versioned_resources (resource_id, check_order)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
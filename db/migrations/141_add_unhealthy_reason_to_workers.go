@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddUnhealthyReasonToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+			ADD COLUMN unhealthy_reason text
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddBaseResourceTypeVersionToResourceConfigs(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE resource_configs
+			ADD COLUMN base_resource_type_version text NOT NULL DEFAULT ''
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateTaskCaches(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE task_caches (
+			id serial PRIMARY KEY,
+			team_id int NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			pipeline_id int REFERENCES pipelines (id) ON DELETE CASCADE,
+			job_name text NOT NULL DEFAULT '',
+			step_name text NOT NULL,
+			cache_key text NOT NULL,
+			worker_name text NOT NULL REFERENCES workers (name) ON DELETE CASCADE,
+			UNIQUE (team_id, cache_key)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE task_cache_uses (
+			task_cache_id int NOT NULL REFERENCES task_caches (id) ON DELETE CASCADE,
+			build_id int NOT NULL REFERENCES builds (id) ON DELETE CASCADE
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+			ADD COLUMN task_cache_id int REFERENCES task_caches (id) ON DELETE SET NULL,
+			ADD COLUMN task_cache_output text
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateWorkerTaskCaches(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE worker_task_caches (
+			id serial PRIMARY KEY,
+			worker_name text NOT NULL REFERENCES workers (name) ON DELETE CASCADE,
+			job_id int NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+			step_name text NOT NULL,
+			path text NOT NULL,
+			UNIQUE (worker_name, job_id, step_name, path)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+			ADD COLUMN worker_task_cache_id int REFERENCES worker_task_caches (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+		DROP CONSTRAINT cannot_invalidate_during_initialization,
+		ADD CONSTRAINT cannot_invalidate_during_initialization CHECK (
+			(
+				state IN ('created', 'destroying') AND (
+					(
+						resource_cache_id IS NULL
+					) AND (
+						base_resource_type_id IS NULL
+					) AND (
+						container_id IS NULL
+					) AND (
+						task_cache_id IS NULL
+					) AND (
+						worker_task_cache_id IS NULL
+					)
+				)
+			) OR (
+				(
+					resource_cache_id IS NOT NULL
+				) OR (
+					base_resource_type_id IS NOT NULL
+				) OR (
+					container_id IS NOT NULL
+				) OR (
+					task_cache_id IS NOT NULL
+				) OR (
+					worker_task_cache_id IS NOT NULL
+				)
+			)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateClusterEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE cluster_events (
+			id bigserial PRIMARY KEY,
+			type text NOT NULL,
+			version text NOT NULL,
+			payload text NOT NULL,
+			team_name text NOT NULL,
+			pipeline_name text,
+			job_name text,
+			created_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX cluster_events_team_name_idx ON cluster_events (team_name)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateBuildLogLines(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_log_lines (
+			id bigserial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			origin_id text NOT NULL,
+			origin_source text NOT NULL,
+			line_number integer NOT NULL,
+			line text NOT NULL,
+			tsv tsvector NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX build_log_lines_build_id_idx ON build_log_lines (build_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX build_log_lines_tsv_idx ON build_log_lines USING gin (tsv)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds ADD COLUMN logs_indexed boolean NOT NULL DEFAULT false
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
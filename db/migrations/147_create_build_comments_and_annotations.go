@@ -0,0 +1,48 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateBuildCommentsAndAnnotations(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_comments (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			team_name text NOT NULL,
+			comment text NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX build_comments_build_id_idx ON build_comments (build_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE build_annotations (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			plan_id text NOT NULL,
+			step_name text NOT NULL,
+			name text NOT NULL,
+			value text NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX build_annotations_build_id_idx ON build_annotations (build_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateBuildTestResults(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_test_results (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			plan_id text NOT NULL,
+			step_name text NOT NULL,
+			suite text NOT NULL,
+			class_name text NOT NULL,
+			name text NOT NULL,
+			status text NOT NULL,
+			duration double precision NOT NULL,
+			message text NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX build_test_results_build_id_idx ON build_test_results (build_id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateBuildStepCheckpoints(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_step_checkpoints (
+			id serial PRIMARY KEY,
+			build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			plan_id text NOT NULL,
+			exit_status integer NOT NULL,
+			version text,
+			metadata text,
+			UNIQUE (build_id, plan_id)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
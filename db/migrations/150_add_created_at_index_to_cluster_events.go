@@ -0,0 +1,14 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddCreatedAtIndexToClusterEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE INDEX cluster_events_created_at_idx ON cluster_events (created_at)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
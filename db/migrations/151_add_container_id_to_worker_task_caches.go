@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddContainerIDToWorkerTaskCaches(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE worker_task_caches
+			ADD COLUMN container_id int REFERENCES containers (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
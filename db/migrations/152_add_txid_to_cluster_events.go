@@ -0,0 +1,22 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddTxidToClusterEvents(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE cluster_events
+			ADD COLUMN txid bigint NOT NULL DEFAULT txid_current()
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX cluster_events_txid_id_idx ON cluster_events (txid, id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
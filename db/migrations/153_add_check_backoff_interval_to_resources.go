@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddCheckBackoffIntervalToResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE resources
+			ADD COLUMN check_backoff_interval integer NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
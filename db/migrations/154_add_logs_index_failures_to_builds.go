@@ -0,0 +1,22 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddLogsIndexFailuresToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+			ADD COLUMN logs_index_failures integer NOT NULL DEFAULT 0
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE INDEX builds_unindexed_logs_idx ON builds (id) WHERE logs_indexed = false
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
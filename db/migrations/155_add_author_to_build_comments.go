@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddAuthorToBuildComments(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE build_comments
+			ADD COLUMN author text NOT NULL DEFAULT ''
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
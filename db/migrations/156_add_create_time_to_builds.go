@@ -0,0 +1,25 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddCreateTimeToBuilds(tx migration.LimitedTx) error {
+	// existing builds are left without one, as when they were created isn't
+	// known
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+			ADD COLUMN create_time timestamp with time zone
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+			ALTER COLUMN create_time SET DEFAULT now()
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -144,4 +144,23 @@ var Migrations = []migration.Migrator{
 	AddDiscontinuedToContainers,
 	AddSourceHashToResources,
 	AddWorkerBaseResourceTypeIdToContainers,
+	AddCheckFailuresToResources,
+	AddJSONBIndexesToVersionedResources,
+	AddMaxContainersToWorkers,
+	AddUnhealthyReasonToWorkers,
+	AddBaseResourceTypeVersionToResourceConfigs,
+	CreateTaskCaches,
+	CreateWorkerTaskCaches,
+	CreateClusterEvents,
+	CreateBuildLogLines,
+	CreateBuildCommentsAndAnnotations,
+	CreateBuildTestResults,
+	CreateBuildStepCheckpoints,
+	AddCreatedAtIndexToClusterEvents,
+	AddContainerIDToWorkerTaskCaches,
+	AddTxidToClusterEvents,
+	AddCheckBackoffIntervalToResources,
+	AddLogsIndexFailuresToBuilds,
+	AddAuthorToBuildComments,
+	AddCreateTimeToBuilds,
 }
@@ -9,9 +9,13 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/config"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/event"
 )
 
 //go:generate counterfeiter . PipelineDB
@@ -39,7 +43,7 @@ type PipelineDB interface {
 	GetResource(resourceName string) (SavedResource, bool, error)
 	GetResources() ([]SavedResource, bool, error)
 	GetResourceType(resourceTypeName string) (SavedResourceType, bool, error)
-	GetResourceVersions(resourceName string, page Page) ([]SavedVersionedResource, Pagination, bool, error)
+	GetResourceVersions(resourceName string, filter VersionFilter, page Page) ([]SavedVersionedResource, Pagination, bool, error)
 
 	PauseResource(resourceName string) error
 	UnpauseResource(resourceName string) error
@@ -51,6 +55,7 @@ type PipelineDB interface {
 	EnableVersionedResource(versionedResourceID int) error
 	DisableVersionedResource(versionedResourceID int) error
 	SetResourceCheckError(resource SavedResource, err error) error
+	SetResourceCheckBackoffInterval(resource SavedResource, interval time.Duration) error
 	AcquireResourceTypeCheckingLock(logger lager.Logger, resourceType SavedResourceType, length time.Duration, immediate bool) (lock.Lock, bool, error)
 
 	GetJobs() ([]SavedJob, error)
@@ -64,6 +69,9 @@ type PipelineDB interface {
 
 	GetJobBuilds(job string, page Page) ([]Build, Pagination, error)
 	GetAllJobBuilds(job string) ([]Build, error)
+	GetBuildsEvents(buildIDs []int, types []atc.EventType) (map[int][]event.Envelope, error)
+	SearchJobBuildLogs(job string, query string, limit int) ([]LogMatch, error)
+	GetJobFlakyTests(job string, limit int) ([]FlakyTest, error)
 
 	GetJobBuild(job string, build string) (Build, bool, error)
 	CreateJobBuild(job string) (Build, error)
@@ -101,7 +109,7 @@ type pipelineDB struct {
 
 	SavedPipeline
 
-	versionsDB *algorithm.VersionsDB
+	versionsDBCaches *versionsDBCaches
 
 	lockFactory  lock.LockFactory
 	buildFactory *buildFactory
@@ -166,21 +174,53 @@ func (pdb *pipelineDB) IsPublic() bool {
 }
 
 func (pdb *pipelineDB) Unpause() error {
-	_, err := pdb.conn.Exec(`
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
 		UPDATE pipelines
 		SET paused = false
 		WHERE id = $1
 	`, pdb.ID)
-	return err
+	if err != nil {
+		return err
+	}
+
+	err = dbng.SaveClusterEvent(tx, pdb.clusterEventScope(""), event.PipelineUnpaused{})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (pdb *pipelineDB) Pause() error {
-	_, err := pdb.conn.Exec(`
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
 		UPDATE pipelines
 		SET paused = true
 		WHERE id = $1
 	`, pdb.ID)
-	return err
+	if err != nil {
+		return err
+	}
+
+	err = dbng.SaveClusterEvent(tx, pdb.clusterEventScope(""), event.PipelinePaused{})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (pdb *pipelineDB) UpdateName(newName string) error {
@@ -214,7 +254,12 @@ func (pdb *pipelineDB) Destroy() error {
 		return err
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return pdb.versionsDBCaches.release(pdb.ID)
 }
 
 func (pdb *pipelineDB) Reload() (bool, error) {
@@ -261,7 +306,7 @@ func (pdb *pipelineDB) GetResource(resourceName string) (SavedResource, bool, er
 
 func (pdb *pipelineDB) GetResources() ([]SavedResource, bool, error) {
 	rows, err := pdb.conn.Query(`
-			SELECT id, name, config, check_error, paused
+			SELECT id, name, config, check_error, check_failures, check_backoff_interval, last_checked, paused
 			FROM resources
 			WHERE pipeline_id = $1
 				AND active = true
@@ -393,7 +438,7 @@ func (pdb *pipelineDB) AcquireSchedulingLock(logger lager.Logger, interval time.
 	return lock, true, nil
 }
 
-func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]SavedVersionedResource, Pagination, bool, error) {
+func (pdb *pipelineDB) GetResourceVersions(resourceName string, filter VersionFilter, page Page) ([]SavedVersionedResource, Pagination, bool, error) {
 	dbResource, found, err := pdb.GetResource(resourceName)
 	if err != nil {
 		return []SavedVersionedResource{}, Pagination{}, false, err
@@ -403,68 +448,82 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 		return []SavedVersionedResource{}, Pagination{}, false, nil
 	}
 
+	params := []interface{}{dbResource.ID}
+
+	conditions, err := filter.conditions(&params)
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
+	filterParams := params
+
 	query := `
 		SELECT v.id, v.enabled, v.type, v.version, v.metadata, r.name, v.check_order
 		FROM versioned_resources v
 		INNER JOIN resources r ON v.resource_id = r.id
 		WHERE v.resource_id = $1
-	`
+	` + conditions
 
 	var rows *sql.Rows
 	if page.Until != 0 {
+		params = append(params, page.Until, page.Limit)
 		rows, err = pdb.conn.Query(fmt.Sprintf(`
 			SELECT sub.*
 				FROM (
 						%s
-					AND v.check_order > (SELECT check_order FROM versioned_resources WHERE id = $2)
+					AND v.check_order > (SELECT check_order FROM versioned_resources WHERE id = $%d)
 				ORDER BY v.check_order ASC
-				LIMIT $3
+				LIMIT $%d
 			) sub
 			ORDER BY sub.check_order DESC
-		`, query), dbResource.ID, page.Until, page.Limit)
+		`, query, len(params)-1, len(params)), params...)
 		if err != nil {
 			return nil, Pagination{}, false, err
 		}
 	} else if page.Since != 0 {
+		params = append(params, page.Since, page.Limit)
 		rows, err = pdb.conn.Query(fmt.Sprintf(`
 			%s
-				AND v.check_order < (SELECT check_order FROM versioned_resources WHERE id = $2)
+				AND v.check_order < (SELECT check_order FROM versioned_resources WHERE id = $%d)
 			ORDER BY v.check_order DESC
-			LIMIT $3
-		`, query), dbResource.ID, page.Since, page.Limit)
+			LIMIT $%d
+		`, query, len(params)-1, len(params)), params...)
 		if err != nil {
 			return nil, Pagination{}, false, err
 		}
 	} else if page.To != 0 {
+		params = append(params, page.To, page.Limit)
 		rows, err = pdb.conn.Query(fmt.Sprintf(`
 			SELECT sub.*
 				FROM (
 						%s
-					AND v.check_order >= (SELECT check_order FROM versioned_resources WHERE id = $2)
+					AND v.check_order >= (SELECT check_order FROM versioned_resources WHERE id = $%d)
 				ORDER BY v.check_order ASC
-				LIMIT $3
+				LIMIT $%d
 			) sub
 			ORDER BY sub.check_order DESC
-		`, query), dbResource.ID, page.To, page.Limit)
+		`, query, len(params)-1, len(params)), params...)
 		if err != nil {
 			return nil, Pagination{}, false, err
 		}
 	} else if page.From != 0 {
+		params = append(params, page.From, page.Limit)
 		rows, err = pdb.conn.Query(fmt.Sprintf(`
 			%s
-				AND v.check_order <= (SELECT check_order FROM versioned_resources WHERE id = $2)
+				AND v.check_order <= (SELECT check_order FROM versioned_resources WHERE id = $%d)
 			ORDER BY v.check_order DESC
-			LIMIT $3
-		`, query), dbResource.ID, page.From, page.Limit)
+			LIMIT $%d
+		`, query, len(params)-1, len(params)), params...)
 		if err != nil {
 			return nil, Pagination{}, false, err
 		}
 	} else {
+		params = append(params, page.Limit)
 		rows, err = pdb.conn.Query(fmt.Sprintf(`
 			%s
 			ORDER BY v.check_order DESC
-			LIMIT $2
-		`, query), dbResource.ID, page.Limit)
+			LIMIT $%d
+		`, query, len(params)), params...)
 		if err != nil {
 			return nil, Pagination{}, false, err
 		}
@@ -518,7 +577,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 			COALESCE(MIN(v.check_order), 0) as minCheckOrder
 		FROM versioned_resources v
 		WHERE v.resource_id = $1
-	`, dbResource.ID).Scan(&maxCheckOrder, &minCheckOrder)
+	`+conditions, filterParams...).Scan(&maxCheckOrder, &minCheckOrder)
 	if err != nil {
 		return nil, Pagination{}, false, err
 	}
@@ -547,7 +606,7 @@ func (pdb *pipelineDB) GetResourceVersions(resourceName string, page Page) ([]Sa
 
 func (pdb *pipelineDB) getResource(tx Tx, name string) (SavedResource, bool, error) {
 	return pdb.scanResource(tx.QueryRow(`
-			SELECT id, name, config, check_error, paused
+			SELECT id, name, config, check_error, check_failures, check_backoff_interval, last_checked, paused
 			FROM resources
 			WHERE name = $1
 				AND pipeline_id = $2
@@ -559,8 +618,10 @@ func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error)
 	var checkErr sql.NullString
 	var resource SavedResource
 	var configBlob []byte
+	var lastChecked time.Time
+	var backoffSeconds int
 
-	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.Paused)
+	err := row.Scan(&resource.ID, &resource.Name, &configBlob, &checkErr, &resource.CheckFailures, &backoffSeconds, &lastChecked, &resource.Paused)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return SavedResource{}, false, nil
@@ -570,6 +631,7 @@ func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error)
 	}
 
 	resource.PipelineName = pdb.GetPipelineName()
+	resource.CheckBackoffInterval = time.Duration(backoffSeconds) * time.Second
 
 	var config atc.ResourceConfig
 	err = json.Unmarshal(configBlob, &config)
@@ -582,6 +644,11 @@ func (pdb *pipelineDB) scanResource(row scannable) (SavedResource, bool, error)
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
+	// resources that have never been checked default to the epoch
+	if lastChecked.Unix() > 0 {
+		resource.LastChecked = lastChecked
+	}
+
 	return resource, true, nil
 }
 
@@ -687,6 +754,8 @@ func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions
 
 	defer tx.Rollback()
 
+	discovered := []atc.Version{}
+
 	for _, version := range versions {
 		vr := VersionedResource{
 			Resource: config.Name,
@@ -708,17 +777,31 @@ func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions
 			return ResourceNotFoundError{Name: vr.Resource}
 		}
 
-		_, _, err = pdb.saveVersionedResource(tx, savedResource, vr)
+		_, created, err := pdb.saveVersionedResource(tx, savedResource, vr)
 		if err != nil {
 			return err
 		}
 
+		if created {
+			discovered = append(discovered, version)
+		}
+
 		err = pdb.incrementCheckOrderWhenNewerVersion(tx, savedResource.ID, vr.Type, string(versionJSON))
 		if err != nil {
 			return err
 		}
 	}
 
+	if len(discovered) > 0 {
+		err = dbng.SaveClusterEvent(tx, pdb.clusterEventScope(""), event.ResourceVersionsDiscovered{
+			Resource: config.Name,
+			Versions: discovered,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -790,7 +873,14 @@ func (pdb *pipelineDB) toggleVersionedResource(versionedResourceID int, enable b
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
-	return nil
+	return pdb.invalidateVersionsDB()
+}
+
+// invalidateVersionsDB makes every ATC load the pipeline's versions from
+// scratch, for changes that cannot be applied incrementally.
+func (pdb *pipelineDB) invalidateVersionsDB() error {
+	pdb.versionsDBCaches.invalidate(pdb.ID)
+	return pdb.bus.Notify(versionsDBChannel(pdb.ID))
 }
 
 func (pdb *pipelineDB) GetLatestEnabledVersionedResource(resourceName string) (SavedVersionedResource, bool, error) {
@@ -888,13 +978,13 @@ func (pdb *pipelineDB) SetResourceCheckError(resource SavedResource, cause error
 	if cause == nil {
 		_, err = pdb.conn.Exec(`
 			UPDATE resources
-			SET check_error = NULL
+			SET check_error = NULL, check_failures = 0, check_backoff_interval = 0
 			WHERE id = $1
 			`, resource.ID)
 	} else {
 		_, err = pdb.conn.Exec(`
 			UPDATE resources
-			SET check_error = $2
+			SET check_error = $2, check_failures = check_failures + 1
 			WHERE id = $1
 		`, resource.ID, cause.Error())
 	}
@@ -902,6 +992,18 @@ func (pdb *pipelineDB) SetResourceCheckError(resource SavedResource, cause error
 	return err
 }
 
+// SetResourceCheckBackoffInterval records how long to wait before checking
+// the resource again after a failed check. It is reset along with the check
+// error once a check succeeds.
+func (pdb *pipelineDB) SetResourceCheckBackoffInterval(resource SavedResource, interval time.Duration) error {
+	_, err := pdb.conn.Exec(`
+		UPDATE resources
+		SET check_backoff_interval = $2
+		WHERE id = $1
+	`, resource.ID, int(interval.Seconds()))
+	return err
+}
+
 func (pdb *pipelineDB) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int, resourceType string, version string) error {
 	_, err := tx.Exec(`
 		WITH max_checkorder AS (
@@ -912,7 +1014,7 @@ func (pdb *pipelineDB) incrementCheckOrderWhenNewerVersion(tx Tx, resourceID int
 		)
 
 		UPDATE versioned_resources
-		SET check_order = mc.co + 1
+		SET check_order = mc.co + 1, modified_time = now()
 		FROM max_checkorder mc
 		WHERE resource_id = $1
 		AND type = $2
@@ -1073,7 +1175,7 @@ func (pdb *pipelineDB) UseInputsForBuild(buildID int, inputs []BuildInput) error
 
 	defer tx.Rollback()
 
-	_, err = tx.Exec(`
+	result, err := tx.Exec(`
 		DELETE FROM build_inputs
 		WHERE build_id = $1
 	`, buildID)
@@ -1081,6 +1183,11 @@ func (pdb *pipelineDB) UseInputsForBuild(buildID int, inputs []BuildInput) error
 		return err
 	}
 
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
 	for _, input := range inputs {
 		_, err := pdb.saveBuildInput(tx, buildID, input)
 		if err != nil {
@@ -1088,7 +1195,16 @@ func (pdb *pipelineDB) UseInputsForBuild(buildID int, inputs []BuildInput) error
 		}
 	}
 
-	return tx.Commit()
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		return pdb.invalidateVersionsDB()
+	}
+
+	return nil
 }
 
 func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
@@ -1124,6 +1240,15 @@ func (pdb *pipelineDB) CreateJobBuild(jobName string) (Build, error) {
 		return nil, err
 	}
 
+	err = dbng.SaveClusterEvent(tx, pdb.clusterEventScope(jobName), event.BuildCreated{
+		BuildID:   build.ID(),
+		BuildName: build.Name(),
+		Time:      time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -1172,12 +1297,29 @@ func (pdb *pipelineDB) EnsurePendingBuildExists(jobName string) error {
 			return err
 		}
 
+		err = dbng.SaveClusterEvent(tx, pdb.clusterEventScope(jobName), event.BuildCreated{
+			BuildID:   buildID,
+			BuildName: buildName,
+			Time:      time.Now().Unix(),
+		})
+		if err != nil {
+			return err
+		}
+
 		return tx.Commit()
 	}
 
 	return nil
 }
 
+func (pdb *pipelineDB) clusterEventScope(jobName string) dbng.ClusterEventScope {
+	return dbng.ClusterEventScope{
+		TeamID:       pdb.SavedPipeline.TeamID,
+		PipelineName: pdb.Name,
+		JobName:      jobName,
+	}
+}
+
 func getNewBuildNameForJob(tx Tx, jobName string, pipelineID int) (string, int, error) {
 	var buildName string
 	var jobID int
@@ -1414,7 +1556,7 @@ func (pdb *pipelineDB) GetAllPendingBuilds() (map[string][]Build, error) {
 	builds := map[string][]Build{}
 
 	rows, err := pdb.conn.Query(`
-		SELECT b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name
+		SELECT b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.create_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name
 		FROM builds b
 		JOIN jobs j ON b.job_id = j.id
 		JOIN pipelines p ON j.pipeline_id = p.id
@@ -1587,178 +1729,92 @@ func (pdb *pipelineDB) UpdateBuildToScheduled(buildID int) (bool, error) {
 	return rows == 1, nil
 }
 
-func (pdb *pipelineDB) getLatestModifiedTime() (time.Time, error) {
-	var max_modified_time time.Time
-
-	err := pdb.conn.QueryRow(`
-	SELECT
-		CASE
-			WHEN bo_max > vr_max AND bo_max > bi_max THEN bo_max
-			WHEN bi_max > vr_max THEN bi_max
-			ELSE vr_max
-		END
-	FROM
-		(
-			SELECT COALESCE(MAX(bo.modified_time), 'epoch') as bo_max
-			FROM build_outputs bo
-			LEFT OUTER JOIN versioned_resources v ON v.id = bo.versioned_resource_id
-			LEFT OUTER JOIN resources r ON r.id = v.resource_id
-			WHERE r.pipeline_id = $1
-		) bo,
-		(
-			SELECT COALESCE(MAX(bi.modified_time), 'epoch') as bi_max
-			FROM build_inputs bi
-			LEFT OUTER JOIN versioned_resources v ON v.id = bi.versioned_resource_id
-			LEFT OUTER JOIN resources r ON r.id = v.resource_id
-			WHERE r.pipeline_id = $1
-		) bi,
-		(
-			SELECT COALESCE(MAX(vr.modified_time), 'epoch') as vr_max
-			FROM versioned_resources vr
-			LEFT OUTER JOIN resources r ON r.id = vr.resource_id
-			WHERE r.pipeline_id = $1
-		) vr
-	`, pdb.ID).Scan(&max_modified_time)
-
-	return max_modified_time, err
-}
-
-func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
-	latestModifiedTime, err := pdb.getLatestModifiedTime()
-	if err != nil {
-		return nil, err
-	}
-
-	if pdb.versionsDB != nil && pdb.versionsDB.CachedAt.Equal(latestModifiedTime) {
-		return pdb.versionsDB, nil
+// getOtherPipelineJobIDs looks up the jobs in other pipelines of the team
+// that are referred to by `passed` constraints, keyed by `pipeline/job`,
+// along with the IDs of their pipelines. Jobs that do not exist (yet) are
+// left out, as are jobs of this pipeline whose names contain a slash.
+func (pdb *pipelineDB) getOtherPipelineJobIDs() (map[string]int, []int, error) {
+	jobIDs := map[string]int{}
+	pipelineIDs := []int{}
+
+	jobs := pdb.Config().Jobs
+
+	passedJobs := map[string]bool{}
+	pipelineNames := []string{}
+	for _, jobConfig := range jobs {
+		for _, input := range config.JobInputs(jobConfig) {
+			for _, passed := range input.Passed {
+				if _, found := jobs.Lookup(passed); found {
+					continue
+				}
+
+				passedJob := atc.ParsePassedJob(passed)
+				if !passedJob.IsInOtherPipeline() || passedJobs[passedJob.String()] {
+					continue
+				}
+
+				passedJobs[passedJob.String()] = true
+				pipelineNames = append(pipelineNames, passedJob.PipelineName)
+			}
+		}
 	}
 
-	db := &algorithm.VersionsDB{
-		BuildOutputs:     []algorithm.BuildOutput{},
-		BuildInputs:      []algorithm.BuildInput{},
-		ResourceVersions: []algorithm.ResourceVersion{},
-		JobIDs:           map[string]int{},
-		ResourceIDs:      map[string]int{},
-		CachedAt:         latestModifiedTime,
+	if len(passedJobs) == 0 {
+		return jobIDs, pipelineIDs, nil
 	}
 
-	rows, err := pdb.conn.Query(`
-    SELECT v.id, v.check_order, r.id, o.build_id, j.id
-    FROM build_outputs o, builds b, versioned_resources v, jobs j, resources r
-    WHERE v.id = o.versioned_resource_id
-    AND b.id = o.build_id
-    AND j.id = b.job_id
-    AND r.id = v.resource_id
-    AND v.enabled
-		AND b.status = 'succeeded'
-		AND r.pipeline_id = $1
-  `, pdb.ID)
+	query, args, err := sq.Select("p.name, j.name, j.id, p.id").
+		From("jobs j, pipelines p").
+		Where(sq.Expr("p.id = j.pipeline_id")).
+		Where(sq.Eq{
+			"p.team_id": pdb.TeamID(),
+			"p.name":    pipelineNames,
+			"j.active":  true,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
 	if err != nil {
-		return nil, err
-	}
-
-	for rows.Next() {
-		var output algorithm.BuildOutput
-		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID)
-		if err != nil {
-			return nil, err
-		}
-
-		output.ResourceVersion.CheckOrder = output.CheckOrder
-
-		db.BuildOutputs = append(db.BuildOutputs, output)
+		return nil, nil, err
 	}
 
-	rows, err = pdb.conn.Query(`
-    SELECT v.id, v.check_order, r.id, i.build_id, i.name, j.id
-    FROM build_inputs i, builds b, versioned_resources v, jobs j, resources r
-    WHERE v.id = i.versioned_resource_id
-    AND b.id = i.build_id
-    AND j.id = b.job_id
-    AND r.id = v.resource_id
-    AND v.enabled
-		AND r.pipeline_id = $1
-  `, pdb.ID)
+	rows, err := pdb.conn.Query(query, args...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	defer rows.Close()
+
 	for rows.Next() {
-		var input algorithm.BuildInput
-		err := rows.Scan(&input.VersionID, &input.CheckOrder, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID)
+		var passedJob atc.PassedJob
+		var jobID, pipelineID int
+		err := rows.Scan(&passedJob.PipelineName, &passedJob.JobName, &jobID, &pipelineID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		input.ResourceVersion.CheckOrder = input.CheckOrder
-
-		db.BuildInputs = append(db.BuildInputs, input)
-	}
-
-	rows, err = pdb.conn.Query(`
-    SELECT v.id, v.check_order, r.id
-    FROM versioned_resources v, resources r
-    WHERE r.id = v.resource_id
-    AND v.enabled
-		AND r.pipeline_id = $1
-  `, pdb.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	for rows.Next() {
-		var output algorithm.ResourceVersion
-		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID)
-		if err != nil {
-			return nil, err
+		if !passedJobs[passedJob.String()] {
+			continue
 		}
 
-		db.ResourceVersions = append(db.ResourceVersions, output)
-	}
+		jobIDs[passedJob.String()] = jobID
 
-	rows, err = pdb.conn.Query(`
-    SELECT j.name, j.id
-    FROM jobs j
-    WHERE j.pipeline_id = $1
-  `, pdb.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	for rows.Next() {
-		var name string
-		var id int
-		err := rows.Scan(&name, &id)
-		if err != nil {
-			return nil, err
+		if !containsInt(pipelineIDs, pipelineID) {
+			pipelineIDs = append(pipelineIDs, pipelineID)
 		}
-
-		db.JobIDs[name] = id
 	}
 
-	rows, err = pdb.conn.Query(`
-    SELECT r.name, r.id
-    FROM resources r
-    WHERE r.pipeline_id = $1
-  `, pdb.ID)
+	return jobIDs, pipelineIDs, nil
+}
+
+func (pdb *pipelineDB) LoadVersionsDB() (*algorithm.VersionsDB, error) {
+	otherPipelineJobIDs, otherPipelineIDs, err := pdb.getOtherPipelineJobIDs()
 	if err != nil {
 		return nil, err
 	}
 
-	for rows.Next() {
-		var name string
-		var id int
-		err := rows.Scan(&name, &id)
-		if err != nil {
-			return nil, err
-		}
-
-		db.ResourceIDs[name] = id
-	}
-
-	pdb.versionsDB = db
+	cache := pdb.versionsDBCaches.forPipeline(pdb.ID)
 
-	return db, nil
+	return cache.load(pdb.conn, pdb.bus, pdb.ID, otherPipelineIDs, otherPipelineJobIDs)
 }
 
 func (pdb *pipelineDB) GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error) {
@@ -2244,6 +2300,62 @@ func (pdb *pipelineDB) GetAllJobBuilds(job string) ([]Build, error) {
 	return bs, nil
 }
 
+// GetBuildsEvents gets the events of the given types saved by the pipeline's
+// builds, in one go rather than build by build.
+func (pdb *pipelineDB) GetBuildsEvents(buildIDs []int, types []atc.EventType) (map[int][]event.Envelope, error) {
+	buildsEvents := map[int][]event.Envelope{}
+	if len(buildIDs) == 0 {
+		return buildsEvents, nil
+	}
+
+	query := sq.Select("build_id, type, version, payload").
+		From(fmt.Sprintf("pipeline_build_events_%d", pdb.ID)).
+		Where(sq.Eq{"build_id": buildIDs})
+
+	if len(types) > 0 {
+		typeNames := make([]string, len(types))
+		for i, t := range types {
+			typeNames[i] = string(t)
+		}
+
+		query = query.Where(sq.Eq{"type": typeNames})
+	}
+
+	sqlQuery, args, err := query.
+		OrderBy("build_id ASC", "event_id ASC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pdb.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var t, v, p string
+		err := rows.Scan(&buildID, &t, &v, &p)
+		if err != nil {
+			return nil, err
+		}
+
+		data := json.RawMessage(p)
+
+		buildsEvents[buildID] = append(buildsEvents[buildID], event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+		})
+	}
+
+	return buildsEvents, rows.Err()
+}
+
 func (pdb *pipelineDB) GetJobFinishedAndNextBuild(job string) (Build, Build, error) {
 	finished, _, err := pdb.buildFactory.ScanBuild(pdb.conn.QueryRow(`
 		SELECT `+qualifiedBuildColumns+`
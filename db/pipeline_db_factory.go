@@ -6,6 +6,10 @@ import "github.com/concourse/atc/db/lock"
 
 type PipelineDBFactory interface {
 	Build(pipeline SavedPipeline) PipelineDB
+
+	// Release frees what is kept around between the PipelineDBs built for a
+	// pipeline, once it is no longer being scheduled.
+	Release(pipelineID int) error
 }
 
 type pipelineDBFactory struct {
@@ -13,6 +17,8 @@ type pipelineDBFactory struct {
 	bus  *notificationsBus
 
 	lockFactory lock.LockFactory
+
+	versionsDBCaches *versionsDBCaches
 }
 
 func NewPipelineDBFactory(
@@ -24,6 +30,8 @@ func NewPipelineDBFactory(
 		conn:        sqldbConnection,
 		bus:         bus,
 		lockFactory: lockFactory,
+
+		versionsDBCaches: newVersionsDBCaches(bus),
 	}
 }
 
@@ -35,6 +43,12 @@ func (pdbf *pipelineDBFactory) Build(pipeline SavedPipeline) PipelineDB {
 		buildFactory: newBuildFactory(pdbf.conn, pdbf.bus, pdbf.lockFactory),
 		lockFactory:  pdbf.lockFactory,
 
+		versionsDBCaches: pdbf.versionsDBCaches,
+
 		SavedPipeline: pipeline,
 	}
 }
+
+func (pdbf *pipelineDBFactory) Release(pipelineID int) error {
+	return pdbf.versionsDBCaches.release(pipelineID)
+}
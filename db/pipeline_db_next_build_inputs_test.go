@@ -94,7 +94,7 @@ var _ = Describe("next build inputs for job", func() {
 		})
 		Expect(err).NotTo(HaveOccurred())
 
-		reversions, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Limit: 3})
+		reversions, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Limit: 3})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(found).To(BeTrue())
 
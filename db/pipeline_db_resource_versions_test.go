@@ -89,7 +89,7 @@ var _ = Describe("Resource History", func() {
 
 		Context("when the resource does not exist", func() {
 			It("returns false and no error", func() {
-				_, _, found, err := pipelineDB.GetResourceVersions("nope", db.Page{})
+				_, _, found, err := pipelineDB.GetResourceVersions("nope", db.VersionFilter{}, db.Page{})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -126,7 +126,7 @@ var _ = Describe("Resource History", func() {
 
 			Context("with no since/until", func() {
 				It("returns the first page, with the given limit, and a next page", func() {
-					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Limit: 2})
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Limit: 2})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[9], expectedVersions[8]}))
@@ -137,7 +137,7 @@ var _ = Describe("Resource History", func() {
 
 			Context("with a since that places it in the middle of the builds", func() {
 				It("returns the builds, with previous/next pages", func() {
-					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Since: expectedVersions[6].ID, Limit: 2})
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Since: expectedVersions[6].ID, Limit: 2})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[5], expectedVersions[4]}))
@@ -148,7 +148,7 @@ var _ = Describe("Resource History", func() {
 
 			Context("with a since that places it at the end of the builds", func() {
 				It("returns the builds, with previous/next pages", func() {
-					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Since: expectedVersions[2].ID, Limit: 2})
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Since: expectedVersions[2].ID, Limit: 2})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[1], expectedVersions[0]}))
@@ -159,7 +159,7 @@ var _ = Describe("Resource History", func() {
 
 			Context("with an until that places it in the middle of the builds", func() {
 				It("returns the builds, with previous/next pages", func() {
-					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Until: expectedVersions[6].ID, Limit: 2})
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Until: expectedVersions[6].ID, Limit: 2})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[8], expectedVersions[7]}))
@@ -170,7 +170,7 @@ var _ = Describe("Resource History", func() {
 
 			Context("with a until that places it at the beginning of the builds", func() {
 				It("returns the builds, with previous/next pages", func() {
-					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Until: expectedVersions[7].ID, Limit: 2})
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Until: expectedVersions[7].ID, Limit: 2})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[9], expectedVersions[8]}))
@@ -199,7 +199,7 @@ var _ = Describe("Resource History", func() {
 				})
 
 				It("returns the metadata in the version history", func() {
-					historyPage, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Limit: 1})
+					historyPage, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Limit: 1})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[9]}))
@@ -214,12 +214,110 @@ var _ = Describe("Resource History", func() {
 				})
 
 				It("returns a disabled version", func() {
-					historyPage, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Limit: 1})
+					historyPage, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Limit: 1})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[9]}))
 				})
 			})
+
+			Context("when filtering by version fields", func() {
+				It("returns only the matching versions, without pagination", func() {
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{
+						Version: db.Version{"version": "3"},
+					}, db.Page{Limit: 2})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[2]}))
+					Expect(pagination.Previous).To(BeNil())
+					Expect(pagination.Next).To(BeNil())
+				})
+			})
+
+			Context("when filtering by metadata", func() {
+				BeforeEach(func() {
+					metadata := []db.MetadataField{{Name: "author", Value: "alice"}}
+
+					expectedVersions[4].Metadata = metadata
+
+					build, err := pipelineDB.CreateJobBuild("some-job")
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = pipelineDB.SaveInput(build.ID(), db.BuildInput{
+						Name:              "some-input",
+						VersionedResource: expectedVersions[4].VersionedResource,
+						FirstOccurrence:   true,
+					})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns only the versions with the metadata", func() {
+					historyPage, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{
+						Metadata: []db.MetadataField{{Name: "author", Value: "alice"}},
+					}, db.Page{Limit: 2})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(historyPage).To(HaveLen(1))
+					Expect(historyPage[0].ID).To(Equal(expectedVersions[4].ID))
+					Expect(historyPage[0].Metadata).To(Equal(expectedVersions[4].Metadata))
+				})
+			})
+
+			Context("when filtering by enabled state", func() {
+				BeforeEach(func() {
+					err := pipelineDB.DisableVersionedResource(3)
+					Expect(err).ToNot(HaveOccurred())
+
+					err = pipelineDB.DisableVersionedResource(7)
+					Expect(err).ToNot(HaveOccurred())
+
+					expectedVersions[2].Enabled = false
+					expectedVersions[6].Enabled = false
+				})
+
+				It("pages through only the matching versions", func() {
+					disabled := false
+
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{
+						Enabled: &disabled,
+					}, db.Page{Limit: 1})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[6]}))
+					Expect(pagination.Previous).To(BeNil())
+					Expect(pagination.Next).To(Equal(&db.Page{Since: expectedVersions[6].ID, Limit: 1}))
+
+					historyPage, pagination, found, err = pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{
+						Enabled: &disabled,
+					}, *pagination.Next)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[2]}))
+					Expect(pagination.Previous).To(Equal(&db.Page{Until: expectedVersions[2].ID, Limit: 1}))
+					Expect(pagination.Next).To(BeNil())
+				})
+			})
+
+			Context("when filtering by modified time", func() {
+				It("returns nothing outside of the range", func() {
+					historyPage, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{
+						ModifiedAfter: time.Now().UTC().Add(time.Hour),
+					}, db.Page{Limit: 2})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(historyPage).To(BeEmpty())
+				})
+
+				It("returns versions within the range", func() {
+					historyPage, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{
+						ModifiedAfter:  time.Now().UTC().Add(-time.Hour),
+						ModifiedBefore: time.Now().UTC().Add(time.Hour),
+					}, db.Page{Limit: 2})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(historyPage).To(Equal([]db.SavedVersionedResource{expectedVersions[9], expectedVersions[8]}))
+				})
+			})
 		})
 
 		Context("when check orders are different than versions ids", func() {
@@ -264,7 +362,7 @@ var _ = Describe("Resource History", func() {
 
 			Context("with no since/until", func() {
 				It("returns versions ordered by check order", func() {
-					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Limit: 4})
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Limit: 4})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(HaveLen(4))
@@ -281,7 +379,7 @@ var _ = Describe("Resource History", func() {
 
 			Context("with a since", func() {
 				It("returns the builds, with previous/next pages excluding since", func() {
-					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Since: 3, Limit: 2})
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Since: 3, Limit: 2})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(HaveLen(2))
@@ -296,7 +394,7 @@ var _ = Describe("Resource History", func() {
 
 			Context("with from", func() {
 				It("returns the builds, with previous/next pages including from", func() {
-					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{From: 2, Limit: 2})
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{From: 2, Limit: 2})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(HaveLen(2))
@@ -311,7 +409,7 @@ var _ = Describe("Resource History", func() {
 
 			Context("with a until", func() {
 				It("returns the builds, with previous/next pages excluding until", func() {
-					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Until: 1, Limit: 2})
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Until: 1, Limit: 2})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(HaveLen(2))
@@ -326,7 +424,7 @@ var _ = Describe("Resource History", func() {
 
 			Context("with to", func() {
 				It("returns the builds, with previous/next pages including to", func() {
-					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{To: 4, Limit: 2})
+					historyPage, pagination, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{To: 4, Limit: 2})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(historyPage).To(HaveLen(2))
@@ -22,6 +22,7 @@ var _ = Describe("PipelineDB", func() {
 	var listener *pq.Listener
 
 	var pipelineDBFactory db.PipelineDBFactory
+	var otherATCPipelineDBFactory db.PipelineDBFactory
 	var sqlDB *db.SQLDB
 	var teamDBFactory db.TeamDBFactory
 
@@ -41,6 +42,7 @@ var _ = Describe("PipelineDB", func() {
 		lockFactory := lock.NewLockFactory(retryableConn)
 		sqlDB = db.NewSQL(dbConn, bus, lockFactory)
 		pipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
+		otherATCPipelineDBFactory = db.NewPipelineDBFactory(dbConn, bus, lockFactory)
 		teamDBFactory = db.NewTeamDBFactory(dbConn, bus, lockFactory)
 	})
 
@@ -1071,7 +1073,7 @@ var _ = Describe("PipelineDB", func() {
 				})
 				Expect(err).NotTo(HaveOccurred())
 
-				savedVersions, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.Page{Limit: 2})
+				savedVersions, _, found, err := pipelineDB.GetResourceVersions("some-resource", db.VersionFilter{}, db.Page{Limit: 2})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(savedVersions).To(HaveLen(2))
@@ -1508,6 +1510,9 @@ var _ = Describe("PipelineDB", func() {
 					_, err = pipelineDB.SaveOutput(build.ID(), savedVR.VersionedResource, true)
 					Expect(err).NotTo(HaveOccurred())
 
+					err = build.Finish(db.StatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+
 					cachedVersionsDB, err := pipelineDB.LoadVersionsDB()
 					Expect(err).NotTo(HaveOccurred())
 					Expect(versionsDB != cachedVersionsDB).To(BeTrue(), "Expected VersionsDB to be different objects")
@@ -1545,6 +1550,97 @@ var _ = Describe("PipelineDB", func() {
 				})
 			})
 
+			Context("when versions are added after the VersionsDB was loaded", func() {
+				It("loads the new versions without changing the previously loaded VersionsDB", func() {
+					err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name: "some-resource",
+						Type: "some-type",
+					}, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB.ResourceVersions).To(HaveLen(1))
+
+					err = pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name: "some-resource",
+						Type: "some-type",
+					}, []atc.Version{{"version": "2"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					savedVR, found, err := pipelineDB.GetLatestVersionedResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					savedResource, _, err := pipelineDB.GetResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+
+					newVersionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(newVersionsDB.ResourceVersions).To(HaveLen(2))
+					Expect(newVersionsDB.ResourceVersions).To(ContainElement(algorithm.ResourceVersion{
+						VersionID:  savedVR.ID,
+						ResourceID: savedResource.ID,
+						CheckOrder: savedVR.CheckOrder,
+					}))
+
+					Expect(versionsDB.ResourceVersions).To(HaveLen(1))
+				})
+
+				It("shares the loaded VersionsDB between pipeline DBs of the same pipeline", func() {
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+
+					cachedVersionsDB, err := pipelineDBFactory.Build(savedPipeline).LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB == cachedVersionsDB).To(BeTrue(), "Expected VersionsDB to be the same object")
+				})
+
+				It("stops sharing the loaded VersionsDB once the pipeline is released", func() {
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+
+					err = pipelineDBFactory.Release(savedPipeline.ID)
+					Expect(err).NotTo(HaveOccurred())
+
+					reloadedVersionsDB, err := pipelineDBFactory.Build(savedPipeline).LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB != reloadedVersionsDB).To(BeTrue(), "Expected VersionsDB to be different objects")
+					Expect(reloadedVersionsDB.ResourceVersions).To(Equal(versionsDB.ResourceVersions))
+
+					releasedVersionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(releasedVersionsDB.ResourceVersions).To(Equal(versionsDB.ResourceVersions))
+				})
+			})
+
+			Context("when a version is disabled by another ATC", func() {
+				It("reloads the VersionsDB once notified", func() {
+					err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name: "some-resource",
+						Type: "some-type",
+					}, []atc.Version{{"version": "1"}})
+					Expect(err).NotTo(HaveOccurred())
+
+					savedVR, found, err := pipelineDB.GetLatestVersionedResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					versionsDB, err := pipelineDB.LoadVersionsDB()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(versionsDB.ResourceVersions).To(HaveLen(1))
+
+					err = otherATCPipelineDBFactory.Build(savedPipeline).DisableVersionedResource(savedVR.ID)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(func() []algorithm.ResourceVersion {
+						versionsDB, err := pipelineDB.LoadVersionsDB()
+						Expect(err).NotTo(HaveOccurred())
+						return versionsDB.ResourceVersions
+					}).Should(BeEmpty())
+				})
+			})
+
 			Context("when versioned resources are added", func() {
 				It("will cache VersionsDB if no change has occured", func() {
 					err := pipelineDB.SaveResourceVersions(atc.ResourceConfig{
@@ -1612,6 +1708,171 @@ var _ = Describe("PipelineDB", func() {
 			})
 		})
 
+		Describe("passed constraints on jobs in other pipelines", func() {
+			var downstreamPipelineDB db.PipelineDB
+
+			BeforeEach(func() {
+				downstreamSavedPipeline, _, err := teamDB.SaveConfigToBeDeprecated("downstream-pipeline-name", atc.Config{
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-type",
+						},
+					},
+					Jobs: atc.JobConfigs{
+						{
+							Name: "downstream-job",
+							Plan: atc.PlanSequence{
+								{
+									Get:    "some-resource",
+									Passed: []string{"other-pipeline-name/some-job", "other-pipeline-name/bogus-job"},
+								},
+							},
+						},
+					},
+				}, 0, db.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				downstreamPipelineDB = pipelineDBFactory.Build(downstreamSavedPipeline)
+			})
+
+			It("includes the outputs of succeeded builds of the other pipeline's job", func() {
+				otherJob, found, err := otherPipelineDB.GetJob("some-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = otherPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name: "some-resource",
+					Type: "some-type",
+				}, []atc.Version{{"version": "1"}, {"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = downstreamPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name: "some-resource",
+					Type: "some-type",
+				}, []atc.Version{{"version": "1"}, {"version": "2"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamVR, found, err := otherPipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				failedUpstreamVR, found, err := otherPipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "2"}, "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				downstreamVR, found, err := downstreamPipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				downstreamResource, _, err := downstreamPipelineDB.GetResource("some-resource")
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.JobIDs).To(HaveKeyWithValue("other-pipeline-name/some-job", otherJob.ID))
+				Expect(versions.JobIDs).NotTo(HaveKey("other-pipeline-name/bogus-job"))
+				Expect(versions.BuildOutputs).To(BeEmpty())
+
+				succeededBuild, err := otherPipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherPipelineDB.SaveOutput(succeededBuild.ID(), upstreamVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = succeededBuild.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				failedBuild, err := otherPipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherPipelineDB.SaveOutput(failedBuild.ID(), failedUpstreamVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = failedBuild.Finish(db.StatusFailed)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err = downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.BuildOutputs).To(ConsistOf([]algorithm.BuildOutput{
+					{
+						ResourceVersion: algorithm.ResourceVersion{
+							VersionID:  downstreamVR.ID,
+							ResourceID: downstreamResource.ID,
+							CheckOrder: downstreamVR.CheckOrder,
+						},
+						JobID:   otherJob.ID,
+						BuildID: succeededBuild.ID(),
+					},
+				}))
+			})
+
+			It("only matches versions of the upstream resource of the same name", func() {
+				err := otherPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name: "some-other-resource",
+					Type: "some-type",
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = downstreamPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name: "some-resource",
+					Type: "some-type",
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				otherResourceVR, found, err := otherPipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, "some-other-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err := otherPipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherPipelineDB.SaveOutput(build.ID(), otherResourceVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				versions, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versions.BuildOutputs).To(BeEmpty())
+			})
+
+			It("invalidates the cache when the other pipeline's job produces outputs", func() {
+				err := otherPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name: "some-resource",
+					Type: "some-type",
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				err = downstreamPipelineDB.SaveResourceVersions(atc.ResourceConfig{
+					Name: "some-resource",
+					Type: "some-type",
+				}, []atc.Version{{"version": "1"}})
+				Expect(err).NotTo(HaveOccurred())
+
+				upstreamVR, found, err := otherPipelineDB.GetVersionedResourceByVersion(atc.Version{"version": "1"}, "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				versionsDB, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				build, err := otherPipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherPipelineDB.SaveOutput(build.ID(), upstreamVR.VersionedResource, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.Finish(db.StatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				cachedVersionsDB, err := downstreamPipelineDB.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionsDB != cachedVersionsDB).To(BeTrue(), "Expected VersionsDB to be different objects")
+			})
+		})
+
 		Describe("saving versioned resources", func() {
 			It("updates the latest versioned resource", func() {
 				err := pipelineDB.SaveResourceVersions(
@@ -1664,6 +1925,58 @@ var _ = Describe("PipelineDB", func() {
 			Expect(pendingBuilds).To(HaveLen(0))
 		})
 
+		It("records when each build was created", func() {
+			build, err := pipelineDB.CreateJobBuild("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.CreateTime()).To(BeTemporally("~", time.Now(), time.Minute))
+
+			builds, err := pipelineDB.GetAllJobBuilds("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].CreateTime()).To(BeTemporally("~", build.CreateTime(), time.Second))
+		})
+
+		Describe("GetBuildsEvents", func() {
+			It("gets the events of the given types saved by the given builds", func() {
+				build1, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				build2, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				otherBuild, err := pipelineDB.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, build := range []db.Build{build1, build2, otherBuild} {
+					err = build.SaveEvent(event.StartStep{Time: 100, Origin: event.Origin{ID: "some-id"}, Step: "get"})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = build.SaveEvent(event.Log{Payload: "some log"})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = build.SaveEvent(event.FinishStep{Time: 110, Origin: event.Origin{ID: "some-id"}, Step: "get", Status: atc.StatusSucceeded})
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				buildsEvents, err := pipelineDB.GetBuildsEvents([]int{build1.ID(), build2.ID()}, []atc.EventType{event.EventTypeStartStep, event.EventTypeFinishStep})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buildsEvents).To(HaveLen(2))
+
+				for _, build := range []db.Build{build1, build2} {
+					Expect(buildsEvents[build.ID()]).To(HaveLen(2))
+					Expect(buildsEvents[build.ID()][0].Event).To(Equal(event.EventTypeStartStep))
+					Expect(buildsEvents[build.ID()][1].Event).To(Equal(event.EventTypeFinishStep))
+				}
+			})
+
+			It("gets nothing when given no builds", func() {
+				buildsEvents, err := pipelineDB.GetBuildsEvents([]int{}, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildsEvents).To(BeEmpty())
+			})
+		})
+
 		Describe("marking resource checks as errored", func() {
 			var resource db.SavedResource
 
@@ -1691,6 +2004,32 @@ var _ = Describe("PipelineDB", func() {
 
 					Expect(returnedResource.CheckError).To(Equal(originalCause))
 				})
+
+				It("counts consecutive failures", func() {
+					err := pipelineDB.SetResourceCheckError(resource, errors.New("on fire"))
+					Expect(err).NotTo(HaveOccurred())
+
+					err = pipelineDB.SetResourceCheckError(resource, errors.New("still on fire"))
+					Expect(err).NotTo(HaveOccurred())
+
+					returnedResource, _, err := pipelineDB.GetResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(returnedResource.CheckFailures).To(Equal(2))
+				})
+
+				It("saves the interval the checks backed off to", func() {
+					err := pipelineDB.SetResourceCheckError(resource, errors.New("on fire"))
+					Expect(err).NotTo(HaveOccurred())
+
+					err = pipelineDB.SetResourceCheckBackoffInterval(resource, 2*time.Minute)
+					Expect(err).NotTo(HaveOccurred())
+
+					returnedResource, _, err := pipelineDB.GetResource("some-resource")
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(returnedResource.CheckBackoffInterval).To(Equal(2 * time.Minute))
+				})
 			})
 
 			Context("when a resource is cleared of check errors", func() {
@@ -1700,6 +2039,9 @@ var _ = Describe("PipelineDB", func() {
 					err := pipelineDB.SetResourceCheckError(resource, originalCause)
 					Expect(err).NotTo(HaveOccurred())
 
+					err = pipelineDB.SetResourceCheckBackoffInterval(resource, 2*time.Minute)
+					Expect(err).NotTo(HaveOccurred())
+
 					err = pipelineDB.SetResourceCheckError(resource, nil)
 					Expect(err).NotTo(HaveOccurred())
 
@@ -1707,6 +2049,8 @@ var _ = Describe("PipelineDB", func() {
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(returnedResource.CheckError).To(BeNil())
+					Expect(returnedResource.CheckFailures).To(BeZero())
+					Expect(returnedResource.CheckBackoffInterval).To(BeZero())
 				})
 			})
 		})
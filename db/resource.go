@@ -11,12 +11,18 @@ type Resource struct {
 }
 
 type SavedResource struct {
-	ID           int
-	CheckError   error
-	Paused       bool
-	PipelineName string
-	Config       atc.ResourceConfig
+	ID            int
+	CheckError    error
+	CheckFailures int
+	LastChecked   time.Time
+	Paused        bool
+	PipelineName  string
+	Config        atc.ResourceConfig
 	Resource
+
+	// CheckBackoffInterval is how long radar waits between checks of the
+	// resource while they are failing, or zero if they aren't.
+	CheckBackoffInterval time.Duration
 }
 
 type SavedResourceType struct {
@@ -2,10 +2,12 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"strconv"
 	"strings"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
 )
 
 func (db *SQLDB) FindJobIDForBuild(buildID int) (int, bool, error) {
@@ -27,6 +29,56 @@ func (db *SQLDB) FindJobIDForBuild(buildID int) (int, bool, error) {
 	return id, true, nil
 }
 
+// FindJobConfigsForBuilds returns the config of the job of each of the given
+// builds, keyed by build ID. Builds that no longer exist, aren't of a job, or
+// are of a job that has since been removed from its pipeline are left out.
+func (db *SQLDB) FindJobConfigsForBuilds(buildIDs []int) (map[int]atc.JobConfig, error) {
+	jobConfigs := map[int]atc.JobConfig{}
+
+	if len(buildIDs) == 0 {
+		return jobConfigs, nil
+	}
+
+	query, args, err := sq.Select("b.id", "j.config").
+		From("builds b").
+		Join("jobs j ON b.job_id = j.id").
+		Where(sq.Eq{
+			"b.id":     buildIDs,
+			"j.active": true,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var buildID int
+		var configBlob []byte
+		err := rows.Scan(&buildID, &configBlob)
+		if err != nil {
+			return nil, err
+		}
+
+		var config atc.JobConfig
+		err = json.Unmarshal(configBlob, &config)
+		if err != nil {
+			return nil, err
+		}
+
+		jobConfigs[buildID] = config
+	}
+
+	return jobConfigs, rows.Err()
+}
+
 func (db *SQLDB) GetBuildByID(buildID int) (Build, bool, error) {
 	return db.buildFactory.ScanBuild(db.conn.QueryRow(`
 		SELECT `+qualifiedBuildColumns+`
@@ -77,6 +129,42 @@ func (db *SQLDB) GetAllStartedBuilds() ([]Build, error) {
 	return bs, nil
 }
 
+// GetBuildsWithUnindexedLogs returns up to limit finished pipeline builds
+// whose output hasn't been indexed for search, most recent first. Builds which
+// have failed to be indexed too many times are skipped.
+func (db *SQLDB) GetBuildsWithUnindexedLogs(limit int) ([]Build, error) {
+	rows, err := db.conn.Query(`
+		SELECT `+qualifiedBuildColumns+`
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN pipelines p ON j.pipeline_id = p.id
+		LEFT OUTER JOIN teams t ON b.team_id = t.id
+		WHERE b.logs_indexed = false
+			AND b.status NOT IN ('pending', 'started')
+			AND b.logs_index_failures < $1
+		ORDER BY b.id DESC
+		LIMIT $2
+	`, maxLogIndexFailures, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, _, err := db.buildFactory.ScanBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, build)
+	}
+
+	return bs, rows.Err()
+}
+
 func (db *SQLDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	if len(buildIDs) == 0 {
 		return nil
@@ -107,6 +195,14 @@ func (db *SQLDB) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 		return err
 	}
 
+	_, err = tx.Exec(`
+		DELETE FROM build_log_lines
+		WHERE build_id IN (`+strings.Join(indexStrings, ",")+`)
+	`, interfaceBuildIDs...)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(`
 		UPDATE builds
 		SET reap_time = now()
@@ -55,6 +55,22 @@ func (db *SQLDB) FindJobContainersFromUnsuccessfulBuilds() ([]SavedContainer, er
 	return scanRows(rows)
 }
 
+// CountContainersOnWorker returns the number of containers placed on the
+// worker, including those still being created or destroyed.
+func (db *SQLDB) CountContainersOnWorker(workerName string) (int, error) {
+	var count int
+	err := db.conn.QueryRow(`
+		SELECT COUNT(*)
+		FROM containers
+		WHERE worker_name = $1
+	`, workerName).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (db *SQLDB) FindContainerByIdentifier(id ContainerIdentifier) (SavedContainer, bool, error) {
 	conditions := []string{}
 	params := []interface{}{}
@@ -6,10 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/event"
 )
 
 //go:generate counterfeiter . TeamDB
@@ -691,6 +694,15 @@ func (db *teamDB) CreateOneOffBuild() (Build, error) {
 		return nil, err
 	}
 
+	err = dbng.SaveClusterEvent(tx, dbng.ClusterEventScope{TeamID: build.TeamID()}, event.BuildCreated{
+		BuildID:   build.ID(),
+		BuildName: build.Name(),
+		Time:      time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A VersionFilter narrows down the versions returned by GetResourceVersions.
+// Zero-valued fields do not filter anything.
+type VersionFilter struct {
+	// fields that must be present in the version, e.g. ref: abc123
+	Version Version
+
+	// metadata fields that must be present, e.g. author: alice
+	Metadata []MetadataField
+
+	// only return enabled (or disabled) versions
+	Enabled *bool
+
+	// only return versions modified within the given range
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+}
+
+// conditions returns the SQL conditions for the filter, appending any
+// parameters they refer to onto params.
+//
+// The version and metadata columns are matched by containment so that the
+// GIN indexes on their jsonb representations can be used.
+func (filter VersionFilter) conditions(params *[]interface{}) (string, error) {
+	bind := func(param interface{}) string {
+		*params = append(*params, param)
+		return fmt.Sprintf("$%d", len(*params))
+	}
+
+	conditions := ""
+
+	if len(filter.Version) > 0 {
+		versionJSON, err := json.Marshal(filter.Version)
+		if err != nil {
+			return "", err
+		}
+
+		conditions += `
+			AND v.version::jsonb @> ` + bind(string(versionJSON)) + `::jsonb`
+	}
+
+	if len(filter.Metadata) > 0 {
+		metadataJSON, err := json.Marshal(filter.Metadata)
+		if err != nil {
+			return "", err
+		}
+
+		conditions += `
+			AND v.metadata::jsonb @> ` + bind(string(metadataJSON)) + `::jsonb`
+	}
+
+	if filter.Enabled != nil {
+		conditions += `
+			AND v.enabled = ` + bind(*filter.Enabled)
+	}
+
+	if !filter.ModifiedAfter.IsZero() {
+		conditions += `
+			AND v.modified_time >= ` + bind(filter.ModifiedAfter)
+	}
+
+	if !filter.ModifiedBefore.IsZero() {
+		conditions += `
+			AND v.modified_time <= ` + bind(filter.ModifiedBefore)
+	}
+
+	return conditions, nil
+}
@@ -0,0 +1,600 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc/db/algorithm"
+)
+
+// rows modified this long before the latest modification that was loaded are
+// loaded again, to catch rows from transactions that committed late
+const versionsDBDeltaOverlap = time.Minute
+
+// the versions are loaded from scratch at least this often, for rows from
+// transactions that committed even later than the overlap
+const versionsDBFullReloadInterval = 10 * time.Minute
+
+// caches that haven't been loaded for this long are released, e.g. those of
+// pipelines that were only looked at through the API
+const versionsDBCacheIdleTimeout = 10 * time.Minute
+
+var errVersionsDBChanged = errors.New("versions db changed in a way that cannot be applied incrementally")
+
+func versionsDBChannel(pipelineID int) string {
+	return fmt.Sprintf("versions_db_%d", pipelineID)
+}
+
+type versionsDBCaches struct {
+	bus *notificationsBus
+
+	caches map[int]*versionsDBCache
+	lock   sync.Mutex
+}
+
+func newVersionsDBCaches(bus *notificationsBus) *versionsDBCaches {
+	return &versionsDBCaches{
+		bus:    bus,
+		caches: map[int]*versionsDBCache{},
+	}
+}
+
+// forPipeline returns the pipeline's cache, releasing any caches that have
+// gone idle along the way.
+func (caches *versionsDBCaches) forPipeline(pipelineID int) *versionsDBCache {
+	now := time.Now()
+
+	caches.lock.Lock()
+
+	idle := map[int]*versionsDBCache{}
+	for id, cache := range caches.caches {
+		if id != pipelineID && now.Sub(cache.usedAt) > versionsDBCacheIdleTimeout {
+			idle[id] = cache
+			delete(caches.caches, id)
+		}
+	}
+
+	cache, found := caches.caches[pipelineID]
+	if !found {
+		cache = &versionsDBCache{}
+		caches.caches[pipelineID] = cache
+	}
+
+	cache.usedAt = now
+
+	caches.lock.Unlock()
+
+	for id, idleCache := range idle {
+		// the caches are loaded from scratch by anyone still holding on to
+		// them, so failing to stop listening only costs a notification
+		_ = idleCache.release(caches.bus, id)
+	}
+
+	return cache
+}
+
+// invalidate makes the next load of the pipeline's cache start from scratch,
+// if this ATC has one.
+func (caches *versionsDBCaches) invalidate(pipelineID int) {
+	caches.lock.Lock()
+	cache, found := caches.caches[pipelineID]
+	caches.lock.Unlock()
+
+	if found {
+		cache.invalidate()
+	}
+}
+
+// release forgets the pipeline's cache and stops listening for its changes,
+// once the pipeline is destroyed or no longer scheduled by this ATC.
+func (caches *versionsDBCaches) release(pipelineID int) error {
+	caches.lock.Lock()
+	cache, found := caches.caches[pipelineID]
+	delete(caches.caches, pipelineID)
+	caches.lock.Unlock()
+
+	if !found {
+		return nil
+	}
+
+	return cache.release(caches.bus, pipelineID)
+}
+
+type versionState struct {
+	enabled    bool
+	checkOrder int
+}
+
+type outputKey struct {
+	versionID int
+	buildID   int
+	jobID     int
+}
+
+type inputKey struct {
+	versionID int
+	buildID   int
+	name      string
+}
+
+// versionsDBCache holds the last VersionsDB loaded for a pipeline, along with
+// what is needed to only load what changed since.
+type versionsDBCache struct {
+	lock sync.Mutex
+
+	// notified of changes to the pipeline and to the other pipelines that
+	// its passed constraints refer to, keyed by pipeline ID
+	notify      map[int]chan bool
+	invalidated bool
+	released    bool
+
+	// guarded by the versionsDBCaches' lock rather than the cache's own
+	usedAt time.Time
+
+	reloadedAt time.Time
+
+	versionsDB          *algorithm.VersionsDB
+	otherPipelineJobIDs map[string]int
+
+	versions map[int]versionState
+	outputs  map[outputKey]struct{}
+	inputs   map[inputKey]struct{}
+
+	versionsModifiedAt time.Time
+	outputsModifiedAt  time.Time
+	inputsModifiedAt   time.Time
+}
+
+// invalidate forces the next load to start from scratch, e.g. after rows were
+// deleted or versions were enabled or disabled.
+func (cache *versionsDBCache) invalidate() {
+	cache.lock.Lock()
+	cache.invalidated = true
+	cache.lock.Unlock()
+}
+
+// release stops listening for changes; anyone still holding on to the cache
+// loads the versions from scratch from then on.
+func (cache *versionsDBCache) release(bus *notificationsBus, pipelineID int) error {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.released = true
+	cache.versionsDB = nil
+	cache.versions = nil
+	cache.outputs = nil
+	cache.inputs = nil
+
+	return cache.unlisten(bus, nil)
+}
+
+// listen makes sure the cache is notified of changes to exactly the given
+// pipelines. Newly listened to pipelines invalidate the cache, as their
+// changes may have been missed.
+func (cache *versionsDBCache) listen(bus *notificationsBus, pipelineIDs []int) error {
+	if bus == nil {
+		return nil
+	}
+
+	err := cache.unlisten(bus, pipelineIDs)
+	if err != nil {
+		return err
+	}
+
+	if cache.notify == nil {
+		cache.notify = map[int]chan bool{}
+	}
+
+	for _, pipelineID := range pipelineIDs {
+		if _, found := cache.notify[pipelineID]; found {
+			continue
+		}
+
+		notify, err := bus.Listen(versionsDBChannel(pipelineID))
+		if err != nil {
+			return err
+		}
+
+		cache.notify[pipelineID] = notify
+		cache.invalidated = true
+	}
+
+	return nil
+}
+
+// unlisten stops listening for changes to the pipelines other than the given
+// ones.
+func (cache *versionsDBCache) unlisten(bus *notificationsBus, keep []int) error {
+	if bus == nil {
+		return nil
+	}
+
+	for pipelineID, notify := range cache.notify {
+		if containsInt(keep, pipelineID) {
+			continue
+		}
+
+		delete(cache.notify, pipelineID)
+
+		err := bus.Unlisten(versionsDBChannel(pipelineID), notify)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cache *versionsDBCache) reset() {
+	cache.invalidated = false
+	cache.reloadedAt = time.Now()
+	cache.versionsDB = &algorithm.VersionsDB{
+		BuildOutputs:     []algorithm.BuildOutput{},
+		BuildInputs:      []algorithm.BuildInput{},
+		ResourceVersions: []algorithm.ResourceVersion{},
+		JobIDs:           map[string]int{},
+		ResourceIDs:      map[string]int{},
+	}
+	cache.otherPipelineJobIDs = nil
+	cache.versions = map[int]versionState{}
+	cache.outputs = map[outputKey]struct{}{}
+	cache.inputs = map[inputKey]struct{}{}
+	cache.versionsModifiedAt = time.Time{}
+	cache.outputsModifiedAt = time.Time{}
+	cache.inputsModifiedAt = time.Time{}
+}
+
+func (cache *versionsDBCache) load(conn Conn, bus *notificationsBus, pipelineID int, otherPipelineIDs []int, otherPipelineJobIDs map[string]int) (*algorithm.VersionsDB, error) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if cache.released {
+		cache.invalidated = true
+	} else {
+		err := cache.listen(bus, append([]int{pipelineID}, otherPipelineIDs...))
+		if err != nil {
+			cache.invalidated = true
+			return nil, err
+		}
+	}
+
+	for _, notify := range cache.notify {
+		select {
+		case <-notify:
+			// either notified of a change or the connection was lost
+			cache.invalidated = true
+		default:
+		}
+	}
+
+	if time.Since(cache.reloadedAt) > versionsDBFullReloadInterval {
+		cache.invalidated = true
+	}
+
+	if cache.versionsDB == nil || cache.invalidated || !equalIDs(cache.otherPipelineJobIDs, otherPipelineJobIDs) {
+		cache.reset()
+	}
+
+	delta, err := cache.loadDelta(conn, pipelineID, otherPipelineJobIDs)
+	if err == errVersionsDBChanged {
+		cache.reset()
+		delta, err = cache.loadDelta(conn, pipelineID, otherPipelineJobIDs)
+	}
+
+	if err != nil {
+		// the cache may be half-way through a delta
+		cache.invalidated = true
+		return nil, err
+	}
+
+	cache.otherPipelineJobIDs = otherPipelineJobIDs
+
+	if !delta.IsEmpty() {
+		cache.versionsDB = cache.versionsDB.Apply(delta)
+		cache.versionsDB.CachedAt = latestTime(cache.versionsModifiedAt, cache.outputsModifiedAt, cache.inputsModifiedAt)
+	}
+
+	return cache.versionsDB, nil
+}
+
+func (cache *versionsDBCache) loadDelta(conn Conn, pipelineID int, otherPipelineJobIDs map[string]int) (algorithm.VersionsDBDelta, error) {
+	delta := algorithm.VersionsDBDelta{
+		CheckOrders: map[int]int{},
+	}
+
+	err := cache.loadVersions(conn, pipelineID, &delta)
+	if err != nil {
+		return algorithm.VersionsDBDelta{}, err
+	}
+
+	outputsSince := since(cache.outputsModifiedAt)
+
+	rows, err := conn.Query(`
+		SELECT v.id, v.check_order, r.id, o.build_id, j.id,
+			GREATEST(o.modified_time, v.modified_time, COALESCE(b.end_time::timestamp, 'epoch'))
+		FROM build_outputs o, builds b, versioned_resources v, jobs j, resources r
+		WHERE v.id = o.versioned_resource_id
+		AND b.id = o.build_id
+		AND j.id = b.job_id
+		AND r.id = v.resource_id
+		AND v.enabled
+		AND b.status = 'succeeded'
+		AND r.pipeline_id = $1
+		AND (o.modified_time > $2 OR v.modified_time > $2 OR b.end_time::timestamp > $2)
+	`, pipelineID, outputsSince)
+	if err != nil {
+		return algorithm.VersionsDBDelta{}, err
+	}
+
+	err = cache.scanOutputs(rows, &delta)
+	if err != nil {
+		return algorithm.VersionsDBDelta{}, err
+	}
+
+	// versions that made it through jobs in other pipelines are matched up
+	// with the same versions of this pipeline's resources of the same name
+	if len(otherPipelineJobIDs) > 0 {
+		jobIDs := make([]int, 0, len(otherPipelineJobIDs))
+		for _, jobID := range otherPipelineJobIDs {
+			jobIDs = append(jobIDs, jobID)
+		}
+
+		query, args, err := sq.Select("lv.id, lv.check_order, lv.resource_id, o.build_id, b.job_id").
+			Column("GREATEST(o.modified_time, v.modified_time, lv.modified_time, COALESCE(b.end_time::timestamp, 'epoch'))").
+			From("build_outputs o, builds b, versioned_resources v, resources r, versioned_resources lv, resources lr").
+			Where(sq.Expr("v.id = o.versioned_resource_id")).
+			Where(sq.Expr("b.id = o.build_id")).
+			Where(sq.Expr("r.id = v.resource_id")).
+			Where(sq.Expr("lr.name = r.name")).
+			Where(sq.Expr("lv.resource_id = lr.id")).
+			Where(sq.Expr("lv.type = v.type")).
+			Where(sq.Expr("lv.version = v.version")).
+			Where(sq.Expr("lv.enabled")).
+			Where(sq.Eq{
+				"b.status":       "succeeded",
+				"b.job_id":       jobIDs,
+				"lr.pipeline_id": pipelineID,
+			}).
+			Where(sq.Expr(
+				"(o.modified_time > ? OR v.modified_time > ? OR lv.modified_time > ? OR b.end_time::timestamp > ?)",
+				outputsSince, outputsSince, outputsSince, outputsSince,
+			)).
+			PlaceholderFormat(sq.Dollar).
+			ToSql()
+		if err != nil {
+			return algorithm.VersionsDBDelta{}, err
+		}
+
+		rows, err := conn.Query(query, args...)
+		if err != nil {
+			return algorithm.VersionsDBDelta{}, err
+		}
+
+		err = cache.scanOutputs(rows, &delta)
+		if err != nil {
+			return algorithm.VersionsDBDelta{}, err
+		}
+	}
+
+	rows, err = conn.Query(`
+		SELECT v.id, v.check_order, r.id, i.build_id, i.name, j.id, GREATEST(i.modified_time, v.modified_time)
+		FROM build_inputs i, builds b, versioned_resources v, jobs j, resources r
+		WHERE v.id = i.versioned_resource_id
+		AND b.id = i.build_id
+		AND j.id = b.job_id
+		AND r.id = v.resource_id
+		AND v.enabled
+		AND r.pipeline_id = $1
+		AND (i.modified_time > $2 OR v.modified_time > $2)
+	`, pipelineID, since(cache.inputsModifiedAt))
+	if err != nil {
+		return algorithm.VersionsDBDelta{}, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var input algorithm.BuildInput
+		var modifiedTime time.Time
+		err := rows.Scan(&input.VersionID, &input.CheckOrder, &input.ResourceID, &input.BuildID, &input.InputName, &input.JobID, &modifiedTime)
+		if err != nil {
+			return algorithm.VersionsDBDelta{}, err
+		}
+
+		if modifiedTime.After(cache.inputsModifiedAt) {
+			cache.inputsModifiedAt = modifiedTime
+		}
+
+		key := inputKey{versionID: input.VersionID, buildID: input.BuildID, name: input.InputName}
+		if _, found := cache.inputs[key]; found {
+			continue
+		}
+
+		cache.inputs[key] = struct{}{}
+		delta.BuildInputs = append(delta.BuildInputs, input)
+	}
+
+	jobIDs, err := loadIDs(conn, `
+		SELECT j.name, j.id
+		FROM jobs j
+		WHERE j.pipeline_id = $1
+	`, pipelineID)
+	if err != nil {
+		return algorithm.VersionsDBDelta{}, err
+	}
+
+	for name, id := range otherPipelineJobIDs {
+		jobIDs[name] = id
+	}
+
+	if !equalIDs(cache.versionsDB.JobIDs, jobIDs) {
+		delta.JobIDs = jobIDs
+	}
+
+	resourceIDs, err := loadIDs(conn, `
+		SELECT r.name, r.id
+		FROM resources r
+		WHERE r.pipeline_id = $1
+	`, pipelineID)
+	if err != nil {
+		return algorithm.VersionsDBDelta{}, err
+	}
+
+	if !equalIDs(cache.versionsDB.ResourceIDs, resourceIDs) {
+		delta.ResourceIDs = resourceIDs
+	}
+
+	return delta, nil
+}
+
+func (cache *versionsDBCache) loadVersions(conn Conn, pipelineID int, delta *algorithm.VersionsDBDelta) error {
+	rows, err := conn.Query(`
+		SELECT v.id, v.check_order, r.id, v.enabled, v.modified_time
+		FROM versioned_resources v, resources r
+		WHERE r.id = v.resource_id
+		AND r.pipeline_id = $1
+		AND v.modified_time > $2
+	`, pipelineID, since(cache.versionsModifiedAt))
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var version algorithm.ResourceVersion
+		var enabled bool
+		var modifiedTime time.Time
+		err := rows.Scan(&version.VersionID, &version.CheckOrder, &version.ResourceID, &enabled, &modifiedTime)
+		if err != nil {
+			return err
+		}
+
+		if modifiedTime.After(cache.versionsModifiedAt) {
+			cache.versionsModifiedAt = modifiedTime
+		}
+
+		state, found := cache.versions[version.VersionID]
+		if !found {
+			cache.versions[version.VersionID] = versionState{enabled: enabled, checkOrder: version.CheckOrder}
+
+			if enabled {
+				delta.ResourceVersions = append(delta.ResourceVersions, version)
+			}
+
+			continue
+		}
+
+		if state.enabled != enabled {
+			// the version's inputs and outputs need to be added or removed
+			return errVersionsDBChanged
+		}
+
+		if state.checkOrder != version.CheckOrder {
+			cache.versions[version.VersionID] = versionState{enabled: enabled, checkOrder: version.CheckOrder}
+
+			if enabled {
+				delta.CheckOrders[version.VersionID] = version.CheckOrder
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cache *versionsDBCache) scanOutputs(rows *sql.Rows, delta *algorithm.VersionsDBDelta) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		var output algorithm.BuildOutput
+		var modifiedTime time.Time
+		err := rows.Scan(&output.VersionID, &output.CheckOrder, &output.ResourceID, &output.BuildID, &output.JobID, &modifiedTime)
+		if err != nil {
+			return err
+		}
+
+		if modifiedTime.After(cache.outputsModifiedAt) {
+			cache.outputsModifiedAt = modifiedTime
+		}
+
+		key := outputKey{versionID: output.VersionID, buildID: output.BuildID, jobID: output.JobID}
+		if _, found := cache.outputs[key]; found {
+			continue
+		}
+
+		cache.outputs[key] = struct{}{}
+		delta.BuildOutputs = append(delta.BuildOutputs, output)
+	}
+
+	return nil
+}
+
+func loadIDs(conn Conn, query string, pipelineID int) (map[string]int, error) {
+	rows, err := conn.Query(query, pipelineID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ids := map[string]int{}
+	for rows.Next() {
+		var name string
+		var id int
+		err := rows.Scan(&name, &id)
+		if err != nil {
+			return nil, err
+		}
+
+		ids[name] = id
+	}
+
+	return ids, nil
+}
+
+func since(modifiedAt time.Time) time.Time {
+	if modifiedAt.IsZero() {
+		return modifiedAt
+	}
+
+	return modifiedAt.Add(-versionsDBDeltaOverlap)
+}
+
+func latestTime(times ...time.Time) time.Time {
+	var latest time.Time
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+
+	return latest
+}
+
+func containsInt(ints []int, i int) bool {
+	for _, x := range ints {
+		if x == i {
+			return true
+		}
+	}
+
+	return false
+}
+
+func equalIDs(a map[string]int, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for name, id := range a {
+		if otherID, found := b[name]; !found || otherID != id {
+			return false
+		}
+	}
+
+	return true
+}
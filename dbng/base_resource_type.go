@@ -16,8 +16,13 @@ var ErrBaseResourceTypeAlreadyExists = errors.New("base-resource-type-already-ex
 //
 // It is removed by gc.BaseResourceTypeCollector, once there are no references
 // to it from worker_base_resource_types.
+//
+// The Version is the one required by the ATC, if any. It is not part of the
+// base_resource_types row, but ResourceConfigs created by the type are keyed
+// by it so that changing the required version invalidates their caches.
 type BaseResourceType struct {
-	Name string // The name of the type, e.g. 'git'.
+	Name    string // The name of the type, e.g. 'git'.
+	Version string // The required version of the type, e.g. '1.2.3'.
 }
 
 // UsedBaseResourceType is created whenever a ResourceConfig is used, either
@@ -0,0 +1,51 @@
+package dbng
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/concourse/atc"
+)
+
+// ClusterEventsChannel is notified whenever an event is saved to the
+// cluster-wide firehose.
+const ClusterEventsChannel = "cluster_events"
+
+// ClusterEventScope is what an event on the cluster-wide firehose is about.
+// The pipeline and job are left empty for events that aren't about one.
+type ClusterEventScope struct {
+	TeamID       int
+	PipelineName string
+	JobName      string
+}
+
+// SaveClusterEvent records an event on the cluster-wide firehose as part of
+// the given transaction. It is used by the db package too; see
+// db.ClusterEventSource for the reading side.
+func SaveClusterEvent(tx Tx, scope ClusterEventScope, ev atc.Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO cluster_events (type, version, payload, team_name, pipeline_name, job_name)
+		SELECT $1, $2, $3, t.name, $4, $5
+		FROM teams t
+		WHERE t.id = $6
+	`,
+		string(ev.EventType()),
+		string(ev.Version()),
+		payload,
+		sql.NullString{String: scope.PipelineName, Valid: scope.PipelineName != ""},
+		sql.NullString{String: scope.JobName, Valid: scope.JobName != ""},
+		scope.TeamID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// delivered to listeners once the transaction commits
+	_, err = tx.Exec("NOTIFY " + ClusterEventsChannel)
+	return err
+}
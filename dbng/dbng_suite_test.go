@@ -41,6 +41,8 @@ var (
 	resourceTypeFactory     dbng.ResourceTypeFactory
 	resourceCacheFactory    dbng.ResourceCacheFactory
 	baseResourceTypeFactory dbng.BaseResourceTypeFactory
+	taskCacheFactory        dbng.TaskCacheFactory
+	workerTaskCacheFactory  dbng.WorkerTaskCacheFactory
 
 	defaultTeam              dbng.Team
 	defaultWorkerPayload     atc.Worker
@@ -83,10 +85,12 @@ var _ = BeforeEach(func() {
 	lockFactory = lock.NewLockFactory(retryableConn)
 	teamFactory = dbng.NewTeamFactory(dbConn, lockFactory)
 	workerFactory = dbng.NewWorkerFactory(dbConn)
-	resourceConfigFactory = dbng.NewResourceConfigFactory(dbConn, lockFactory)
+	resourceConfigFactory = dbng.NewResourceConfigFactory(dbConn, lockFactory, nil)
 	resourceTypeFactory = dbng.NewResourceTypeFactory(dbConn)
-	resourceCacheFactory = dbng.NewResourceCacheFactory(dbConn, lockFactory)
+	resourceCacheFactory = dbng.NewResourceCacheFactory(dbConn, lockFactory, nil)
 	baseResourceTypeFactory = dbng.NewBaseResourceTypeFactory(dbConn)
+	taskCacheFactory = dbng.NewTaskCacheFactory(dbConn)
+	workerTaskCacheFactory = dbng.NewWorkerTaskCacheFactory(dbConn)
 
 	defaultTeam, err = teamFactory.CreateTeam("default-team")
 	Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,199 @@
+// This file was generated by counterfeiter
+package dbngfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/dbng"
+)
+
+type FakeTaskCacheFactory struct {
+	FindTaskCacheForBuildStub        func(buildID int, cache dbng.TaskCache) (*dbng.UsedTaskCache, bool, error)
+	findTaskCacheForBuildMutex       sync.RWMutex
+	findTaskCacheForBuildArgsForCall []struct {
+		buildID int
+		cache   dbng.TaskCache
+	}
+	findTaskCacheForBuildReturns struct {
+		result1 *dbng.UsedTaskCache
+		result2 bool
+		result3 error
+	}
+	CreateTaskCacheForBuildStub        func(buildID int, cache dbng.TaskCache, workerName string, outputs map[string]string) (*dbng.UsedTaskCache, error)
+	createTaskCacheForBuildMutex       sync.RWMutex
+	createTaskCacheForBuildArgsForCall []struct {
+		buildID    int
+		cache      dbng.TaskCache
+		workerName string
+		outputs    map[string]string
+	}
+	createTaskCacheForBuildReturns struct {
+		result1 *dbng.UsedTaskCache
+		result2 error
+	}
+	CleanUsesForFinishedBuildsStub        func() error
+	cleanUsesForFinishedBuildsMutex       sync.RWMutex
+	cleanUsesForFinishedBuildsArgsForCall []struct{}
+	cleanUsesForFinishedBuildsReturns     struct {
+		result1 error
+	}
+	CleanUpInvalidCachesStub        func() error
+	cleanUpInvalidCachesMutex       sync.RWMutex
+	cleanUpInvalidCachesArgsForCall []struct{}
+	cleanUpInvalidCachesReturns     struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTaskCacheFactory) FindTaskCacheForBuild(buildID int, cache dbng.TaskCache) (*dbng.UsedTaskCache, bool, error) {
+	fake.findTaskCacheForBuildMutex.Lock()
+	fake.findTaskCacheForBuildArgsForCall = append(fake.findTaskCacheForBuildArgsForCall, struct {
+		buildID int
+		cache   dbng.TaskCache
+	}{buildID, cache})
+	fake.recordInvocation("FindTaskCacheForBuild", []interface{}{buildID, cache})
+	fake.findTaskCacheForBuildMutex.Unlock()
+	if fake.FindTaskCacheForBuildStub != nil {
+		return fake.FindTaskCacheForBuildStub(buildID, cache)
+	} else {
+		return fake.findTaskCacheForBuildReturns.result1, fake.findTaskCacheForBuildReturns.result2, fake.findTaskCacheForBuildReturns.result3
+	}
+}
+
+func (fake *FakeTaskCacheFactory) FindTaskCacheForBuildCallCount() int {
+	fake.findTaskCacheForBuildMutex.RLock()
+	defer fake.findTaskCacheForBuildMutex.RUnlock()
+	return len(fake.findTaskCacheForBuildArgsForCall)
+}
+
+func (fake *FakeTaskCacheFactory) FindTaskCacheForBuildArgsForCall(i int) (int, dbng.TaskCache) {
+	fake.findTaskCacheForBuildMutex.RLock()
+	defer fake.findTaskCacheForBuildMutex.RUnlock()
+	return fake.findTaskCacheForBuildArgsForCall[i].buildID, fake.findTaskCacheForBuildArgsForCall[i].cache
+}
+
+func (fake *FakeTaskCacheFactory) FindTaskCacheForBuildReturns(result1 *dbng.UsedTaskCache, result2 bool, result3 error) {
+	fake.FindTaskCacheForBuildStub = nil
+	fake.findTaskCacheForBuildReturns = struct {
+		result1 *dbng.UsedTaskCache
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTaskCacheFactory) CreateTaskCacheForBuild(buildID int, cache dbng.TaskCache, workerName string, outputs map[string]string) (*dbng.UsedTaskCache, error) {
+	fake.createTaskCacheForBuildMutex.Lock()
+	fake.createTaskCacheForBuildArgsForCall = append(fake.createTaskCacheForBuildArgsForCall, struct {
+		buildID    int
+		cache      dbng.TaskCache
+		workerName string
+		outputs    map[string]string
+	}{buildID, cache, workerName, outputs})
+	fake.recordInvocation("CreateTaskCacheForBuild", []interface{}{buildID, cache, workerName, outputs})
+	fake.createTaskCacheForBuildMutex.Unlock()
+	if fake.CreateTaskCacheForBuildStub != nil {
+		return fake.CreateTaskCacheForBuildStub(buildID, cache, workerName, outputs)
+	} else {
+		return fake.createTaskCacheForBuildReturns.result1, fake.createTaskCacheForBuildReturns.result2
+	}
+}
+
+func (fake *FakeTaskCacheFactory) CreateTaskCacheForBuildCallCount() int {
+	fake.createTaskCacheForBuildMutex.RLock()
+	defer fake.createTaskCacheForBuildMutex.RUnlock()
+	return len(fake.createTaskCacheForBuildArgsForCall)
+}
+
+func (fake *FakeTaskCacheFactory) CreateTaskCacheForBuildArgsForCall(i int) (int, dbng.TaskCache, string, map[string]string) {
+	fake.createTaskCacheForBuildMutex.RLock()
+	defer fake.createTaskCacheForBuildMutex.RUnlock()
+	return fake.createTaskCacheForBuildArgsForCall[i].buildID, fake.createTaskCacheForBuildArgsForCall[i].cache, fake.createTaskCacheForBuildArgsForCall[i].workerName, fake.createTaskCacheForBuildArgsForCall[i].outputs
+}
+
+func (fake *FakeTaskCacheFactory) CreateTaskCacheForBuildReturns(result1 *dbng.UsedTaskCache, result2 error) {
+	fake.CreateTaskCacheForBuildStub = nil
+	fake.createTaskCacheForBuildReturns = struct {
+		result1 *dbng.UsedTaskCache
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskCacheFactory) CleanUsesForFinishedBuilds() error {
+	fake.cleanUsesForFinishedBuildsMutex.Lock()
+	fake.cleanUsesForFinishedBuildsArgsForCall = append(fake.cleanUsesForFinishedBuildsArgsForCall, struct{}{})
+	fake.recordInvocation("CleanUsesForFinishedBuilds", []interface{}{})
+	fake.cleanUsesForFinishedBuildsMutex.Unlock()
+	if fake.CleanUsesForFinishedBuildsStub != nil {
+		return fake.CleanUsesForFinishedBuildsStub()
+	} else {
+		return fake.cleanUsesForFinishedBuildsReturns.result1
+	}
+}
+
+func (fake *FakeTaskCacheFactory) CleanUsesForFinishedBuildsCallCount() int {
+	fake.cleanUsesForFinishedBuildsMutex.RLock()
+	defer fake.cleanUsesForFinishedBuildsMutex.RUnlock()
+	return len(fake.cleanUsesForFinishedBuildsArgsForCall)
+}
+
+func (fake *FakeTaskCacheFactory) CleanUsesForFinishedBuildsReturns(result1 error) {
+	fake.CleanUsesForFinishedBuildsStub = nil
+	fake.cleanUsesForFinishedBuildsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskCacheFactory) CleanUpInvalidCaches() error {
+	fake.cleanUpInvalidCachesMutex.Lock()
+	fake.cleanUpInvalidCachesArgsForCall = append(fake.cleanUpInvalidCachesArgsForCall, struct{}{})
+	fake.recordInvocation("CleanUpInvalidCaches", []interface{}{})
+	fake.cleanUpInvalidCachesMutex.Unlock()
+	if fake.CleanUpInvalidCachesStub != nil {
+		return fake.CleanUpInvalidCachesStub()
+	} else {
+		return fake.cleanUpInvalidCachesReturns.result1
+	}
+}
+
+func (fake *FakeTaskCacheFactory) CleanUpInvalidCachesCallCount() int {
+	fake.cleanUpInvalidCachesMutex.RLock()
+	defer fake.cleanUpInvalidCachesMutex.RUnlock()
+	return len(fake.cleanUpInvalidCachesArgsForCall)
+}
+
+func (fake *FakeTaskCacheFactory) CleanUpInvalidCachesReturns(result1 error) {
+	fake.CleanUpInvalidCachesStub = nil
+	fake.cleanUpInvalidCachesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskCacheFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findTaskCacheForBuildMutex.RLock()
+	defer fake.findTaskCacheForBuildMutex.RUnlock()
+	fake.createTaskCacheForBuildMutex.RLock()
+	defer fake.createTaskCacheForBuildMutex.RUnlock()
+	fake.cleanUsesForFinishedBuildsMutex.RLock()
+	defer fake.cleanUsesForFinishedBuildsMutex.RUnlock()
+	fake.cleanUpInvalidCachesMutex.RLock()
+	defer fake.cleanUpInvalidCachesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeTaskCacheFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ dbng.TaskCacheFactory = new(FakeTaskCacheFactory)
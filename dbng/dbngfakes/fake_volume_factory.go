@@ -124,6 +124,29 @@ type FakeVolumeFactory struct {
 		result2 bool
 		result3 error
 	}
+	FindWorkerTaskCacheVolumeStub        func(int, *dbng.Worker, *dbng.UsedWorkerTaskCache) (dbng.CreatingVolume, dbng.CreatedVolume, error)
+	findWorkerTaskCacheVolumeMutex       sync.RWMutex
+	findWorkerTaskCacheVolumeArgsForCall []struct {
+		arg1 int
+		arg2 *dbng.Worker
+		arg3 *dbng.UsedWorkerTaskCache
+	}
+	findWorkerTaskCacheVolumeReturns struct {
+		result1 dbng.CreatingVolume
+		result2 dbng.CreatedVolume
+		result3 error
+	}
+	CreateWorkerTaskCacheVolumeStub        func(int, *dbng.Worker, *dbng.UsedWorkerTaskCache) (dbng.CreatingVolume, error)
+	createWorkerTaskCacheVolumeMutex       sync.RWMutex
+	createWorkerTaskCacheVolumeArgsForCall []struct {
+		arg1 int
+		arg2 *dbng.Worker
+		arg3 *dbng.UsedWorkerTaskCache
+	}
+	createWorkerTaskCacheVolumeReturns struct {
+		result1 dbng.CreatingVolume
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -513,6 +536,79 @@ func (fake *FakeVolumeFactory) FindCreatedVolumeReturns(result1 dbng.CreatedVolu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeFactory) FindWorkerTaskCacheVolume(arg1 int, arg2 *dbng.Worker, arg3 *dbng.UsedWorkerTaskCache) (dbng.CreatingVolume, dbng.CreatedVolume, error) {
+	fake.findWorkerTaskCacheVolumeMutex.Lock()
+	fake.findWorkerTaskCacheVolumeArgsForCall = append(fake.findWorkerTaskCacheVolumeArgsForCall, struct {
+		arg1 int
+		arg2 *dbng.Worker
+		arg3 *dbng.UsedWorkerTaskCache
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("FindWorkerTaskCacheVolume", []interface{}{arg1, arg2, arg3})
+	fake.findWorkerTaskCacheVolumeMutex.Unlock()
+	if fake.FindWorkerTaskCacheVolumeStub != nil {
+		return fake.FindWorkerTaskCacheVolumeStub(arg1, arg2, arg3)
+	} else {
+		return fake.findWorkerTaskCacheVolumeReturns.result1, fake.findWorkerTaskCacheVolumeReturns.result2, fake.findWorkerTaskCacheVolumeReturns.result3
+	}
+}
+
+func (fake *FakeVolumeFactory) FindWorkerTaskCacheVolumeCallCount() int {
+	fake.findWorkerTaskCacheVolumeMutex.RLock()
+	defer fake.findWorkerTaskCacheVolumeMutex.RUnlock()
+	return len(fake.findWorkerTaskCacheVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) FindWorkerTaskCacheVolumeArgsForCall(i int) (int, *dbng.Worker, *dbng.UsedWorkerTaskCache) {
+	fake.findWorkerTaskCacheVolumeMutex.RLock()
+	defer fake.findWorkerTaskCacheVolumeMutex.RUnlock()
+	return fake.findWorkerTaskCacheVolumeArgsForCall[i].arg1, fake.findWorkerTaskCacheVolumeArgsForCall[i].arg2, fake.findWorkerTaskCacheVolumeArgsForCall[i].arg3
+}
+
+func (fake *FakeVolumeFactory) FindWorkerTaskCacheVolumeReturns(result1 dbng.CreatingVolume, result2 dbng.CreatedVolume, result3 error) {
+	fake.FindWorkerTaskCacheVolumeStub = nil
+	fake.findWorkerTaskCacheVolumeReturns = struct {
+		result1 dbng.CreatingVolume
+		result2 dbng.CreatedVolume
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolumeFactory) CreateWorkerTaskCacheVolume(arg1 int, arg2 *dbng.Worker, arg3 *dbng.UsedWorkerTaskCache) (dbng.CreatingVolume, error) {
+	fake.createWorkerTaskCacheVolumeMutex.Lock()
+	fake.createWorkerTaskCacheVolumeArgsForCall = append(fake.createWorkerTaskCacheVolumeArgsForCall, struct {
+		arg1 int
+		arg2 *dbng.Worker
+		arg3 *dbng.UsedWorkerTaskCache
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateWorkerTaskCacheVolume", []interface{}{arg1, arg2, arg3})
+	fake.createWorkerTaskCacheVolumeMutex.Unlock()
+	if fake.CreateWorkerTaskCacheVolumeStub != nil {
+		return fake.CreateWorkerTaskCacheVolumeStub(arg1, arg2, arg3)
+	} else {
+		return fake.createWorkerTaskCacheVolumeReturns.result1, fake.createWorkerTaskCacheVolumeReturns.result2
+	}
+}
+
+func (fake *FakeVolumeFactory) CreateWorkerTaskCacheVolumeCallCount() int {
+	fake.createWorkerTaskCacheVolumeMutex.RLock()
+	defer fake.createWorkerTaskCacheVolumeMutex.RUnlock()
+	return len(fake.createWorkerTaskCacheVolumeArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) CreateWorkerTaskCacheVolumeArgsForCall(i int) (int, *dbng.Worker, *dbng.UsedWorkerTaskCache) {
+	fake.createWorkerTaskCacheVolumeMutex.RLock()
+	defer fake.createWorkerTaskCacheVolumeMutex.RUnlock()
+	return fake.createWorkerTaskCacheVolumeArgsForCall[i].arg1, fake.createWorkerTaskCacheVolumeArgsForCall[i].arg2, fake.createWorkerTaskCacheVolumeArgsForCall[i].arg3
+}
+
+func (fake *FakeVolumeFactory) CreateWorkerTaskCacheVolumeReturns(result1 dbng.CreatingVolume, result2 error) {
+	fake.CreateWorkerTaskCacheVolumeStub = nil
+	fake.createWorkerTaskCacheVolumeReturns = struct {
+		result1 dbng.CreatingVolume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -538,6 +634,10 @@ func (fake *FakeVolumeFactory) Invocations() map[string][][]interface{} {
 	defer fake.getOrphanedVolumesMutex.RUnlock()
 	fake.findCreatedVolumeMutex.RLock()
 	defer fake.findCreatedVolumeMutex.RUnlock()
+	fake.findWorkerTaskCacheVolumeMutex.RLock()
+	defer fake.findWorkerTaskCacheVolumeMutex.RUnlock()
+	fake.createWorkerTaskCacheVolumeMutex.RLock()
+	defer fake.createWorkerTaskCacheVolumeMutex.RUnlock()
 	return fake.invocations
 }
 
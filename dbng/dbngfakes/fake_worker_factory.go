@@ -118,6 +118,32 @@ type FakeWorkerFactory struct {
 		result1 *dbng.Worker
 		result2 error
 	}
+	WorkerPinsStub        func(name string) ([]dbng.WorkerPin, error)
+	workerPinsMutex       sync.RWMutex
+	workerPinsArgsForCall []struct {
+		name string
+	}
+	workerPinsReturns struct {
+		result1 []dbng.WorkerPin
+		result2 error
+	}
+	MarkWorkerUnhealthyStub        func(name string, reason string) error
+	markWorkerUnhealthyMutex       sync.RWMutex
+	markWorkerUnhealthyArgsForCall []struct {
+		name   string
+		reason string
+	}
+	markWorkerUnhealthyReturns struct {
+		result1 error
+	}
+	MarkWorkerHealthyStub        func(name string) error
+	markWorkerHealthyMutex       sync.RWMutex
+	markWorkerHealthyArgsForCall []struct {
+		name string
+	}
+	markWorkerHealthyReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -531,6 +557,107 @@ func (fake *FakeWorkerFactory) HeartbeatWorkerReturns(result1 *dbng.Worker, resu
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerFactory) WorkerPins(name string) ([]dbng.WorkerPin, error) {
+	fake.workerPinsMutex.Lock()
+	fake.workerPinsArgsForCall = append(fake.workerPinsArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("WorkerPins", []interface{}{name})
+	fake.workerPinsMutex.Unlock()
+	if fake.WorkerPinsStub != nil {
+		return fake.WorkerPinsStub(name)
+	} else {
+		return fake.workerPinsReturns.result1, fake.workerPinsReturns.result2
+	}
+}
+
+func (fake *FakeWorkerFactory) WorkerPinsCallCount() int {
+	fake.workerPinsMutex.RLock()
+	defer fake.workerPinsMutex.RUnlock()
+	return len(fake.workerPinsArgsForCall)
+}
+
+func (fake *FakeWorkerFactory) WorkerPinsArgsForCall(i int) string {
+	fake.workerPinsMutex.RLock()
+	defer fake.workerPinsMutex.RUnlock()
+	return fake.workerPinsArgsForCall[i].name
+}
+
+func (fake *FakeWorkerFactory) WorkerPinsReturns(result1 []dbng.WorkerPin, result2 error) {
+	fake.WorkerPinsStub = nil
+	fake.workerPinsReturns = struct {
+		result1 []dbng.WorkerPin
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerFactory) MarkWorkerUnhealthy(name string, reason string) error {
+	fake.markWorkerUnhealthyMutex.Lock()
+	fake.markWorkerUnhealthyArgsForCall = append(fake.markWorkerUnhealthyArgsForCall, struct {
+		name   string
+		reason string
+	}{name, reason})
+	fake.recordInvocation("MarkWorkerUnhealthy", []interface{}{name, reason})
+	fake.markWorkerUnhealthyMutex.Unlock()
+	if fake.MarkWorkerUnhealthyStub != nil {
+		return fake.MarkWorkerUnhealthyStub(name, reason)
+	} else {
+		return fake.markWorkerUnhealthyReturns.result1
+	}
+}
+
+func (fake *FakeWorkerFactory) MarkWorkerUnhealthyCallCount() int {
+	fake.markWorkerUnhealthyMutex.RLock()
+	defer fake.markWorkerUnhealthyMutex.RUnlock()
+	return len(fake.markWorkerUnhealthyArgsForCall)
+}
+
+func (fake *FakeWorkerFactory) MarkWorkerUnhealthyArgsForCall(i int) (string, string) {
+	fake.markWorkerUnhealthyMutex.RLock()
+	defer fake.markWorkerUnhealthyMutex.RUnlock()
+	return fake.markWorkerUnhealthyArgsForCall[i].name, fake.markWorkerUnhealthyArgsForCall[i].reason
+}
+
+func (fake *FakeWorkerFactory) MarkWorkerUnhealthyReturns(result1 error) {
+	fake.MarkWorkerUnhealthyStub = nil
+	fake.markWorkerUnhealthyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerFactory) MarkWorkerHealthy(name string) error {
+	fake.markWorkerHealthyMutex.Lock()
+	fake.markWorkerHealthyArgsForCall = append(fake.markWorkerHealthyArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("MarkWorkerHealthy", []interface{}{name})
+	fake.markWorkerHealthyMutex.Unlock()
+	if fake.MarkWorkerHealthyStub != nil {
+		return fake.MarkWorkerHealthyStub(name)
+	} else {
+		return fake.markWorkerHealthyReturns.result1
+	}
+}
+
+func (fake *FakeWorkerFactory) MarkWorkerHealthyCallCount() int {
+	fake.markWorkerHealthyMutex.RLock()
+	defer fake.markWorkerHealthyMutex.RUnlock()
+	return len(fake.markWorkerHealthyArgsForCall)
+}
+
+func (fake *FakeWorkerFactory) MarkWorkerHealthyArgsForCall(i int) string {
+	fake.markWorkerHealthyMutex.RLock()
+	defer fake.markWorkerHealthyMutex.RUnlock()
+	return fake.markWorkerHealthyArgsForCall[i].name
+}
+
+func (fake *FakeWorkerFactory) MarkWorkerHealthyReturns(result1 error) {
+	fake.MarkWorkerHealthyStub = nil
+	fake.markWorkerHealthyReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorkerFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -560,6 +687,12 @@ func (fake *FakeWorkerFactory) Invocations() map[string][][]interface{} {
 	defer fake.deleteWorkerMutex.RUnlock()
 	fake.heartbeatWorkerMutex.RLock()
 	defer fake.heartbeatWorkerMutex.RUnlock()
+	fake.workerPinsMutex.RLock()
+	defer fake.workerPinsMutex.RUnlock()
+	fake.markWorkerUnhealthyMutex.RLock()
+	defer fake.markWorkerUnhealthyMutex.RUnlock()
+	fake.markWorkerHealthyMutex.RLock()
+	defer fake.markWorkerHealthyMutex.RUnlock()
 	return fake.invocations
 }
 
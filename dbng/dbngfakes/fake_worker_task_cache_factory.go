@@ -0,0 +1,158 @@
+// This file was generated by counterfeiter
+package dbngfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/dbng"
+)
+
+type FakeWorkerTaskCacheFactory struct {
+	FindOrCreateStub        func(cache dbng.WorkerTaskCache) (*dbng.UsedWorkerTaskCache, error)
+	findOrCreateMutex       sync.RWMutex
+	findOrCreateArgsForCall []struct {
+		cache dbng.WorkerTaskCache
+	}
+	findOrCreateReturns struct {
+		result1 *dbng.UsedWorkerTaskCache
+		result2 error
+	}
+	CleanUpForInactiveJobsStub        func() error
+	cleanUpForInactiveJobsMutex       sync.RWMutex
+	cleanUpForInactiveJobsArgsForCall []struct{}
+	cleanUpForInactiveJobsReturns     struct {
+		result1 error
+	}
+	ClaimStub        func(cache *dbng.UsedWorkerTaskCache, container dbng.CreatingContainer) (bool, error)
+	claimMutex       sync.RWMutex
+	claimArgsForCall []struct {
+		cache     *dbng.UsedWorkerTaskCache
+		container dbng.CreatingContainer
+	}
+	claimReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWorkerTaskCacheFactory) FindOrCreate(cache dbng.WorkerTaskCache) (*dbng.UsedWorkerTaskCache, error) {
+	fake.findOrCreateMutex.Lock()
+	fake.findOrCreateArgsForCall = append(fake.findOrCreateArgsForCall, struct {
+		cache dbng.WorkerTaskCache
+	}{cache})
+	fake.recordInvocation("FindOrCreate", []interface{}{cache})
+	fake.findOrCreateMutex.Unlock()
+	if fake.FindOrCreateStub != nil {
+		return fake.FindOrCreateStub(cache)
+	} else {
+		return fake.findOrCreateReturns.result1, fake.findOrCreateReturns.result2
+	}
+}
+
+func (fake *FakeWorkerTaskCacheFactory) FindOrCreateCallCount() int {
+	fake.findOrCreateMutex.RLock()
+	defer fake.findOrCreateMutex.RUnlock()
+	return len(fake.findOrCreateArgsForCall)
+}
+
+func (fake *FakeWorkerTaskCacheFactory) FindOrCreateArgsForCall(i int) dbng.WorkerTaskCache {
+	fake.findOrCreateMutex.RLock()
+	defer fake.findOrCreateMutex.RUnlock()
+	return fake.findOrCreateArgsForCall[i].cache
+}
+
+func (fake *FakeWorkerTaskCacheFactory) FindOrCreateReturns(result1 *dbng.UsedWorkerTaskCache, result2 error) {
+	fake.FindOrCreateStub = nil
+	fake.findOrCreateReturns = struct {
+		result1 *dbng.UsedWorkerTaskCache
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerTaskCacheFactory) CleanUpForInactiveJobs() error {
+	fake.cleanUpForInactiveJobsMutex.Lock()
+	fake.cleanUpForInactiveJobsArgsForCall = append(fake.cleanUpForInactiveJobsArgsForCall, struct{}{})
+	fake.recordInvocation("CleanUpForInactiveJobs", []interface{}{})
+	fake.cleanUpForInactiveJobsMutex.Unlock()
+	if fake.CleanUpForInactiveJobsStub != nil {
+		return fake.CleanUpForInactiveJobsStub()
+	} else {
+		return fake.cleanUpForInactiveJobsReturns.result1
+	}
+}
+
+func (fake *FakeWorkerTaskCacheFactory) CleanUpForInactiveJobsCallCount() int {
+	fake.cleanUpForInactiveJobsMutex.RLock()
+	defer fake.cleanUpForInactiveJobsMutex.RUnlock()
+	return len(fake.cleanUpForInactiveJobsArgsForCall)
+}
+
+func (fake *FakeWorkerTaskCacheFactory) CleanUpForInactiveJobsReturns(result1 error) {
+	fake.CleanUpForInactiveJobsStub = nil
+	fake.cleanUpForInactiveJobsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerTaskCacheFactory) Claim(cache *dbng.UsedWorkerTaskCache, container dbng.CreatingContainer) (bool, error) {
+	fake.claimMutex.Lock()
+	fake.claimArgsForCall = append(fake.claimArgsForCall, struct {
+		cache     *dbng.UsedWorkerTaskCache
+		container dbng.CreatingContainer
+	}{cache, container})
+	fake.recordInvocation("Claim", []interface{}{cache, container})
+	fake.claimMutex.Unlock()
+	if fake.ClaimStub != nil {
+		return fake.ClaimStub(cache, container)
+	} else {
+		return fake.claimReturns.result1, fake.claimReturns.result2
+	}
+}
+
+func (fake *FakeWorkerTaskCacheFactory) ClaimCallCount() int {
+	fake.claimMutex.RLock()
+	defer fake.claimMutex.RUnlock()
+	return len(fake.claimArgsForCall)
+}
+
+func (fake *FakeWorkerTaskCacheFactory) ClaimArgsForCall(i int) (*dbng.UsedWorkerTaskCache, dbng.CreatingContainer) {
+	fake.claimMutex.RLock()
+	defer fake.claimMutex.RUnlock()
+	return fake.claimArgsForCall[i].cache, fake.claimArgsForCall[i].container
+}
+
+func (fake *FakeWorkerTaskCacheFactory) ClaimReturns(result1 bool, result2 error) {
+	fake.ClaimStub = nil
+	fake.claimReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerTaskCacheFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findOrCreateMutex.RLock()
+	defer fake.findOrCreateMutex.RUnlock()
+	fake.cleanUpForInactiveJobsMutex.RLock()
+	defer fake.cleanUpForInactiveJobsMutex.RUnlock()
+	fake.claimMutex.RLock()
+	defer fake.claimMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeWorkerTaskCacheFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ dbng.WorkerTaskCacheFactory = new(FakeWorkerTaskCacheFactory)
@@ -15,6 +15,8 @@ func (p *pipeline) AcquireResourceCheckingLock(
 	interval time.Duration,
 	immediate bool,
 ) (lock.Lock, bool, error) {
+	// the checking lock only needs to be shared by resources with the same
+	// source, so the required base resource type versions are not considered
 	resourceConfig, err := findOrCreateResourceConfigForResource(
 		p.conn,
 		p.lockFactory,
@@ -24,6 +26,7 @@ func (p *pipeline) AcquireResourceCheckingLock(
 		resource.Source,
 		p.id,
 		resourceTypes,
+		nil,
 	)
 	if err != nil {
 		return nil, false, err
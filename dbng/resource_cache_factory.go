@@ -54,12 +54,16 @@ type ResourceCacheFactory interface {
 type resourceCacheFactory struct {
 	conn        Conn
 	lockFactory lock.LockFactory
+
+	baseResourceTypeVersions atc.BaseResourceTypeVersions
 }
 
-func NewResourceCacheFactory(conn Conn, lockFactory lock.LockFactory) ResourceCacheFactory {
+func NewResourceCacheFactory(conn Conn, lockFactory lock.LockFactory, baseResourceTypeVersions atc.BaseResourceTypeVersions) ResourceCacheFactory {
 	return &resourceCacheFactory{
 		conn:        conn,
 		lockFactory: lockFactory,
+
+		baseResourceTypeVersions: baseResourceTypeVersions,
 	}
 }
 
@@ -80,7 +84,7 @@ func (f *resourceCacheFactory) FindOrCreateResourceCacheForBuild(
 
 	defer tx.Rollback()
 
-	resourceConfig, err := constructResourceConfig(tx, resourceTypeName, source, resourceTypes, pipelineID)
+	resourceConfig, err := constructResourceConfig(tx, resourceTypeName, source, resourceTypes, pipelineID, f.baseResourceTypeVersions)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +136,7 @@ func (f *resourceCacheFactory) FindOrCreateResourceCacheForResource(
 
 	defer tx.Rollback()
 
-	resourceConfig, err := constructResourceConfig(tx, resourceTypeName, source, resourceTypes, pipelineID)
+	resourceConfig, err := constructResourceConfig(tx, resourceTypeName, source, resourceTypes, pipelineID, f.baseResourceTypeVersions)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +206,7 @@ func (f *resourceCacheFactory) FindOrCreateResourceCacheForResourceType(
 		return nil, ErrResourceTypeNotFound{resourceTypeName}
 	}
 
-	resourceConfig, err := constructResourceConfig(tx, resourceType.Name, source, resourceTypes, pipelineID)
+	resourceConfig, err := constructResourceConfig(tx, resourceType.Name, source, resourceTypes, pipelineID, f.baseResourceTypeVersions)
 	if err != nil {
 		return nil, err
 	}
@@ -189,6 +189,40 @@ var _ = Describe("ResourceCacheFactory", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(Equal(dbng.ErrBaseResourceTypeNotFound))
 		})
+
+		Context("when the required version of the base resource type changes", func() {
+			var findOrCreate func(dbng.ResourceCacheFactory) *dbng.UsedResourceCache
+
+			BeforeEach(func() {
+				findOrCreate = func(factory dbng.ResourceCacheFactory) *dbng.UsedResourceCache {
+					usedResourceCache, err := factory.FindOrCreateResourceCacheForBuild(
+						logger,
+						defaultBuild.ID(),
+						"some-type",
+						atc.Version{"some": "version"},
+						atc.Source{"some": "source"},
+						atc.Params{"some": "params"},
+						defaultPipeline.ID(),
+						atc.ResourceTypes{resourceType1, resourceType2},
+					)
+					Expect(err).NotTo(HaveOccurred())
+					return usedResourceCache
+				}
+			})
+
+			It("creates a new resource cache for the new version", func() {
+				unversionedCache := findOrCreate(resourceCacheFactory)
+
+				versionedFactory := dbng.NewResourceCacheFactory(dbConn, lockFactory, atc.BaseResourceTypeVersions{
+					"some-base-type": "some-required-version",
+				})
+
+				versionedCache := findOrCreate(versionedFactory)
+				Expect(versionedCache.ID).NotTo(Equal(unversionedCache.ID))
+
+				Expect(findOrCreate(versionedFactory).ID).To(Equal(versionedCache.ID))
+			})
+		})
 	})
 })
 
@@ -189,10 +189,12 @@ func (resourceConfig ResourceConfig) findOrCreate(logger lager.Logger, tx Tx, lo
 			Columns(
 				parentColumnName,
 				"source_hash",
+				"base_resource_type_version",
 			).
 			Values(
 				parentID,
 				mapHash(resourceConfig.Source),
+				resourceConfig.baseResourceTypeVersion(),
 			).
 			Suffix("RETURNING id").
 			RunWith(tx).
@@ -264,8 +266,9 @@ func (resourceConfig ResourceConfig) lockName() (string, error) {
 func (resourceConfig ResourceConfig) findWithParentID(tx Tx, parentColumnName string, parentID int) (int, bool, error) {
 	var id int
 	err := psql.Select("id").From("resource_configs").Where(sq.Eq{
-		parentColumnName: parentID,
-		"source_hash":    mapHash(resourceConfig.Source),
+		parentColumnName:             parentID,
+		"source_hash":                mapHash(resourceConfig.Source),
+		"base_resource_type_version": resourceConfig.baseResourceTypeVersion(),
 	}).RunWith(tx).QueryRow().Scan(&id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -277,3 +280,11 @@ func (resourceConfig ResourceConfig) findWithParentID(tx Tx, parentColumnName st
 
 	return id, true, nil
 }
+
+func (resourceConfig ResourceConfig) baseResourceTypeVersion() string {
+	if resourceConfig.CreatedByBaseResourceType == nil {
+		return ""
+	}
+
+	return resourceConfig.CreatedByBaseResourceType.Version
+}
@@ -45,12 +45,16 @@ type ResourceConfigFactory interface {
 type resourceConfigFactory struct {
 	conn        Conn
 	lockFactory lock.LockFactory
+
+	baseResourceTypeVersions atc.BaseResourceTypeVersions
 }
 
-func NewResourceConfigFactory(conn Conn, lockFactory lock.LockFactory) ResourceConfigFactory {
+func NewResourceConfigFactory(conn Conn, lockFactory lock.LockFactory, baseResourceTypeVersions atc.BaseResourceTypeVersions) ResourceConfigFactory {
 	return &resourceConfigFactory{
 		conn:        conn,
 		lockFactory: lockFactory,
+
+		baseResourceTypeVersions: baseResourceTypeVersions,
 	}
 }
 
@@ -69,7 +73,7 @@ func (f *resourceConfigFactory) FindOrCreateResourceConfigForBuild(
 
 	defer tx.Rollback()
 
-	resourceConfig, err := constructResourceConfig(tx, resourceType, source, resourceTypes, pipelineID)
+	resourceConfig, err := constructResourceConfig(tx, resourceType, source, resourceTypes, pipelineID, f.baseResourceTypeVersions)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +120,7 @@ func (f *resourceConfigFactory) FindOrCreateResourceConfigForResource(
 		source,
 		pipelineID,
 		resourceTypes,
+		f.baseResourceTypeVersions,
 	)
 }
 
@@ -138,7 +143,7 @@ func (f *resourceConfigFactory) FindOrCreateResourceConfigForResourceType(
 
 	defer tx.Rollback()
 
-	resourceConfig, err := constructResourceConfig(tx, resourceTypeName, source, resourceTypes, pipelineID)
+	resourceConfig, err := constructResourceConfig(tx, resourceTypeName, source, resourceTypes, pipelineID, f.baseResourceTypeVersions)
 	if err != nil {
 		return nil, err
 	}
@@ -187,6 +192,7 @@ func constructResourceConfig(
 	source atc.Source,
 	resourceTypes []atc.ResourceType,
 	pipelineID int,
+	baseResourceTypeVersions atc.BaseResourceTypeVersions,
 ) (ResourceConfig, error) {
 	resourceConfig := ResourceConfig{
 		Source: source,
@@ -195,7 +201,8 @@ func constructResourceConfig(
 	resourceTypesList := resourceTypesList(resourceType, resourceTypes, []atc.ResourceType{})
 	if len(resourceTypesList) == 0 {
 		resourceConfig.CreatedByBaseResourceType = &BaseResourceType{
-			Name: resourceType,
+			Name:    resourceType,
+			Version: baseResourceTypeVersions[resourceType],
 		}
 	} else {
 		lastResourceType := resourceTypesList[len(resourceTypesList)-1]
@@ -213,7 +220,8 @@ func constructResourceConfig(
 		parentResourceCache := &ResourceCache{
 			ResourceConfig: ResourceConfig{
 				CreatedByBaseResourceType: &BaseResourceType{
-					Name: lastResourceType.Type,
+					Name:    lastResourceType.Type,
+					Version: baseResourceTypeVersions[lastResourceType.Type],
 				},
 				Source: lastResourceType.Source,
 			},
@@ -402,6 +410,7 @@ func findOrCreateResourceConfigForResource(
 	source atc.Source,
 	pipelineID int,
 	resourceTypes atc.ResourceTypes,
+	baseResourceTypeVersions atc.BaseResourceTypeVersions,
 ) (*UsedResourceConfig, error) {
 	tx, err := conn.Begin()
 	if err != nil {
@@ -410,7 +419,7 @@ func findOrCreateResourceConfigForResource(
 
 	defer tx.Rollback()
 
-	resourceConfig, err := constructResourceConfig(tx, resourceType, source, resourceTypes, pipelineID)
+	resourceConfig, err := constructResourceConfig(tx, resourceType, source, resourceTypes, pipelineID, baseResourceTypeVersions)
 	if err != nil {
 		return nil, err
 	}
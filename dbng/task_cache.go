@@ -0,0 +1,36 @@
+package dbng
+
+import (
+	"errors"
+)
+
+var ErrTaskCacheAlreadyExists = errors.New("task-cache-already-exists")
+
+// TaskCache represents the outputs of a task step that ran with a given cache
+// key, which is derived from the task's config and the versions of its
+// inputs.
+//
+// A TaskCache is created by a task step configured with `cache_outputs`, once
+// it has succeeded.
+//
+// TaskCaches are garbage-collected by gcng.TaskCacheCollector.
+type TaskCache struct {
+	TeamID     int    // The team that ran the task.
+	PipelineID int    // The pipeline of the job that ran the task, if any.
+	JobName    string // The job that ran the task, if any.
+	StepName   string // The name of the task step.
+	Key        string // The hash of the task's config and inputs.
+}
+
+// UsedTaskCache is created whenever a TaskCache is Created and/or Used by a
+// build.
+//
+// So long as the build is running, the TaskCache and its output volumes can
+// not be removed. Once no longer used, only the most recent TaskCache for
+// each task step is kept.
+type UsedTaskCache struct {
+	ID         int
+	Key        string
+	WorkerName string
+	Outputs    map[string]string // Volume handles, keyed by output name.
+}
@@ -0,0 +1,271 @@
+package dbng
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+//go:generate counterfeiter . TaskCacheFactory
+
+type TaskCacheFactory interface {
+	FindTaskCacheForBuild(buildID int, cache TaskCache) (*UsedTaskCache, bool, error)
+	CreateTaskCacheForBuild(buildID int, cache TaskCache, workerName string, outputs map[string]string) (*UsedTaskCache, error)
+
+	CleanUsesForFinishedBuilds() error
+	CleanUpInvalidCaches() error
+}
+
+type taskCacheFactory struct {
+	conn Conn
+}
+
+func NewTaskCacheFactory(conn Conn) TaskCacheFactory {
+	return &taskCacheFactory{
+		conn: conn,
+	}
+}
+
+// FindTaskCacheForBuild looks up the outputs of a previous run of a task with
+// the same cache key, and marks them as used by the build.
+func (f *taskCacheFactory) FindTaskCacheForBuild(buildID int, cache TaskCache) (*UsedTaskCache, bool, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer tx.Rollback()
+
+	usedTaskCache := &UsedTaskCache{
+		Key:     cache.Key,
+		Outputs: map[string]string{},
+	}
+
+	err = psql.Select("id", "worker_name").
+		From("task_caches").
+		Where(sq.Eq{
+			"team_id":   cache.TeamID,
+			"cache_key": cache.Key,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&usedTaskCache.ID, &usedTaskCache.WorkerName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	rows, err := psql.Select("handle", "task_cache_output").
+		From("volumes").
+		Where(sq.Eq{
+			"task_cache_id": usedTaskCache.ID,
+			"state":         VolumeStateCreated,
+		}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var handle, outputName string
+		err = rows.Scan(&handle, &outputName)
+		if err != nil {
+			return nil, false, err
+		}
+
+		usedTaskCache.Outputs[outputName] = handle
+	}
+
+	err = f.use(tx, usedTaskCache.ID, buildID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return usedTaskCache, true, nil
+}
+
+// CreateTaskCacheForBuild records the output volumes of a task that succeeded
+// under the given cache key, so that they outlive the task's container and
+// can be reused by later runs.
+//
+// If another build has already created a cache for the same key,
+// ErrTaskCacheAlreadyExists is returned.
+func (f *taskCacheFactory) CreateTaskCacheForBuild(buildID int, cache TaskCache, workerName string, outputs map[string]string) (*UsedTaskCache, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	var pipelineID sql.NullInt64
+	if cache.PipelineID != 0 {
+		pipelineID = sql.NullInt64{Int64: int64(cache.PipelineID), Valid: true}
+	}
+
+	var id int
+	err = psql.Insert("task_caches").
+		Columns(
+			"team_id",
+			"pipeline_id",
+			"job_name",
+			"step_name",
+			"cache_key",
+			"worker_name",
+		).
+		Values(
+			cache.TeamID,
+			pipelineID,
+			cache.JobName,
+			cache.StepName,
+			cache.Key,
+			workerName,
+		).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return nil, ErrTaskCacheAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	for outputName, handle := range outputs {
+		result, err := psql.Update("volumes").
+			Set("task_cache_id", id).
+			Set("task_cache_output", outputName).
+			Where(sq.Eq{
+				"handle":      handle,
+				"worker_name": workerName,
+				"state":       VolumeStateCreated,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+
+		if affected == 0 {
+			return nil, ErrVolumeMissing
+		}
+	}
+
+	err = f.use(tx, id, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UsedTaskCache{
+		ID:         id,
+		Key:        cache.Key,
+		WorkerName: workerName,
+		Outputs:    outputs,
+	}, nil
+}
+
+func (f *taskCacheFactory) CleanUsesForFinishedBuilds() error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = psql.Delete("task_cache_uses tcu USING builds b").
+		Where(sq.And{
+			sq.Expr("tcu.build_id = b.id"),
+			sq.NotEq{
+				"b.status": []string{"pending", "started"},
+			},
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CleanUpInvalidCaches removes every unused task cache other than the most
+// recent one for each task step. Their volumes are then orphaned and
+// collected by gcng.VolumeCollector.
+func (f *taskCacheFactory) CleanUpInvalidCaches() error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stillInUseCacheIds, _, err := sq.
+		Select("tcu.task_cache_id").
+		Distinct().
+		From("task_cache_uses tcu").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	latestCacheIds, _, err := sq.
+		Select("MAX(tc.id)").
+		From("task_caches tc").
+		GroupBy("tc.team_id", "tc.pipeline_id", "tc.job_name", "tc.step_name").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = sq.Delete("task_caches").
+		Where("id NOT IN (" + stillInUseCacheIds + ")").
+		Where("id NOT IN (" + latestCacheIds + ")").
+		PlaceholderFormat(sq.Dollar).
+		RunWith(tx).Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (f *taskCacheFactory) use(tx Tx, taskCacheID int, buildID int) error {
+	_, err := psql.Insert("task_cache_uses").
+		Columns("task_cache_id", "build_id").
+		Values(taskCacheID, buildID).
+		RunWith(tx).
+		Exec()
+	return err
+}
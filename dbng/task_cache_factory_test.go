@@ -0,0 +1,172 @@
+package dbng_test
+
+import (
+	"github.com/concourse/atc/dbng"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskCacheFactory", func() {
+	var (
+		build        dbng.Build
+		outputVolume dbng.CreatedVolume
+		container    dbng.CreatingContainer
+		taskCache    dbng.TaskCache
+	)
+
+	BeforeEach(func() {
+		build, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		container, err = defaultTeam.CreateBuildContainer(defaultWorker, build.ID(), "some-plan", dbng.ContainerMetadata{
+			Type: "task",
+			Name: "some-task",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		creatingVolume, err := volumeFactory.CreateContainerVolume(defaultTeam.ID(), defaultWorker, container, "/tmp/build/some-output")
+		Expect(err).NotTo(HaveOccurred())
+
+		outputVolume, err = creatingVolume.Created()
+		Expect(err).NotTo(HaveOccurred())
+
+		taskCache = dbng.TaskCache{
+			TeamID:   defaultTeam.ID(),
+			StepName: "some-task",
+			Key:      "some-key",
+		}
+	})
+
+	Describe("FindTaskCacheForBuild", func() {
+		Context("when no task has run with the key", func() {
+			It("returns false", func() {
+				_, found, err := taskCacheFactory.FindTaskCacheForBuild(build.ID(), taskCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when a task cache has been created with the key", func() {
+			BeforeEach(func() {
+				_, err := taskCacheFactory.CreateTaskCacheForBuild(build.ID(), taskCache, defaultWorker.Name, map[string]string{
+					"some-output": outputVolume.Handle(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the cached outputs", func() {
+				usedTaskCache, found, err := taskCacheFactory.FindTaskCacheForBuild(build.ID(), taskCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(usedTaskCache.WorkerName).To(Equal(defaultWorker.Name))
+				Expect(usedTaskCache.Outputs).To(Equal(map[string]string{
+					"some-output": outputVolume.Handle(),
+				}))
+			})
+
+			It("does not return it for another team", func() {
+				otherTeam, err := teamFactory.CreateTeam("some-other-team")
+				Expect(err).NotTo(HaveOccurred())
+
+				taskCache.TeamID = otherTeam.ID()
+
+				_, found, err := taskCacheFactory.FindTaskCacheForBuild(build.ID(), taskCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("keeps the output volumes from being orphaned once the container is gone", func() {
+				createdContainer, err := container.Created()
+				Expect(err).NotTo(HaveOccurred())
+				destroyingContainer, err := createdContainer.Destroying()
+				Expect(err).NotTo(HaveOccurred())
+				destroyed, err := destroyingContainer.Destroy()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destroyed).To(BeTrue())
+
+				createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+				Expect(err).NotTo(HaveOccurred())
+				for _, volume := range createdVolumes {
+					Expect(volume.Handle()).NotTo(Equal(outputVolume.Handle()))
+				}
+			})
+		})
+	})
+
+	Describe("CreateTaskCacheForBuild", func() {
+		Context("when a task cache already exists with the key", func() {
+			BeforeEach(func() {
+				_, err := taskCacheFactory.CreateTaskCacheForBuild(build.ID(), taskCache, defaultWorker.Name, map[string]string{
+					"some-output": outputVolume.Handle(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns ErrTaskCacheAlreadyExists", func() {
+				_, err := taskCacheFactory.CreateTaskCacheForBuild(build.ID(), taskCache, defaultWorker.Name, map[string]string{})
+				Expect(err).To(Equal(dbng.ErrTaskCacheAlreadyExists))
+			})
+		})
+
+		Context("when an output volume does not exist", func() {
+			It("returns ErrVolumeMissing", func() {
+				_, err := taskCacheFactory.CreateTaskCacheForBuild(build.ID(), taskCache, defaultWorker.Name, map[string]string{
+					"some-output": "bogus-handle",
+				})
+				Expect(err).To(Equal(dbng.ErrVolumeMissing))
+			})
+		})
+	})
+
+	Describe("CleanUpInvalidCaches", func() {
+		var newerTaskCache dbng.TaskCache
+
+		BeforeEach(func() {
+			_, err := taskCacheFactory.CreateTaskCacheForBuild(build.ID(), taskCache, defaultWorker.Name, map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			newerTaskCache = taskCache
+			newerTaskCache.Key = "some-newer-key"
+
+			_, err = taskCacheFactory.CreateTaskCacheForBuild(build.ID(), newerTaskCache, defaultWorker.Name, map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("while the build that used them is running", func() {
+			It("keeps both caches", func() {
+				err := taskCacheFactory.CleanUsesForFinishedBuilds()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = taskCacheFactory.CleanUpInvalidCaches()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := taskCacheFactory.FindTaskCacheForBuild(build.ID(), taskCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("once the build has finished", func() {
+			BeforeEach(func() {
+				err := build.Finish(dbng.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("removes all but the most recent cache for the step", func() {
+				err := taskCacheFactory.CleanUsesForFinishedBuilds()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = taskCacheFactory.CleanUpInvalidCaches()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := taskCacheFactory.FindTaskCacheForBuild(build.ID(), taskCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				_, found, err = taskCacheFactory.FindTaskCacheForBuild(build.ID(), newerTaskCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/lock"
+	"github.com/concourse/atc/event"
 	"github.com/lib/pq"
 	uuid "github.com/nu7hatch/gouuid"
 )
@@ -500,6 +501,25 @@ func (t *team) SavePipeline(
 		}
 	}
 
+	scope := ClusterEventScope{TeamID: t.id, PipelineName: pipelineName}
+
+	err = SaveClusterEvent(tx, scope, event.PipelineConfigSaved{
+		Created: created,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch pausedState {
+	case PipelinePaused:
+		err = SaveClusterEvent(tx, scope, event.PipelinePaused{})
+	case PipelineUnpaused:
+		err = SaveClusterEvent(tx, scope, event.PipelineUnpaused{})
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, false, err
@@ -19,6 +19,51 @@ var _ = Describe("Team", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	Describe("SavePipeline", func() {
+		pipelineEvents := func(pipelineName string) []string {
+			rows, err := dbConn.Query(`
+				SELECT type
+				FROM cluster_events
+				WHERE pipeline_name = $1
+				ORDER BY id ASC
+			`, pipelineName)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer rows.Close()
+
+			types := []string{}
+			for rows.Next() {
+				var t string
+				err := rows.Scan(&t)
+				Expect(err).NotTo(HaveOccurred())
+
+				types = append(types, t)
+			}
+
+			return types
+		}
+
+		It("records the config being saved and the pipeline being paused", func() {
+			_, _, err := otherTeam.SavePipeline("paused-pipeline", atc.Config{}, dbng.ConfigVersion(0), dbng.PipelineNoChange)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(pipelineEvents("paused-pipeline")).To(Equal([]string{
+				"pipeline-config-saved",
+				"pipeline-paused",
+			}))
+		})
+
+		It("records the config being saved and the pipeline being unpaused", func() {
+			_, _, err := otherTeam.SavePipeline("unpaused-pipeline", atc.Config{}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(pipelineEvents("unpaused-pipeline")).To(Equal([]string{
+				"pipeline-config-saved",
+				"pipeline-unpaused",
+			}))
+		})
+	})
+
 	Describe("SaveWorker", func() {
 		var (
 			team      dbng.Team
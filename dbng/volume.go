@@ -38,10 +38,12 @@ const (
 type VolumeType string
 
 const (
-	VolumeTypeContainer    = "container"
-	VolumeTypeResource     = "resource"
-	VolumeTypeResourceType = "resource-type"
-	VolumeTypeUknown       = "unknown" // for migration to life
+	VolumeTypeContainer       = "container"
+	VolumeTypeResource        = "resource"
+	VolumeTypeResourceType    = "resource-type"
+	VolumeTypeTaskCache       = "task-cache"
+	VolumeTypeWorkerTaskCache = "worker-task-cache"
+	VolumeTypeUknown          = "unknown" // for migration to life
 )
 
 //go:generate counterfeiter . CreatingVolume
@@ -23,6 +23,9 @@ type VolumeFactory interface {
 	FindResourceCacheInitializedVolume(*Worker, *UsedResourceCache) (CreatedVolume, bool, error)
 	CreateResourceCacheVolume(*Worker, *UsedResourceCache) (CreatingVolume, error)
 
+	FindWorkerTaskCacheVolume(int, *Worker, *UsedWorkerTaskCache) (CreatingVolume, CreatedVolume, error)
+	CreateWorkerTaskCacheVolume(int, *Worker, *UsedWorkerTaskCache) (CreatingVolume, error)
+
 	FindVolumesForContainer(CreatedContainer) ([]CreatedVolume, error)
 	GetOrphanedVolumes() ([]CreatedVolume, []DestroyingVolume, error)
 
@@ -126,6 +129,28 @@ func (factory *volumeFactory) CreateBaseResourceTypeVolume(teamID int, worker *W
 	return volume, nil
 }
 
+func (factory *volumeFactory) CreateWorkerTaskCacheVolume(teamID int, worker *Worker, workerTaskCache *UsedWorkerTaskCache) (CreatingVolume, error) {
+	tx, err := factory.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	volume, err := factory.createVolume(
+		tx,
+		teamID,
+		worker,
+		map[string]interface{}{"worker_task_cache_id": workerTaskCache.ID},
+		VolumeTypeWorkerTaskCache,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return volume, nil
+}
+
 func (factory *volumeFactory) CreateContainerVolume(teamID int, worker *Worker, container CreatingContainer, mountPath string) (CreatingVolume, error) {
 	tx, err := factory.conn.Begin()
 	if err != nil {
@@ -209,6 +234,12 @@ func (factory *volumeFactory) FindResourceCacheVolume(worker *Worker, resourceCa
 	})
 }
 
+func (factory *volumeFactory) FindWorkerTaskCacheVolume(teamID int, worker *Worker, workerTaskCache *UsedWorkerTaskCache) (CreatingVolume, CreatedVolume, error) {
+	return factory.findVolume(teamID, worker, map[string]interface{}{
+		"v.worker_task_cache_id": workerTaskCache.ID,
+	})
+}
+
 func (factory *volumeFactory) FindResourceCacheInitializedVolume(worker *Worker, resourceCache *UsedResourceCache) (CreatedVolume, bool, error) {
 	_, createdVolume, err := factory.findVolume(0, worker, map[string]interface{}{
 		"v.resource_cache_id": resourceCache.ID,
@@ -250,6 +281,8 @@ func (factory *volumeFactory) GetOrphanedVolumes() ([]CreatedVolume, []Destroyin
 			"v.initialized":           true,
 			"v.resource_cache_id":     nil,
 			"v.base_resource_type_id": nil,
+			"v.task_cache_id":         nil,
+			"v.worker_task_cache_id":  nil,
 			"v.container_id":          nil,
 		}).ToSql()
 	if err != nil {
@@ -411,6 +444,8 @@ var volumeColumns = []string{
 	`case when v.container_id is not NULL then 'container'
 	  when v.resource_cache_id is not NULL then 'resource'
 		when v.base_resource_type_id is not NULL then 'resource-type'
+		when v.task_cache_id is not NULL then 'task-cache'
+		when v.worker_task_cache_id is not NULL then 'worker-task-cache'
 		else 'unknown'
 	end`,
 }
@@ -33,6 +33,8 @@ type Worker struct {
 	NoProxy         string
 
 	ActiveContainers int
+	MaxContainers    int
+	UnhealthyReason  string
 	ResourceTypes    []atc.WorkerResourceType
 	Platform         string
 	Tags             []string
@@ -42,3 +44,15 @@ type Worker struct {
 	TeamName  string
 	ExpiresIn time.Duration
 }
+
+// WorkerPin is a build that keeps a landing or retiring worker around, along
+// with the handles of its containers on that worker.
+type WorkerPin struct {
+	BuildID      int
+	BuildName    string
+	JobName      string
+	PipelineName string
+	TeamName     string
+
+	ContainerHandles []string
+}
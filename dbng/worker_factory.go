@@ -23,6 +23,9 @@ type WorkerFactory interface {
 	LandFinishedLandingWorkers() error
 	SaveWorker(worker atc.Worker, ttl time.Duration) (*Worker, error)
 	LandWorker(name string) (*Worker, error)
+	WorkerPins(name string) ([]WorkerPin, error)
+	MarkWorkerUnhealthy(name string, reason string) error
+	MarkWorkerHealthy(name string) error
 	RetireWorker(name string) (*Worker, error)
 	PruneWorker(name string) error
 	DeleteWorker(name string) error
@@ -56,6 +59,8 @@ func (f *workerFactory) GetWorker(name string) (*Worker, bool, error) {
 		w.https_proxy_url,
 		w.no_proxy,
 		w.active_containers,
+		w.max_containers,
+		w.unhealthy_reason,
 		w.resource_types,
 		w.platform,
 		w.tags,
@@ -95,6 +100,8 @@ var workersQuery = psql.Select(`
 		w.https_proxy_url,
 		w.no_proxy,
 		w.active_containers,
+		w.max_containers,
+		w.unhealthy_reason,
 		w.resource_types,
 		w.platform,
 		w.tags,
@@ -160,6 +167,8 @@ func scanWorker(row scannable) (*Worker, error) {
 		noProxy       sql.NullString
 
 		activeContainers int
+		maxContainers    int
+		unhealthyReason  sql.NullString
 		resourceTypes    []byte
 		platform         sql.NullString
 		tags             []byte
@@ -179,6 +188,8 @@ func scanWorker(row scannable) (*Worker, error) {
 		&httpsProxyURL,
 		&noProxy,
 		&activeContainers,
+		&maxContainers,
+		&unhealthyReason,
 		&resourceTypes,
 		&platform,
 		&tags,
@@ -195,6 +206,7 @@ func scanWorker(row scannable) (*Worker, error) {
 		Name:             name,
 		State:            WorkerState(state),
 		ActiveContainers: activeContainers,
+		MaxContainers:    maxContainers,
 		StartTime:        startTime,
 	}
 
@@ -226,6 +238,10 @@ func scanWorker(row scannable) (*Worker, error) {
 		worker.TeamName = teamName.String
 	}
 
+	if unhealthyReason.Valid {
+		worker.UnhealthyReason = unhealthyReason.String
+	}
+
 	if teamID.Valid {
 		worker.TeamID = int(teamID.Int64)
 	}
@@ -634,28 +650,7 @@ func (f *workerFactory) DeleteFinishedRetiringWorkers() error {
 	// First we generate the subquery's SQL and args using
 	// sq.Select instead of psql.Select so that we get
 	// unordered placeholders instead of psql's ordered placeholders
-	subQ, subQArgs, err := sq.Select("w.name").
-		Distinct().
-		From("builds b").
-		Join("containers c ON b.id = c.build_id").
-		Join("workers w ON w.name = c.worker_name").
-		LeftJoin("jobs j ON j.id = b.job_id").
-		Where(sq.Or{
-			sq.Eq{
-				"b.status": string(BuildStatusStarted),
-			},
-			sq.Eq{
-				"b.status": string(BuildStatusPending),
-			},
-		}).
-		Where(sq.Or{
-			sq.Eq{
-				"j.interruptible": false,
-			},
-			sq.Eq{
-				"b.job_id": nil,
-			},
-		}).ToSql()
+	subQ, subQArgs, err := pinningBuilds(sq.Select("w.name").Distinct()).ToSql()
 
 	if err != nil {
 		return err
@@ -696,28 +691,7 @@ func (f *workerFactory) LandFinishedLandingWorkers() error {
 	}
 	defer tx.Rollback()
 
-	subQ, subQArgs, err := sq.Select("w.name").
-		Distinct().
-		From("builds b").
-		Join("containers c ON b.id = c.build_id").
-		Join("workers w ON w.name = c.worker_name").
-		LeftJoin("jobs j ON j.id = b.job_id").
-		Where(sq.Or{
-			sq.Eq{
-				"b.status": string(BuildStatusStarted),
-			},
-			sq.Eq{
-				"b.status": string(BuildStatusPending),
-			},
-		}).
-		Where(sq.Or{
-			sq.Eq{
-				"j.interruptible": false,
-			},
-			sq.Eq{
-				"b.job_id": nil,
-			},
-		}).ToSql()
+	subQ, subQArgs, err := pinningBuilds(sq.Select("w.name").Distinct()).ToSql()
 
 	if err != nil {
 		return err
@@ -747,6 +721,111 @@ func (f *workerFactory) LandFinishedLandingWorkers() error {
 	return nil
 }
 
+func (f *workerFactory) MarkWorkerUnhealthy(name string, reason string) error {
+	return f.saveUnhealthyReason(name, &reason)
+}
+
+func (f *workerFactory) MarkWorkerHealthy(name string) error {
+	return f.saveUnhealthyReason(name, nil)
+}
+
+func (f *workerFactory) saveUnhealthyReason(name string, reason *string) error {
+	result, err := psql.Update("workers").
+		Set("unhealthy_reason", reason).
+		Where(sq.Eq{"name": name}).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrWorkerNotPresent
+	}
+
+	return nil
+}
+
+func (f *workerFactory) WorkerPins(name string) ([]WorkerPin, error) {
+	rows, err := pinningBuilds(psql.Select("b.id", "b.name", "j.name", "p.name", "t.name", "c.handle")).
+		LeftJoin("pipelines p ON p.id = j.pipeline_id").
+		Join("teams t ON t.id = b.team_id").
+		Where(sq.Eq{"w.name": name}).
+		OrderBy("b.id", "c.handle").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	pins := []WorkerPin{}
+
+	for rows.Next() {
+		var (
+			buildID      int
+			buildName    string
+			jobName      sql.NullString
+			pipelineName sql.NullString
+			teamName     string
+			handle       string
+		)
+
+		err = rows.Scan(&buildID, &buildName, &jobName, &pipelineName, &teamName, &handle)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(pins) == 0 || pins[len(pins)-1].BuildID != buildID {
+			pins = append(pins, WorkerPin{
+				BuildID:      buildID,
+				BuildName:    buildName,
+				JobName:      jobName.String,
+				PipelineName: pipelineName.String,
+				TeamName:     teamName,
+			})
+		}
+
+		pin := &pins[len(pins)-1]
+		pin.ContainerHandles = append(pin.ContainerHandles, handle)
+	}
+
+	return pins, nil
+}
+
+// pinningBuilds restricts the given query to the containers of builds that a
+// landing or retiring worker has to wait for: pending or started builds that
+// are either one-off or belong to a job that is not interruptible.
+func pinningBuilds(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.
+		From("builds b").
+		Join("containers c ON b.id = c.build_id").
+		Join("workers w ON w.name = c.worker_name").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		Where(sq.Or{
+			sq.Eq{
+				"b.status": string(BuildStatusStarted),
+			},
+			sq.Eq{
+				"b.status": string(BuildStatusPending),
+			},
+		}).
+		Where(sq.Or{
+			sq.Eq{
+				"j.interruptible": false,
+			},
+			sq.Eq{
+				"b.job_id": nil,
+			},
+		})
+}
+
 func (f *workerFactory) SaveWorker(worker atc.Worker, ttl time.Duration) (*Worker, error) {
 	tx, err := f.conn.Begin()
 	if err != nil {
@@ -804,6 +883,7 @@ func saveWorker(tx Tx, worker atc.Worker, teamID *int, ttl time.Duration) (*Work
 					"addr",
 					"expires",
 					"active_containers",
+					"max_containers",
 					"resource_types",
 					"tags",
 					"platform",
@@ -820,6 +900,7 @@ func saveWorker(tx Tx, worker atc.Worker, teamID *int, ttl time.Duration) (*Work
 					worker.GardenAddr,
 					sq.Expr(expires),
 					worker.ActiveContainers,
+					worker.MaxContainers,
 					resourceTypes,
 					tags,
 					worker.Platform,
@@ -851,6 +932,7 @@ func saveWorker(tx Tx, worker atc.Worker, teamID *int, ttl time.Duration) (*Work
 			Set("addr", worker.GardenAddr).
 			Set("expires", sq.Expr(expires)).
 			Set("active_containers", worker.ActiveContainers).
+			Set("max_containers", worker.MaxContainers).
 			Set("resource_types", resourceTypes).
 			Set("tags", tags).
 			Set("platform", worker.Platform).
@@ -28,6 +28,7 @@ var _ = Describe("WorkerFactory", func() {
 			HTTPSProxyURL:    "some-https-proxy-url",
 			NoProxy:          "some-no-proxy",
 			ActiveContainers: 140,
+			MaxContainers:    250,
 			ResourceTypes: []atc.WorkerResourceType{
 				{
 					Type:    "some-resource-type",
@@ -191,6 +192,7 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.HTTPSProxyURL).To(Equal("some-https-proxy-url"))
 				Expect(foundWorker.NoProxy).To(Equal("some-no-proxy"))
 				Expect(foundWorker.ActiveContainers).To(Equal(140))
+				Expect(foundWorker.MaxContainers).To(Equal(250))
 				Expect(foundWorker.ResourceTypes).To(Equal([]atc.WorkerResourceType{
 					{
 						Type:    "some-resource-type",
@@ -270,6 +272,7 @@ var _ = Describe("WorkerFactory", func() {
 						HTTPSProxyURL:    "some-https-proxy-url",
 						NoProxy:          "some-no-proxy",
 						ActiveContainers: 140,
+						MaxContainers:    250,
 						ResourceTypes: []atc.WorkerResourceType{
 							{
 								Type:    "some-resource-type",
@@ -295,6 +298,7 @@ var _ = Describe("WorkerFactory", func() {
 						HTTPSProxyURL:    "some-https-proxy-url",
 						NoProxy:          "some-no-proxy",
 						ActiveContainers: 140,
+						MaxContainers:    250,
 						ResourceTypes: []atc.WorkerResourceType{
 							{
 								Type:    "some-resource-type",
@@ -767,6 +771,140 @@ var _ = Describe("WorkerFactory", func() {
 		})
 	})
 
+	Describe("WorkerPins", func() {
+		var (
+			dbWorker *dbng.Worker
+			pipeline dbng.Pipeline
+			pins     []dbng.WorkerPin
+			pinsErr  error
+		)
+
+		BeforeEach(func() {
+			var err error
+			dbWorker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			var created bool
+			pipeline, created, err = defaultTeam.SavePipeline("some-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:          "some-job",
+						Interruptible: false,
+					},
+					{
+						Name:          "some-interruptible-job",
+						Interruptible: true,
+					},
+				},
+			}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created).To(BeTrue())
+		})
+
+		JustBeforeEach(func() {
+			pins, pinsErr = workerFactory.WorkerPins(atcWorker.Name)
+		})
+
+		Context("when the worker has no containers", func() {
+			It("returns no pins", func() {
+				Expect(pinsErr).NotTo(HaveOccurred())
+				Expect(pins).To(BeEmpty())
+			})
+		})
+
+		Context("when the worker has containers for running builds", func() {
+			var (
+				pinnedHandles []string
+			)
+
+			BeforeEach(func() {
+				build, err := pipeline.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = build.SaveStatus(dbng.BuildStatusStarted)
+				Expect(err).NotTo(HaveOccurred())
+
+				pinnedHandles = []string{}
+				for _, planID := range []atc.PlanID{"1", "2"} {
+					container, err := defaultTeam.CreateBuildContainer(dbWorker, build.ID(), planID, dbng.ContainerMetadata{})
+					Expect(err).NotTo(HaveOccurred())
+
+					pinnedHandles = append(pinnedHandles, container.Handle())
+				}
+
+				interruptibleBuild, err := pipeline.CreateJobBuild("some-interruptible-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = interruptibleBuild.SaveStatus(dbng.BuildStatusStarted)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = defaultTeam.CreateBuildContainer(dbWorker, interruptibleBuild.ID(), atc.PlanID("3"), dbng.ContainerMetadata{})
+				Expect(err).NotTo(HaveOccurred())
+
+				finishedBuild, err := pipeline.CreateJobBuild("some-job")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = finishedBuild.Finish(dbng.BuildStatusSucceeded)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = defaultTeam.CreateBuildContainer(dbWorker, finishedBuild.ID(), atc.PlanID("4"), dbng.ContainerMetadata{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns only the builds the worker has to wait for, with their containers", func() {
+				Expect(pinsErr).NotTo(HaveOccurred())
+				Expect(pins).To(HaveLen(1))
+				Expect(pins[0].BuildName).To(Equal("1"))
+				Expect(pins[0].JobName).To(Equal("some-job"))
+				Expect(pins[0].PipelineName).To(Equal("some-pipeline"))
+				Expect(pins[0].TeamName).To(Equal("default-team"))
+				Expect(pins[0].ContainerHandles).To(ConsistOf(pinnedHandles))
+			})
+		})
+	})
+
+	Describe("MarkWorkerUnhealthy", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves the reason on the worker", func() {
+			err := workerFactory.MarkWorkerUnhealthy(atcWorker.Name, "garden: connection refused")
+			Expect(err).NotTo(HaveOccurred())
+
+			foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundWorker.UnhealthyReason).To(Equal("garden: connection refused"))
+			Expect(foundWorker.State).To(Equal(dbng.WorkerStateRunning))
+		})
+
+		Context("when the worker becomes healthy again", func() {
+			BeforeEach(func() {
+				err := workerFactory.MarkWorkerUnhealthy(atcWorker.Name, "garden: connection refused")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("clears the reason", func() {
+				err := workerFactory.MarkWorkerHealthy(atcWorker.Name)
+				Expect(err).NotTo(HaveOccurred())
+
+				foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(foundWorker.UnhealthyReason).To(BeEmpty())
+			})
+		})
+
+		Context("when the worker is not present", func() {
+			It("returns an error", func() {
+				err := workerFactory.MarkWorkerUnhealthy("bogus-worker", "nope")
+				Expect(err).To(Equal(dbng.ErrWorkerNotPresent))
+			})
+		})
+	})
+
 	Describe("LandWorker", func() {
 		Context("when the worker is present", func() {
 			BeforeEach(func() {
@@ -0,0 +1,117 @@
+package dbng
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+var ErrWorkerTaskCacheJobNotFound = errors.New("worker-task-cache-job-not-found")
+
+// WorkerTaskCache represents a directory listed in a task's `caches`, kept on
+// a worker and reused by every build of the job's task step that runs there.
+//
+// It is created when a task step with caches first runs on the worker. All
+// creates are upserts.
+//
+// It is removed along with its job or worker, and by
+// gcng.WorkerTaskCacheCollector once the job is no longer in the pipeline.
+type WorkerTaskCache struct {
+	WorkerName string // The worker that holds the cache volume.
+	PipelineID int    // The pipeline of the job running the task.
+	JobName    string // The job running the task.
+	StepName   string // The name of the task step.
+	Path       string // The path of the cache, relative to the task's working directory.
+}
+
+// UsedWorkerTaskCache is returned by FindOrCreate. Its ID is referenced by the
+// cache's volume, which is kept for as long as the WorkerTaskCache exists.
+type UsedWorkerTaskCache struct {
+	ID         int
+	WorkerName string
+}
+
+// FindOrCreate looks for an existing WorkerTaskCache and creates it if it
+// doesn't exist.
+//
+// This method can return ErrSafeRetryFindOrCreate if two concurrent
+// FindOrCreates clashed. The caller should retry from the start of the
+// transaction.
+func (wtc WorkerTaskCache) FindOrCreate(tx Tx) (*UsedWorkerTaskCache, error) {
+	jobID, err := wtc.jobID(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var id int
+	err = psql.Select("id").
+		From("worker_task_caches").
+		Where(sq.Eq{
+			"worker_name": wtc.WorkerName,
+			"job_id":      jobID,
+			"step_name":   wtc.StepName,
+			"path":        wtc.Path,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		err = psql.Insert("worker_task_caches").
+			Columns(
+				"worker_name",
+				"job_id",
+				"step_name",
+				"path",
+			).
+			Values(
+				wtc.WorkerName,
+				jobID,
+				wtc.StepName,
+				wtc.Path,
+			).
+			Suffix("RETURNING id").
+			RunWith(tx).
+			QueryRow().
+			Scan(&id)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+				return nil, ErrSafeRetryFindOrCreate
+			}
+
+			return nil, err
+		}
+	}
+
+	return &UsedWorkerTaskCache{
+		ID:         id,
+		WorkerName: wtc.WorkerName,
+	}, nil
+}
+
+func (wtc WorkerTaskCache) jobID(tx Tx) (int, error) {
+	var jobID int
+	err := psql.Select("id").
+		From("jobs").
+		Where(sq.Eq{
+			"pipeline_id": wtc.PipelineID,
+			"name":        wtc.JobName,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&jobID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrWorkerTaskCacheJobNotFound
+		}
+
+		return 0, err
+	}
+
+	return jobID, nil
+}
@@ -0,0 +1,79 @@
+package dbng
+
+import sq "github.com/Masterminds/squirrel"
+
+//go:generate counterfeiter . WorkerTaskCacheFactory
+
+type WorkerTaskCacheFactory interface {
+	FindOrCreate(cache WorkerTaskCache) (*UsedWorkerTaskCache, error)
+	Claim(cache *UsedWorkerTaskCache, container CreatingContainer) (bool, error)
+
+	CleanUpForInactiveJobs() error
+}
+
+type workerTaskCacheFactory struct {
+	conn Conn
+}
+
+func NewWorkerTaskCacheFactory(conn Conn) WorkerTaskCacheFactory {
+	return &workerTaskCacheFactory{
+		conn: conn,
+	}
+}
+
+func (f *workerTaskCacheFactory) FindOrCreate(cache WorkerTaskCache) (*UsedWorkerTaskCache, error) {
+	var usedWorkerTaskCache *UsedWorkerTaskCache
+
+	err := safeFindOrCreate(f.conn, func(tx Tx) error {
+		var err error
+		usedWorkerTaskCache, err = cache.FindOrCreate(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return usedWorkerTaskCache, nil
+}
+
+// Claim marks the cache as in use by the container, so that builds of the
+// step running at the same time don't write to the same volume. It fails if
+// the cache is held by a container of a build that is still running.
+func (f *workerTaskCacheFactory) Claim(cache *UsedWorkerTaskCache, container CreatingContainer) (bool, error) {
+	result, err := psql.Update("worker_task_caches").
+		Set("container_id", container.ID()).
+		Where(sq.Eq{"id": cache.ID}).
+		Where(sq.Or{
+			sq.Eq{"container_id": nil},
+			sq.Eq{"container_id": container.ID()},
+			sq.Expr(`container_id IN (
+				SELECT c.id
+				FROM containers c
+				JOIN builds b ON b.id = c.build_id
+				WHERE b.completed
+			)`),
+		}).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+// CleanUpForInactiveJobs removes the caches of jobs that have been removed
+// from their pipeline's config. Caches of deleted pipelines go away with
+// their jobs.
+func (f *workerTaskCacheFactory) CleanUpForInactiveJobs() error {
+	_, err := psql.Delete("worker_task_caches").
+		Where(sq.Expr("job_id IN (SELECT id FROM jobs WHERE active = false)")).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
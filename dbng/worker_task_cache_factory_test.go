@@ -0,0 +1,196 @@
+package dbng_test
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerTaskCacheFactory", func() {
+	var workerTaskCache dbng.WorkerTaskCache
+
+	BeforeEach(func() {
+		workerTaskCache = dbng.WorkerTaskCache{
+			WorkerName: defaultWorker.Name,
+			PipelineID: defaultPipeline.ID(),
+			JobName:    "some-job",
+			StepName:   "some-step",
+			Path:       "some-cache",
+		}
+	})
+
+	Describe("FindOrCreate", func() {
+		It("returns the same cache each time", func() {
+			usedWorkerTaskCache, err := workerTaskCacheFactory.FindOrCreate(workerTaskCache)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usedWorkerTaskCache.WorkerName).To(Equal(defaultWorker.Name))
+
+			sameWorkerTaskCache, err := workerTaskCacheFactory.FindOrCreate(workerTaskCache)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sameWorkerTaskCache.ID).To(Equal(usedWorkerTaskCache.ID))
+		})
+
+		It("returns a different cache for another path", func() {
+			usedWorkerTaskCache, err := workerTaskCacheFactory.FindOrCreate(workerTaskCache)
+			Expect(err).NotTo(HaveOccurred())
+
+			workerTaskCache.Path = "some-other-cache"
+
+			otherWorkerTaskCache, err := workerTaskCacheFactory.FindOrCreate(workerTaskCache)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherWorkerTaskCache.ID).NotTo(Equal(usedWorkerTaskCache.ID))
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				workerTaskCache.JobName = "bogus-job"
+			})
+
+			It("returns ErrWorkerTaskCacheJobNotFound", func() {
+				_, err := workerTaskCacheFactory.FindOrCreate(workerTaskCache)
+				Expect(err).To(Equal(dbng.ErrWorkerTaskCacheJobNotFound))
+			})
+		})
+	})
+
+	Describe("Claim", func() {
+		var usedWorkerTaskCache *dbng.UsedWorkerTaskCache
+		var creatingContainer dbng.CreatingContainer
+
+		createBuildContainer := func() (dbng.Build, dbng.CreatingContainer) {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			container, err := defaultTeam.CreateBuildContainer(defaultWorker, build.ID(), atc.PlanID("some-plan"), dbng.ContainerMetadata{Type: "task", Name: "some-task"})
+			Expect(err).NotTo(HaveOccurred())
+
+			return build, container
+		}
+
+		BeforeEach(func() {
+			var err error
+			usedWorkerTaskCache, err = workerTaskCacheFactory.FindOrCreate(workerTaskCache)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, creatingContainer = createBuildContainer()
+		})
+
+		It("claims the cache when no one else has", func() {
+			claimed, err := workerTaskCacheFactory.Claim(usedWorkerTaskCache, creatingContainer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claimed).To(BeTrue())
+
+			claimedAgain, err := workerTaskCacheFactory.Claim(usedWorkerTaskCache, creatingContainer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claimedAgain).To(BeTrue())
+		})
+
+		Context("when the cache is claimed by a container of another build", func() {
+			var otherBuild dbng.Build
+
+			BeforeEach(func() {
+				var otherContainer dbng.CreatingContainer
+				otherBuild, otherContainer = createBuildContainer()
+
+				claimed, err := workerTaskCacheFactory.Claim(usedWorkerTaskCache, otherContainer)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(claimed).To(BeTrue())
+			})
+
+			It("does not claim the cache while the build is running", func() {
+				claimed, err := workerTaskCacheFactory.Claim(usedWorkerTaskCache, creatingContainer)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(claimed).To(BeFalse())
+			})
+
+			Context("when the other build has finished", func() {
+				BeforeEach(func() {
+					err := otherBuild.Finish(dbng.BuildStatusSucceeded)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("claims the cache", func() {
+					claimed, err := workerTaskCacheFactory.Claim(usedWorkerTaskCache, creatingContainer)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(claimed).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("CleanUpForInactiveJobs", func() {
+		var cacheVolume dbng.CreatedVolume
+
+		BeforeEach(func() {
+			usedWorkerTaskCache, err := workerTaskCacheFactory.FindOrCreate(workerTaskCache)
+			Expect(err).NotTo(HaveOccurred())
+
+			creatingVolume, err := volumeFactory.CreateWorkerTaskCacheVolume(defaultTeam.ID(), defaultWorker, usedWorkerTaskCache)
+			Expect(err).NotTo(HaveOccurred())
+
+			cacheVolume, err = creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = cacheVolume.Initialize()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the job is active", func() {
+			It("keeps the cache volume", func() {
+				err := workerTaskCacheFactory.CleanUpForInactiveJobs()
+				Expect(err).NotTo(HaveOccurred())
+
+				createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+				Expect(err).NotTo(HaveOccurred())
+				for _, volume := range createdVolumes {
+					Expect(volume.Handle()).NotTo(Equal(cacheVolume.Handle()))
+				}
+			})
+		})
+
+		Context("when the job has been removed from the pipeline", func() {
+			BeforeEach(func() {
+				_, err := psql.Update("jobs").
+					Set("active", false).
+					Where(sq.Eq{"name": "some-job"}).
+					RunWith(dbConn).
+					Exec()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("orphans the cache volume", func() {
+				err := workerTaskCacheFactory.CleanUpForInactiveJobs()
+				Expect(err).NotTo(HaveOccurred())
+
+				createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+				Expect(err).NotTo(HaveOccurred())
+
+				handles := []string{}
+				for _, volume := range createdVolumes {
+					handles = append(handles, volume.Handle())
+				}
+				Expect(handles).To(ContainElement(cacheVolume.Handle()))
+			})
+		})
+
+		Context("when the pipeline is destroyed", func() {
+			BeforeEach(func() {
+				err := defaultPipeline.Destroy()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("orphans the cache volume", func() {
+				createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+				Expect(err).NotTo(HaveOccurred())
+
+				handles := []string{}
+				for _, volume := range createdVolumes {
+					handles = append(handles, volume.Handle())
+				}
+				Expect(handles).To(ContainElement(cacheVolume.Handle()))
+			})
+		})
+	})
+})
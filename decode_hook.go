@@ -48,6 +48,40 @@ var VersionConfigDecodeHook = func(
 	return data, nil
 }
 
+var AttemptsConfigDecodeHook = func(
+	srcType reflect.Type,
+	dstType reflect.Type,
+	data interface{},
+) (interface{}, error) {
+	if dstType != reflect.TypeOf(AttemptsConfig{}) {
+		return data, nil
+	}
+
+	switch actual := data.(type) {
+	case int:
+		return AttemptsConfig{Count: actual}, nil
+	case float64:
+		return AttemptsConfig{Count: int(actual)}, nil
+	case map[interface{}]interface{}:
+		attempts := map[string]interface{}{}
+		for key, val := range actual {
+			switch k := key.(type) {
+			case string:
+				attempts[k] = val
+			case bool:
+				// YAML parses a bare `on` key as the boolean true
+				if k {
+					attempts["on"] = val
+				}
+			}
+		}
+
+		return attempts, nil
+	}
+
+	return data, nil
+}
+
 var SanitizeDecodeHook = func(
 	dataKind reflect.Kind,
 	valKind reflect.Kind,
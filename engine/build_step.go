@@ -54,6 +54,15 @@ func (build *execBuild) buildTryStep(logger lager.Logger, plan atc.Plan) exec.St
 	return exec.Try(step)
 }
 
+func (build *execBuild) buildSaveArtifactsStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	return exec.SaveArtifacts(
+		logger.Session("save-artifacts"),
+		build.artifactStore,
+		build.buildID,
+		plan.SaveArtifacts.Names,
+	)
+}
+
 func (build *execBuild) buildOnSuccessStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	plan.OnSuccess.Step.Attempts = plan.Attempts
 	step := build.buildStepFactory(logger, plan.OnSuccess.Step)
@@ -82,7 +91,7 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 	logger = logger.Session("task")
 
 	var configSource exec.TaskConfigSource
-	if plan.Task.ConfigPath != "" && (plan.Task.Config != nil || plan.Task.Params != nil) {
+	if plan.Task.ConfigPath != "" && (plan.Task.Config != nil || plan.Task.Params != nil || plan.Task.ContainerLimits != nil) {
 		configSource = exec.MergedConfigSource{
 			A: exec.FileConfigSource{plan.Task.ConfigPath},
 			B: exec.StaticConfigSource{*plan.Task},
@@ -122,6 +131,9 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		plan.Task.InputMapping,
 		plan.Task.OutputMapping,
 		plan.Task.ImageArtifactName,
+		plan.Task.CacheOutputs,
+		plan.Task.Reports,
+		plan.Task.AbortGracePeriod,
 		clock,
 	)
 }
@@ -229,14 +241,24 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("retry")
 
-	step := exec.Retry{}
+	attempts := []exec.StepFactory{}
 
 	for index, innerPlan := range *plan.Retry {
 		innerPlan.Attempts = append(plan.Attempts, index+1)
 
 		stepFactory := build.buildStepFactory(logger, innerPlan)
-		step = append(step, stepFactory)
+		attempts = append(attempts, stepFactory)
 	}
 
-	return step
+	policy := atc.RetryPolicy{}
+	if plan.RetryPolicy != nil {
+		policy = *plan.RetryPolicy
+	}
+
+	return exec.RetryWithPolicy(
+		attempts,
+		policy,
+		build.delegate.RetryDelegate(logger, plan),
+		clock.NewClock(),
+	)
 }
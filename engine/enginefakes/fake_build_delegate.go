@@ -50,6 +50,25 @@ type FakeBuildDelegate struct {
 		arg3 exec.Success
 		arg4 bool
 	}
+	RetryDelegateStub        func(lager.Logger, atc.Plan) exec.RetryDelegate
+	retryDelegateMutex       sync.RWMutex
+	retryDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}
+	retryDelegateReturns struct {
+		result1 exec.RetryDelegate
+	}
+	StepDelegateStub        func(lager.Logger, atc.Plan, string) exec.TimingDelegate
+	stepDelegateMutex       sync.RWMutex
+	stepDelegateArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 string
+	}
+	stepDelegateReturns struct {
+		result1 exec.TimingDelegate
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -186,6 +205,75 @@ func (fake *FakeBuildDelegate) FinishArgsForCall(i int) (lager.Logger, error, ex
 	return fake.finishArgsForCall[i].arg1, fake.finishArgsForCall[i].arg2, fake.finishArgsForCall[i].arg3, fake.finishArgsForCall[i].arg4
 }
 
+func (fake *FakeBuildDelegate) RetryDelegate(arg1 lager.Logger, arg2 atc.Plan) exec.RetryDelegate {
+	fake.retryDelegateMutex.Lock()
+	fake.retryDelegateArgsForCall = append(fake.retryDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+	}{arg1, arg2})
+	fake.recordInvocation("RetryDelegate", []interface{}{arg1, arg2})
+	fake.retryDelegateMutex.Unlock()
+	if fake.RetryDelegateStub != nil {
+		return fake.RetryDelegateStub(arg1, arg2)
+	} else {
+		return fake.retryDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateCallCount() int {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return len(fake.retryDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateArgsForCall(i int) (lager.Logger, atc.Plan) {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return fake.retryDelegateArgsForCall[i].arg1, fake.retryDelegateArgsForCall[i].arg2
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturns(result1 exec.RetryDelegate) {
+	fake.RetryDelegateStub = nil
+	fake.retryDelegateReturns = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) StepDelegate(arg1 lager.Logger, arg2 atc.Plan, arg3 string) exec.TimingDelegate {
+	fake.stepDelegateMutex.Lock()
+	fake.stepDelegateArgsForCall = append(fake.stepDelegateArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.Plan
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("StepDelegate", []interface{}{arg1, arg2, arg3})
+	fake.stepDelegateMutex.Unlock()
+	if fake.StepDelegateStub != nil {
+		return fake.StepDelegateStub(arg1, arg2, arg3)
+	} else {
+		return fake.stepDelegateReturns.result1
+	}
+}
+
+func (fake *FakeBuildDelegate) StepDelegateCallCount() int {
+	fake.stepDelegateMutex.RLock()
+	defer fake.stepDelegateMutex.RUnlock()
+	return len(fake.stepDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) StepDelegateArgsForCall(i int) (lager.Logger, atc.Plan, string) {
+	fake.stepDelegateMutex.RLock()
+	defer fake.stepDelegateMutex.RUnlock()
+	return fake.stepDelegateArgsForCall[i].arg1, fake.stepDelegateArgsForCall[i].arg2, fake.stepDelegateArgsForCall[i].arg3
+}
+
+func (fake *FakeBuildDelegate) StepDelegateReturns(result1 exec.TimingDelegate) {
+	fake.StepDelegateStub = nil
+	fake.stepDelegateReturns = struct {
+		result1 exec.TimingDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -197,6 +285,10 @@ func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	defer fake.outputDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	fake.stepDelegateMutex.RLock()
+	defer fake.stepDelegateMutex.RUnlock()
 	return fake.invocations
 }
 
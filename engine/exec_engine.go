@@ -9,6 +9,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactstore"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
@@ -25,6 +26,7 @@ type execEngine struct {
 	factory         exec.Factory
 	delegateFactory BuildDelegateFactory
 	teamDBFactory   db.TeamDBFactory
+	artifactStore   artifactstore.Store
 	externalURL     string
 }
 
@@ -32,12 +34,14 @@ func NewExecEngine(
 	factory exec.Factory,
 	delegateFactory BuildDelegateFactory,
 	teamDBFactory db.TeamDBFactory,
+	artifactStore artifactstore.Store,
 	externalURL string,
 ) Engine {
 	return &execEngine{
 		factory:         factory,
 		delegateFactory: delegateFactory,
 		teamDBFactory:   teamDBFactory,
+		artifactStore:   artifactStore,
 		externalURL:     externalURL,
 	}
 }
@@ -53,8 +57,9 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build db.Build, plan
 		teamID:       build.TeamID(),
 		stepMetadata: buildMetadata(build, engine.externalURL),
 
-		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(build),
+		factory:       engine.factory,
+		delegate:      engine.delegateFactory.Delegate(build),
+		artifactStore: engine.artifactStore,
 		metadata: execMetadata{
 			Plan: plan,
 		},
@@ -82,9 +87,10 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build db.Build) (Buil
 		teamID:       build.TeamID(),
 		stepMetadata: buildMetadata(build, engine.externalURL),
 
-		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(build),
-		metadata: metadata,
+		factory:       engine.factory,
+		delegate:      engine.delegateFactory.Delegate(build),
+		artifactStore: engine.artifactStore,
+		metadata:      metadata,
 
 		signals: make(chan os.Signal, 1),
 	}, nil
@@ -156,8 +162,9 @@ type execBuild struct {
 	teamName     string
 	teamID       int
 
-	factory  exec.Factory
-	delegate BuildDelegate
+	factory       exec.Factory
+	delegate      BuildDelegate
+	artifactStore artifactstore.Store
 
 	signals chan os.Signal
 
@@ -221,6 +228,18 @@ func (build *execBuild) Resume(logger lager.Logger) {
 }
 
 func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	stepType := planStepType(plan)
+	if stepType == "" {
+		return exec.Identity{}
+	}
+
+	return exec.Timed(
+		build.buildUntimedStepFactory(logger, plan),
+		build.delegate.StepDelegate(logger, plan, stepType),
+	)
+}
+
+func (build *execBuild) buildUntimedStepFactory(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	if plan.Aggregate != nil {
 		return build.buildAggregateStep(logger, plan)
 	}
@@ -269,9 +288,46 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 		return build.buildRetryStep(logger, plan)
 	}
 
+	if plan.SaveArtifacts != nil {
+		return build.buildSaveArtifactsStep(logger, plan)
+	}
+
 	return exec.Identity{}
 }
 
+func planStepType(plan atc.Plan) string {
+	switch {
+	case plan.Aggregate != nil:
+		return "aggregate"
+	case plan.Do != nil:
+		return "do"
+	case plan.Timeout != nil:
+		return "timeout"
+	case plan.Try != nil:
+		return "try"
+	case plan.OnSuccess != nil:
+		return "on_success"
+	case plan.OnFailure != nil:
+		return "on_failure"
+	case plan.Ensure != nil:
+		return "ensure"
+	case plan.Task != nil:
+		return "task"
+	case plan.Get != nil:
+		return "get"
+	case plan.Put != nil:
+		return "put"
+	case plan.DependentGet != nil:
+		return "dependent_get"
+	case plan.Retry != nil:
+		return "retry"
+	case plan.SaveArtifacts != nil:
+		return "save_artifacts"
+	default:
+		return ""
+	}
+}
+
 func (build *execBuild) stepIdentifier(
 	logger lager.Logger,
 	stepName string,
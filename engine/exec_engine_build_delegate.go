@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"bytes"
 	"io"
 	"sync"
 	"time"
@@ -25,6 +26,8 @@ type BuildDelegate interface {
 	InputDelegate(lager.Logger, atc.GetPlan, event.OriginID) exec.GetDelegate
 	ExecutionDelegate(lager.Logger, atc.TaskPlan, event.OriginID) exec.TaskDelegate
 	OutputDelegate(lager.Logger, atc.PutPlan, event.OriginID) exec.PutDelegate
+	RetryDelegate(lager.Logger, atc.Plan) exec.RetryDelegate
+	StepDelegate(lager.Logger, atc.Plan, string) exec.TimingDelegate
 
 	Finish(lager.Logger, error, exec.Success, bool)
 }
@@ -35,28 +38,40 @@ type BuildDelegateFactory interface {
 	Delegate(db.Build) BuildDelegate
 }
 
-type buildDelegateFactory struct{}
+type buildDelegateFactory struct {
+	imageFetchTimeouts worker.ImageFetchTimeouts
+}
 
-func NewBuildDelegateFactory() BuildDelegateFactory {
-	return buildDelegateFactory{}
+func NewBuildDelegateFactory(imageFetchTimeouts worker.ImageFetchTimeouts) BuildDelegateFactory {
+	return buildDelegateFactory{
+		imageFetchTimeouts: imageFetchTimeouts,
+	}
 }
 
 func (factory buildDelegateFactory) Delegate(build db.Build) BuildDelegate {
-	return newBuildDelegate(build)
+	return newBuildDelegate(build, factory.imageFetchTimeouts)
 }
 
 type delegate struct {
 	build db.Build
 
+	imageFetchTimeouts worker.ImageFetchTimeouts
+
 	implicitOutputs map[string]implicitOutput
 
+	// steps whose start has been saved, including by earlier runs of a
+	// resumed build; loaded when the first step starts
+	startedSteps map[event.OriginID]bool
+
 	lock sync.Mutex
 }
 
-func newBuildDelegate(build db.Build) BuildDelegate {
+func newBuildDelegate(build db.Build, imageFetchTimeouts worker.ImageFetchTimeouts) BuildDelegate {
 	return &delegate{
 		build: build,
 
+		imageFetchTimeouts: imageFetchTimeouts,
+
 		implicitOutputs: make(map[string]implicitOutput),
 	}
 }
@@ -91,6 +106,25 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 	}
 }
 
+func (delegate *delegate) RetryDelegate(logger lager.Logger, plan atc.Plan) exec.RetryDelegate {
+	return &retryDelegate{
+		logger: logger,
+
+		plan:     plan,
+		delegate: delegate,
+	}
+}
+
+func (delegate *delegate) StepDelegate(logger lager.Logger, plan atc.Plan, step string) exec.TimingDelegate {
+	return &stepDelegate{
+		logger: logger,
+
+		id:       event.OriginID(plan.ID),
+		step:     step,
+		delegate: delegate,
+	}
+}
+
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.Success, aborted bool) {
 	if aborted {
 		delegate.saveStatus(logger, atc.StatusAborted)
@@ -157,6 +191,26 @@ func (delegate *delegate) saveInitializePut(logger lager.Logger, origin event.Or
 	}
 }
 
+func (delegate *delegate) saveStartGet(logger lager.Logger, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.StartGet{
+		Time:   time.Now().Unix(),
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-event", err)
+	}
+}
+
+func (delegate *delegate) saveStartPut(logger lager.Logger, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.StartPut{
+		Time:   time.Now().Unix(),
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-event", err)
+	}
+}
+
 func (delegate *delegate) saveStart(logger lager.Logger, origin event.Origin) {
 	err := delegate.build.SaveEvent(event.StartTask{
 		Time:   time.Now().Unix(),
@@ -167,6 +221,150 @@ func (delegate *delegate) saveStart(logger lager.Logger, origin event.Origin) {
 	}
 }
 
+func (delegate *delegate) saveWaitingForWorker(logger lager.Logger, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.WaitingForWorker{
+		Time:   time.Now().Unix(),
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-waiting-for-worker-event", err)
+	}
+}
+
+func (delegate *delegate) saveFinishAttempt(logger lager.Logger, attempts []int, status atc.BuildStatus, retrying bool, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.FinishAttempt{
+		Time:     time.Now().Unix(),
+		Origin:   origin,
+		Attempts: attempts,
+		Status:   status,
+		Retrying: retrying,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-attempt-event", err)
+	}
+}
+
+func (delegate *delegate) saveCacheHit(logger lager.Logger, key string, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.CacheHit{
+		Time:   time.Now().Unix(),
+		Origin: origin,
+		Key:    key,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-cache-hit-event", err)
+	}
+}
+
+func (delegate *delegate) saveStartStep(logger lager.Logger, step string, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.StartStep{
+		Time:   time.Now().Unix(),
+		Origin: origin,
+		Step:   step,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-step-event", err)
+	}
+}
+
+// markStepStarted reports whether the step's start was already saved, as
+// happens when the build is resumed, and otherwise remembers that it is about
+// to be.
+func (delegate *delegate) markStepStarted(id event.OriginID) (bool, error) {
+	delegate.lock.Lock()
+	defer delegate.lock.Unlock()
+
+	if delegate.startedSteps == nil {
+		envelopes, err := delegate.build.GetEvents([]atc.EventType{event.EventTypeStartStep})
+		if err != nil {
+			return false, err
+		}
+
+		startedSteps := map[event.OriginID]bool{}
+		for _, envelope := range envelopes {
+			if envelope.Data == nil {
+				continue
+			}
+
+			ev, err := event.ParseEvent(envelope.Version, envelope.Event, *envelope.Data)
+			if err != nil {
+				return false, err
+			}
+
+			if startStep, ok := ev.(event.StartStep); ok {
+				startedSteps[startStep.Origin.ID] = true
+			}
+		}
+
+		delegate.startedSteps = startedSteps
+	}
+
+	started := delegate.startedSteps[id]
+	delegate.startedSteps[id] = true
+
+	return started, nil
+}
+
+func (delegate *delegate) saveFinishStep(logger lager.Logger, step string, status atc.BuildStatus, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.FinishStep{
+		Time:   time.Now().Unix(),
+		Origin: origin,
+		Step:   step,
+		Status: status,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-step-event", err)
+	}
+}
+
+func (delegate *delegate) saveImageFetchPhase(logger lager.Logger, phase string, start time.Time, end time.Time, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.ImageFetchPhase{
+		StartTime: unixSeconds(start),
+		EndTime:   unixSeconds(end),
+		Origin:    origin,
+		Phase:     phase,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-image-fetch-phase-event", err)
+	}
+}
+
+func (delegate *delegate) saveImageFetchTimeout(logger lager.Logger, phase string, timeout time.Duration, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.ImageFetchTimeout{
+		Time:    time.Now().Unix(),
+		Origin:  origin,
+		Phase:   phase,
+		Timeout: int64(timeout / time.Second),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-image-fetch-timeout-event", err)
+	}
+}
+
+func (delegate *delegate) saveStreamPhase(logger lager.Logger, direction exec.StreamDirection, name worker.ArtifactName, start time.Time, end time.Time, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.StreamPhase{
+		StartTime: unixSeconds(start),
+		EndTime:   unixSeconds(end),
+		Origin:    origin,
+		Direction: string(direction),
+		Artifact:  string(name),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-stream-phase-event", err)
+	}
+}
+
+func (delegate *delegate) saveAbortTask(logger lager.Logger, phase exec.AbortPhase, gracePeriod time.Duration, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.AbortTask{
+		Time:        time.Now().Unix(),
+		Origin:      origin,
+		Phase:       string(phase),
+		GracePeriod: int64(gracePeriod / time.Second),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-abort-task-event", err)
+	}
+}
+
 func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus, origin event.Origin) {
 	err := delegate.build.SaveEvent(event.FinishTask{
 		ExitStatus: int(status),
@@ -182,6 +380,14 @@ func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus
 	err := delegate.build.Finish(db.Status(status))
 	if err != nil {
 		logger.Error("failed-to-finish-build", err)
+		return
+	}
+
+	// the build's output can still be searched without the index, so failing
+	// to index it is no reason to fail the build
+	err = delegate.build.IndexLogs()
+	if err != nil {
+		logger.Error("failed-to-index-build-logs", err)
 	}
 }
 
@@ -293,10 +499,47 @@ func (delegate *delegate) saveImplicitOutput(logger lager.Logger, plan atc.GetPl
 	logger.Info("saved", lager.Data{"resource": plan.Resource})
 }
 
+func (delegate *delegate) saveCheckpoint(logger lager.Logger, id event.OriginID, status exec.ExitStatus, info *exec.VersionInfo) {
+	checkpoint := db.StepCheckpoint{
+		ExitStatus: int(status),
+	}
+
+	if info != nil {
+		checkpoint.Version = info.Version
+		checkpoint.Metadata = info.Metadata
+	}
+
+	err := delegate.build.SaveStepCheckpoint(atc.PlanID(id), checkpoint)
+	if err != nil {
+		logger.Error("failed-to-save-checkpoint", err)
+	}
+}
+
+func (delegate *delegate) checkpoint(id event.OriginID) (exec.Checkpoint, bool, error) {
+	saved, found, err := delegate.build.GetStepCheckpoint(atc.PlanID(id))
+	if err != nil || !found {
+		return exec.Checkpoint{}, false, err
+	}
+
+	checkpoint := exec.Checkpoint{
+		ExitStatus: exec.ExitStatus(saved.ExitStatus),
+	}
+
+	if saved.Version != nil {
+		checkpoint.VersionInfo = &exec.VersionInfo{
+			Version:  saved.Version,
+			Metadata: saved.Metadata,
+		}
+	}
+
+	return checkpoint, true, nil
+}
+
 func (delegate *delegate) eventWriter(origin event.Origin) io.Writer {
 	return &dbEventWriter{
-		build:  delegate.build,
-		origin: origin,
+		build:       delegate.build,
+		origin:      origin,
+		atLineStart: true,
 	}
 }
 
@@ -312,11 +555,17 @@ func (input *inputDelegate) Initializing() {
 	input.delegate.saveInitializeGet(input.logger, event.Origin{ID: input.id})
 }
 
+func (input *inputDelegate) Started() {
+	input.delegate.saveStartGet(input.logger, event.Origin{ID: input.id})
+}
+
 func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
 	input.delegate.saveInput(input.logger, status, input.plan, info, event.Origin{
 		ID: input.id,
 	})
 
+	input.delegate.saveCheckpoint(input.logger, input.id, status, info)
+
 	if info != nil {
 		input.delegate.registerImplicitOutput(input.plan.Resource, implicitOutput{input.plan, *info})
 	}
@@ -324,6 +573,18 @@ func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.Version
 	input.logger.Info("finished", lager.Data{"version-info": info})
 }
 
+func (input *inputDelegate) Checkpoint() (exec.Checkpoint, bool, error) {
+	return input.delegate.checkpoint(input.id)
+}
+
+func (input *inputDelegate) Resumed(checkpoint exec.Checkpoint) {
+	if checkpoint.VersionInfo != nil {
+		input.delegate.registerImplicitOutput(input.plan.Resource, implicitOutput{input.plan, *checkpoint.VersionInfo})
+	}
+
+	input.logger.Info("resumed", lager.Data{"version-info": checkpoint.VersionInfo})
+}
+
 func (input *inputDelegate) Failed(err error) {
 	input.delegate.saveErr(input.logger, err, event.Origin{
 		ID: input.id,
@@ -336,6 +597,36 @@ func (input *inputDelegate) ImageVersionDetermined(resourceCacheIdentifier worke
 	return input.delegate.build.SaveImageResourceVersion(atc.PlanID(input.id), db.ResourceCacheIdentifier(resourceCacheIdentifier))
 }
 
+func (input *inputDelegate) WaitingForWorker() {
+	input.delegate.saveWaitingForWorker(input.logger, event.Origin{
+		ID: input.id,
+	})
+
+	input.logger.Info("waiting-for-worker")
+}
+
+func (input *inputDelegate) ImageFetchPhaseFinished(phase string, start time.Time, end time.Time) {
+	input.delegate.saveImageFetchPhase(input.logger, phase, start, end, event.Origin{
+		ID: input.id,
+	})
+}
+
+func (input *inputDelegate) ImageFetchTimedOut(phase string, timeout time.Duration) {
+	input.delegate.saveImageFetchTimeout(input.logger, phase, timeout, event.Origin{
+		ID: input.id,
+	})
+}
+
+func (input *inputDelegate) ArtifactStreamed(direction exec.StreamDirection, name worker.ArtifactName, start time.Time, end time.Time) {
+	input.delegate.saveStreamPhase(input.logger, direction, name, start, end, event.Origin{
+		ID: input.id,
+	})
+}
+
+func (input *inputDelegate) ImageFetchTimeouts() worker.ImageFetchTimeouts {
+	return input.delegate.imageFetchTimeouts
+}
+
 func (input *inputDelegate) Stdout() io.Writer {
 	return input.delegate.eventWriter(event.Origin{
 		Source: event.OriginSourceStdout,
@@ -363,15 +654,31 @@ func (output *outputDelegate) Initializing() {
 	output.delegate.saveInitializePut(output.logger, event.Origin{ID: output.id})
 }
 
+func (output *outputDelegate) Started() {
+	output.delegate.saveStartPut(output.logger, event.Origin{ID: output.id})
+}
+
 func (output *outputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
 	output.delegate.unregisterImplicitOutput(output.plan.Resource)
 	output.delegate.saveOutput(output.logger, status, output.plan, info, event.Origin{
 		ID: output.id,
 	})
 
+	output.delegate.saveCheckpoint(output.logger, output.id, status, info)
+
 	output.logger.Info("finished", lager.Data{"version-info": info})
 }
 
+func (output *outputDelegate) Checkpoint() (exec.Checkpoint, bool, error) {
+	return output.delegate.checkpoint(output.id)
+}
+
+func (output *outputDelegate) Resumed(checkpoint exec.Checkpoint) {
+	output.delegate.unregisterImplicitOutput(output.plan.Resource)
+
+	output.logger.Info("resumed", lager.Data{"version-info": checkpoint.VersionInfo})
+}
+
 func (output *outputDelegate) Failed(err error) {
 	output.delegate.saveErr(output.logger, err, event.Origin{
 		ID: output.id,
@@ -384,6 +691,36 @@ func (output *outputDelegate) ImageVersionDetermined(resourceCacheIdentifier wor
 	return output.delegate.build.SaveImageResourceVersion(atc.PlanID(output.id), db.ResourceCacheIdentifier(resourceCacheIdentifier))
 }
 
+func (output *outputDelegate) WaitingForWorker() {
+	output.delegate.saveWaitingForWorker(output.logger, event.Origin{
+		ID: output.id,
+	})
+
+	output.logger.Info("waiting-for-worker")
+}
+
+func (output *outputDelegate) ImageFetchPhaseFinished(phase string, start time.Time, end time.Time) {
+	output.delegate.saveImageFetchPhase(output.logger, phase, start, end, event.Origin{
+		ID: output.id,
+	})
+}
+
+func (output *outputDelegate) ImageFetchTimedOut(phase string, timeout time.Duration) {
+	output.delegate.saveImageFetchTimeout(output.logger, phase, timeout, event.Origin{
+		ID: output.id,
+	})
+}
+
+func (output *outputDelegate) ArtifactStreamed(direction exec.StreamDirection, name worker.ArtifactName, start time.Time, end time.Time) {
+	output.delegate.saveStreamPhase(output.logger, direction, name, start, end, event.Origin{
+		ID: output.id,
+	})
+}
+
+func (output *outputDelegate) ImageFetchTimeouts() worker.ImageFetchTimeouts {
+	return output.delegate.imageFetchTimeouts
+}
+
 func (output *outputDelegate) Stdout() io.Writer {
 	return output.delegate.eventWriter(event.Origin{
 		Source: event.OriginSourceStdout,
@@ -423,6 +760,14 @@ func (execution *executionDelegate) Started() {
 	execution.logger.Info("started")
 }
 
+func (execution *executionDelegate) CacheHit(key string) {
+	execution.delegate.saveCacheHit(execution.logger, key, event.Origin{
+		ID: execution.id,
+	})
+
+	execution.logger.Info("cache-hit", lager.Data{"key": key})
+}
+
 func (execution *executionDelegate) Finished(status exec.ExitStatus) {
 	execution.delegate.saveFinish(execution.logger, status, event.Origin{
 		ID: execution.id,
@@ -438,10 +783,68 @@ func (execution *executionDelegate) Failed(err error) {
 	execution.logger.Info("errored", lager.Data{"error": err.Error()})
 }
 
+func (execution *executionDelegate) Annotated(annotations []atc.MetadataField) {
+	err := execution.delegate.build.SaveAnnotations(atc.PlanID(execution.id), execution.plan.Name, annotations)
+	if err != nil {
+		execution.logger.Error("failed-to-save-annotations", err)
+		return
+	}
+
+	execution.logger.Info("annotated", lager.Data{"annotations": len(annotations)})
+}
+
+func (execution *executionDelegate) TestResultsReported(results []atc.TestResult) {
+	err := execution.delegate.build.SaveTestResults(atc.PlanID(execution.id), execution.plan.Name, results)
+	if err != nil {
+		execution.logger.Error("failed-to-save-test-results", err)
+		return
+	}
+
+	execution.logger.Info("test-results-reported", lager.Data{"results": len(results)})
+}
+
 func (execution *executionDelegate) ImageVersionDetermined(resourceCacheIdentifier worker.ResourceCacheIdentifier) error {
 	return execution.delegate.build.SaveImageResourceVersion(atc.PlanID(execution.id), db.ResourceCacheIdentifier(resourceCacheIdentifier))
 }
 
+func (execution *executionDelegate) WaitingForWorker() {
+	execution.delegate.saveWaitingForWorker(execution.logger, event.Origin{
+		ID: execution.id,
+	})
+
+	execution.logger.Info("waiting-for-worker")
+}
+
+func (execution *executionDelegate) ImageFetchPhaseFinished(phase string, start time.Time, end time.Time) {
+	execution.delegate.saveImageFetchPhase(execution.logger, phase, start, end, event.Origin{
+		ID: execution.id,
+	})
+}
+
+func (execution *executionDelegate) ImageFetchTimedOut(phase string, timeout time.Duration) {
+	execution.delegate.saveImageFetchTimeout(execution.logger, phase, timeout, event.Origin{
+		ID: execution.id,
+	})
+}
+
+func (execution *executionDelegate) ArtifactStreamed(direction exec.StreamDirection, name worker.ArtifactName, start time.Time, end time.Time) {
+	execution.delegate.saveStreamPhase(execution.logger, direction, name, start, end, event.Origin{
+		ID: execution.id,
+	})
+}
+
+func (execution *executionDelegate) AbortProgressed(phase exec.AbortPhase, gracePeriod time.Duration) {
+	execution.delegate.saveAbortTask(execution.logger, phase, gracePeriod, event.Origin{
+		ID: execution.id,
+	})
+
+	execution.logger.Info("abort-progressed", lager.Data{"phase": phase, "grace-period": gracePeriod.String()})
+}
+
+func (execution *executionDelegate) ImageFetchTimeouts() worker.ImageFetchTimeouts {
+	return execution.delegate.imageFetchTimeouts
+}
+
 func (execution *executionDelegate) Stdout() io.Writer {
 	return execution.delegate.eventWriter(event.Origin{
 		Source: event.OriginSourceStdout,
@@ -461,23 +864,54 @@ type dbEventWriter struct {
 
 	origin event.Origin
 
-	dangling []byte
+	dangling      []byte
+	danglingLines []event.LogLine
+
+	atLineStart bool
 }
 
 func (writer *dbEventWriter) Write(data []byte) (int, error) {
+	now := time.Now()
+
+	// record when each line was written, as the lines of a payload saved
+	// with dangling data from earlier writes may have been written apart
+	lines := writer.danglingLines
+	for offset := 0; offset < len(data); {
+		if writer.atLineStart {
+			lines = append(lines, event.LogLine{
+				Offset: len(writer.dangling) + offset,
+				Time:   unixSeconds(now),
+			})
+
+			writer.atLineStart = false
+		}
+
+		end := bytes.IndexByte(data[offset:], '\n')
+		if end == -1 {
+			break
+		}
+
+		offset += end + 1
+		writer.atLineStart = true
+	}
+
 	text := append(writer.dangling, data...)
 
 	checkEncoding, _ := utf8.DecodeLastRune(text)
 	if checkEncoding == utf8.RuneError {
 		writer.dangling = text
+		writer.danglingLines = lines
 		return len(data), nil
 	}
 
 	writer.dangling = nil
+	writer.danglingLines = nil
 
 	err := writer.build.SaveEvent(event.Log{
 		Payload: string(text),
 		Origin:  writer.origin,
+		Time:    now.Unix(),
+		Lines:   lines,
 	})
 	if err != nil {
 		return 0, err
@@ -529,3 +963,74 @@ func atcMetadataToDBMetadata(atcm []atc.MetadataField) []db.MetadataField {
 
 	return metadata
 }
+
+type retryDelegate struct {
+	logger lager.Logger
+
+	plan atc.Plan
+
+	delegate *delegate
+}
+
+func (retry *retryDelegate) AttemptFinished(attempt int, status atc.BuildStatus, retrying bool) {
+	attemptPlan := (*retry.plan.Retry)[attempt-1]
+
+	attempts := make([]int, len(retry.plan.Attempts), len(retry.plan.Attempts)+1)
+	copy(attempts, retry.plan.Attempts)
+	attempts = append(attempts, attempt)
+
+	retry.delegate.saveFinishAttempt(retry.logger, attempts, status, retrying, event.Origin{
+		ID: event.OriginID(attemptPlan.ID),
+	})
+
+	retry.logger.Info("attempt-finished", lager.Data{
+		"attempts": attempts,
+		"status":   status,
+		"retrying": retrying,
+	})
+}
+
+type stepDelegate struct {
+	logger lager.Logger
+
+	id   event.OriginID
+	step string
+
+	delegate *delegate
+}
+
+func (step *stepDelegate) StepStarted() {
+	started, err := step.delegate.markStepStarted(step.id)
+	if err != nil {
+		step.logger.Error("failed-to-find-started-steps", err)
+	}
+
+	if started {
+		return
+	}
+
+	step.delegate.saveStartStep(step.logger, step.step, event.Origin{
+		ID: step.id,
+	})
+}
+
+func (step *stepDelegate) StepFinished(succeeded exec.Success, err error) {
+	var status atc.BuildStatus
+	if err == exec.ErrInterrupted {
+		status = atc.StatusAborted
+	} else if err != nil {
+		status = atc.StatusErrored
+	} else if bool(succeeded) {
+		status = atc.StatusSucceeded
+	} else {
+		status = atc.StatusFailed
+	}
+
+	step.delegate.saveFinishStep(step.logger, step.step, status, event.Origin{
+		ID: step.id,
+	})
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
@@ -1,6 +1,7 @@
 package engine_test
 
 import (
+	"encoding/json"
 	"errors"
 	"io"
 	"time"
@@ -16,6 +17,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("BuildDelegate", func() {
@@ -32,7 +34,10 @@ var _ = Describe("BuildDelegate", func() {
 	)
 
 	BeforeEach(func() {
-		factory = NewBuildDelegateFactory()
+		factory = NewBuildDelegateFactory(worker.ImageFetchTimeouts{
+			Check: time.Minute,
+			Get:   time.Hour,
+		})
 
 		fakeBuild = new(dbfakes.FakeBuild)
 		delegate = factory.Delegate(fakeBuild)
@@ -80,6 +85,22 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("Started", func() {
+			JustBeforeEach(func() {
+				inputDelegate.Started()
+			})
+
+			It("saves a start event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.StartGet)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+				Expect(savedEvent.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+			})
+		})
+
 		Describe("Completed", func() {
 			var versionInfo *exec.VersionInfo
 
@@ -116,6 +137,14 @@ var _ = Describe("BuildDelegate", func() {
 						ExitStatus: 12,
 					}))
 				})
+
+				It("saves a checkpoint with the exit status", func() {
+					Expect(fakeBuild.SaveStepCheckpointCallCount()).To(Equal(1))
+
+					planID, checkpoint := fakeBuild.SaveStepCheckpointArgsForCall(0)
+					Expect(planID).To(Equal(atc.PlanID("some-origin-id")))
+					Expect(checkpoint).To(Equal(db.StepCheckpoint{ExitStatus: 12}))
+				})
 			})
 
 			Context("when the version is null", func() {
@@ -210,6 +239,36 @@ var _ = Describe("BuildDelegate", func() {
 							savedStatus := fakeBuild.FinishArgsForCall(0)
 							Expect(savedStatus).To(Equal(db.StatusFailed))
 						})
+
+						It("indexes the build's logs", func() {
+							delegate.Finish(logger, finishErr, succeeded, aborted)
+
+							Expect(fakeBuild.IndexLogsCallCount()).To(Equal(1))
+						})
+
+						Context("when indexing the build's logs fails", func() {
+							BeforeEach(func() {
+								fakeBuild.IndexLogsReturns(errors.New("nope"))
+							})
+
+							It("logs the error", func() {
+								delegate.Finish(logger, finishErr, succeeded, aborted)
+
+								Expect(logger).To(gbytes.Say("failed-to-index-build-logs"))
+							})
+						})
+
+						Context("when finishing the build fails", func() {
+							BeforeEach(func() {
+								fakeBuild.FinishReturns(errors.New("nope"))
+							})
+
+							It("does not index the build's logs", func() {
+								delegate.Finish(logger, finishErr, succeeded, aborted)
+
+								Expect(fakeBuild.IndexLogsCallCount()).To(BeZero())
+							})
+						})
 					})
 
 					Context("when it was told it succeeded", func() {
@@ -404,6 +463,106 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("Checkpoint", func() {
+			Context("when the build has a checkpoint for the step", func() {
+				BeforeEach(func() {
+					fakeBuild.GetStepCheckpointReturns(db.StepCheckpoint{
+						ExitStatus: 0,
+						Version:    atc.Version{"result": "version"},
+						Metadata:   []atc.MetadataField{{"result", "metadata"}},
+					}, true, nil)
+				})
+
+				It("returns it with its version info", func() {
+					checkpoint, found, err := inputDelegate.Checkpoint()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(checkpoint).To(Equal(exec.Checkpoint{
+						ExitStatus: 0,
+						VersionInfo: &exec.VersionInfo{
+							Version:  atc.Version{"result": "version"},
+							Metadata: []atc.MetadataField{{"result", "metadata"}},
+						},
+					}))
+
+					Expect(fakeBuild.GetStepCheckpointArgsForCall(0)).To(Equal(atc.PlanID("some-origin-id")))
+				})
+			})
+
+			Context("when the checkpoint has no version", func() {
+				BeforeEach(func() {
+					fakeBuild.GetStepCheckpointReturns(db.StepCheckpoint{ExitStatus: 1}, true, nil)
+				})
+
+				It("returns it without version info", func() {
+					checkpoint, found, err := inputDelegate.Checkpoint()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(checkpoint).To(Equal(exec.Checkpoint{ExitStatus: 1}))
+				})
+			})
+
+			Context("when the build has no checkpoint for the step", func() {
+				BeforeEach(func() {
+					fakeBuild.GetStepCheckpointReturns(db.StepCheckpoint{}, false, nil)
+				})
+
+				It("returns false", func() {
+					_, found, err := inputDelegate.Checkpoint()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
+
+			Context("when looking up the checkpoint fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeBuild.GetStepCheckpointReturns(db.StepCheckpoint{}, false, disaster)
+				})
+
+				It("returns the error", func() {
+					_, _, err := inputDelegate.Checkpoint()
+					Expect(err).To(Equal(disaster))
+				})
+			})
+		})
+
+		Describe("Resumed", func() {
+			JustBeforeEach(func() {
+				inputDelegate.Resumed(exec.Checkpoint{
+					ExitStatus: 0,
+					VersionInfo: &exec.VersionInfo{
+						Version:  atc.Version{"result": "version"},
+						Metadata: []atc.MetadataField{{"result", "metadata"}},
+					},
+				})
+			})
+
+			It("does not save any events or inputs", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+				Expect(fakeBuild.SaveInputCallCount()).To(BeZero())
+				Expect(fakeBuild.SaveStepCheckpointCallCount()).To(BeZero())
+			})
+
+			It("saves the input as an implicit output when the build succeeds", func() {
+				delegate.Finish(logger, nil, true, false)
+
+				Expect(fakeBuild.SaveOutputCallCount()).To(Equal(1))
+
+				savedOutput, explicit := fakeBuild.SaveOutputArgsForCall(0)
+				Expect(savedOutput).To(Equal(db.VersionedResource{
+					PipelineID: 57,
+					Resource:   "some-input-resource",
+					Type:       "some-type",
+					Version:    db.Version{"result": "version"},
+					Metadata:   []db.MetadataField{{"result", "metadata"}},
+				}))
+
+				Expect(explicit).To(BeFalse())
+			})
+		})
+
 		Describe("Failed", func() {
 			JustBeforeEach(func() {
 				inputDelegate.Failed(errors.New("nope"))
@@ -460,6 +619,15 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("ImageFetchTimeouts", func() {
+			It("returns the timeouts given to the factory", func() {
+				Expect(inputDelegate.ImageFetchTimeouts()).To(Equal(worker.ImageFetchTimeouts{
+					Check: time.Minute,
+					Get:   time.Hour,
+				}))
+			})
+		})
+
 		Describe("Stdout", func() {
 			var writer io.Writer
 
@@ -473,14 +641,16 @@ var _ = Describe("BuildDelegate", func() {
 
 				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
 
-				savedEvent := fakeBuild.SaveEventArgsForCall(0)
-				Expect(savedEvent).To(Equal(event.Log{
-					Origin: event.Origin{
-						Source: event.OriginSourceStdout,
-						ID:     originID,
-					},
-					Payload: "some stdout",
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.Log)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{
+					Source: event.OriginSourceStdout,
+					ID:     originID,
 				}))
+				Expect(savedEvent.Payload).To(Equal("some stdout"))
+				Expect(savedEvent.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.Lines).To(HaveLen(1))
+				Expect(savedEvent.Lines[0].Offset).To(BeZero())
+				Expect(savedEvent.Lines[0].Time).To(BeNumerically("~", time.Now().Unix(), 1))
 			})
 
 			Context("when the DB errors", func() {
@@ -510,14 +680,15 @@ var _ = Describe("BuildDelegate", func() {
 
 				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
 
-				savedEvent := fakeBuild.SaveEventArgsForCall(0)
-				Expect(savedEvent).To(Equal(event.Log{
-					Origin: event.Origin{
-						Source: event.OriginSourceStderr,
-						ID:     originID,
-					},
-					Payload: "some stderr",
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.Log)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{
+					Source: event.OriginSourceStderr,
+					ID:     originID,
 				}))
+				Expect(savedEvent.Payload).To(Equal("some stderr"))
+				Expect(savedEvent.Lines).To(HaveLen(1))
+				Expect(savedEvent.Lines[0].Offset).To(BeZero())
+				Expect(savedEvent.Lines[0].Time).To(BeNumerically("~", time.Now().Unix(), 1))
 			})
 
 			Context("when the DB errors", func() {
@@ -560,6 +731,10 @@ var _ = Describe("BuildDelegate", func() {
 						Path: "ls",
 						Dir:  "some/dir",
 					},
+					ContainerLimits: atc.ContainerLimits{
+						CPU:    512,
+						Memory: 1024,
+					},
 				}
 			})
 
@@ -577,6 +752,10 @@ var _ = Describe("BuildDelegate", func() {
 							Path: "ls",
 							Dir:  "some/dir",
 						},
+						ContainerLimits: event.ContainerLimits{
+							CPU:    512,
+							Memory: 1024,
+						},
 					},
 					Origin: event.Origin{
 						ID: originID,
@@ -715,6 +894,115 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("WaitingForWorker", func() {
+			JustBeforeEach(func() {
+				executionDelegate.WaitingForWorker()
+			})
+
+			It("saves a waiting-for-worker event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.WaitingForWorker{}))
+				Expect(savedEvent.(event.WaitingForWorker).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.WaitingForWorker).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+
+		Describe("CacheHit", func() {
+			JustBeforeEach(func() {
+				executionDelegate.CacheHit("some-key")
+			})
+
+			It("saves a cache-hit event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.CacheHit{}))
+				Expect(savedEvent.(event.CacheHit).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.CacheHit).Key).To(Equal("some-key"))
+				Expect(savedEvent.(event.CacheHit).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+		})
+
+		Describe("ImageFetchPhaseFinished", func() {
+			var start, end time.Time
+
+			JustBeforeEach(func() {
+				start = time.Unix(100, 0)
+				end = time.Unix(160, int64(250*time.Millisecond))
+
+				executionDelegate.ImageFetchPhaseFinished("get", start, end)
+			})
+
+			It("saves an image-fetch-phase event, in fractional seconds", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(Equal(event.ImageFetchPhase{
+					StartTime: 100,
+					EndTime:   160.25,
+					Origin:    event.Origin{ID: originID},
+					Phase:     "get",
+				}))
+			})
+		})
+
+		Describe("ImageFetchTimedOut", func() {
+			JustBeforeEach(func() {
+				executionDelegate.ImageFetchTimedOut("check", 10*time.Minute)
+			})
+
+			It("saves an image-fetch-timeout event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.ImageFetchTimeout)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{ID: originID}))
+				Expect(savedEvent.Phase).To(Equal("check"))
+				Expect(savedEvent.Timeout).To(Equal(int64(600)))
+				Expect(savedEvent.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+			})
+		})
+
+		Describe("ArtifactStreamed", func() {
+			JustBeforeEach(func() {
+				executionDelegate.ArtifactStreamed(exec.StreamIn, "some-input", time.Unix(100, 0), time.Unix(100, int64(500*time.Millisecond)))
+			})
+
+			It("saves a stream-phase event, in fractional seconds", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(Equal(event.StreamPhase{
+					StartTime: 100,
+					EndTime:   100.5,
+					Origin:    event.Origin{ID: originID},
+					Direction: "in",
+					Artifact:  "some-input",
+				}))
+			})
+		})
+
+		Describe("AbortProgressed", func() {
+			JustBeforeEach(func() {
+				executionDelegate.AbortProgressed(exec.AbortPhaseTerminating, 5*time.Minute)
+			})
+
+			It("saves an abort-task event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.AbortTask)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{ID: originID}))
+				Expect(savedEvent.Phase).To(Equal("terminating"))
+				Expect(savedEvent.GracePeriod).To(Equal(int64(300)))
+				Expect(savedEvent.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+			})
+		})
+
 		Describe("ImageVersionDetermined", func() {
 			var resourceCacheIdentifier worker.ResourceCacheIdentifier
 
@@ -749,6 +1037,49 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("Annotated", func() {
+			It("saves the annotations with the task's plan ID and name", func() {
+				executionDelegate.Annotated([]atc.MetadataField{
+					{Name: "failed test", Value: "TestSomething"},
+				})
+
+				Expect(fakeBuild.SaveAnnotationsCallCount()).To(Equal(1))
+
+				planID, stepName, annotations := fakeBuild.SaveAnnotationsArgsForCall(0)
+				Expect(planID).To(Equal(atc.PlanID("some-origin-id")))
+				Expect(stepName).To(Equal("some-task"))
+				Expect(annotations).To(Equal([]atc.MetadataField{
+					{Name: "failed test", Value: "TestSomething"},
+				}))
+			})
+		})
+
+		Describe("TestResultsReported", func() {
+			It("saves the results with the task's plan ID and name", func() {
+				executionDelegate.TestResultsReported([]atc.TestResult{
+					{Suite: "unit", Name: "renders", Status: atc.TestStatusPassed, Duration: 0.25},
+				})
+
+				Expect(fakeBuild.SaveTestResultsCallCount()).To(Equal(1))
+
+				planID, stepName, results := fakeBuild.SaveTestResultsArgsForCall(0)
+				Expect(planID).To(Equal(atc.PlanID("some-origin-id")))
+				Expect(stepName).To(Equal("some-task"))
+				Expect(results).To(Equal([]atc.TestResult{
+					{Suite: "unit", Name: "renders", Status: atc.TestStatusPassed, Duration: 0.25},
+				}))
+			})
+		})
+
+		Describe("ImageFetchTimeouts", func() {
+			It("returns the timeouts given to the factory", func() {
+				Expect(executionDelegate.ImageFetchTimeouts()).To(Equal(worker.ImageFetchTimeouts{
+					Check: time.Minute,
+					Get:   time.Hour,
+				}))
+			})
+		})
+
 		Describe("Stdout", func() {
 			var writer io.Writer
 
@@ -762,15 +1093,46 @@ var _ = Describe("BuildDelegate", func() {
 
 				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
 
-				savedEvent := fakeBuild.SaveEventArgsForCall(0)
-				Expect(savedEvent).To(Equal(event.Log{
-					Origin: event.Origin{
-						Source: event.OriginSourceStdout,
-						ID:     originID,
-					},
-					Payload: "some stdout",
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.Log)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{
+					Source: event.OriginSourceStdout,
+					ID:     originID,
 				}))
+				Expect(savedEvent.Payload).To(Equal("some stdout"))
+				Expect(savedEvent.Lines).To(HaveLen(1))
+				Expect(savedEvent.Lines[0].Offset).To(BeZero())
+				Expect(savedEvent.Lines[0].Time).To(BeNumerically("~", time.Now().Unix(), 1))
+
+			})
+
+			It("records where each line starts, across writes", func() {
+				_, err := writer.Write([]byte("some line\nsome partial "))
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = writer.Write([]byte("line\n\xe2\x9c"))
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = writer.Write([]byte("\x93 done\nlast line"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+
+				offsets := func(log event.Log) []int {
+					offsets := []int{}
+					for _, line := range log.Lines {
+						offsets = append(offsets, line.Offset)
+					}
+
+					return offsets
+				}
 
+				first := fakeBuild.SaveEventArgsForCall(0).(event.Log)
+				Expect(first.Payload).To(Equal("some line\nsome partial "))
+				Expect(offsets(first)).To(Equal([]int{0, 10}))
+
+				second := fakeBuild.SaveEventArgsForCall(1).(event.Log)
+				Expect(second.Payload).To(Equal("line\n\xe2\x9c\x93 done\nlast line"))
+				Expect(offsets(second)).To(Equal([]int{5, 14}))
 			})
 		})
 
@@ -787,14 +1149,15 @@ var _ = Describe("BuildDelegate", func() {
 
 				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
 
-				savedEvent := fakeBuild.SaveEventArgsForCall(0)
-				Expect(savedEvent).To(Equal(event.Log{
-					Origin: event.Origin{
-						Source: event.OriginSourceStderr,
-						ID:     originID,
-					},
-					Payload: "some stderr",
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.Log)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{
+					Source: event.OriginSourceStderr,
+					ID:     originID,
 				}))
+				Expect(savedEvent.Payload).To(Equal("some stderr"))
+				Expect(savedEvent.Lines).To(HaveLen(1))
+				Expect(savedEvent.Lines[0].Offset).To(BeZero())
+				Expect(savedEvent.Lines[0].Time).To(BeNumerically("~", time.Now().Unix(), 1))
 
 			})
 		})
@@ -837,6 +1200,22 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("Started", func() {
+			JustBeforeEach(func() {
+				outputDelegate.Started()
+			})
+
+			It("saves a start event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.StartPut)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+				Expect(savedEvent.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+			})
+		})
+
 		Describe("Completed", func() {
 			var versionInfo *exec.VersionInfo
 
@@ -916,6 +1295,18 @@ var _ = Describe("BuildDelegate", func() {
 					}))
 
 				})
+
+				It("saves a checkpoint with the created version", func() {
+					Expect(fakeBuild.SaveStepCheckpointCallCount()).To(Equal(1))
+
+					planID, checkpoint := fakeBuild.SaveStepCheckpointArgsForCall(0)
+					Expect(planID).To(Equal(atc.PlanID("some-origin-id")))
+					Expect(checkpoint).To(Equal(db.StepCheckpoint{
+						ExitStatus: 0,
+						Version:    atc.Version{"result": "version"},
+						Metadata:   []atc.MetadataField{{"result", "metadata"}},
+					}))
+				})
 			})
 
 			Context("when exit status is not 0", func() {
@@ -1006,6 +1397,40 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("Resumed", func() {
+			BeforeEach(func() {
+				inputDelegate := delegate.InputDelegate(logger, atc.GetPlan{
+					Name:       "some-input",
+					Resource:   "some-output-resource",
+					PipelineID: 86,
+					Type:       "some-type",
+				}, event.OriginID("some-input-origin-id"))
+
+				inputDelegate.Completed(exec.ExitStatus(0), &exec.VersionInfo{
+					Version: atc.Version{"input": "version"},
+				})
+			})
+
+			JustBeforeEach(func() {
+				outputDelegate.Resumed(exec.Checkpoint{
+					ExitStatus: 0,
+					VersionInfo: &exec.VersionInfo{
+						Version: atc.Version{"result": "version"},
+					},
+				})
+			})
+
+			It("does not save the output again", func() {
+				Expect(fakeBuild.SaveOutputCallCount()).To(BeZero())
+			})
+
+			It("does not save the input as an implicit output when the build succeeds", func() {
+				delegate.Finish(logger, nil, true, false)
+
+				Expect(fakeBuild.SaveOutputCallCount()).To(BeZero())
+			})
+		})
+
 		Describe("Failed", func() {
 			JustBeforeEach(func() {
 				outputDelegate.Failed(errors.New("nope"))
@@ -1063,6 +1488,15 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("ImageFetchTimeouts", func() {
+			It("returns the timeouts given to the factory", func() {
+				Expect(outputDelegate.ImageFetchTimeouts()).To(Equal(worker.ImageFetchTimeouts{
+					Check: time.Minute,
+					Get:   time.Hour,
+				}))
+			})
+		})
+
 		Describe("Stdout", func() {
 			var writer io.Writer
 
@@ -1076,14 +1510,15 @@ var _ = Describe("BuildDelegate", func() {
 
 				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
 
-				savedEvent := fakeBuild.SaveEventArgsForCall(0)
-				Expect(savedEvent).To(Equal(event.Log{
-					Origin: event.Origin{
-						Source: event.OriginSourceStdout,
-						ID:     originID,
-					},
-					Payload: "some stdout",
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.Log)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{
+					Source: event.OriginSourceStdout,
+					ID:     originID,
 				}))
+				Expect(savedEvent.Payload).To(Equal("some stdout"))
+				Expect(savedEvent.Lines).To(HaveLen(1))
+				Expect(savedEvent.Lines[0].Offset).To(BeZero())
+				Expect(savedEvent.Lines[0].Time).To(BeNumerically("~", time.Now().Unix(), 1))
 
 			})
 		})
@@ -1101,15 +1536,205 @@ var _ = Describe("BuildDelegate", func() {
 
 				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
 
+				savedEvent := fakeBuild.SaveEventArgsForCall(0).(event.Log)
+				Expect(savedEvent.Origin).To(Equal(event.Origin{
+					Source: event.OriginSourceStderr,
+					ID:     originID,
+				}))
+				Expect(savedEvent.Payload).To(Equal("some stderr"))
+				Expect(savedEvent.Lines).To(HaveLen(1))
+				Expect(savedEvent.Lines[0].Offset).To(BeZero())
+				Expect(savedEvent.Lines[0].Time).To(BeNumerically("~", time.Now().Unix(), 1))
+
+			})
+		})
+	})
+
+	Describe("RetryDelegate", func() {
+		var retryDelegate exec.RetryDelegate
+
+		BeforeEach(func() {
+			retryPlan := atc.Plan{
+				ID:       "some-retry-id",
+				Attempts: []int{2},
+				Retry: &atc.RetryPlan{
+					{ID: "some-attempt-1-id"},
+					{ID: "some-attempt-2-id"},
+				},
+			}
+
+			retryDelegate = delegate.RetryDelegate(logger, retryPlan)
+		})
+
+		Describe("AttemptFinished", func() {
+			JustBeforeEach(func() {
+				retryDelegate.AttemptFinished(2, atc.StatusErrored, true)
+			})
+
+			It("saves a finish-attempt event with the attempt's origin", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
 				savedEvent := fakeBuild.SaveEventArgsForCall(0)
-				Expect(savedEvent).To(Equal(event.Log{
-					Origin: event.Origin{
-						Source: event.OriginSourceStderr,
-						ID:     originID,
-					},
-					Payload: "some stderr",
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishAttempt{}))
+
+				finishAttempt := savedEvent.(event.FinishAttempt)
+				Expect(finishAttempt.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(finishAttempt.Origin).To(Equal(event.Origin{
+					ID: "some-attempt-2-id",
 				}))
+				Expect(finishAttempt.Attempts).To(Equal([]int{2, 2}))
+				Expect(finishAttempt.Status).To(Equal(atc.StatusErrored))
+				Expect(finishAttempt.Retrying).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("StepDelegate", func() {
+		var (
+			stepPlan     atc.Plan
+			stepDelegate exec.TimingDelegate
+		)
+
+		BeforeEach(func() {
+			stepPlan = atc.Plan{
+				ID:      "some-timeout-id",
+				Timeout: &atc.TimeoutPlan{},
+			}
+
+			stepDelegate = delegate.StepDelegate(logger, stepPlan, "timeout")
+		})
+
+		Describe("StepStarted", func() {
+			JustBeforeEach(func() {
+				stepDelegate.StepStarted()
+			})
+
+			It("saves a start-step event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.StartStep{}))
+
+				startStep := savedEvent.(event.StartStep)
+				Expect(startStep.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(startStep.Origin).To(Equal(event.Origin{ID: "some-timeout-id"}))
+				Expect(startStep.Step).To(Equal("timeout"))
+			})
+
+			It("looks for the steps the build has already started", func() {
+				Expect(fakeBuild.GetEventsCallCount()).To(Equal(1))
+				Expect(fakeBuild.GetEventsArgsForCall(0)).To(Equal([]atc.EventType{event.EventTypeStartStep}))
+			})
+
+			Context("when the step is started again", func() {
+				JustBeforeEach(func() {
+					stepDelegate.StepStarted()
+				})
 
+				It("only saves its start once", func() {
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+					Expect(fakeBuild.GetEventsCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the step started before the build was resumed", func() {
+				BeforeEach(func() {
+					payload, err := json.Marshal(event.StartStep{
+						Time:   100,
+						Origin: event.Origin{ID: "some-timeout-id"},
+						Step:   "timeout",
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					data := json.RawMessage(payload)
+					fakeBuild.GetEventsReturns([]event.Envelope{
+						{
+							Data:    &data,
+							Event:   event.EventTypeStartStep,
+							Version: event.StartStep{}.Version(),
+						},
+					}, nil)
+				})
+
+				It("does not save its start again", func() {
+					Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when looking for the started steps fails", func() {
+				BeforeEach(func() {
+					fakeBuild.GetEventsReturns(nil, errors.New("nope"))
+				})
+
+				It("saves the start anyway", func() {
+					Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Describe("StepFinished", func() {
+			var (
+				succeeded exec.Success
+				finishErr error
+			)
+
+			BeforeEach(func() {
+				succeeded = false
+				finishErr = nil
+			})
+
+			JustBeforeEach(func() {
+				stepDelegate.StepFinished(succeeded, finishErr)
+			})
+
+			finishStatus := func() atc.BuildStatus {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.FinishStep{}))
+
+				finishStep := savedEvent.(event.FinishStep)
+				Expect(finishStep.Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(finishStep.Origin).To(Equal(event.Origin{ID: "some-timeout-id"}))
+				Expect(finishStep.Step).To(Equal("timeout"))
+
+				return finishStep.Status
+			}
+
+			Context("when the step succeeded", func() {
+				BeforeEach(func() {
+					succeeded = true
+				})
+
+				It("saves a finish-step event with status 'succeeded'", func() {
+					Expect(finishStatus()).To(Equal(atc.StatusSucceeded))
+				})
+			})
+
+			Context("when the step failed", func() {
+				It("saves a finish-step event with status 'failed'", func() {
+					Expect(finishStatus()).To(Equal(atc.StatusFailed))
+				})
+			})
+
+			Context("when the step errored", func() {
+				BeforeEach(func() {
+					finishErr = errors.New("nope")
+				})
+
+				It("saves a finish-step event with status 'errored'", func() {
+					Expect(finishStatus()).To(Equal(atc.StatusErrored))
+				})
+			})
+
+			Context("when the step was interrupted", func() {
+				BeforeEach(func() {
+					finishErr = exec.ErrInterrupted
+				})
+
+				It("saves a finish-step event with status 'aborted'", func() {
+					Expect(finishStatus()).To(Equal(atc.StatusAborted))
+				})
 			})
 		})
 	})
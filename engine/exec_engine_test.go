@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactstore/artifactstorefakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine"
@@ -16,6 +18,7 @@ import (
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
 	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -26,6 +29,7 @@ var _ = Describe("ExecEngine", func() {
 		fakeFactory         *execfakes.FakeFactory
 		fakeTeamDB          *dbfakes.FakeTeamDB
 		fakeDelegateFactory *enginefakes.FakeBuildDelegateFactory
+		fakeArtifactStore   *artifactstorefakes.FakeStore
 		logger              *lagertest.TestLogger
 
 		execEngine engine.Engine
@@ -35,6 +39,7 @@ var _ = Describe("ExecEngine", func() {
 	BeforeEach(func() {
 		fakeFactory = new(execfakes.FakeFactory)
 		fakeDelegateFactory = new(enginefakes.FakeBuildDelegateFactory)
+		fakeArtifactStore = new(artifactstorefakes.FakeStore)
 		logger = lagertest.NewTestLogger("test")
 
 		fakeTeamDBFactory := new(dbfakes.FakeTeamDBFactory)
@@ -44,6 +49,7 @@ var _ = Describe("ExecEngine", func() {
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			fakeArtifactStore,
 			"http://example.com",
 		)
 	})
@@ -98,6 +104,7 @@ var _ = Describe("ExecEngine", func() {
 			}
 
 			fakeDelegate = new(enginefakes.FakeBuildDelegate)
+			fakeDelegate.StepDelegateReturns(new(execfakes.FakeTimingDelegate))
 			fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 			fakeInputDelegate = new(execfakes.FakeGetDelegate)
@@ -433,7 +440,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(worker.ArtifactName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -455,7 +462,7 @@ var _ = Describe("ExecEngine", func() {
 				Expect(actualTeamID).To(Equal(teamID))
 				Expect(configSource).To(Equal(exec.ValidatingConfigSource{exec.FileConfigSource{"some-config-path"}}))
 
-				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(sourceName).To(Equal(worker.ArtifactName("some-task")))
 				Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -523,13 +530,13 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
-				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(workerMetadata.Attempts).To(Equal([]int{1}))
 			})
 		})
@@ -589,6 +596,18 @@ var _ = Describe("ExecEngine", func() {
 					_, _, planID := fakeDelegate.InputDelegateArgsForCall(0)
 					Expect(planID).To(Equal(event.OriginID(plan.ID)))
 				})
+
+				It("times the step", func() {
+					build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeDelegate.StepDelegateCallCount()).To(Equal(1))
+
+					_, timedPlan, stepType := fakeDelegate.StepDelegateArgsForCall(0)
+					Expect(timedPlan.ID).To(Equal(plan.ID))
+					Expect(stepType).To(Equal("get"))
+				})
 			})
 
 			Context("that contains tasks", func() {
@@ -628,7 +647,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, actualInputMapping, actualOutputMapping, _, _ := fakeFactory.TaskArgsForCall(0)
+						logger, sourceName, workerID, workerMetadata, delegate, privileged, tags, actualTeamID, configSource, _, actualInputMapping, actualOutputMapping, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 						Expect(logger).NotTo(BeNil())
 						Expect(sourceName).To(Equal(worker.ArtifactName("some-task")))
 						Expect(workerMetadata).To(Equal(worker.Metadata{
@@ -670,11 +689,65 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							Expect(actualImageArtifactName).To(Equal("some-image-artifact-name"))
 						})
 					})
 
+					Context("when the plan caches its outputs", func() {
+						BeforeEach(func() {
+							taskPlan.CacheOutputs = true
+						})
+
+						It("constructs the task with output caching enabled", func() {
+							var err error
+							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+							Expect(err).NotTo(HaveOccurred())
+
+							build.Resume(logger)
+							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+
+							_, _, _, _, _, _, _, _, _, _, _, _, _, actualCacheOutputs, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							Expect(actualCacheOutputs).To(BeTrue())
+						})
+					})
+
+					Context("when the plan collects reports", func() {
+						BeforeEach(func() {
+							taskPlan.Reports = &atc.TaskReports{JUnit: "some-output/*.xml"}
+						})
+
+						It("constructs the task with the reports to collect", func() {
+							var err error
+							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+							Expect(err).NotTo(HaveOccurred())
+
+							build.Resume(logger)
+							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+
+							_, _, _, _, _, _, _, _, _, _, _, _, _, _, actualReports, _, _ := fakeFactory.TaskArgsForCall(0)
+							Expect(actualReports).To(Equal(&atc.TaskReports{JUnit: "some-output/*.xml"}))
+						})
+					})
+
+					Context("when the plan has an abort grace period", func() {
+						BeforeEach(func() {
+							taskPlan.AbortGracePeriod = "5m"
+						})
+
+						It("constructs the task with the grace period", func() {
+							var err error
+							build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+							Expect(err).NotTo(HaveOccurred())
+
+							build.Resume(logger)
+							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+
+							_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, actualAbortGracePeriod, _ := fakeFactory.TaskArgsForCall(0)
+							Expect(actualAbortGracePeriod).To(Equal("5m"))
+						})
+					})
+
 					Context("when the plan contains params and config path", func() {
 						BeforeEach(func() {
 							taskPlan.Params = map[string]interface{}{
@@ -690,7 +763,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
 							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -715,7 +788,7 @@ var _ = Describe("ExecEngine", func() {
 							build.Resume(logger)
 							Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+							_, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 							vcs, ok := configSource.(exec.ValidatingConfigSource)
 							Expect(ok).To(BeTrue())
 							_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -831,6 +904,50 @@ var _ = Describe("ExecEngine", func() {
 					Expect(planID).To(Equal(event.OriginID(dependentGetPlan.ID)))
 				})
 			})
+
+			Context("that saves artifacts", func() {
+				var plan atc.Plan
+
+				BeforeEach(func() {
+					taskStepFactory.UsingStub = func(prev exec.Step, repo *worker.ArtifactRepository) exec.Step {
+						outputSource := new(workerfakes.FakeArtifactSource)
+						outputSource.StreamToStub = func(dest worker.ArtifactDestination) error {
+							return dest.StreamIn(".", strings.NewReader("some-tarball"))
+						}
+
+						repo.RegisterSource("some-output", outputSource)
+						return taskStep
+					}
+
+					plan = planFactory.NewPlan(atc.OnSuccessPlan{
+						Step: planFactory.NewPlan(atc.TaskPlan{
+							Name:       "some-task",
+							Config:     &atc.TaskConfig{},
+							PipelineID: 57,
+						}),
+						Next: planFactory.NewPlan(atc.SaveArtifactsPlan{
+							Names: []string{"some-output"},
+						}),
+					})
+				})
+
+				It("saves the outputs to the artifact store for the build", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+
+					Expect(fakeArtifactStore.SaveCallCount()).To(Equal(1))
+					buildID, name, _ := fakeArtifactStore.SaveArgsForCall(0)
+					Expect(buildID).To(Equal(42))
+					Expect(name).To(Equal("some-output"))
+
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+					_, _, succeeded, _ := fakeDelegate.FinishArgsForCall(0)
+					Expect(succeeded).To(Equal(exec.Success(true)))
+				})
+			})
 		})
 	})
 
@@ -924,6 +1041,7 @@ var _ = Describe("ExecEngine", func() {
 				)
 
 				fakeDelegate := new(enginefakes.FakeBuildDelegate)
+				fakeDelegate.StepDelegateReturns(new(execfakes.FakeTimingDelegate))
 				fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 				inputStepFactory := new(execfakes.FakeStepFactory)
@@ -40,10 +40,12 @@ var _ = Describe("Exec Engine with Try", func() {
 			fakeFactory,
 			fakeDelegateFactory,
 			fakeTeamDBFactory,
+			nil,
 			"http://example.com",
 		)
 
 		fakeDelegate = new(enginefakes.FakeBuildDelegate)
+		fakeDelegate.StepDelegateReturns(new(execfakes.FakeTimingDelegate))
 		fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 		build = new(dbfakes.FakeBuild)
@@ -98,6 +100,7 @@ var _ = Describe("Exec Engine with Try", func() {
 			BeforeEach(func() {
 				planFactory = atc.NewPlanFactory(123)
 				fakeDelegate = new(enginefakes.FakeBuildDelegate)
+				fakeDelegate.StepDelegateReturns(new(execfakes.FakeTimingDelegate))
 				fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 				fakeInputDelegate = new(execfakes.FakeGetDelegate)
@@ -0,0 +1,68 @@
+package event
+
+import "github.com/concourse/atc"
+
+// ClusterEnvelope is an Envelope for an event on the cluster-wide firehose,
+// along with the team, pipeline, and job it happened to.
+type ClusterEnvelope struct {
+	Envelope
+
+	Team     string `json:"team"`
+	Pipeline string `json:"pipeline,omitempty"`
+	Job      string `json:"job,omitempty"`
+}
+
+type BuildCreated struct {
+	BuildID   int    `json:"build_id"`
+	BuildName string `json:"build_name"`
+	Time      int64  `json:"time"`
+}
+
+func (BuildCreated) EventType() atc.EventType  { return EventTypeBuildCreated }
+func (BuildCreated) Version() atc.EventVersion { return "1.0" }
+
+type BuildStarted struct {
+	BuildID   int    `json:"build_id"`
+	BuildName string `json:"build_name"`
+	Time      int64  `json:"time"`
+}
+
+func (BuildStarted) EventType() atc.EventType  { return EventTypeBuildStarted }
+func (BuildStarted) Version() atc.EventVersion { return "1.0" }
+
+type BuildFinished struct {
+	BuildID   int             `json:"build_id"`
+	BuildName string          `json:"build_name"`
+	Status    atc.BuildStatus `json:"status"`
+	Time      int64           `json:"time"`
+}
+
+func (BuildFinished) EventType() atc.EventType  { return EventTypeBuildFinished }
+func (BuildFinished) Version() atc.EventVersion { return "1.0" }
+
+type ResourceVersionsDiscovered struct {
+	Resource string        `json:"resource"`
+	Versions []atc.Version `json:"versions"`
+}
+
+func (ResourceVersionsDiscovered) EventType() atc.EventType {
+	return EventTypeResourceVersionsDiscovered
+}
+func (ResourceVersionsDiscovered) Version() atc.EventVersion { return "1.0" }
+
+type PipelinePaused struct{}
+
+func (PipelinePaused) EventType() atc.EventType  { return EventTypePipelinePaused }
+func (PipelinePaused) Version() atc.EventVersion { return "1.0" }
+
+type PipelineUnpaused struct{}
+
+func (PipelineUnpaused) EventType() atc.EventType  { return EventTypePipelineUnpaused }
+func (PipelineUnpaused) Version() atc.EventVersion { return "1.0" }
+
+type PipelineConfigSaved struct {
+	Created bool `json:"created"`
+}
+
+func (PipelineConfigSaved) EventType() atc.EventType  { return EventTypePipelineConfigSaved }
+func (PipelineConfigSaved) Version() atc.EventVersion { return "1.0" }
@@ -370,6 +370,15 @@ type LogV40 struct {
 func (LogV40) EventType() atc.EventType  { return "log" }
 func (LogV40) Version() atc.EventVersion { return "4.0" }
 
+type LogV50 struct {
+	Origin  Origin `json:"origin"`
+	Payload string `json:"payload"`
+	Time    int64  `json:"time,omitempty"`
+}
+
+func (LogV50) EventType() atc.EventType  { return "log" }
+func (LogV50) Version() atc.EventVersion { return "5.0" }
+
 type OriginV40 struct {
 	Name     string            `json:"name"`
 	Type     OriginV40Type     `json:"type"`
@@ -34,6 +34,13 @@ type TaskConfig struct {
 
 	Run    TaskRunConfig     `json:"run"`
 	Inputs []TaskInputConfig `json:"inputs"`
+
+	ContainerLimits ContainerLimits `json:"container_limits"`
+}
+
+type ContainerLimits struct {
+	CPU    uint64 `json:"cpu,omitempty"`
+	Memory uint64 `json:"memory,omitempty"`
 }
 
 type TaskRunConfig struct {
@@ -66,6 +73,10 @@ func ShadowTaskConfig(config atc.TaskConfig) TaskConfig {
 			Dir:  config.Run.Dir,
 		},
 		Inputs: inputConfigs,
+		ContainerLimits: ContainerLimits{
+			CPU:    config.ContainerLimits.CPU,
+			Memory: config.ContainerLimits.Memory,
+		},
 	}
 }
 
@@ -86,12 +97,21 @@ func (Status) EventType() atc.EventType  { return EventTypeStatus }
 func (Status) Version() atc.EventVersion { return "1.0" }
 
 type Log struct {
-	Origin  Origin `json:"origin"`
-	Payload string `json:"payload"`
+	Origin  Origin    `json:"origin"`
+	Payload string    `json:"payload"`
+	Time    int64     `json:"time,omitempty"`
+	Lines   []LogLine `json:"lines,omitempty"`
 }
 
 func (Log) EventType() atc.EventType  { return EventTypeLog }
-func (Log) Version() atc.EventVersion { return "5.0" }
+func (Log) Version() atc.EventVersion { return "5.1" }
+
+// LogLine is a line starting in a Log event's payload, at the given byte
+// offset, and the time it was written in fractional seconds.
+type LogLine struct {
+	Offset int     `json:"offset"`
+	Time   float64 `json:"time"`
+}
 
 type Origin struct {
 	ID     OriginID     `json:"id,omitempty"`
@@ -149,9 +169,115 @@ type InitializeGet struct {
 func (InitializeGet) EventType() atc.EventType  { return EventTypeInitializeGet }
 func (InitializeGet) Version() atc.EventVersion { return "1.0" }
 
+type StartGet struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (StartGet) EventType() atc.EventType  { return EventTypeStartGet }
+func (StartGet) Version() atc.EventVersion { return "1.0" }
+
 type InitializePut struct {
 	Origin Origin `json:"origin"`
 }
 
 func (InitializePut) EventType() atc.EventType  { return EventTypeInitializePut }
 func (InitializePut) Version() atc.EventVersion { return "1.0" }
+
+type StartPut struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (StartPut) EventType() atc.EventType  { return EventTypeStartPut }
+func (StartPut) Version() atc.EventVersion { return "1.0" }
+
+type WaitingForWorker struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (WaitingForWorker) EventType() atc.EventType  { return EventTypeWaitingForWorker }
+func (WaitingForWorker) Version() atc.EventVersion { return "1.0" }
+
+type FinishAttempt struct {
+	Time     int64           `json:"time"`
+	Origin   Origin          `json:"origin"`
+	Attempts []int           `json:"attempts"`
+	Status   atc.BuildStatus `json:"status"`
+	Retrying bool            `json:"retrying"`
+}
+
+func (FinishAttempt) EventType() atc.EventType  { return EventTypeFinishAttempt }
+func (FinishAttempt) Version() atc.EventVersion { return "1.0" }
+
+type CacheHit struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Key    string `json:"key"`
+}
+
+func (CacheHit) EventType() atc.EventType  { return EventTypeCacheHit }
+func (CacheHit) Version() atc.EventVersion { return "1.0" }
+
+type StartStep struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+	Step   string `json:"step"`
+}
+
+func (StartStep) EventType() atc.EventType  { return EventTypeStartStep }
+func (StartStep) Version() atc.EventVersion { return "1.0" }
+
+type FinishStep struct {
+	Time   int64           `json:"time"`
+	Origin Origin          `json:"origin"`
+	Step   string          `json:"step"`
+	Status atc.BuildStatus `json:"status"`
+}
+
+func (FinishStep) EventType() atc.EventType  { return EventTypeFinishStep }
+func (FinishStep) Version() atc.EventVersion { return "1.0" }
+
+// ImageFetchPhase and StreamPhase times are in fractional seconds, as the
+// phases often take less than a second.
+type ImageFetchPhase struct {
+	StartTime float64 `json:"start_time"`
+	EndTime   float64 `json:"end_time"`
+	Origin    Origin  `json:"origin"`
+	Phase     string  `json:"phase"`
+}
+
+func (ImageFetchPhase) EventType() atc.EventType  { return EventTypeImageFetchPhase }
+func (ImageFetchPhase) Version() atc.EventVersion { return "1.0" }
+
+type StreamPhase struct {
+	StartTime float64 `json:"start_time"`
+	EndTime   float64 `json:"end_time"`
+	Origin    Origin  `json:"origin"`
+	Direction string  `json:"direction"`
+	Artifact  string  `json:"artifact"`
+}
+
+func (StreamPhase) EventType() atc.EventType  { return EventTypeStreamPhase }
+func (StreamPhase) Version() atc.EventVersion { return "1.0" }
+
+type AbortTask struct {
+	Time        int64  `json:"time"`
+	Origin      Origin `json:"origin"`
+	Phase       string `json:"phase"`
+	GracePeriod int64  `json:"grace_period"`
+}
+
+func (AbortTask) EventType() atc.EventType  { return EventTypeAbortTask }
+func (AbortTask) Version() atc.EventVersion { return "1.0" }
+
+type ImageFetchTimeout struct {
+	Time    int64  `json:"time"`
+	Origin  Origin `json:"origin"`
+	Phase   string `json:"phase"`
+	Timeout int64  `json:"timeout"`
+}
+
+func (ImageFetchTimeout) EventType() atc.EventType  { return EventTypeImageFetchTimeout }
+func (ImageFetchTimeout) Version() atc.EventVersion { return "1.0" }
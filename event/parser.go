@@ -37,12 +37,30 @@ func init() {
 	registerEvent(StartTask{})
 	registerEvent(FinishTask{})
 	registerEvent(InitializeGet{})
+	registerEvent(StartGet{})
 	registerEvent(FinishGet{})
 	registerEvent(InitializePut{})
+	registerEvent(StartPut{})
 	registerEvent(FinishPut{})
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
+	registerEvent(WaitingForWorker{})
+	registerEvent(FinishAttempt{})
+	registerEvent(CacheHit{})
+	registerEvent(StartStep{})
+	registerEvent(FinishStep{})
+	registerEvent(ImageFetchPhase{})
+	registerEvent(StreamPhase{})
+	registerEvent(AbortTask{})
+	registerEvent(ImageFetchTimeout{})
+	registerEvent(BuildCreated{})
+	registerEvent(BuildStarted{})
+	registerEvent(BuildFinished{})
+	registerEvent(ResourceVersionsDiscovered{})
+	registerEvent(PipelinePaused{})
+	registerEvent(PipelineUnpaused{})
+	registerEvent(PipelineConfigSaved{})
 
 	// deprecated:
 	registerEvent(FinishV10{})
@@ -68,6 +86,7 @@ func init() {
 	registerEvent(LogV20{})
 	registerEvent(LogV30{})
 	registerEvent(LogV40{})
+	registerEvent(LogV50{})
 	registerEvent(FinishGetV10{})
 	registerEvent(FinishGetV20{})
 	registerEvent(FinishGetV30{})
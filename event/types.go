@@ -21,15 +21,71 @@ const (
 	// get step initializing
 	EventTypeInitializeGet atc.EventType = "initialize-get"
 
+	// get step's script started, or the resource was found in the cache
+	EventTypeStartGet atc.EventType = "start-get"
+
 	// finished getting something
 	EventTypeFinishGet atc.EventType = "finish-get"
 
 	// put step initializing
 	EventTypeInitializePut atc.EventType = "initialize-put"
 
+	// put step's script started
+	EventTypeStartPut atc.EventType = "start-put"
+
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// every compatible worker is full; the step is queued until one frees up
+	EventTypeWaitingForWorker atc.EventType = "waiting-for-worker"
+
+	// an attempt of a step with attempts finished, and may be retried
+	EventTypeFinishAttempt atc.EventType = "finish-attempt"
+
+	// a task's outputs were reused from a previous run with the same inputs
+	EventTypeCacheHit atc.EventType = "cache-hit"
+
+	// a step of any kind started running
+	EventTypeStartStep atc.EventType = "start-step"
+
+	// a step of any kind finished running
+	EventTypeFinishStep atc.EventType = "finish-step"
+
+	// a phase of fetching a step's image (checking or getting) finished
+	EventTypeImageFetchPhase atc.EventType = "image-fetch-phase"
+
+	// an artifact finished streaming into or out of a step
+	EventTypeStreamPhase atc.EventType = "stream-phase"
+
+	// a task's process is being stopped as its build was aborted
+	EventTypeAbortTask atc.EventType = "abort-task"
+
+	// checking or getting a step's image did not finish within its timeout
+	EventTypeImageFetchTimeout atc.EventType = "image-fetch-timeout"
+)
+
+const (
+	// a build was created, either by the scheduler or manually
+	EventTypeBuildCreated atc.EventType = "build-created"
+
+	// a build was picked up by the engine
+	EventTypeBuildStarted atc.EventType = "build-started"
+
+	// a build completed, with its final status
+	EventTypeBuildFinished atc.EventType = "build-finished"
+
+	// a check found versions of a resource that had not been seen before
+	EventTypeResourceVersionsDiscovered atc.EventType = "resource-versions-discovered"
+
+	// a pipeline was paused
+	EventTypePipelinePaused atc.EventType = "pipeline-paused"
+
+	// a pipeline was unpaused
+	EventTypePipelineUnpaused atc.EventType = "pipeline-unpaused"
+
+	// a pipeline's config was set
+	EventTypePipelineConfigSaved atc.EventType = "pipeline-config-saved"
 )
@@ -37,6 +37,10 @@ func (configSource StaticConfigSource) FetchConfig(*worker.ArtifactRepository) (
 		taskConfig = *configSource.Plan.Config
 	}
 
+	if configSource.Plan.ContainerLimits != nil {
+		taskConfig.ContainerLimits = taskConfig.ContainerLimits.Merge(*configSource.Plan.ContainerLimits)
+	}
+
 	if configSource.Plan.Params == nil {
 		return taskConfig, nil
 	}
@@ -191,6 +191,22 @@ var _ = Describe("ConfigSource", func() {
 			})
 		})
 
+		Context("when the plan has container limits", func() {
+			BeforeEach(func() {
+				taskConfig.ContainerLimits = atc.ContainerLimits{CPU: 512, Memory: 1024}
+				taskPlan.ContainerLimits = &atc.ContainerLimits{Memory: 2048}
+			})
+
+			It("merges them into the task config's limits, prefering the plan", func() {
+				fetchedConfig, err := configSource.FetchConfig(repo)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fetchedConfig.ContainerLimits).To(Equal(atc.ContainerLimits{
+					CPU:    512,
+					Memory: 2048,
+				}))
+			})
+		})
+
 		Context("when the plan has no task config", func() {
 			BeforeEach(func() {
 				taskPlan.Config = nil
@@ -4,6 +4,7 @@ package execfakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/worker"
@@ -44,8 +45,11 @@ type FakePutDelegate struct {
 	stderrReturns     struct {
 		result1 io.Writer
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	WaitingForWorkerStub        func()
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct{}
+	invocations                 map[string][][]interface{}
+	invocationsMutex            sync.RWMutex
 }
 
 func (fake *FakePutDelegate) Initializing() {
@@ -196,6 +200,192 @@ func (fake *FakePutDelegate) StderrReturns(result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakePutDelegate) WaitingForWorker() {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct{}{})
+	fake.recordInvocation("WaitingForWorker", []interface{}{})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub()
+	}
+}
+
+func (fake *FakePutDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakePutDelegate) ImageFetchTimeouts() worker.ImageFetchTimeouts {
+	fake.imageFetchTimeoutsMutex.Lock()
+	fake.imageFetchTimeoutsArgsForCall = append(fake.imageFetchTimeoutsArgsForCall, struct{}{})
+	fake.recordInvocation("ImageFetchTimeouts", []interface{}{})
+	fake.imageFetchTimeoutsMutex.Unlock()
+	if fake.ImageFetchTimeoutsStub != nil {
+		return fake.ImageFetchTimeoutsStub()
+	} else {
+		return fake.imageFetchTimeoutsReturns.result1
+	}
+}
+
+func (fake *FakePutDelegate) ImageFetchTimeoutsCallCount() int {
+	fake.imageFetchTimeoutsMutex.RLock()
+	defer fake.imageFetchTimeoutsMutex.RUnlock()
+	return len(fake.imageFetchTimeoutsArgsForCall)
+}
+
+func (fake *FakePutDelegate) ImageFetchTimeoutsReturns(result1 worker.ImageFetchTimeouts) {
+	fake.ImageFetchTimeoutsStub = nil
+	fake.imageFetchTimeoutsReturns = struct {
+		result1 worker.ImageFetchTimeouts
+	}{result1}
+}
+
+func (fake *FakePutDelegate) ImageFetchPhaseFinished(phase string, start time.Time, end time.Time) {
+	fake.imageFetchPhaseFinishedMutex.Lock()
+	fake.imageFetchPhaseFinishedArgsForCall = append(fake.imageFetchPhaseFinishedArgsForCall, struct {
+		phase string
+		start time.Time
+		end   time.Time
+	}{phase, start, end})
+	fake.recordInvocation("ImageFetchPhaseFinished", []interface{}{phase, start, end})
+	fake.imageFetchPhaseFinishedMutex.Unlock()
+	if fake.ImageFetchPhaseFinishedStub != nil {
+		fake.ImageFetchPhaseFinishedStub(phase, start, end)
+	}
+}
+
+func (fake *FakePutDelegate) ImageFetchPhaseFinishedCallCount() int {
+	fake.imageFetchPhaseFinishedMutex.RLock()
+	defer fake.imageFetchPhaseFinishedMutex.RUnlock()
+	return len(fake.imageFetchPhaseFinishedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ImageFetchPhaseFinishedArgsForCall(i int) (string, time.Time, time.Time) {
+	fake.imageFetchPhaseFinishedMutex.RLock()
+	defer fake.imageFetchPhaseFinishedMutex.RUnlock()
+	return fake.imageFetchPhaseFinishedArgsForCall[i].phase, fake.imageFetchPhaseFinishedArgsForCall[i].start, fake.imageFetchPhaseFinishedArgsForCall[i].end
+}
+
+func (fake *FakePutDelegate) ArtifactStreamed(direction exec.StreamDirection, name worker.ArtifactName, start time.Time, end time.Time) {
+	fake.artifactStreamedMutex.Lock()
+	fake.artifactStreamedArgsForCall = append(fake.artifactStreamedArgsForCall, struct {
+		direction exec.StreamDirection
+		name      worker.ArtifactName
+		start     time.Time
+		end       time.Time
+	}{direction, name, start, end})
+	fake.recordInvocation("ArtifactStreamed", []interface{}{direction, name, start, end})
+	fake.artifactStreamedMutex.Unlock()
+	if fake.ArtifactStreamedStub != nil {
+		fake.ArtifactStreamedStub(direction, name, start, end)
+	}
+}
+
+func (fake *FakePutDelegate) ArtifactStreamedCallCount() int {
+	fake.artifactStreamedMutex.RLock()
+	defer fake.artifactStreamedMutex.RUnlock()
+	return len(fake.artifactStreamedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ArtifactStreamedArgsForCall(i int) (exec.StreamDirection, worker.ArtifactName, time.Time, time.Time) {
+	fake.artifactStreamedMutex.RLock()
+	defer fake.artifactStreamedMutex.RUnlock()
+	return fake.artifactStreamedArgsForCall[i].direction, fake.artifactStreamedArgsForCall[i].name, fake.artifactStreamedArgsForCall[i].start, fake.artifactStreamedArgsForCall[i].end
+}
+
+func (fake *FakePutDelegate) Checkpoint() (exec.Checkpoint, bool, error) {
+	fake.checkpointMutex.Lock()
+	fake.checkpointArgsForCall = append(fake.checkpointArgsForCall, struct{}{})
+	fake.recordInvocation("Checkpoint", []interface{}{})
+	fake.checkpointMutex.Unlock()
+	if fake.CheckpointStub != nil {
+		return fake.CheckpointStub()
+	} else {
+		return fake.checkpointReturns.result1, fake.checkpointReturns.result2, fake.checkpointReturns.result3
+	}
+}
+
+func (fake *FakePutDelegate) CheckpointCallCount() int {
+	fake.checkpointMutex.RLock()
+	defer fake.checkpointMutex.RUnlock()
+	return len(fake.checkpointArgsForCall)
+}
+
+func (fake *FakePutDelegate) CheckpointReturns(result1 exec.Checkpoint, result2 bool, result3 error) {
+	fake.CheckpointStub = nil
+	fake.checkpointReturns = struct {
+		result1 exec.Checkpoint
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePutDelegate) Resumed(arg1 exec.Checkpoint) {
+	fake.resumedMutex.Lock()
+	fake.resumedArgsForCall = append(fake.resumedArgsForCall, struct {
+		arg1 exec.Checkpoint
+	}{arg1})
+	fake.recordInvocation("Resumed", []interface{}{arg1})
+	fake.resumedMutex.Unlock()
+	if fake.ResumedStub != nil {
+		fake.ResumedStub(arg1)
+	}
+}
+
+func (fake *FakePutDelegate) ResumedCallCount() int {
+	fake.resumedMutex.RLock()
+	defer fake.resumedMutex.RUnlock()
+	return len(fake.resumedArgsForCall)
+}
+
+func (fake *FakePutDelegate) ResumedArgsForCall(i int) exec.Checkpoint {
+	fake.resumedMutex.RLock()
+	defer fake.resumedMutex.RUnlock()
+	return fake.resumedArgsForCall[i].arg1
+}
+
+func (fake *FakePutDelegate) ImageFetchTimedOut(phase string, timeout time.Duration) {
+	fake.imageFetchTimedOutMutex.Lock()
+	fake.imageFetchTimedOutArgsForCall = append(fake.imageFetchTimedOutArgsForCall, struct {
+		phase   string
+		timeout time.Duration
+	}{phase, timeout})
+	fake.recordInvocation("ImageFetchTimedOut", []interface{}{phase, timeout})
+	fake.imageFetchTimedOutMutex.Unlock()
+	if fake.ImageFetchTimedOutStub != nil {
+		fake.ImageFetchTimedOutStub(phase, timeout)
+	}
+}
+
+func (fake *FakePutDelegate) ImageFetchTimedOutCallCount() int {
+	fake.imageFetchTimedOutMutex.RLock()
+	defer fake.imageFetchTimedOutMutex.RUnlock()
+	return len(fake.imageFetchTimedOutArgsForCall)
+}
+
+func (fake *FakePutDelegate) ImageFetchTimedOutArgsForCall(i int) (string, time.Duration) {
+	fake.imageFetchTimedOutMutex.RLock()
+	defer fake.imageFetchTimedOutMutex.RUnlock()
+	return fake.imageFetchTimedOutArgsForCall[i].phase, fake.imageFetchTimedOutArgsForCall[i].timeout
+}
+
+func (fake *FakePutDelegate) Started() {
+	fake.startedMutex.Lock()
+	fake.startedArgsForCall = append(fake.startedArgsForCall, struct{}{})
+	fake.recordInvocation("Started", []interface{}{})
+	fake.startedMutex.Unlock()
+	if fake.StartedStub != nil {
+		fake.StartedStub()
+	}
+}
+
+func (fake *FakePutDelegate) StartedCallCount() int {
+	fake.startedMutex.RLock()
+	defer fake.startedMutex.RUnlock()
+	return len(fake.startedArgsForCall)
+}
+
 func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -211,6 +401,22 @@ func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stdoutMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	fake.imageFetchTimeoutsMutex.RLock()
+	defer fake.imageFetchTimeoutsMutex.RUnlock()
+	fake.imageFetchPhaseFinishedMutex.RLock()
+	defer fake.imageFetchPhaseFinishedMutex.RUnlock()
+	fake.artifactStreamedMutex.RLock()
+	defer fake.artifactStreamedMutex.RUnlock()
+	fake.checkpointMutex.RLock()
+	defer fake.checkpointMutex.RUnlock()
+	fake.resumedMutex.RLock()
+	defer fake.resumedMutex.RUnlock()
+	fake.imageFetchTimedOutMutex.RLock()
+	defer fake.imageFetchTimedOutMutex.RUnlock()
+	fake.startedMutex.RLock()
+	defer fake.startedMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -4,6 +4,7 @@ package execfakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/exec"
@@ -49,8 +50,11 @@ type FakeTaskDelegate struct {
 	stderrReturns     struct {
 		result1 io.Writer
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	WaitingForWorkerStub        func()
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct{}
+	invocations                 map[string][][]interface{}
+	invocationsMutex            sync.RWMutex
 }
 
 func (fake *FakeTaskDelegate) Initializing(arg1 atc.TaskConfig) {
@@ -224,6 +228,257 @@ func (fake *FakeTaskDelegate) StderrReturns(result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakeTaskDelegate) WaitingForWorker() {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct{}{})
+	fake.recordInvocation("WaitingForWorker", []interface{}{})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub()
+	}
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageFetchTimeouts() worker.ImageFetchTimeouts {
+	fake.imageFetchTimeoutsMutex.Lock()
+	fake.imageFetchTimeoutsArgsForCall = append(fake.imageFetchTimeoutsArgsForCall, struct{}{})
+	fake.recordInvocation("ImageFetchTimeouts", []interface{}{})
+	fake.imageFetchTimeoutsMutex.Unlock()
+	if fake.ImageFetchTimeoutsStub != nil {
+		return fake.ImageFetchTimeoutsStub()
+	} else {
+		return fake.imageFetchTimeoutsReturns.result1
+	}
+}
+
+func (fake *FakeTaskDelegate) ImageFetchTimeoutsCallCount() int {
+	fake.imageFetchTimeoutsMutex.RLock()
+	defer fake.imageFetchTimeoutsMutex.RUnlock()
+	return len(fake.imageFetchTimeoutsArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageFetchTimeoutsReturns(result1 worker.ImageFetchTimeouts) {
+	fake.ImageFetchTimeoutsStub = nil
+	fake.imageFetchTimeoutsReturns = struct {
+		result1 worker.ImageFetchTimeouts
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) CacheHit(arg1 string) {
+	fake.cacheHitMutex.Lock()
+	fake.cacheHitArgsForCall = append(fake.cacheHitArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("CacheHit", []interface{}{arg1})
+	fake.cacheHitMutex.Unlock()
+	if fake.CacheHitStub != nil {
+		fake.CacheHitStub(arg1)
+	}
+}
+
+func (fake *FakeTaskDelegate) CacheHitCallCount() int {
+	fake.cacheHitMutex.RLock()
+	defer fake.cacheHitMutex.RUnlock()
+	return len(fake.cacheHitArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) CacheHitArgsForCall(i int) string {
+	fake.cacheHitMutex.RLock()
+	defer fake.cacheHitMutex.RUnlock()
+	return fake.cacheHitArgsForCall[i].arg1
+}
+
+func (fake *FakeTaskDelegate) ImageFetchPhaseFinished(phase string, start time.Time, end time.Time) {
+	fake.imageFetchPhaseFinishedMutex.Lock()
+	fake.imageFetchPhaseFinishedArgsForCall = append(fake.imageFetchPhaseFinishedArgsForCall, struct {
+		phase string
+		start time.Time
+		end   time.Time
+	}{phase, start, end})
+	fake.recordInvocation("ImageFetchPhaseFinished", []interface{}{phase, start, end})
+	fake.imageFetchPhaseFinishedMutex.Unlock()
+	if fake.ImageFetchPhaseFinishedStub != nil {
+		fake.ImageFetchPhaseFinishedStub(phase, start, end)
+	}
+}
+
+func (fake *FakeTaskDelegate) ImageFetchPhaseFinishedCallCount() int {
+	fake.imageFetchPhaseFinishedMutex.RLock()
+	defer fake.imageFetchPhaseFinishedMutex.RUnlock()
+	return len(fake.imageFetchPhaseFinishedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageFetchPhaseFinishedArgsForCall(i int) (string, time.Time, time.Time) {
+	fake.imageFetchPhaseFinishedMutex.RLock()
+	defer fake.imageFetchPhaseFinishedMutex.RUnlock()
+	return fake.imageFetchPhaseFinishedArgsForCall[i].phase, fake.imageFetchPhaseFinishedArgsForCall[i].start, fake.imageFetchPhaseFinishedArgsForCall[i].end
+}
+
+func (fake *FakeTaskDelegate) ArtifactStreamed(direction exec.StreamDirection, name worker.ArtifactName, start time.Time, end time.Time) {
+	fake.artifactStreamedMutex.Lock()
+	fake.artifactStreamedArgsForCall = append(fake.artifactStreamedArgsForCall, struct {
+		direction exec.StreamDirection
+		name      worker.ArtifactName
+		start     time.Time
+		end       time.Time
+	}{direction, name, start, end})
+	fake.recordInvocation("ArtifactStreamed", []interface{}{direction, name, start, end})
+	fake.artifactStreamedMutex.Unlock()
+	if fake.ArtifactStreamedStub != nil {
+		fake.ArtifactStreamedStub(direction, name, start, end)
+	}
+}
+
+func (fake *FakeTaskDelegate) ArtifactStreamedCallCount() int {
+	fake.artifactStreamedMutex.RLock()
+	defer fake.artifactStreamedMutex.RUnlock()
+	return len(fake.artifactStreamedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ArtifactStreamedArgsForCall(i int) (exec.StreamDirection, worker.ArtifactName, time.Time, time.Time) {
+	fake.artifactStreamedMutex.RLock()
+	defer fake.artifactStreamedMutex.RUnlock()
+	return fake.artifactStreamedArgsForCall[i].direction, fake.artifactStreamedArgsForCall[i].name, fake.artifactStreamedArgsForCall[i].start, fake.artifactStreamedArgsForCall[i].end
+}
+
+func (fake *FakeTaskDelegate) Annotated(arg1 []atc.MetadataField) {
+	var arg1Copy []atc.MetadataField
+	if arg1 != nil {
+		arg1Copy = make([]atc.MetadataField, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.annotatedMutex.Lock()
+	fake.annotatedArgsForCall = append(fake.annotatedArgsForCall, struct {
+		arg1 []atc.MetadataField
+	}{arg1Copy})
+	fake.recordInvocation("Annotated", []interface{}{arg1Copy})
+	fake.annotatedMutex.Unlock()
+	if fake.AnnotatedStub != nil {
+		fake.AnnotatedStub(arg1)
+	}
+}
+
+func (fake *FakeTaskDelegate) AnnotatedCallCount() int {
+	fake.annotatedMutex.RLock()
+	defer fake.annotatedMutex.RUnlock()
+	return len(fake.annotatedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) AnnotatedArgsForCall(i int) []atc.MetadataField {
+	fake.annotatedMutex.RLock()
+	defer fake.annotatedMutex.RUnlock()
+	return fake.annotatedArgsForCall[i].arg1
+}
+
+func (fake *FakeTaskDelegate) TestResultsReported(arg1 []atc.TestResult) {
+	var arg1Copy []atc.TestResult
+	if arg1 != nil {
+		arg1Copy = make([]atc.TestResult, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.testResultsReportedMutex.Lock()
+	fake.testResultsReportedArgsForCall = append(fake.testResultsReportedArgsForCall, struct {
+		arg1 []atc.TestResult
+	}{arg1Copy})
+	fake.recordInvocation("TestResultsReported", []interface{}{arg1Copy})
+	fake.testResultsReportedMutex.Unlock()
+	if fake.TestResultsReportedStub != nil {
+		fake.TestResultsReportedStub(arg1)
+	}
+}
+
+func (fake *FakeTaskDelegate) TestResultsReportedCallCount() int {
+	fake.testResultsReportedMutex.RLock()
+	defer fake.testResultsReportedMutex.RUnlock()
+	return len(fake.testResultsReportedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) TestResultsReportedArgsForCall(i int) []atc.TestResult {
+	fake.testResultsReportedMutex.RLock()
+	defer fake.testResultsReportedMutex.RUnlock()
+	return fake.testResultsReportedArgsForCall[i].arg1
+}
+
+func (fake *FakeTaskDelegate) AbortProgressed(phase exec.AbortPhase, gracePeriod time.Duration) {
+	fake.abortProgressedMutex.Lock()
+	fake.abortProgressedArgsForCall = append(fake.abortProgressedArgsForCall, struct {
+		phase       exec.AbortPhase
+		gracePeriod time.Duration
+	}{phase, gracePeriod})
+	fake.recordInvocation("AbortProgressed", []interface{}{phase, gracePeriod})
+	fake.abortProgressedMutex.Unlock()
+	if fake.AbortProgressedStub != nil {
+		fake.AbortProgressedStub(phase, gracePeriod)
+	}
+}
+
+func (fake *FakeTaskDelegate) AbortProgressedCallCount() int {
+	fake.abortProgressedMutex.RLock()
+	defer fake.abortProgressedMutex.RUnlock()
+	return len(fake.abortProgressedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) AbortProgressedArgsForCall(i int) (exec.AbortPhase, time.Duration) {
+	fake.abortProgressedMutex.RLock()
+	defer fake.abortProgressedMutex.RUnlock()
+	return fake.abortProgressedArgsForCall[i].phase, fake.abortProgressedArgsForCall[i].gracePeriod
+}
+
+func (fake *FakeTaskDelegate) AbortProgressed(phase exec.AbortPhase, gracePeriod time.Duration) {
+	fake.abortProgressedMutex.Lock()
+	fake.abortProgressedArgsForCall = append(fake.abortProgressedArgsForCall, struct {
+		phase       exec.AbortPhase
+		gracePeriod time.Duration
+	}{phase, gracePeriod})
+	fake.recordInvocation("AbortProgressed", []interface{}{phase, gracePeriod})
+	fake.abortProgressedMutex.Unlock()
+	if fake.AbortProgressedStub != nil {
+		fake.AbortProgressedStub(phase, gracePeriod)
+	}
+}
+
+func (fake *FakeTaskDelegate) AbortProgressedCallCount() int {
+	fake.abortProgressedMutex.RLock()
+	defer fake.abortProgressedMutex.RUnlock()
+	return len(fake.abortProgressedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) AbortProgressedArgsForCall(i int) (exec.AbortPhase, time.Duration) {
+	fake.abortProgressedMutex.RLock()
+	defer fake.abortProgressedMutex.RUnlock()
+	return fake.abortProgressedArgsForCall[i].phase, fake.abortProgressedArgsForCall[i].gracePeriod
+}
+
+func (fake *FakeTaskDelegate) ImageFetchTimedOut(phase string, timeout time.Duration) {
+	fake.imageFetchTimedOutMutex.Lock()
+	fake.imageFetchTimedOutArgsForCall = append(fake.imageFetchTimedOutArgsForCall, struct {
+		phase   string
+		timeout time.Duration
+	}{phase, timeout})
+	fake.recordInvocation("ImageFetchTimedOut", []interface{}{phase, timeout})
+	fake.imageFetchTimedOutMutex.Unlock()
+	if fake.ImageFetchTimedOutStub != nil {
+		fake.ImageFetchTimedOutStub(phase, timeout)
+	}
+}
+
+func (fake *FakeTaskDelegate) ImageFetchTimedOutCallCount() int {
+	fake.imageFetchTimedOutMutex.RLock()
+	defer fake.imageFetchTimedOutMutex.RUnlock()
+	return len(fake.imageFetchTimedOutArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageFetchTimedOutArgsForCall(i int) (string, time.Duration) {
+	fake.imageFetchTimedOutMutex.RLock()
+	defer fake.imageFetchTimedOutMutex.RUnlock()
+	return fake.imageFetchTimedOutArgsForCall[i].phase, fake.imageFetchTimedOutArgsForCall[i].timeout
+}
+
 func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -241,6 +496,26 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stdoutMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	fake.imageFetchTimeoutsMutex.RLock()
+	defer fake.imageFetchTimeoutsMutex.RUnlock()
+	fake.cacheHitMutex.RLock()
+	defer fake.cacheHitMutex.RUnlock()
+	fake.imageFetchPhaseFinishedMutex.RLock()
+	defer fake.imageFetchPhaseFinishedMutex.RUnlock()
+	fake.artifactStreamedMutex.RLock()
+	defer fake.artifactStreamedMutex.RUnlock()
+	fake.annotatedMutex.RLock()
+	defer fake.annotatedMutex.RUnlock()
+	fake.testResultsReportedMutex.RLock()
+	defer fake.testResultsReportedMutex.RUnlock()
+	fake.abortProgressedMutex.RLock()
+	defer fake.abortProgressedMutex.RUnlock()
+	fake.abortProgressedMutex.RLock()
+	defer fake.abortProgressedMutex.RUnlock()
+	fake.imageFetchTimedOutMutex.RLock()
+	defer fake.imageFetchTimedOutMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,87 @@
+// This file was generated by counterfeiter
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/exec"
+)
+
+type FakeTimingDelegate struct {
+	StepStartedStub         func()
+	stepStartedMutex        sync.RWMutex
+	stepStartedArgsForCall  []struct{}
+	StepFinishedStub        func(exec.Success, error)
+	stepFinishedMutex       sync.RWMutex
+	stepFinishedArgsForCall []struct {
+		arg1 exec.Success
+		arg2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTimingDelegate) StepStarted() {
+	fake.stepStartedMutex.Lock()
+	fake.stepStartedArgsForCall = append(fake.stepStartedArgsForCall, struct{}{})
+	fake.recordInvocation("StepStarted", []interface{}{})
+	fake.stepStartedMutex.Unlock()
+	if fake.StepStartedStub != nil {
+		fake.StepStartedStub()
+	}
+}
+
+func (fake *FakeTimingDelegate) StepStartedCallCount() int {
+	fake.stepStartedMutex.RLock()
+	defer fake.stepStartedMutex.RUnlock()
+	return len(fake.stepStartedArgsForCall)
+}
+
+func (fake *FakeTimingDelegate) StepFinished(arg1 exec.Success, arg2 error) {
+	fake.stepFinishedMutex.Lock()
+	fake.stepFinishedArgsForCall = append(fake.stepFinishedArgsForCall, struct {
+		arg1 exec.Success
+		arg2 error
+	}{arg1, arg2})
+	fake.recordInvocation("StepFinished", []interface{}{arg1, arg2})
+	fake.stepFinishedMutex.Unlock()
+	if fake.StepFinishedStub != nil {
+		fake.StepFinishedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTimingDelegate) StepFinishedCallCount() int {
+	fake.stepFinishedMutex.RLock()
+	defer fake.stepFinishedMutex.RUnlock()
+	return len(fake.stepFinishedArgsForCall)
+}
+
+func (fake *FakeTimingDelegate) StepFinishedArgsForCall(i int) (exec.Success, error) {
+	fake.stepFinishedMutex.RLock()
+	defer fake.stepFinishedMutex.RUnlock()
+	return fake.stepFinishedArgsForCall[i].arg1, fake.stepFinishedArgsForCall[i].arg2
+}
+
+func (fake *FakeTimingDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.stepStartedMutex.RLock()
+	defer fake.stepStartedMutex.RUnlock()
+	fake.stepFinishedMutex.RLock()
+	defer fake.stepFinishedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeTimingDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.TimingDelegate = new(FakeTimingDelegate)
@@ -2,6 +2,7 @@ package exec
 
 import (
 	"io"
+	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
@@ -74,6 +75,9 @@ type Factory interface {
 		map[string]string,
 		map[string]string,
 		string,
+		bool,
+		*atc.TaskReports,
+		string,
 		clock.Clock,
 	) StepFactory
 }
@@ -91,11 +95,23 @@ type StepMetadata interface {
 type TaskDelegate interface {
 	Initializing(atc.TaskConfig)
 	Started()
+	CacheHit(key string)
 
 	Finished(ExitStatus)
 	Failed(error)
 
+	Annotated([]atc.MetadataField)
+	TestResultsReported([]atc.TestResult)
+
 	ImageVersionDetermined(worker.ResourceCacheIdentifier) error
+	WaitingForWorker()
+	ImageFetchTimeouts() worker.ImageFetchTimeouts
+	ImageFetchPhaseFinished(phase string, start time.Time, end time.Time)
+	ImageFetchTimedOut(phase string, timeout time.Duration)
+
+	ArtifactStreamed(direction StreamDirection, name worker.ArtifactName, start time.Time, end time.Time)
+
+	AbortProgressed(phase AbortPhase, gracePeriod time.Duration)
 
 	Stdout() io.Writer
 	Stderr() io.Writer
@@ -105,11 +121,27 @@ type TaskDelegate interface {
 // behavior.
 type ResourceDelegate interface {
 	Initializing()
+	// Started is called once the resource's script starts running, or the
+	// resource is found in the cache.
+	Started()
 
 	Completed(ExitStatus, *VersionInfo)
 	Failed(error)
 
+	// Checkpoint returns the outcome recorded when the step completed, if it
+	// did before the build was resumed.
+	Checkpoint() (Checkpoint, bool, error)
+	// Resumed is called rather than Completed when the step's outcome is
+	// recovered from its checkpoint.
+	Resumed(Checkpoint)
+
 	ImageVersionDetermined(worker.ResourceCacheIdentifier) error
+	WaitingForWorker()
+	ImageFetchTimeouts() worker.ImageFetchTimeouts
+	ImageFetchPhaseFinished(phase string, start time.Time, end time.Time)
+	ImageFetchTimedOut(phase string, timeout time.Duration)
+
+	ArtifactStreamed(direction StreamDirection, name worker.ArtifactName, start time.Time, end time.Time)
 
 	Stdout() io.Writer
 	Stderr() io.Writer
@@ -131,6 +163,36 @@ type PutDelegate interface {
 	ResourceDelegate
 }
 
+//go:generate counterfeiter . RetryDelegate
+
+// RetryDelegate is used to record the outcome of each of a RetryStep's
+// attempts, numbered from 1.
+type RetryDelegate interface {
+	AttemptFinished(attempt int, status atc.BuildStatus, retrying bool)
+}
+
+// StreamDirection is whether an artifact was streamed into the step reporting
+// it, or out of it to another step.
+type StreamDirection string
+
+const (
+	StreamIn  StreamDirection = "in"
+	StreamOut StreamDirection = "out"
+)
+
+// AbortPhase is a stage of stopping a task's process once its build is
+// aborted.
+type AbortPhase string
+
+const (
+	// the process was sent SIGTERM, and is given the grace period to exit
+	AbortPhaseTerminating AbortPhase = "terminating"
+	// the process exited within the grace period
+	AbortPhaseExited AbortPhase = "exited"
+	// the grace period elapsed, so the process was killed
+	AbortPhaseKilled AbortPhase = "killed"
+)
+
 // Privileged is used to indicate whether the given step should run with
 // special privileges (i.e. as an administrator user).
 type Privileged bool
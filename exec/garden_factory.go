@@ -19,6 +19,8 @@ type gardenFactory struct {
 	resourceFetcher        resource.Fetcher
 	resourceFactory        resource.ResourceFactory
 	dbResourceCacheFactory dbng.ResourceCacheFactory
+	dbTaskCacheFactory     dbng.TaskCacheFactory
+	defaultTaskLimits      atc.ContainerLimits
 }
 
 func NewGardenFactory(
@@ -26,12 +28,16 @@ func NewGardenFactory(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
+	dbTaskCacheFactory dbng.TaskCacheFactory,
+	defaultTaskLimits atc.ContainerLimits,
 ) Factory {
 	return &gardenFactory{
 		workerClient:           workerClient,
 		resourceFetcher:        resourceFetcher,
 		resourceFactory:        resourceFactory,
 		dbResourceCacheFactory: dbResourceCacheFactory,
+		dbTaskCacheFactory:     dbTaskCacheFactory,
+		defaultTaskLimits:      defaultTaskLimits,
 	}
 }
 
@@ -158,6 +164,9 @@ func (factory *gardenFactory) Task(
 	inputMapping map[string]string,
 	outputMapping map[string]string,
 	imageArtifactName string,
+	cacheOutputs bool,
+	reports *atc.TaskReports,
+	abortGracePeriod string,
 	clock clock.Clock,
 ) StepFactory {
 	workingDirectory := factory.taskWorkingDirectory(sourceName)
@@ -178,6 +187,11 @@ func (factory *gardenFactory) Task(
 		inputMapping,
 		outputMapping,
 		imageArtifactName,
+		factory.defaultTaskLimits,
+		cacheOutputs,
+		reports,
+		abortGracePeriod,
+		factory.dbTaskCacheFactory,
 		clock,
 	)
 }
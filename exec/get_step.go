@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -105,8 +106,33 @@ func (step GetStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
 //
 // At the end, the resulting ArtifactSource (either from using the cache or
 // fetching the resource) is registered under the step's SourceName.
+//
+// If the step already completed before its build was resumed, the resource is
+// fetched again, which is expected to hit the cache, so that it can be
+// registered, but the delegate is told it was resumed rather than completed.
+// A step which had failed is not run again at all.
 func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
-	step.delegate.Initializing()
+	checkpoint, resumed, err := step.delegate.Checkpoint()
+	if err != nil {
+		return err
+	}
+
+	started := step.delegate.Started
+
+	if resumed {
+		step.logger.Info("resuming-from-checkpoint", lager.Data{"exit-status": checkpoint.ExitStatus})
+
+		if checkpoint.ExitStatus != 0 {
+			step.delegate.Resumed(checkpoint)
+			close(ready)
+			return nil
+		}
+
+		// the step already started before the build was resumed
+		started = func() {}
+	} else {
+		step.delegate.Initializing()
+	}
 
 	runSession := step.session
 	runSession.ID.Stage = db.ContainerStageRun
@@ -119,7 +145,8 @@ func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		version:      step.version,
 	}
 
-	var err error
+	fetchReady, waitForReady := forwardReady(ready, started)
+
 	step.fetchSource, err = step.resourceFetcher.Fetch(
 		step.logger,
 		runSession,
@@ -131,9 +158,11 @@ func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.delegate,
 		resourceDefinition,
 		signals,
-		ready,
+		fetchReady,
 	)
 
+	waitForReady()
+
 	if err, ok := err.(resource.ErrResourceScriptFailed); ok {
 		step.logger.Error("get-run-resource-script-failed", err)
 		step.delegate.Completed(ExitStatus(err.ExitStatus), nil)
@@ -145,6 +174,13 @@ func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		return err
 	}
 
+	if resumed {
+		step.repository.RegisterSource(step.sourceName, step)
+		step.succeeded = true
+		step.delegate.Resumed(checkpoint)
+		return nil
+	}
+
 	step.registerAndReportResource()
 
 	return nil
@@ -181,8 +217,38 @@ func (step *GetStep) VolumeOn(worker worker.Worker) (worker.Volume, bool, error)
 	return step.resourceInstance.FindOn(step.logger.Session("volume-on"), worker)
 }
 
+// ArtifactVersion identifies the fetched data by the resource's type, source,
+// params, and version.
+func (step *GetStep) ArtifactVersion() (string, bool) {
+	if step.fetchSource == nil {
+		return "", false
+	}
+
+	payload, err := json.Marshal(getArtifactVersion{
+		Type:    step.resourceConfig.Type,
+		Source:  step.resourceConfig.Source,
+		Params:  step.params,
+		Version: step.fetchSource.VersionedSource().Version(),
+	})
+	if err != nil {
+		step.logger.Error("failed-to-marshal-artifact-version", err)
+		return "", false
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(payload)), true
+}
+
+type getArtifactVersion struct {
+	Type    string      `json:"type"`
+	Source  atc.Source  `json:"source"`
+	Params  atc.Params  `json:"params"`
+	Version atc.Version `json:"version"`
+}
+
 // StreamTo streams the resource's data to the destination.
 func (step *GetStep) StreamTo(destination worker.ArtifactDestination) error {
+	start := time.Now()
+
 	out, err := step.fetchSource.VersionedSource().StreamOut(".")
 	if err != nil {
 		return err
@@ -190,7 +256,14 @@ func (step *GetStep) StreamTo(destination worker.ArtifactDestination) error {
 
 	defer out.Close()
 
-	return destination.StreamIn(".", out)
+	err = destination.StreamIn(".", out)
+	if err != nil {
+		return err
+	}
+
+	step.delegate.ArtifactStreamed(StreamOut, step.sourceName, start, time.Now())
+
+	return nil
 }
 
 // StreamFile streams a single file out of the resource.
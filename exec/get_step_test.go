@@ -117,7 +117,7 @@ var _ = Describe("Get", func() {
 
 		fakeDBResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory)
+		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, new(dbngfakes.FakeTaskCacheFactory), atc.ContainerLimits{})
 	})
 
 	JustBeforeEach(func() {
@@ -170,6 +170,32 @@ var _ = Describe("Get", func() {
 		})
 	})
 
+	Context("when the resource's script starts", func() {
+		BeforeEach(func() {
+			fakeResourceFetcher.FetchStub = func(
+				_ lager.Logger,
+				_ resource.Session,
+				_ atc.Tags,
+				_ int,
+				_ atc.ResourceTypes,
+				_ resource.ResourceInstance,
+				_ resource.Metadata,
+				_ worker.ImageFetchingDelegate,
+				_ resource.ResourceOptions,
+				_ <-chan os.Signal,
+				ready chan<- struct{},
+			) (resource.FetchSource, error) {
+				close(ready)
+				return fakeFetchSource, nil
+			}
+		})
+
+		It("becomes ready once the delegate is told it started", func() {
+			Expect(process.Ready()).To(BeClosed())
+			Expect(getDelegate.StartedCallCount()).To(Equal(1))
+		})
+	})
+
 	It("initializes the resource with the correct type and session id, making sure that it is not ephemeral", func() {
 		Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
 		_, sid, tags, actualTeamID, actualResourceTypes, resourceInstance, sm, delegate, resourceOptions, _, _ := fakeResourceFetcher.FetchArgsForCall(0)
@@ -239,6 +265,21 @@ var _ = Describe("Get", func() {
 				Expect(found).To(BeTrue())
 			})
 
+			It("is versioned by the fetched version", func() {
+				versionedSource, ok := artifactSource.(worker.VersionedArtifactSource)
+				Expect(ok).To(BeTrue())
+
+				version, known := versionedSource.ArtifactVersion()
+				Expect(known).To(BeTrue())
+				Expect(version).NotTo(BeEmpty())
+
+				fakeVersionedSource.VersionReturns(atc.Version{"some": "other-version"})
+
+				otherVersion, known := versionedSource.ArtifactVersion()
+				Expect(known).To(BeTrue())
+				Expect(otherVersion).NotTo(Equal(version))
+			})
+
 			Describe("streaming to a destination", func() {
 				var fakeDestination *workerfakes.FakeArtifactDestination
 
@@ -383,6 +424,125 @@ var _ = Describe("Get", func() {
 		})
 	})
 
+	Context("when the delegate has a checkpoint for the step", func() {
+		var checkpoint Checkpoint
+
+		Context("that succeeded", func() {
+			BeforeEach(func() {
+				checkpoint = Checkpoint{
+					ExitStatus: 0,
+					VersionInfo: &VersionInfo{
+						Version:  atc.Version{"some": "version"},
+						Metadata: []atc.MetadataField{{"some", "metadata"}},
+					},
+				}
+
+				getDelegate.CheckpointReturns(checkpoint, true, nil)
+			})
+
+			It("fetches the resource again without initializing", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
+				Expect(getDelegate.InitializingCallCount()).To(BeZero())
+			})
+
+			Context("when the resource is found again", func() {
+				BeforeEach(func() {
+					fakeResourceFetcher.FetchStub = func(
+						_ lager.Logger,
+						_ resource.Session,
+						_ atc.Tags,
+						_ int,
+						_ atc.ResourceTypes,
+						_ resource.ResourceInstance,
+						_ resource.Metadata,
+						_ worker.ImageFetchingDelegate,
+						_ resource.ResourceOptions,
+						_ <-chan os.Signal,
+						ready chan<- struct{},
+					) (resource.FetchSource, error) {
+						close(ready)
+						return fakeFetchSource, nil
+					}
+				})
+
+				It("becomes ready without telling the delegate it started again", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(process.Ready()).To(BeClosed())
+					Expect(getDelegate.StartedCallCount()).To(BeZero())
+				})
+			})
+
+			It("registers the source with the repository", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				_, found := repo.SourceFor(sourceName)
+				Expect(found).To(BeTrue())
+			})
+
+			It("is successful", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(bool(success)).To(BeTrue())
+			})
+
+			It("reports the step as resumed rather than completed", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(getDelegate.CompletedCallCount()).To(BeZero())
+				Expect(getDelegate.ResumedCallCount()).To(Equal(1))
+				Expect(getDelegate.ResumedArgsForCall(0)).To(Equal(checkpoint))
+			})
+		})
+
+		Context("that failed", func() {
+			BeforeEach(func() {
+				checkpoint = Checkpoint{ExitStatus: 1}
+				getDelegate.CheckpointReturns(checkpoint, true, nil)
+			})
+
+			It("does not fetch the resource", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(fakeResourceFetcher.FetchCallCount()).To(BeZero())
+			})
+
+			It("is not successful", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(bool(success)).To(BeFalse())
+			})
+
+			It("reports the step as resumed", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(getDelegate.CompletedCallCount()).To(BeZero())
+				Expect(getDelegate.ResumedCallCount()).To(Equal(1))
+				Expect(getDelegate.ResumedArgsForCall(0)).To(Equal(checkpoint))
+			})
+		})
+
+		Context("when looking up the checkpoint fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				getDelegate.CheckpointReturns(Checkpoint{}, false, disaster)
+			})
+
+			It("exits with the failure without fetching", func() {
+				Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+
+				Expect(fakeResourceFetcher.FetchCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Context("when the tracker fails to initialize the resource", func() {
 		disaster := errors.New("nope")
 
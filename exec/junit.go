@@ -0,0 +1,98 @@
+package exec
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+type junitTestSuite struct {
+	XMLName xml.Name
+
+	Name      string           `xml:"name,attr"`
+	Suites    []junitTestSuite `xml:"testsuite"`
+	TestCases []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string `xml:"classname,attr"`
+	Name      string `xml:"name,attr"`
+	Time      string `xml:"time,attr"`
+
+	Failure *junitProblem `xml:"failure"`
+	Error   *junitProblem `xml:"error"`
+	Skipped *junitProblem `xml:"skipped"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (problem junitProblem) String() string {
+	if problem.Message != "" {
+		return problem.Message
+	}
+
+	return strings.TrimSpace(problem.Text)
+}
+
+// parseJUnit reads the test cases of a JUnit XML report, whose root is either
+// a <testsuites> or a single <testsuite> element.
+func parseJUnit(r io.Reader) ([]atc.TestResult, error) {
+	var root junitTestSuite
+	err := xml.NewDecoder(r).Decode(&root)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root.XMLName.Local {
+	case "testsuites":
+		root.Name = ""
+	case "testsuite":
+	default:
+		return nil, fmt.Errorf("unexpected root element <%s>", root.XMLName.Local)
+	}
+
+	return junitSuiteResults(root), nil
+}
+
+func junitSuiteResults(suite junitTestSuite) []atc.TestResult {
+	results := []atc.TestResult{}
+
+	for _, testCase := range suite.TestCases {
+		result := atc.TestResult{
+			Suite:     suite.Name,
+			ClassName: testCase.ClassName,
+			Name:      testCase.Name,
+			Status:    atc.TestStatusPassed,
+		}
+
+		// reporters disagree on the format of the time; it is best-effort
+		result.Duration, _ = strconv.ParseFloat(strings.TrimSpace(testCase.Time), 64)
+
+		switch {
+		case testCase.Error != nil:
+			result.Status = atc.TestStatusErrored
+			result.Message = testCase.Error.String()
+		case testCase.Failure != nil:
+			result.Status = atc.TestStatusFailed
+			result.Message = testCase.Failure.String()
+		case testCase.Skipped != nil:
+			result.Status = atc.TestStatusSkipped
+			result.Message = testCase.Skipped.String()
+		}
+
+		results = append(results, result)
+	}
+
+	for _, nested := range suite.Suites {
+		results = append(results, junitSuiteResults(nested)...)
+	}
+
+	return results
+}
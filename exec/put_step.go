@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"os"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -30,7 +31,7 @@ type PutStep struct {
 
 	resource resource.Resource
 
-	versionedSource resource.VersionedSource
+	versionInfo VersionInfo
 
 	succeeded bool
 }
@@ -80,7 +81,30 @@ func (step PutStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
 //
 // The resource's put script is then invoked. The PutStep is ready as soon as
 // the resource's script starts, and signals will be forwarded to the script.
+//
+// If the step already completed before its build was resumed, its outcome is
+// recovered from its checkpoint rather than putting again. If the script was
+// still running, its container is found again and the script re-attached to.
 func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	checkpoint, found, err := step.delegate.Checkpoint()
+	if err != nil {
+		return err
+	}
+
+	if found {
+		step.logger.Info("resuming-from-checkpoint", lager.Data{"exit-status": checkpoint.ExitStatus})
+
+		if checkpoint.VersionInfo != nil {
+			step.versionInfo = *checkpoint.VersionInfo
+		}
+
+		step.succeeded = checkpoint.ExitStatus == 0
+		step.delegate.Resumed(checkpoint)
+
+		close(ready)
+		return nil
+	}
+
 	step.delegate.Initializing()
 
 	runSession := step.session
@@ -107,6 +131,7 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	putResource, missingSources, err := step.resourceFactory.NewBuildResource(
 		step.logger,
+		signals,
 		runSession.ID,
 		runSession.Metadata,
 		resourceSpec,
@@ -115,6 +140,10 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		inputSources,
 		map[string]string{},
 	)
+	if err == worker.ErrInterrupted {
+		return ErrInterrupted
+	}
+
 	if err != nil {
 		return err
 	}
@@ -131,14 +160,25 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		return err
 	}
 
+	timedRepo := worker.NewArtifactRepository()
+	for name, source := range scopedRepo.AsMap() {
+		timedRepo.RegisterSource(name, streamTimedSource{
+			ArtifactSource: source,
+			name:           name,
+			delegate:       step.delegate,
+		})
+	}
+
 	var artifactSource worker.ArtifactSource
 	if len(inputSources) == 0 {
 		artifactSource = emptySource{}
 	} else {
-		artifactSource = resourceSource{scopedRepo}
+		artifactSource = resourceSource{timedRepo}
 	}
 
-	step.versionedSource, err = step.resource.Put(
+	putReady, waitForReady := forwardReady(ready, step.delegate.Started)
+
+	versionedSource, err := step.resource.Put(
 		resource.IOConfig{
 			Stdout: step.delegate.Stdout(),
 			Stderr: step.delegate.Stderr(),
@@ -147,9 +187,11 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.params,
 		artifactSource,
 		signals,
-		ready,
+		putReady,
 	)
 
+	waitForReady()
+
 	if err, ok := err.(resource.ErrResourceScriptFailed); ok {
 		step.delegate.Completed(ExitStatus(err.ExitStatus), nil)
 		return nil
@@ -163,11 +205,13 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		return err
 	}
 
+	step.versionInfo = VersionInfo{
+		Version:  versionedSource.Version(),
+		Metadata: versionedSource.Metadata(),
+	}
+
 	step.succeeded = true
-	step.delegate.Completed(ExitStatus(0), &VersionInfo{
-		Version:  step.versionedSource.Version(),
-		Metadata: step.versionedSource.Metadata(),
-	})
+	step.delegate.Completed(ExitStatus(0), &step.versionInfo)
 
 	return nil
 }
@@ -183,10 +227,7 @@ func (step *PutStep) Result(x interface{}) bool {
 		*v = Success(step.succeeded)
 		return true
 	case *VersionInfo:
-		*v = VersionInfo{
-			Version:  step.versionedSource.Version(),
-			Metadata: step.versionedSource.Metadata(),
-		}
+		*v = step.versionInfo
 		return true
 
 	default:
@@ -222,6 +263,28 @@ func (source emptySource) StreamTo(dest worker.ArtifactDestination) error {
 	return nil
 }
 
+// streamTimedSource reports how long its artifact took to stream into the
+// put's container.
+type streamTimedSource struct {
+	worker.ArtifactSource
+
+	name     worker.ArtifactName
+	delegate PutDelegate
+}
+
+func (source streamTimedSource) StreamTo(dest worker.ArtifactDestination) error {
+	start := time.Now()
+
+	err := source.ArtifactSource.StreamTo(dest)
+	if err != nil {
+		return err
+	}
+
+	source.delegate.ArtifactStreamed(StreamIn, source.name, start, time.Now())
+
+	return nil
+}
+
 type putInputSource struct {
 	name   worker.ArtifactName
 	source worker.ArtifactSource
@@ -53,7 +53,7 @@ var _ = Describe("GardenFactory", func() {
 		fakeResourceFactory = new(resourcefakes.FakeResourceFactory)
 		fakeDBResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory)
+		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, new(dbngfakes.FakeTaskCacheFactory), atc.ContainerLimits{})
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -158,7 +158,7 @@ var _ = Describe("GardenFactory", func() {
 				It("initializes the resource with the correct type, session, and sources", func() {
 					Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(Equal(1))
 
-					_, sid, sm, resourceSpec, actualResourceTypes, delegate, sources, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+					_, _, sid, sm, resourceSpec, actualResourceTypes, delegate, sources, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
 					Expect(sm).To(Equal(worker.Metadata{
 						PipelineName:     "some-pipeline",
 						Type:             db.ContainerTypePut,
@@ -240,6 +240,28 @@ var _ = Describe("GardenFactory", func() {
 					Expect(fakeMountedSource.StreamToCallCount()).To(Equal(0))
 				})
 
+				It("reports how long each streamed input took", func() {
+					_, _, _, putArtifactSource, _, _ := fakeResource.PutArgsForCall(0)
+
+					err := putArtifactSource.StreamTo(new(workerfakes.FakeArtifactDestination))
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(putDelegate.ArtifactStreamedCallCount()).To(Equal(2))
+
+					streamed := []worker.ArtifactName{}
+					for i := 0; i < putDelegate.ArtifactStreamedCallCount(); i++ {
+						direction, name, start, end := putDelegate.ArtifactStreamedArgsForCall(i)
+						Expect(direction).To(Equal(StreamIn))
+						Expect(end).To(BeTemporally(">=", start))
+						streamed = append(streamed, name)
+					}
+
+					Expect(streamed).To(ConsistOf(
+						worker.ArtifactName("some-source"),
+						worker.ArtifactName("some-other-source"),
+					))
+				})
+
 				It("puts the resource with the io config forwarded", func() {
 					Expect(fakeResource.PutCallCount()).To(Equal(1))
 
@@ -304,6 +326,14 @@ var _ = Describe("GardenFactory", func() {
 						Eventually(receivedSignals).Should(Receive(Equal(os.Interrupt)))
 						Eventually(process.Wait()).Should(Receive())
 					})
+
+					It("becomes ready once the delegate is told the script started", func() {
+						Expect(process.Ready()).To(BeClosed())
+						Expect(putDelegate.StartedCallCount()).To(Equal(1))
+
+						process.Signal(os.Interrupt)
+						Eventually(process.Wait()).Should(Receive())
+					})
 				})
 
 				Context("when performing the put fails", func() {
@@ -404,6 +434,91 @@ var _ = Describe("GardenFactory", func() {
 			})
 		})
 
+		Context("when the delegate has a checkpoint for the step", func() {
+			var checkpoint Checkpoint
+
+			Context("that succeeded", func() {
+				BeforeEach(func() {
+					checkpoint = Checkpoint{
+						ExitStatus: 0,
+						VersionInfo: &VersionInfo{
+							Version:  atc.Version{"some": "version"},
+							Metadata: []atc.MetadataField{{"some", "metadata"}},
+						},
+					}
+
+					putDelegate.CheckpointReturns(checkpoint, true, nil)
+				})
+
+				It("does not put the resource again", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(BeZero())
+					Expect(putDelegate.InitializingCallCount()).To(BeZero())
+				})
+
+				It("reports the checkpointed version info", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					var info VersionInfo
+					Expect(step.Result(&info)).To(BeTrue())
+					Expect(info).To(Equal(*checkpoint.VersionInfo))
+				})
+
+				It("is successful", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					var success Success
+					Expect(step.Result(&success)).To(BeTrue())
+					Expect(bool(success)).To(BeTrue())
+				})
+
+				It("reports the step as resumed rather than completed", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(putDelegate.CompletedCallCount()).To(BeZero())
+					Expect(putDelegate.ResumedCallCount()).To(Equal(1))
+					Expect(putDelegate.ResumedArgsForCall(0)).To(Equal(checkpoint))
+				})
+			})
+
+			Context("that failed", func() {
+				BeforeEach(func() {
+					checkpoint = Checkpoint{ExitStatus: 1}
+					putDelegate.CheckpointReturns(checkpoint, true, nil)
+				})
+
+				It("is not successful", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					var success Success
+					Expect(step.Result(&success)).To(BeTrue())
+					Expect(bool(success)).To(BeFalse())
+				})
+
+				It("reports the step as resumed", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(putDelegate.ResumedCallCount()).To(Equal(1))
+					Expect(putDelegate.ResumedArgsForCall(0)).To(Equal(checkpoint))
+				})
+			})
+
+			Context("when looking up the checkpoint fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					putDelegate.CheckpointReturns(Checkpoint{}, false, disaster)
+				})
+
+				It("exits with the failure without putting", func() {
+					Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+
+					Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when there are no sources in repo", func() {
 			var (
 				fakeResource        *resourcefakes.FakeResource
@@ -0,0 +1,33 @@
+package exec
+
+// forwardReady returns a ready channel to hand to a nested runner, which
+// calls started and closes ready once the nested runner closes it. The
+// returned function must be called once the nested runner returns; it waits
+// for ready to have been forwarded, if it is going to be.
+func forwardReady(ready chan<- struct{}, started func()) (chan<- struct{}, func()) {
+	nestedReady := make(chan struct{})
+	returned := make(chan struct{})
+	forwarded := make(chan struct{})
+
+	go func() {
+		defer close(forwarded)
+
+		select {
+		case <-nestedReady:
+		case <-returned:
+			select {
+			case <-nestedReady:
+			default:
+				return
+			}
+		}
+
+		started()
+		close(ready)
+	}()
+
+	return nestedReady, func() {
+		close(returned)
+		<-forwarded
+	}
+}
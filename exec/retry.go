@@ -2,7 +2,10 @@ package exec
 
 import (
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/worker"
 )
 
@@ -10,11 +13,46 @@ import (
 // succeeds.
 type Retry []StepFactory
 
-// Using constructs a *RetryStep.
+// Using constructs a *RetryStep which retries every outcome immediately.
 func (stepFactory Retry) Using(prev Step, repo *worker.ArtifactRepository) Step {
-	retry := &RetryStep{}
+	return RetryWithPolicy(stepFactory, atc.RetryPolicy{}, nil, clock.NewClock()).Using(prev, repo)
+}
+
+// RetryWithPolicyFactory constructs a Step that will run the steps in order
+// until one of them succeeds or finishes with a status that the policy does
+// not retry.
+type RetryWithPolicyFactory struct {
+	attempts []StepFactory
+	policy   atc.RetryPolicy
+	delegate RetryDelegate
+	clock    clock.Clock
+}
+
+// RetryWithPolicy constructs a RetryWithPolicyFactory. The delegate, if
+// given, is told the outcome of each attempt.
+func RetryWithPolicy(
+	attempts []StepFactory,
+	policy atc.RetryPolicy,
+	delegate RetryDelegate,
+	clock clock.Clock,
+) RetryWithPolicyFactory {
+	return RetryWithPolicyFactory{
+		attempts: attempts,
+		policy:   policy,
+		delegate: delegate,
+		clock:    clock,
+	}
+}
+
+// Using constructs a *RetryStep.
+func (factory RetryWithPolicyFactory) Using(prev Step, repo *worker.ArtifactRepository) Step {
+	retry := &RetryStep{
+		policy:   factory.policy,
+		delegate: factory.delegate,
+		clock:    factory.clock,
+	}
 
-	for _, subStepFactory := range stepFactory {
+	for _, subStepFactory := range factory.attempts {
 		retry.Attempts = append(retry.Attempts, subStepFactory.Using(prev, repo))
 	}
 
@@ -26,16 +64,30 @@ func (stepFactory Retry) Using(prev Step, repo *worker.ArtifactRepository) Step
 type RetryStep struct {
 	Attempts    []Step
 	LastAttempt Step
+
+	policy   atc.RetryPolicy
+	delegate RetryDelegate
+	clock    clock.Clock
 }
 
-// Run iterates through each step, stopping once a step succeeds. If all steps
-// fail, the RetryStep will fail.
+// Run iterates through each step, stopping once a step succeeds or finishes
+// with a status that the policy does not retry. Between attempts it waits for
+// the policy's backoff. If all steps fail, the RetryStep will fail.
 func (step *RetryStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	var backoff time.Duration
+	if step.policy.Backoff != "" {
+		var err error
+		backoff, err = time.ParseDuration(step.policy.Backoff)
+		if err != nil {
+			return err
+		}
+	}
+
 	close(ready)
 
 	var attemptErr error
 
-	for _, attempt := range step.Attempts {
+	for i, attempt := range step.Attempts {
 		step.LastAttempt = attempt
 
 		var succeeded Success
@@ -44,13 +96,33 @@ func (step *RetryStep) Run(signals <-chan os.Signal, ready chan<- struct{}) erro
 			return attemptErr
 		}
 
+		status := atc.StatusFailed
 		if attemptErr != nil {
-			continue
+			status = atc.StatusErrored
+		} else if attempt.Result(&succeeded) && bool(succeeded) {
+			status = atc.StatusSucceeded
+		}
+
+		retrying := i < len(step.Attempts)-1 && step.policy.RetriesOn(status)
+
+		if step.delegate != nil {
+			step.delegate.AttemptFinished(i+1, status, retrying)
 		}
 
-		if attempt.Result(&succeeded) && bool(succeeded) {
+		if !retrying {
 			break
 		}
+
+		if backoff > 0 {
+			timer := step.clock.NewTimer(backoff)
+
+			select {
+			case <-timer.C():
+			case <-signals:
+				timer.Stop()
+				return ErrInterrupted
+			}
+		}
 	}
 
 	return attemptErr
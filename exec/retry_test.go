@@ -3,7 +3,10 @@ package exec_test
 import (
 	"errors"
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/exec"
 	"github.com/tedsuo/ifrit"
 
@@ -343,4 +346,115 @@ var _ = Describe("Retry Step", func() {
 			})
 		})
 	})
+
+	Describe("RetryWithPolicy", func() {
+		var (
+			policy       atc.RetryPolicy
+			fakeDelegate *execfakes.FakeRetryDelegate
+			fakeClock    *fakeclock.FakeClock
+
+			process ifrit.Process
+		)
+
+		BeforeEach(func() {
+			policy = atc.RetryPolicy{
+				Backoff: "30s",
+				On:      []string{"errored"},
+			}
+
+			fakeDelegate = new(execfakes.FakeRetryDelegate)
+			fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		})
+
+		JustBeforeEach(func() {
+			stepFactory = RetryWithPolicy(
+				[]StepFactory{attempt1Factory, attempt2Factory, attempt3Factory},
+				policy,
+				fakeDelegate,
+				fakeClock,
+			)
+			step = stepFactory.Using(nil, nil)
+
+			process = ifrit.Invoke(step)
+		})
+
+		Context("when attempt 1 fails", func() {
+			BeforeEach(func() {
+				attempt1Step.ResultStub = successResult(false)
+			})
+
+			It("does not retry it", func() {
+				Expect(<-process.Wait()).ToNot(HaveOccurred())
+
+				Expect(attempt1Step.RunCallCount()).To(Equal(1))
+				Expect(attempt2Step.RunCallCount()).To(Equal(0))
+			})
+
+			It("records the attempt's outcome", func() {
+				<-process.Wait()
+
+				Expect(fakeDelegate.AttemptFinishedCallCount()).To(Equal(1))
+				attempt, status, retrying := fakeDelegate.AttemptFinishedArgsForCall(0)
+				Expect(attempt).To(Equal(1))
+				Expect(status).To(Equal(atc.StatusFailed))
+				Expect(retrying).To(BeFalse())
+			})
+		})
+
+		Context("when attempt 1 errors and attempt 2 succeeds", func() {
+			BeforeEach(func() {
+				attempt1Step.RunReturns(errors.New("nope"))
+				attempt2Step.ResultStub = successResult(true)
+			})
+
+			It("runs attempt 2 only once the backoff has elapsed", func() {
+				fakeClock.WaitForWatcherAndIncrement(29 * time.Second)
+				Consistently(attempt2Step.RunCallCount).Should(Equal(0))
+
+				fakeClock.Increment(time.Second)
+				Expect(<-process.Wait()).ToNot(HaveOccurred())
+
+				Expect(attempt2Step.RunCallCount()).To(Equal(1))
+				Expect(attempt3Step.RunCallCount()).To(Equal(0))
+			})
+
+			It("records each attempt's outcome", func() {
+				fakeClock.WaitForWatcherAndIncrement(30 * time.Second)
+				<-process.Wait()
+
+				Expect(fakeDelegate.AttemptFinishedCallCount()).To(Equal(2))
+
+				attempt, status, retrying := fakeDelegate.AttemptFinishedArgsForCall(0)
+				Expect(attempt).To(Equal(1))
+				Expect(status).To(Equal(atc.StatusErrored))
+				Expect(retrying).To(BeTrue())
+
+				attempt, status, retrying = fakeDelegate.AttemptFinishedArgsForCall(1)
+				Expect(attempt).To(Equal(2))
+				Expect(status).To(Equal(atc.StatusSucceeded))
+				Expect(retrying).To(BeFalse())
+			})
+
+			Context("when interrupted during the backoff", func() {
+				It("returns ErrInterrupted without running attempt 2", func() {
+					fakeClock.WaitForWatcherAndIncrement(time.Second)
+					process.Signal(os.Interrupt)
+
+					Expect(<-process.Wait()).To(Equal(ErrInterrupted))
+					Expect(attempt2Step.RunCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the backoff cannot be parsed", func() {
+			BeforeEach(func() {
+				policy.Backoff = "nope"
+			})
+
+			It("returns an error without running any attempts", func() {
+				Expect(<-process.Wait()).To(HaveOccurred())
+				Expect(attempt1Step.RunCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
@@ -0,0 +1,118 @@
+package exec
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/artifactstore"
+	"github.com/concourse/atc/worker"
+)
+
+// MissingArtifactsError is returned when the build did not produce some of the
+// artifacts it was configured to save.
+type MissingArtifactsError struct {
+	Artifacts []string
+}
+
+// Error prints a human-friendly message listing the artifacts that were
+// missing.
+func (err MissingArtifactsError) Error() string {
+	return fmt.Sprintf("missing artifacts: %s", strings.Join(err.Artifacts, ", "))
+}
+
+// SaveArtifactsStep copies artifacts produced by earlier steps of the build
+// into the artifact store, so that they outlive the build's volumes.
+type SaveArtifactsStep struct {
+	logger  lager.Logger
+	store   artifactstore.Store
+	buildID int
+	names   []string
+
+	repo *worker.ArtifactRepository
+
+	succeeded bool
+}
+
+// SaveArtifacts constructs a SaveArtifactsStep factory.
+func SaveArtifacts(
+	logger lager.Logger,
+	store artifactstore.Store,
+	buildID int,
+	names []string,
+) SaveArtifactsStep {
+	return SaveArtifactsStep{
+		logger:  logger,
+		store:   store,
+		buildID: buildID,
+		names:   names,
+	}
+}
+
+// Using constructs a *SaveArtifactsStep.
+func (step SaveArtifactsStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
+	step.repo = repo
+	return &step
+}
+
+// Run streams each named artifact out of the repository and saves it to the
+// store. It fails if any of the artifacts is missing.
+func (step *SaveArtifactsStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	sources := map[string]worker.ArtifactSource{}
+
+	var missing []string
+	for _, name := range step.names {
+		source, found := step.repo.SourceFor(worker.ArtifactName(name))
+		if !found {
+			missing = append(missing, name)
+			continue
+		}
+
+		sources[name] = source
+	}
+
+	if len(missing) > 0 {
+		return MissingArtifactsError{missing}
+	}
+
+	for _, name := range step.names {
+		err := sources[name].StreamTo(storeDestination{
+			store:   step.store,
+			buildID: step.buildID,
+			name:    name,
+		})
+		if err != nil {
+			step.logger.Error("failed-to-save-artifact", err, lager.Data{"artifact": name})
+			return err
+		}
+	}
+
+	step.succeeded = true
+
+	return nil
+}
+
+// Result indicates Success as true once every artifact has been saved.
+func (step *SaveArtifactsStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(step.succeeded)
+		return true
+	default:
+		return false
+	}
+}
+
+type storeDestination struct {
+	store   artifactstore.Store
+	buildID int
+	name    string
+}
+
+func (dest storeDestination) StreamIn(path string, tarStream io.Reader) error {
+	return dest.store.Save(dest.buildID, dest.name, tarStream)
+}
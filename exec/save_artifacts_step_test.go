@@ -0,0 +1,100 @@
+package exec_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/artifactstore/artifactstorefakes"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SaveArtifactsStep", func() {
+	var (
+		fakeStore *artifactstorefakes.FakeStore
+		repo      *worker.ArtifactRepository
+
+		fakeSource *workerfakes.FakeArtifactSource
+
+		names []string
+
+		step   Step
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeStore = new(artifactstorefakes.FakeStore)
+		repo = worker.NewArtifactRepository()
+
+		fakeSource = new(workerfakes.FakeArtifactSource)
+		fakeSource.StreamToStub = func(dest worker.ArtifactDestination) error {
+			return dest.StreamIn(".", bytes.NewBufferString("some-tarball"))
+		}
+		repo.RegisterSource("some-output", fakeSource)
+
+		names = []string{"some-output"}
+	})
+
+	JustBeforeEach(func() {
+		step = SaveArtifacts(lagertest.NewTestLogger("test"), fakeStore, 42, names).Using(nil, repo)
+		runErr = step.Run(make(chan os.Signal), make(chan struct{}))
+	})
+
+	It("saves the artifact's tarball for the build", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		Expect(fakeStore.SaveCallCount()).To(Equal(1))
+		buildID, name, tarStream := fakeStore.SaveArgsForCall(0)
+		Expect(buildID).To(Equal(42))
+		Expect(name).To(Equal("some-output"))
+
+		contents, err := ioutil.ReadAll(tarStream)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal("some-tarball"))
+	})
+
+	It("succeeds", func() {
+		var success Success
+		Expect(step.Result(&success)).To(BeTrue())
+		Expect(bool(success)).To(BeTrue())
+	})
+
+	Context("when an artifact is missing", func() {
+		BeforeEach(func() {
+			names = []string{"some-output", "bogus-output"}
+		})
+
+		It("returns a MissingArtifactsError without saving anything", func() {
+			Expect(runErr).To(Equal(MissingArtifactsError{[]string{"bogus-output"}}))
+			Expect(fakeStore.SaveCallCount()).To(BeZero())
+		})
+
+		It("does not succeed", func() {
+			var success Success
+			Expect(step.Result(&success)).To(BeTrue())
+			Expect(bool(success)).To(BeFalse())
+		})
+	})
+
+	Context("when saving fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStore.SaveStub = func(int, string, io.Reader) error {
+				return disaster
+			}
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
@@ -74,6 +74,13 @@ type VersionInfo struct {
 	Metadata []atc.MetadataField
 }
 
+// Checkpoint is the recorded outcome of a get or put step which completed
+// before its build was resumed, e.g. after the ATC restarted.
+type Checkpoint struct {
+	ExitStatus  ExitStatus
+	VersionInfo *VersionInfo
+}
+
 // NoopStep implements a step that successfully does nothing.
 type NoopStep struct{}
 
@@ -3,18 +3,23 @@ package exec
 import (
 	"archive/tar"
 	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
@@ -22,6 +27,15 @@ import (
 const taskProcessPropertyName = "concourse:task-process"
 const taskExitStatusPropertyName = "concourse:exit-status"
 
+// AnnotationsFile is the file, relative to any of a task's outputs, to which
+// the task can write annotations for its build, as a JSON list of name/value
+// pairs like resource metadata.
+const AnnotationsFile = ".annotations.json"
+
+// maxJUnitReportSize is the size of the largest JUnit report parsed. Larger
+// reports are skipped, as they're decoded in memory.
+const maxJUnitReportSize = 10 * 1024 * 1024
+
 // MissingInputsError is returned when any of the task's required inputs are
 // missing.
 type MissingInputsError struct {
@@ -42,6 +56,10 @@ func (err MissingTaskImageSourceError) Error() string {
 make sure there's a corresponding 'get' step, or a task that produces it as an output`, err.SourceName)
 }
 
+// ErrTaskCacheVolumeNotFound is returned when a cached output's volume has
+// disappeared from its worker.
+var ErrTaskCacheVolumeNotFound = errors.New("task cache volume not found")
+
 // TaskStep executes a TaskConfig, whose inputs will be fetched from the
 // worker.ArtifactRepository and outputs will be added to the worker.ArtifactRepository.
 type TaskStep struct {
@@ -60,10 +78,16 @@ type TaskStep struct {
 	inputMapping      map[string]string
 	outputMapping     map[string]string
 	imageArtifactName string
+	defaultLimits     atc.ContainerLimits
+	cacheOutputs      bool
+	reports           *atc.TaskReports
+	abortGracePeriod  string
+	taskCacheFactory  dbng.TaskCacheFactory
 	clock             clock.Clock
 	repo              *worker.ArtifactRepository
 
-	process garden.Process
+	process  garden.Process
+	cacheKey string
 
 	exitStatus int
 }
@@ -84,6 +108,11 @@ func newTaskStep(
 	inputMapping map[string]string,
 	outputMapping map[string]string,
 	imageArtifactName string,
+	defaultLimits atc.ContainerLimits,
+	cacheOutputs bool,
+	reports *atc.TaskReports,
+	abortGracePeriod string,
+	taskCacheFactory dbng.TaskCacheFactory,
 	clock clock.Clock,
 ) TaskStep {
 	return TaskStep{
@@ -102,6 +131,11 @@ func newTaskStep(
 		inputMapping:      inputMapping,
 		outputMapping:     outputMapping,
 		imageArtifactName: imageArtifactName,
+		defaultLimits:     defaultLimits,
+		cacheOutputs:      cacheOutputs,
+		reports:           reports,
+		abortGracePeriod:  abortGracePeriod,
+		taskCacheFactory:  taskCacheFactory,
 		clock:             clock,
 	}
 }
@@ -133,10 +167,31 @@ func (step TaskStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
 // are registered with the worker.ArtifactRepository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
 // name of the task.
+//
+// If the step caches its outputs and all of its inputs are versioned, the
+// outputs of a previous run with the same config and input versions are
+// registered instead, and the script is not executed at all. Otherwise, the
+// outputs of a successful run are recorded for later runs to reuse.
+//
+// Once the script has exited, the results of the test reports the step
+// collects, if any, are reported to the delegate, whether the script
+// succeeded or not.
+//
+// If the step is signalled while the script is running and it has an abort
+// grace period, the script is sent SIGTERM and killed if it has not exited
+// once the grace period has elapsed. Otherwise the container is stopped.
 func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	var err error
 	var found bool
 
+	var gracePeriod time.Duration
+	if step.abortGracePeriod != "" {
+		gracePeriod, err = time.ParseDuration(step.abortGracePeriod)
+		if err != nil {
+			return err
+		}
+	}
+
 	processIO := garden.ProcessIO{
 		Stdout: step.delegate.Stdout(),
 		Stderr: step.delegate.Stderr(),
@@ -152,8 +207,23 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		return err
 	}
 
+	config.ContainerLimits = step.defaultLimits.Merge(config.ContainerLimits)
+
 	step.metadata.EnvironmentVariables = step.envForParams(config.Params)
 
+	if step.cacheOutputs {
+		if len(config.Outputs) == 0 {
+			// nothing to reuse; a cache hit would merely skip running the task
+			step.logger.Info("not-caching-task-without-outputs")
+		} else {
+			var cacheable bool
+			step.cacheKey, cacheable = step.outputCacheKey(config)
+			if !cacheable {
+				step.logger.Info("not-caching-outputs-of-unversioned-inputs-or-image")
+			}
+		}
+	}
+
 	runContainerID := step.containerID
 	runContainerID.Stage = db.ContainerStageRun
 
@@ -180,24 +250,55 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 		processID, err := container.Property(taskProcessPropertyName)
 		if err != nil {
-			// rogue container? perhaps did not shut down cleanly.
-			return err
-		}
+			released, releaseErr := worker.ReleaseContainerOnDrainingWorker(step.logger, step.workerPool, container, taskProcessPropertyName)
+			if releaseErr != nil {
+				return releaseErr
+			}
 
-		step.logger.Info("already-running", lager.Data{"process-id": processID})
+			if !released {
+				// rogue container? perhaps did not shut down cleanly.
+				return err
+			}
 
-		// process still running; re-attach
-		step.process, err = container.Attach(processID, processIO)
-		if err != nil {
-			return err
+			// process never started; place the task somewhere else
+			found = false
+		} else {
+			step.logger.Info("already-running", lager.Data{"process-id": processID})
+
+			// process still running; re-attach
+			step.process, err = container.Attach(processID, processIO)
+			if err != nil {
+				return err
+			}
+
+			step.logger.Info("attached")
 		}
+	}
 
-		step.logger.Info("attached")
-	} else {
+	if err != nil || !found {
 		// container does not exist; new session
 
 		step.delegate.Initializing(config)
 
+		if step.cacheKey != "" {
+			reused, err := step.reuseCachedOutputs(config)
+			if err != nil {
+				return err
+			}
+
+			if reused {
+				step.delegate.CacheHit(step.cacheKey)
+
+				// test results are only streamed out of the task's container, so
+				// they are not reported again; annotations live in the outputs
+				step.collectAnnotations(config)
+
+				step.delegate.Finished(ExitStatus(0))
+				close(ready)
+				return nil
+			}
+		}
+
 		workerSpec := worker.WorkerSpec{
 			Platform: config.Platform,
 			Tags:     step.tags,
@@ -264,9 +365,13 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 	case <-signals:
 		step.registerSource(config, container)
 
-		err = container.Stop(false)
-		if err != nil {
-			step.logger.Error("stopping-container", err)
+		if gracePeriod > 0 {
+			step.terminateProcess(container, gracePeriod, exited)
+		} else {
+			err = container.Stop(false)
+			if err != nil {
+				step.logger.Error("stopping-container", err)
+			}
 		}
 
 		<-exited
@@ -287,12 +392,50 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			return err
 		}
 
+		if processStatus == 0 && step.cacheKey != "" {
+			step.saveCachedOutputs(config, container)
+		}
+
+		step.collectTestResults(container)
+		step.collectAnnotations(config)
+
 		step.delegate.Finished(ExitStatus(processStatus))
 
 		return nil
 	}
 }
 
+// terminateProcess sends SIGTERM to the task's process, giving it the grace
+// period to exit before the container is stopped, killing it.
+func (step *TaskStep) terminateProcess(container worker.Container, gracePeriod time.Duration, exited <-chan struct{}) {
+	logger := step.logger.Session("terminate-process", lager.Data{"grace-period": gracePeriod.String()})
+
+	step.delegate.AbortProgressed(AbortPhaseTerminating, gracePeriod)
+
+	err := step.process.Signal(garden.SignalTerminate)
+	if err != nil {
+		logger.Error("failed-to-signal-process", err)
+	}
+
+	timer := step.clock.NewTimer(gracePeriod)
+	defer timer.Stop()
+
+	select {
+	case <-exited:
+		step.delegate.AbortProgressed(AbortPhaseExited, gracePeriod)
+		return
+
+	case <-timer.C():
+	}
+
+	step.delegate.AbortProgressed(AbortPhaseKilled, gracePeriod)
+
+	err = container.Stop(true)
+	if err != nil {
+		logger.Error("failed-to-kill-process", err)
+	}
+}
+
 func (step *TaskStep) createContainer(config atc.TaskConfig, signals <-chan os.Signal) (resource.Resource, []resource.InputSource, error) {
 	outputPaths := map[string]string{}
 	for _, output := range config.Outputs {
@@ -300,6 +443,14 @@ func (step *TaskStep) createContainer(config atc.TaskConfig, signals <-chan os.S
 		outputPaths[output.Name] = path
 	}
 
+	var taskCaches []worker.TaskCacheMount
+	for _, cache := range config.Caches {
+		taskCaches = append(taskCaches, worker.TaskCacheMount{
+			Path:      cache.Path,
+			MountPath: path.Join(step.artifactsRoot, cache.Path),
+		})
+	}
+
 	imageSpec := worker.ImageSpec{
 		Privileged: bool(step.privileged),
 	}
@@ -346,15 +497,18 @@ func (step *TaskStep) createContainer(config atc.TaskConfig, signals <-chan os.S
 	}
 
 	containerSpec := worker.ContainerSpec{
-		Platform:  config.Platform,
-		Tags:      step.tags,
-		TeamID:    step.teamID,
-		ImageSpec: imageSpec,
-		User:      config.Run.User,
+		Platform:   config.Platform,
+		Tags:       step.tags,
+		TeamID:     step.teamID,
+		ImageSpec:  imageSpec,
+		User:       config.Run.User,
+		Limits:     config.ContainerLimits,
+		TaskCaches: taskCaches,
 	}
 
 	resource, missingInputSources, err := step.resourceFactory.NewBuildResource(
 		step.logger,
+		signals,
 		runContainerID,
 		step.metadata,
 		containerSpec,
@@ -363,6 +517,10 @@ func (step *TaskStep) createContainer(config atc.TaskConfig, signals <-chan os.S
 		inputSources,
 		outputPaths,
 	)
+	if err == worker.ErrInterrupted {
+		return nil, nil, ErrInterrupted
+	}
+
 	if err != nil {
 		return nil, nil, err
 	}
@@ -388,17 +546,300 @@ func (step *TaskStep) registerSource(config atc.TaskConfig, container worker.Con
 				if mount.MountPath == outputPath {
 
 					source := newContainerSource(step.artifactsRoot, container, output, step.logger, mount.Volume.Handle())
+					source.cacheKey = step.cacheKey
+					source.name = worker.ArtifactName(outputName)
+					source.delegate = step.delegate
 					step.repo.RegisterSource(worker.ArtifactName(outputName), source)
 				}
 			}
 		} else {
 			step.logger.Debug("container-has-volume-mounts-NONE")
 			source := newContainerSource(step.artifactsRoot, container, output, step.logger, "")
+			source.cacheKey = step.cacheKey
 			step.repo.RegisterSource(worker.ArtifactName(outputName), source)
 		}
 	}
 }
 
+// collectAnnotations reads the annotations the task wrote to its outputs, if
+// any. Malformed annotations are reported to the task's stderr rather than
+// failing the task.
+func (step *TaskStep) collectAnnotations(config atc.TaskConfig) {
+	logger := step.logger.Session("collect-annotations")
+
+	annotations := []atc.MetadataField{}
+
+	for _, output := range config.Outputs {
+		outputName := output.Name
+		if destinationName, ok := step.outputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		source, found := step.repo.SourceFor(worker.ArtifactName(outputName))
+		if !found {
+			continue
+		}
+
+		file, err := source.StreamFile(AnnotationsFile)
+		if err != nil {
+			logger.Debug("no-annotations", lager.Data{"output": output.Name, "error": err.Error()})
+			continue
+		}
+
+		var outputAnnotations []atc.MetadataField
+		err = json.NewDecoder(file).Decode(&outputAnnotations)
+		file.Close()
+		if err != nil {
+			fmt.Fprintf(step.delegate.Stderr(), "\x1b[31minvalid annotations in output '%s': %s\x1b[0m\n", output.Name, err)
+			continue
+		}
+
+		annotations = append(annotations, outputAnnotations...)
+	}
+
+	if len(annotations) > 0 {
+		step.delegate.Annotated(annotations)
+	}
+}
+
+// collectTestResults parses the JUnit reports matching the step's reports
+// path, streamed out of the container, and reports their results to the
+// delegate. Invalid or oversized reports are skipped, noting so in the task's
+// stderr.
+//
+// Everything under the path's base directory is streamed out, so paths
+// matching files at the root of the working directory are refused rather
+// than streaming all of the task's inputs and outputs through the ATC.
+func (step *TaskStep) collectTestResults(container worker.Container) {
+	if step.reports == nil || step.reports.JUnit == "" {
+		return
+	}
+
+	logger := step.logger.Session("collect-test-results")
+
+	pattern := path.Clean(step.reports.JUnit)
+	baseDir := step.reports.JUnitDir()
+	if baseDir == "." || baseDir == ".." || strings.HasPrefix(baseDir, "../") || path.IsAbs(baseDir) {
+		fmt.Fprintf(step.delegate.Stderr(), "\x1b[31mnot collecting junit reports '%s': the path must be within a directory of the working directory\x1b[0m\n", step.reports.JUnit)
+		return
+	}
+
+	out, err := container.StreamOut(garden.StreamOutSpec{
+		Path: path.Join(step.artifactsRoot, baseDir) + "/",
+	})
+	if err != nil {
+		logger.Info("no-reports", lager.Data{"error": err.Error()})
+		return
+	}
+
+	defer out.Close()
+
+	results := []atc.TestResult{}
+
+	tarReader := tar.NewReader(out)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			logger.Error("failed-to-read-reports", err)
+			break
+		}
+
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		name := path.Join(baseDir, header.Name)
+		if matched, _ := path.Match(pattern, name); !matched {
+			continue
+		}
+
+		if header.Size > maxJUnitReportSize {
+			fmt.Fprintf(step.delegate.Stderr(), "\x1b[31mskipping junit report '%s': larger than %d bytes\x1b[0m\n", name, maxJUnitReportSize)
+			continue
+		}
+
+		reportResults, err := parseJUnit(io.LimitReader(tarReader, maxJUnitReportSize))
+		if err != nil {
+			fmt.Fprintf(step.delegate.Stderr(), "\x1b[31minvalid junit report '%s': %s\x1b[0m\n", name, err)
+			continue
+		}
+
+		results = append(results, reportResults...)
+	}
+
+	if len(results) > 0 {
+		step.delegate.TestResultsReported(results)
+	}
+}
+
+// outputCacheKey hashes the task's config along with the versions of its
+// inputs and image artifact. The outputs cannot be cached if any of them is
+// not a worker.VersionedArtifactSource with a known version, or if the image
+// comes from an image_resource, whose version is only resolved once the
+// image is fetched.
+func (step *TaskStep) outputCacheKey(config atc.TaskConfig) (string, bool) {
+	inputVersions := map[string]string{}
+	for _, input := range config.Inputs {
+		inputName := input.Name
+		if sourceName, ok := step.inputMapping[inputName]; ok {
+			inputName = sourceName
+		}
+
+		version, ok := step.artifactVersion(worker.ArtifactName(inputName))
+		if !ok {
+			return "", false
+		}
+
+		inputVersions[input.Name] = version
+	}
+
+	var imageVersion string
+	if step.imageArtifactName != "" {
+		var ok bool
+		imageVersion, ok = step.artifactVersion(worker.ArtifactName(step.imageArtifactName))
+		if !ok {
+			return "", false
+		}
+	} else if config.ImageResource != nil {
+		return "", false
+	}
+
+	payload, err := json.Marshal(taskOutputCacheKey{
+		Config:     config,
+		Privileged: bool(step.privileged),
+		Image:      imageVersion,
+		Inputs:     inputVersions,
+	})
+	if err != nil {
+		step.logger.Error("failed-to-marshal-cache-key", err)
+		return "", false
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(payload)), true
+}
+
+func (step *TaskStep) artifactVersion(name worker.ArtifactName) (string, bool) {
+	source, found := step.repo.SourceFor(name)
+	if !found {
+		return "", false
+	}
+
+	versionedSource, ok := source.(worker.VersionedArtifactSource)
+	if !ok {
+		return "", false
+	}
+
+	return versionedSource.ArtifactVersion()
+}
+
+func (step *TaskStep) taskCache() dbng.TaskCache {
+	return dbng.TaskCache{
+		TeamID:     step.teamID,
+		PipelineID: step.metadata.PipelineID,
+		JobName:    step.metadata.JobName,
+		StepName:   step.metadata.StepName,
+		Key:        step.cacheKey,
+	}
+}
+
+// reuseCachedOutputs registers the outputs of a previous run with the same
+// cache key. It reports false if there was no such run, or if any of its
+// output volumes can no longer be found, in which case the task must run.
+func (step *TaskStep) reuseCachedOutputs(config atc.TaskConfig) (bool, error) {
+	logger := step.logger.Session("reuse-cached-outputs", lager.Data{"key": step.cacheKey})
+
+	usedTaskCache, found, err := step.taskCacheFactory.FindTaskCacheForBuild(step.containerID.BuildID, step.taskCache())
+	if err != nil {
+		logger.Error("failed-to-find-task-cache", err)
+		return false, err
+	}
+
+	if !found {
+		logger.Debug("cache-miss")
+		return false, nil
+	}
+
+	sources := map[worker.ArtifactName]worker.ArtifactSource{}
+	for _, output := range config.Outputs {
+		handle, ok := usedTaskCache.Outputs[output.Name]
+		if !ok {
+			logger.Info("output-not-cached", lager.Data{"output": output.Name})
+			return false, nil
+		}
+
+		source := &taskCacheSource{
+			logger:     logger,
+			workerPool: step.workerPool,
+			workerName: usedTaskCache.WorkerName,
+			handle:     handle,
+			version:    outputVersion(step.cacheKey, output),
+		}
+
+		_, err := source.volume()
+		if err != nil {
+			logger.Info("cached-output-unavailable", lager.Data{"output": output.Name, "error": err.Error()})
+			return false, nil
+		}
+
+		outputName := output.Name
+		if destinationName, ok := step.outputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		sources[worker.ArtifactName(outputName)] = source
+	}
+
+	for name, source := range sources {
+		step.repo.RegisterSource(name, source)
+	}
+
+	return true, nil
+}
+
+// saveCachedOutputs records the task's output volumes under its cache key.
+// Failing to do so does not fail the task; the next run will simply not be
+// able to reuse them.
+func (step *TaskStep) saveCachedOutputs(config atc.TaskConfig, container worker.Container) {
+	logger := step.logger.Session("save-cached-outputs", lager.Data{"key": step.cacheKey})
+
+	volumeMounts := container.VolumeMounts()
+
+	outputs := map[string]string{}
+	for _, output := range config.Outputs {
+		outputPath := artifactsPath(output, step.artifactsRoot)
+
+		for _, mount := range volumeMounts {
+			if mount.MountPath == outputPath {
+				outputs[output.Name] = mount.Volume.Handle()
+			}
+		}
+
+		if _, found := outputs[output.Name]; !found {
+			logger.Info("output-has-no-volume", lager.Data{"output": output.Name})
+			return
+		}
+	}
+
+	_, err := step.taskCacheFactory.CreateTaskCacheForBuild(
+		step.containerID.BuildID,
+		step.taskCache(),
+		container.WorkerName(),
+		outputs,
+	)
+	if err == dbng.ErrTaskCacheAlreadyExists {
+		logger.Debug("already-cached")
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed-to-create-task-cache", err)
+	}
+}
+
 // Result indicates Success as true if the script's exit status was 0.
 //
 // It also indicates ExitStatus as the exit status of the script.
@@ -430,10 +871,14 @@ func (step *TaskStep) streamInputs(inputSources []resource.InputSource, containe
 			container,
 		)
 
+		start := step.clock.Now()
+
 		err := inputSource.Source().StreamTo(destination)
 		if err != nil {
 			return err
 		}
+
+		step.delegate.ArtifactStreamed(StreamIn, inputSource.Name(), start, step.clock.Now())
 	}
 
 	return nil
@@ -486,7 +931,13 @@ type containerSource struct {
 	outputConfig  atc.TaskOutputConfig
 	artifactsRoot string
 	volumeHandle  string
+	cacheKey      string
 	logger        lager.Logger
+
+	// name and delegate are only set for registered outputs, whose streams
+	// are reported to the delegate
+	name     worker.ArtifactName
+	delegate TaskDelegate
 }
 
 func newContainerSource(
@@ -506,6 +957,8 @@ func newContainerSource(
 }
 
 func (src *containerSource) StreamTo(destination worker.ArtifactDestination) error {
+	start := time.Now()
+
 	out, err := src.container.StreamOut(garden.StreamOutSpec{
 		Path: artifactsPath(src.outputConfig, src.artifactsRoot),
 	})
@@ -515,7 +968,16 @@ func (src *containerSource) StreamTo(destination worker.ArtifactDestination) err
 
 	defer out.Close()
 
-	return destination.StreamIn(".", out)
+	err = destination.StreamIn(".", out)
+	if err != nil {
+		return err
+	}
+
+	if src.delegate != nil {
+		src.delegate.ArtifactStreamed(StreamOut, src.name, start, time.Now())
+	}
+
+	return nil
 }
 
 func (src *containerSource) StreamFile(filename string) (io.ReadCloser, error) {
@@ -543,6 +1005,107 @@ func (src *containerSource) VolumeOn(w worker.Worker) (worker.Volume, bool, erro
 	return w.LookupVolume(src.logger, src.volumeHandle)
 }
 
+// ArtifactVersion is only known for outputs of a task that caches them, in
+// which case it is derived from the task's cache key.
+func (src *containerSource) ArtifactVersion() (string, bool) {
+	if src.cacheKey == "" {
+		return "", false
+	}
+
+	return outputVersion(src.cacheKey, src.outputConfig), true
+}
+
+func outputVersion(cacheKey string, outputConfig atc.TaskOutputConfig) string {
+	return cacheKey + "/" + outputConfig.Name
+}
+
+type taskOutputCacheKey struct {
+	Config     atc.TaskConfig    `json:"config"`
+	Privileged bool              `json:"privileged"`
+	Image      string            `json:"image,omitempty"`
+	Inputs     map[string]string `json:"inputs"`
+}
+
+// taskCacheSource is an output of a previous run of a task, whose volume has
+// been kept around by a dbng.TaskCache.
+type taskCacheSource struct {
+	logger     lager.Logger
+	workerPool worker.Client
+	workerName string
+	handle     string
+	version    string
+}
+
+func (src *taskCacheSource) volume() (worker.Volume, error) {
+	cacheWorker, err := src.workerPool.GetWorker(src.workerName)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, found, err := cacheWorker.LookupVolume(src.logger, src.handle)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrTaskCacheVolumeNotFound
+	}
+
+	return volume, nil
+}
+
+func (src *taskCacheSource) StreamTo(destination worker.ArtifactDestination) error {
+	volume, err := src.volume()
+	if err != nil {
+		return err
+	}
+
+	out, err := volume.StreamOut(".")
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	return destination.StreamIn(".", out)
+}
+
+func (src *taskCacheSource) StreamFile(filename string) (io.ReadCloser, error) {
+	volume, err := src.volume()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := volume.StreamOut(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	tarReader := tar.NewReader(out)
+
+	_, err = tarReader.Next()
+	if err != nil {
+		return nil, FileNotFoundError{Path: filename}
+	}
+
+	return fileReadCloser{
+		Reader: tarReader,
+		Closer: out,
+	}, nil
+}
+
+func (src *taskCacheSource) VolumeOn(w worker.Worker) (worker.Volume, bool, error) {
+	if w.Name() != src.workerName {
+		return nil, false, nil
+	}
+
+	return w.LookupVolume(src.logger, src.handle)
+}
+
+func (src *taskCacheSource) ArtifactVersion() (string, bool) {
+	return src.version, true
+}
+
 func artifactsPath(outputConfig atc.TaskOutputConfig, artifactsRoot string) string {
 	outputSrc := outputConfig.Path
 	if len(outputSrc) == 0 {
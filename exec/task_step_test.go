@@ -16,6 +16,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
@@ -33,6 +34,7 @@ var _ = Describe("GardenFactory", func() {
 	var (
 		fakeWorkerClient           *workerfakes.FakeClient
 		fakeDBResourceCacheFactory *dbngfakes.FakeResourceCacheFactory
+		fakeDBTaskCacheFactory     *dbngfakes.FakeTaskCacheFactory
 		fakeResource               *resourcefakes.FakeResource
 		fakeResourceFactory        *resourcefakes.FakeResourceFactory
 
@@ -53,7 +55,8 @@ var _ = Describe("GardenFactory", func() {
 		fakeResourceFactory = new(resourcefakes.FakeResourceFactory)
 		fakeResourceFetcher := new(resourcefakes.FakeFetcher)
 		fakeDBResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
-		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory)
+		fakeDBTaskCacheFactory = new(dbngfakes.FakeTaskCacheFactory)
+		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, fakeDBTaskCacheFactory, atc.ContainerLimits{})
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -61,14 +64,17 @@ var _ = Describe("GardenFactory", func() {
 
 	Describe("Task", func() {
 		var (
-			taskDelegate  *execfakes.FakeTaskDelegate
-			privileged    Privileged
-			tags          []string
-			teamID        int
-			configSource  *execfakes.FakeTaskConfigSource
-			resourceTypes atc.ResourceTypes
-			inputMapping  map[string]string
-			outputMapping map[string]string
+			taskDelegate     *execfakes.FakeTaskDelegate
+			privileged       Privileged
+			tags             []string
+			teamID           int
+			configSource     *execfakes.FakeTaskConfigSource
+			resourceTypes    atc.ResourceTypes
+			inputMapping     map[string]string
+			outputMapping    map[string]string
+			cacheOutputs     bool
+			reports          *atc.TaskReports
+			abortGracePeriod string
 
 			inStep *execfakes.FakeStep
 			repo   *worker.ArtifactRepository
@@ -101,6 +107,9 @@ var _ = Describe("GardenFactory", func() {
 			inputMapping = nil
 			outputMapping = nil
 			imageArtifactName = ""
+			cacheOutputs = false
+			reports = nil
+			abortGracePeriod = ""
 			fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
 
 			identifier = worker.Identifier{
@@ -130,6 +139,9 @@ var _ = Describe("GardenFactory", func() {
 				inputMapping,
 				outputMapping,
 				imageArtifactName,
+				cacheOutputs,
+				reports,
+				abortGracePeriod,
 				fakeClock,
 			).Using(inStep, repo)
 
@@ -180,6 +192,7 @@ var _ = Describe("GardenFactory", func() {
 						fakeContainer.RunReturns(fakeProcess, nil)
 
 						fakeContainer.StreamInReturns(nil)
+						fakeContainer.StreamOutReturns(nil, errors.New("no such file"))
 					})
 
 					Describe("before having created the container", func() {
@@ -212,7 +225,7 @@ var _ = Describe("GardenFactory", func() {
 
 					It("creates a container with the config's image and the session ID as the handle", func() {
 						Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(Equal(1))
-						_, createdIdentifier, createdMetadata, spec, actualResourceTypes, delegate, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+						_, _, createdIdentifier, createdMetadata, spec, actualResourceTypes, delegate, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
 						Expect(createdIdentifier).To(Equal(worker.Identifier{
 							BuildID: 1234,
 							PlanID:  atc.PlanID("some-plan-id"),
@@ -248,6 +261,58 @@ var _ = Describe("GardenFactory", func() {
 						}))
 					})
 
+					Context("when the config has container limits", func() {
+						BeforeEach(func() {
+							fetchedConfig.ContainerLimits = atc.ContainerLimits{Memory: 1024}
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+						})
+
+						It("creates the container with the limits", func() {
+							_, _, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+							Expect(spec.Limits).To(Equal(atc.ContainerLimits{Memory: 1024}))
+						})
+
+						Context("when the ATC has default limits", func() {
+							BeforeEach(func() {
+								factory = NewGardenFactory(
+									fakeWorkerClient,
+									new(resourcefakes.FakeFetcher),
+									fakeResourceFactory,
+									fakeDBResourceCacheFactory,
+									fakeDBTaskCacheFactory,
+									atc.ContainerLimits{CPU: 512, Memory: 2048},
+								)
+							})
+
+							It("uses the defaults for limits the config does not set", func() {
+								_, _, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+								Expect(spec.Limits).To(Equal(atc.ContainerLimits{CPU: 512, Memory: 1024}))
+							})
+
+							It("reports the limits to the delegate's Initializing callback", func() {
+								Expect(taskDelegate.InitializingArgsForCall(0).ContainerLimits).To(Equal(atc.ContainerLimits{CPU: 512, Memory: 1024}))
+							})
+						})
+					})
+
+					Context("when the config has caches", func() {
+						BeforeEach(func() {
+							fetchedConfig.Caches = []atc.CacheConfig{
+								{Path: "some-cache"},
+								{Path: "some/nested/cache"},
+							}
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+						})
+
+						It("creates the container with the caches mounted under the artifacts root", func() {
+							_, _, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+							Expect(spec.TaskCaches).To(Equal([]worker.TaskCacheMount{
+								{Path: "some-cache", MountPath: "/tmp/build/a1f5c0c1/some-cache"},
+								{Path: "some/nested/cache", MountPath: "/tmp/build/a1f5c0c1/some/nested/cache"},
+							}))
+						})
+					})
+
 					It("ensures artifacts root exists by streaming in an empty payload", func() {
 						Expect(fakeContainer.StreamInCallCount()).To(Equal(1))
 
@@ -300,7 +365,7 @@ var _ = Describe("GardenFactory", func() {
 
 						It("creates the container privileged", func() {
 							Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(Equal(1))
-							_, createdIdentifier, createdMetadata, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+							_, _, createdIdentifier, createdMetadata, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
 							Expect(createdIdentifier).To(Equal(worker.Identifier{
 								BuildID: 1234,
 								PlanID:  atc.PlanID("some-plan-id"),
@@ -546,6 +611,340 @@ var _ = Describe("GardenFactory", func() {
 						})
 					})
 
+					Context("when the task caches its outputs", func() {
+						var fakeInputSource *workerfakes.FakeVersionedArtifactSource
+
+						BeforeEach(func() {
+							cacheOutputs = true
+
+							configSource.FetchConfigReturns(atc.TaskConfig{
+								Platform: "some-platform",
+								Image:    "some-image",
+								Run: atc.TaskRunConfig{
+									Path: "ls",
+								},
+								Inputs: []atc.TaskInputConfig{
+									{Name: "some-input"},
+								},
+								Outputs: []atc.TaskOutputConfig{
+									{Name: "some-output"},
+								},
+							}, nil)
+
+							fakeInputSource = new(workerfakes.FakeVersionedArtifactSource)
+							fakeInputSource.ArtifactVersionReturns("some-input-version", true)
+							repo.RegisterSource("some-input", fakeInputSource)
+						})
+
+						Context("when a previous run with the same inputs cached its outputs", func() {
+							var (
+								fakeCacheWorker  *workerfakes.FakeWorker
+								fakeCachedVolume *workerfakes.FakeVolume
+							)
+
+							BeforeEach(func() {
+								fakeDBTaskCacheFactory.FindTaskCacheForBuildReturns(&dbng.UsedTaskCache{
+									ID:         1,
+									WorkerName: "some-cache-worker",
+									Outputs: map[string]string{
+										"some-output": "some-cached-handle",
+									},
+								}, true, nil)
+
+								fakeCachedVolume = new(workerfakes.FakeVolume)
+								fakeCachedVolume.StreamOutReturns(nil, errors.New("no such file"))
+
+								fakeCacheWorker = new(workerfakes.FakeWorker)
+								fakeCacheWorker.NameReturns("some-cache-worker")
+								fakeCacheWorker.LookupVolumeReturns(fakeCachedVolume, true, nil)
+								fakeWorkerClient.GetWorkerReturns(fakeCacheWorker, nil)
+							})
+
+							It("looks up the cache for the build by the task's step and key", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								Expect(fakeDBTaskCacheFactory.FindTaskCacheForBuildCallCount()).To(Equal(1))
+								buildID, taskCache := fakeDBTaskCacheFactory.FindTaskCacheForBuildArgsForCall(0)
+								Expect(buildID).To(Equal(1234))
+								Expect(taskCache.TeamID).To(Equal(123))
+								Expect(taskCache.JobName).To(Equal("some-job"))
+								Expect(taskCache.StepName).To(Equal("some-step"))
+								Expect(taskCache.Key).NotTo(BeEmpty())
+							})
+
+							It("does not run the task", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(BeZero())
+								Expect(fakeContainer.RunCallCount()).To(BeZero())
+							})
+
+							It("registers the cached volumes as the task's outputs", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								source, found := repo.SourceFor("some-output")
+								Expect(found).To(BeTrue())
+
+								volume, found, err := source.VolumeOn(fakeCacheWorker)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(found).To(BeTrue())
+								Expect(volume).To(Equal(fakeCachedVolume))
+
+								_, handle := fakeCacheWorker.LookupVolumeArgsForCall(0)
+								Expect(handle).To(Equal("some-cached-handle"))
+							})
+
+							It("reports a cache hit and a successful finish", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								Expect(taskDelegate.CacheHitCallCount()).To(Equal(1))
+								_, taskCache := fakeDBTaskCacheFactory.FindTaskCacheForBuildArgsForCall(0)
+								Expect(taskDelegate.CacheHitArgsForCall(0)).To(Equal(taskCache.Key))
+
+								Expect(taskDelegate.StartedCallCount()).To(BeZero())
+								Expect(taskDelegate.FinishedCallCount()).To(Equal(1))
+								Expect(taskDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(0)))
+
+								var success Success
+								Expect(step.Result(&success)).To(BeTrue())
+								Expect(bool(success)).To(BeTrue())
+							})
+
+							Context("when a cached output contains annotations", func() {
+								BeforeEach(func() {
+									annotations := `[{"name":"failed test","value":"TestSomething"}]`
+
+									fakeCachedVolume.StreamOutStub = func(path string) (io.ReadCloser, error) {
+										if path != AnnotationsFile {
+											return nil, errors.New("no such file")
+										}
+
+										tarBuffer := gbytes.NewBuffer()
+										tarWriter := tar.NewWriter(tarBuffer)
+
+										err := tarWriter.WriteHeader(&tar.Header{
+											Name: AnnotationsFile,
+											Mode: 0644,
+											Size: int64(len(annotations)),
+										})
+										Expect(err).NotTo(HaveOccurred())
+
+										_, err = tarWriter.Write([]byte(annotations))
+										Expect(err).NotTo(HaveOccurred())
+
+										return tarBuffer, nil
+									}
+								})
+
+								It("reports them to the delegate", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(taskDelegate.AnnotatedCallCount()).To(Equal(1))
+									Expect(taskDelegate.AnnotatedArgsForCall(0)).To(Equal([]atc.MetadataField{
+										{Name: "failed test", Value: "TestSomething"},
+									}))
+								})
+							})
+
+							Context("when a cached volume has disappeared", func() {
+								BeforeEach(func() {
+									fakeCacheWorker.LookupVolumeReturns(nil, false, nil)
+								})
+
+								It("runs the task", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(taskDelegate.CacheHitCallCount()).To(BeZero())
+									Expect(fakeContainer.RunCallCount()).To(Equal(1))
+								})
+							})
+						})
+
+						Context("when the task has no outputs", func() {
+							BeforeEach(func() {
+								configSource.FetchConfigReturns(atc.TaskConfig{
+									Platform: "some-platform",
+									Image:    "some-image",
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+									},
+									Inputs: []atc.TaskInputConfig{
+										{Name: "some-input"},
+									},
+								}, nil)
+
+								fakeProcess.WaitReturns(0, nil)
+							})
+
+							It("runs the task without caching it", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								Expect(fakeDBTaskCacheFactory.FindTaskCacheForBuildCallCount()).To(BeZero())
+								Expect(fakeContainer.RunCallCount()).To(Equal(1))
+								Expect(fakeDBTaskCacheFactory.CreateTaskCacheForBuildCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when no previous run cached its outputs", func() {
+							BeforeEach(func() {
+								fakeDBTaskCacheFactory.FindTaskCacheForBuildReturns(nil, false, nil)
+
+								fakeOutputVolume := new(workerfakes.FakeVolume)
+								fakeOutputVolume.HandleReturns("some-output-handle")
+
+								fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+									{
+										Volume:    fakeOutputVolume,
+										MountPath: "/tmp/build/a1f5c0c1/some-output/",
+									},
+								})
+								fakeContainer.WorkerNameReturns("some-worker")
+							})
+
+							Context("when the process exits 0", func() {
+								BeforeEach(func() {
+									fakeProcess.WaitReturns(0, nil)
+								})
+
+								It("runs the task and caches its output volumes", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(fakeContainer.RunCallCount()).To(Equal(1))
+
+									Expect(fakeDBTaskCacheFactory.CreateTaskCacheForBuildCallCount()).To(Equal(1))
+									buildID, taskCache, workerName, outputs := fakeDBTaskCacheFactory.CreateTaskCacheForBuildArgsForCall(0)
+									Expect(buildID).To(Equal(1234))
+									Expect(workerName).To(Equal("some-worker"))
+									Expect(outputs).To(Equal(map[string]string{
+										"some-output": "some-output-handle",
+									}))
+
+									_, lookedUpTaskCache := fakeDBTaskCacheFactory.FindTaskCacheForBuildArgsForCall(0)
+									Expect(taskCache).To(Equal(lookedUpTaskCache))
+								})
+
+								It("registers outputs versioned by the cache key", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									source, found := repo.SourceFor("some-output")
+									Expect(found).To(BeTrue())
+
+									versionedSource, ok := source.(worker.VersionedArtifactSource)
+									Expect(ok).To(BeTrue())
+
+									version, known := versionedSource.ArtifactVersion()
+									Expect(known).To(BeTrue())
+
+									_, taskCache, _, _ := fakeDBTaskCacheFactory.CreateTaskCacheForBuildArgsForCall(0)
+									Expect(version).To(Equal(taskCache.Key + "/some-output"))
+								})
+
+								Context("when caching the outputs fails", func() {
+									BeforeEach(func() {
+										fakeDBTaskCacheFactory.CreateTaskCacheForBuildReturns(nil, errors.New("nope"))
+									})
+
+									It("still succeeds", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										var success Success
+										Expect(step.Result(&success)).To(BeTrue())
+										Expect(bool(success)).To(BeTrue())
+									})
+								})
+							})
+
+							Context("when the process exits nonzero", func() {
+								BeforeEach(func() {
+									fakeProcess.WaitReturns(1, nil)
+								})
+
+								It("does not cache its outputs", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(fakeDBTaskCacheFactory.CreateTaskCacheForBuildCallCount()).To(BeZero())
+								})
+							})
+						})
+
+						Context("when the input's version changes", func() {
+							It("looks up a different key", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								_, firstTaskCache := fakeDBTaskCacheFactory.FindTaskCacheForBuildArgsForCall(0)
+
+								fakeInputSource.ArtifactVersionReturns("some-other-input-version", true)
+
+								secondProcess := ifrit.Invoke(factory.Task(
+									lagertest.NewTestLogger("test"),
+									sourceName,
+									identifier,
+									workerMetadata,
+									taskDelegate,
+									privileged,
+									tags,
+									teamID,
+									configSource,
+									resourceTypes,
+									inputMapping,
+									outputMapping,
+									imageArtifactName,
+									cacheOutputs,
+									reports,
+									abortGracePeriod,
+									fakeClock,
+								).Using(inStep, repo))
+								Eventually(secondProcess.Wait()).Should(Receive(BeNil()))
+
+								_, secondTaskCache := fakeDBTaskCacheFactory.FindTaskCacheForBuildArgsForCall(1)
+								Expect(secondTaskCache.Key).NotTo(Equal(firstTaskCache.Key))
+							})
+						})
+
+						Context("when an input is not versioned", func() {
+							BeforeEach(func() {
+								repo.RegisterSource("some-input", new(workerfakes.FakeArtifactSource))
+							})
+
+							It("neither looks up nor creates a cache", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								Expect(fakeContainer.RunCallCount()).To(Equal(1))
+								Expect(fakeDBTaskCacheFactory.FindTaskCacheForBuildCallCount()).To(BeZero())
+								Expect(fakeDBTaskCacheFactory.CreateTaskCacheForBuildCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when the image comes from an image_resource", func() {
+							BeforeEach(func() {
+								configSource.FetchConfigReturns(atc.TaskConfig{
+									Platform: "some-platform",
+									ImageResource: &atc.ImageResource{
+										Type:   "docker-image",
+										Source: atc.Source{"repository": "some-repository"},
+									},
+									Run: atc.TaskRunConfig{
+										Path: "ls",
+									},
+									Inputs: []atc.TaskInputConfig{
+										{Name: "some-input"},
+									},
+									Outputs: []atc.TaskOutputConfig{
+										{Name: "some-output"},
+									},
+								}, nil)
+							})
+
+							It("neither looks up nor creates a cache, as the image's version isn't known yet", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								Expect(fakeContainer.RunCallCount()).To(Equal(1))
+								Expect(fakeDBTaskCacheFactory.FindTaskCacheForBuildCallCount()).To(BeZero())
+								Expect(fakeDBTaskCacheFactory.CreateTaskCacheForBuildCallCount()).To(BeZero())
+							})
+						})
+					})
+
 					Context("when the configuration specifies paths for outputs", func() {
 						BeforeEach(func() {
 							configSource.FetchConfigReturns(atc.TaskConfig{
@@ -600,6 +999,218 @@ var _ = Describe("GardenFactory", func() {
 								fakeProcess.WaitReturns(0, nil)
 							})
 
+							Context("when an output contains annotations", func() {
+								var annotations string
+
+								BeforeEach(func() {
+									annotations = `[{"name":"failed test","value":"TestSomething"}]`
+
+									fakeContainer.StreamOutStub = func(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+										if spec.Path != "/tmp/build/a1f5c0c1/some-other-output/"+AnnotationsFile {
+											return nil, errors.New("no such file")
+										}
+
+										tarBuffer := gbytes.NewBuffer()
+										tarWriter := tar.NewWriter(tarBuffer)
+
+										err := tarWriter.WriteHeader(&tar.Header{
+											Name: AnnotationsFile,
+											Mode: 0644,
+											Size: int64(len(annotations)),
+										})
+										Expect(err).NotTo(HaveOccurred())
+
+										_, err = tarWriter.Write([]byte(annotations))
+										Expect(err).NotTo(HaveOccurred())
+
+										return tarBuffer, nil
+									}
+
+									taskDelegate.AnnotatedStub = func([]atc.MetadataField) {
+										defer GinkgoRecover()
+										Expect(taskDelegate.FinishedCallCount()).To(BeZero())
+									}
+								})
+
+								It("reports them to the delegate before finishing", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(taskDelegate.AnnotatedCallCount()).To(Equal(1))
+									Expect(taskDelegate.AnnotatedArgsForCall(0)).To(Equal([]atc.MetadataField{
+										{Name: "failed test", Value: "TestSomething"},
+									}))
+
+									Expect(taskDelegate.FinishedCallCount()).To(Equal(1))
+								})
+
+								Context("when they are malformed", func() {
+									BeforeEach(func() {
+										annotations = `{"failed test":`
+									})
+
+									It("reports them on stderr rather than failing", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(stderrBuf).To(gbytes.Say("invalid annotations in output 'some-other-output'"))
+										Expect(taskDelegate.AnnotatedCallCount()).To(BeZero())
+										Expect(taskDelegate.FinishedCallCount()).To(Equal(1))
+									})
+								})
+							})
+
+							Context("when no output contains annotations", func() {
+								It("does not report any", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(taskDelegate.AnnotatedCallCount()).To(BeZero())
+								})
+							})
+
+							Context("when the step collects junit reports", func() {
+								var reportFiles map[string]string
+
+								BeforeEach(func() {
+									reports = &atc.TaskReports{JUnit: "some-other-output/reports/*.xml"}
+
+									reportFiles = map[string]string{
+										"./unit.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="unit">
+    <testcase classname="pkg.Widget" name="renders" time="0.25"/>
+    <testcase classname="pkg.Widget" name="resizes" time="1.5">
+      <failure message="expected 2, got 3">stack trace</failure>
+    </testcase>
+    <testsuite name="nested">
+      <testcase name="connects"><error>connection refused</error></testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`,
+										"./integration.xml": `<testsuite name="integration"><testcase name="pending"><skipped/></testcase></testsuite>`,
+										"./notes.txt":       "not a report",
+									}
+
+									fakeContainer.StreamOutStub = func(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+										if spec.Path != "/tmp/build/a1f5c0c1/some-other-output/reports/" {
+											return nil, errors.New("no such file")
+										}
+
+										tarBuffer := gbytes.NewBuffer()
+										tarWriter := tar.NewWriter(tarBuffer)
+
+										for _, name := range []string{"./unit.xml", "./integration.xml", "./notes.txt"} {
+											contents, found := reportFiles[name]
+											if !found {
+												continue
+											}
+
+											err := tarWriter.WriteHeader(&tar.Header{
+												Name: name,
+												Mode: 0644,
+												Size: int64(len(contents)),
+											})
+											Expect(err).NotTo(HaveOccurred())
+
+											_, err = tarWriter.Write([]byte(contents))
+											Expect(err).NotTo(HaveOccurred())
+										}
+
+										err := tarWriter.Close()
+										Expect(err).NotTo(HaveOccurred())
+
+										return tarBuffer, nil
+									}
+
+									taskDelegate.TestResultsReportedStub = func([]atc.TestResult) {
+										defer GinkgoRecover()
+										Expect(taskDelegate.FinishedCallCount()).To(BeZero())
+									}
+								})
+
+								It("reports the results of the matching reports to the delegate before finishing", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+
+									Expect(taskDelegate.TestResultsReportedCallCount()).To(Equal(1))
+									Expect(taskDelegate.TestResultsReportedArgsForCall(0)).To(Equal([]atc.TestResult{
+										{Suite: "unit", ClassName: "pkg.Widget", Name: "renders", Status: atc.TestStatusPassed, Duration: 0.25},
+										{Suite: "unit", ClassName: "pkg.Widget", Name: "resizes", Status: atc.TestStatusFailed, Duration: 1.5, Message: "expected 2, got 3"},
+										{Suite: "nested", Name: "connects", Status: atc.TestStatusErrored, Message: "connection refused"},
+										{Suite: "integration", Name: "pending", Status: atc.TestStatusSkipped},
+									}))
+
+									Expect(taskDelegate.FinishedCallCount()).To(Equal(1))
+								})
+
+								Context("when a report is malformed", func() {
+									BeforeEach(func() {
+										reportFiles["./unit.xml"] = `<testsuites><testsuite>`
+									})
+
+									It("reports it on stderr and reports the other results", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(stderrBuf).To(gbytes.Say("invalid junit report 'some-other-output/reports/unit.xml'"))
+
+										Expect(taskDelegate.TestResultsReportedCallCount()).To(Equal(1))
+										Expect(taskDelegate.TestResultsReportedArgsForCall(0)).To(Equal([]atc.TestResult{
+											{Suite: "integration", Name: "pending", Status: atc.TestStatusSkipped},
+										}))
+									})
+								})
+
+								Context("when no report matches", func() {
+									BeforeEach(func() {
+										delete(reportFiles, "./unit.xml")
+										delete(reportFiles, "./integration.xml")
+									})
+
+									It("does not report any results", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+										Expect(taskDelegate.TestResultsReportedCallCount()).To(BeZero())
+									})
+								})
+
+								Context("when a report is too large", func() {
+									BeforeEach(func() {
+										reportFiles["./unit.xml"] = "<testsuites>" + strings.Repeat(" ", 10*1024*1024) + "</testsuites>"
+									})
+
+									It("skips it, noting so on stderr, and reports the other results", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(stderrBuf).To(gbytes.Say("skipping junit report 'some-other-output/reports/unit.xml': larger than 10485760 bytes"))
+
+										Expect(taskDelegate.TestResultsReportedCallCount()).To(Equal(1))
+										Expect(taskDelegate.TestResultsReportedArgsForCall(0)).To(Equal([]atc.TestResult{
+											{Suite: "integration", Name: "pending", Status: atc.TestStatusSkipped},
+										}))
+									})
+								})
+
+								Context("when the junit path matches files at the root of the working directory", func() {
+									BeforeEach(func() {
+										reports = &atc.TaskReports{JUnit: "*.xml"}
+									})
+
+									It("refuses to stream out the working directory, noting so on stderr", func() {
+										Eventually(process.Wait()).Should(Receive(BeNil()))
+
+										Expect(stderrBuf).To(gbytes.Say("not collecting junit reports '\\*.xml'"))
+
+										for i := 0; i < fakeContainer.StreamOutCallCount(); i++ {
+											Expect(fakeContainer.StreamOutArgsForCall(i).Path).NotTo(Equal("/tmp/build/a1f5c0c1/"))
+										}
+
+										Expect(taskDelegate.TestResultsReportedCallCount()).To(BeZero())
+									})
+								})
+							})
+
+							Context("when the step does not collect reports", func() {
+								It("does not report any results", func() {
+									Eventually(process.Wait()).Should(Receive(BeNil()))
+									Expect(taskDelegate.TestResultsReportedCallCount()).To(BeZero())
+								})
+							})
+
 							Describe("the registered sources", func() {
 								var (
 									artifactSource1 worker.ArtifactSource
@@ -609,11 +1220,16 @@ var _ = Describe("GardenFactory", func() {
 									fakeMountPath1 string = "/tmp/build/a1f5c0c1/some-output-configured-path/"
 									fakeMountPath2 string = "/tmp/build/a1f5c0c1/some-other-output/"
 									fakeMountPath3 string = "/tmp/build/a1f5c0c1/some-output-configured-path-with-trailing-slash/"
+
+									// looking for annotations streams out of the outputs too
+									streamOutsDuringRun int
 								)
 
 								JustBeforeEach(func() {
 									Eventually(process.Wait()).Should(Receive(BeNil()))
 
+									streamOutsDuringRun = fakeContainer.StreamOutCallCount()
+
 									var found bool
 									artifactSource1, found = repo.SourceFor("some-output")
 									Expect(found).To(BeTrue())
@@ -642,6 +1258,9 @@ var _ = Describe("GardenFactory", func() {
 
 										BeforeEach(func() {
 											streamedOut = gbytes.NewBuffer()
+										})
+
+										JustBeforeEach(func() {
 											fakeContainer.StreamOutReturns(streamedOut, nil)
 										})
 
@@ -688,7 +1307,7 @@ var _ = Describe("GardenFactory", func() {
 											})
 
 											It("passes existing output volumes to the resource", func() {
-												_, _, _, _, _, _, _, outputPaths := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+												_, _, _, _, _, _, _, _, outputPaths := fakeResourceFactory.NewBuildResourceArgsForCall(0)
 												Expect(outputPaths).To(Equal(map[string]string{
 													"some-output":                "/tmp/build/a1f5c0c1/some-output-configured-path/",
 													"some-other-output":          "/tmp/build/a1f5c0c1/some-other-output/",
@@ -700,8 +1319,8 @@ var _ = Describe("GardenFactory", func() {
 												err := artifactSource1.StreamTo(fakeDestination)
 												Expect(err).NotTo(HaveOccurred())
 
-												Expect(fakeContainer.StreamOutCallCount()).To(Equal(1))
-												spec := fakeContainer.StreamOutArgsForCall(0)
+												Expect(fakeContainer.StreamOutCallCount()).To(Equal(streamOutsDuringRun + 1))
+												spec := fakeContainer.StreamOutArgsForCall(streamOutsDuringRun)
 												Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/some-output-configured-path/"))
 												Expect(spec.User).To(Equal("")) // use default
 
@@ -717,8 +1336,8 @@ var _ = Describe("GardenFactory", func() {
 												err := artifactSource1.StreamTo(fakeDestination)
 												Expect(err).NotTo(HaveOccurred())
 
-												Expect(fakeContainer.StreamOutCallCount()).To(Equal(1))
-												spec := fakeContainer.StreamOutArgsForCall(0)
+												Expect(fakeContainer.StreamOutCallCount()).To(Equal(streamOutsDuringRun + 1))
+												spec := fakeContainer.StreamOutArgsForCall(streamOutsDuringRun)
 												Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/some-output-configured-path/"))
 												Expect(spec.User).To(Equal("")) // use default
 
@@ -732,8 +1351,8 @@ var _ = Describe("GardenFactory", func() {
 												err := artifactSource3.StreamTo(fakeDestination)
 												Expect(err).NotTo(HaveOccurred())
 
-												Expect(fakeContainer.StreamOutCallCount()).To(Equal(1))
-												spec := fakeContainer.StreamOutArgsForCall(0)
+												Expect(fakeContainer.StreamOutCallCount()).To(Equal(streamOutsDuringRun + 1))
+												spec := fakeContainer.StreamOutArgsForCall(streamOutsDuringRun)
 												Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/some-output-configured-path-with-trailing-slash/"))
 												Expect(spec.User).To(Equal("")) // use default
 
@@ -747,8 +1366,8 @@ var _ = Describe("GardenFactory", func() {
 												err := artifactSource2.StreamTo(fakeDestination)
 												Expect(err).NotTo(HaveOccurred())
 
-												Expect(fakeContainer.StreamOutCallCount()).To(Equal(1))
-												spec := fakeContainer.StreamOutArgsForCall(0)
+												Expect(fakeContainer.StreamOutCallCount()).To(Equal(streamOutsDuringRun + 1))
+												spec := fakeContainer.StreamOutArgsForCall(streamOutsDuringRun)
 												Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/some-other-output/"))
 												Expect(spec.User).To(Equal("")) // use default
 
@@ -761,7 +1380,7 @@ var _ = Describe("GardenFactory", func() {
 											Context("when streaming out of the versioned source fails", func() {
 												disaster := errors.New("nope")
 
-												BeforeEach(func() {
+												JustBeforeEach(func() {
 													fakeContainer.StreamOutReturns(nil, disaster)
 												})
 
@@ -795,6 +1414,9 @@ var _ = Describe("GardenFactory", func() {
 
 										BeforeEach(func() {
 											tarBuffer = gbytes.NewBuffer()
+										})
+
+										JustBeforeEach(func() {
 											fakeContainer.StreamOutReturns(tarBuffer, nil)
 										})
 
@@ -819,7 +1441,7 @@ var _ = Describe("GardenFactory", func() {
 
 												Expect(ioutil.ReadAll(reader)).To(Equal([]byte(fileContent)))
 
-												spec := fakeContainer.StreamOutArgsForCall(0)
+												spec := fakeContainer.StreamOutArgsForCall(streamOutsDuringRun)
 												Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/some-output-configured-path/some-path"))
 												Expect(spec.User).To(Equal("")) // use default
 											})
@@ -936,6 +1558,82 @@ var _ = Describe("GardenFactory", func() {
 								Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
 							})
 
+							Context("when the step has an abort grace period", func() {
+								BeforeEach(func() {
+									abortGracePeriod = "1m"
+								})
+
+								Context("when the process exits within the grace period", func() {
+									BeforeEach(func() {
+										fakeProcess.SignalStub = func(signal garden.Signal) error {
+											if signal == garden.SignalTerminate {
+												close(stopped)
+											}
+
+											return nil
+										}
+									})
+
+									It("sends the process SIGTERM rather than stopping the container", func() {
+										process.Signal(os.Interrupt)
+										Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+										Expect(fakeProcess.SignalCallCount()).To(Equal(1))
+										Expect(fakeProcess.SignalArgsForCall(0)).To(Equal(garden.SignalTerminate))
+										Expect(fakeContainer.StopCallCount()).To(BeZero())
+									})
+
+									It("reports the abort's progression to the delegate", func() {
+										process.Signal(os.Interrupt)
+										Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+										Expect(taskDelegate.AbortProgressedCallCount()).To(Equal(2))
+
+										phase, gracePeriod := taskDelegate.AbortProgressedArgsForCall(0)
+										Expect(phase).To(Equal(AbortPhaseTerminating))
+										Expect(gracePeriod).To(Equal(time.Minute))
+
+										phase, _ = taskDelegate.AbortProgressedArgsForCall(1)
+										Expect(phase).To(Equal(AbortPhaseExited))
+									})
+								})
+
+								Context("when the process does not exit within the grace period", func() {
+									It("kills it once the grace period has elapsed", func() {
+										process.Signal(os.Interrupt)
+
+										Eventually(fakeProcess.SignalCallCount).Should(Equal(1))
+										Expect(fakeProcess.SignalArgsForCall(0)).To(Equal(garden.SignalTerminate))
+
+										fakeClock.WaitForWatcherAndIncrement(59 * time.Second)
+										Consistently(fakeContainer.StopCallCount).Should(BeZero())
+
+										fakeClock.Increment(time.Second)
+
+										Eventually(fakeContainer.StopCallCount).Should(Equal(1))
+										Expect(fakeContainer.StopArgsForCall(0)).To(BeTrue())
+
+										Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+										Expect(taskDelegate.AbortProgressedCallCount()).To(Equal(2))
+
+										phase, _ := taskDelegate.AbortProgressedArgsForCall(1)
+										Expect(phase).To(Equal(AbortPhaseKilled))
+									})
+								})
+							})
+
+							Context("when the step's abort grace period cannot be parsed", func() {
+								BeforeEach(func() {
+									abortGracePeriod = "a while"
+								})
+
+								It("returns an error without running the task", func() {
+									Eventually(process.Wait()).Should(Receive(HaveOccurred()))
+									Expect(fakeContainer.RunCallCount()).To(BeZero())
+								})
+							})
+
 							Context("when container.stop returns an error", func() {
 								var disaster error
 
@@ -1051,7 +1749,7 @@ var _ = Describe("GardenFactory", func() {
 							})
 
 							It("creates the container with the image artifact source", func() {
-								_, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+								_, _, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
 								Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 									ImageArtifactSource: imageArtifactSource,
 									ImageArtifactName:   worker.ArtifactName(imageArtifactName),
@@ -1086,7 +1784,7 @@ var _ = Describe("GardenFactory", func() {
 										})
 
 										It("still creates the container with the volume and a metadata stream", func() {
-											_, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+											_, _, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
 											Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 												ImageArtifactSource: imageArtifactSource,
 												ImageArtifactName:   worker.ArtifactName(imageArtifactName),
@@ -1113,7 +1811,7 @@ var _ = Describe("GardenFactory", func() {
 										})
 
 										It("still creates the container with the volume and a metadata stream", func() {
-											_, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+											_, _, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
 											Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 												ImageArtifactSource: imageArtifactSource,
 												ImageArtifactName:   worker.ArtifactName(imageArtifactName),
@@ -1141,7 +1839,7 @@ var _ = Describe("GardenFactory", func() {
 										})
 
 										It("still creates the container with the volume and a metadata stream", func() {
-											_, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+											_, _, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
 											Expect(spec.ImageSpec).To(Equal(worker.ImageSpec{
 												ImageArtifactSource: imageArtifactSource,
 												ImageArtifactName:   worker.ArtifactName(imageArtifactName),
@@ -1178,7 +1876,7 @@ var _ = Describe("GardenFactory", func() {
 						})
 
 						It("adds the user to the container spec", func() {
-							_, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
+							_, _, _, _, spec, _, _, _, _ := fakeResourceFactory.NewBuildResourceArgsForCall(0)
 							Expect(spec.User).To(Equal("some-user"))
 						})
 
@@ -1506,6 +2204,7 @@ var _ = Describe("GardenFactory", func() {
 
 			BeforeEach(func() {
 				fakeContainer = new(workerfakes.FakeContainer)
+				fakeContainer.StreamOutReturns(nil, errors.New("no such file"))
 				fakeWorkerClient.FindContainerForIdentifierReturns(fakeContainer, true, nil)
 			})
 			Context("when the configuration specifies paths for outputs", func() {
@@ -1651,10 +2350,19 @@ var _ = Describe("GardenFactory", func() {
 				})
 
 				Context("when the process id cannot be found", func() {
-					disaster := errors.New("nope")
+					var (
+						disaster        error
+						containerWorker *workerfakes.FakeWorker
+					)
 
 					BeforeEach(func() {
+						disaster = errors.New("nope")
 						fakeContainer.PropertyReturns("", disaster)
+						fakeContainer.WorkerNameReturns("some-worker")
+						fakeContainer.HandleReturns("some-handle")
+
+						containerWorker = new(workerfakes.FakeWorker)
+						fakeWorkerClient.GetWorkerReturns(containerWorker, nil)
 					})
 
 					It("exits with the error", func() {
@@ -1666,6 +2374,73 @@ var _ = Describe("GardenFactory", func() {
 						Eventually(taskDelegate.FailedCallCount()).Should(Equal(1))
 						Expect(taskDelegate.FailedArgsForCall(0)).To(Equal(disaster))
 					})
+
+					It("looks up the container's worker", func() {
+						Eventually(process.Wait()).Should(Receive())
+						Expect(fakeWorkerClient.GetWorkerCallCount()).To(Equal(1))
+						Expect(fakeWorkerClient.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+					})
+
+					It("does not destroy the container", func() {
+						Eventually(process.Wait()).Should(Receive())
+						Expect(fakeContainer.DestroyCallCount()).To(BeZero())
+					})
+
+					Context("when the container's worker is draining", func() {
+						var (
+							newContainer *workerfakes.FakeContainer
+							newProcess   *gardenfakes.FakeProcess
+						)
+
+						BeforeEach(func() {
+							containerWorker.IsDrainingReturns(true)
+
+							newContainer = new(workerfakes.FakeContainer)
+							newProcess = new(gardenfakes.FakeProcess)
+							newProcess.IDReturns("new-process-id")
+							newContainer.RunReturns(newProcess, nil)
+							newContainer.StreamOutReturns(nil, errors.New("no such file"))
+
+							fakeResource := new(resourcefakes.FakeResource)
+							fakeResource.ContainerReturns(newContainer)
+							fakeResourceFactory.NewBuildResourceReturns(fakeResource, []resource.InputSource{}, nil)
+						})
+
+						It("destroys the container that never ran", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+							Expect(fakeContainer.DestroyCallCount()).To(Equal(1))
+						})
+
+						It("places the task again and runs it", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+							Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(Equal(1))
+							Expect(newContainer.RunCallCount()).To(Equal(1))
+							Expect(taskDelegate.StartedCallCount()).To(Equal(1))
+						})
+
+						Context("when destroying the container fails", func() {
+							BeforeEach(func() {
+								fakeContainer.DestroyReturns(disaster)
+							})
+
+							It("exits with the error", func() {
+								Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+								Expect(fakeResourceFactory.NewBuildResourceCallCount()).To(BeZero())
+							})
+						})
+					})
+
+					Context("when looking up the container's worker fails", func() {
+						workerErr := errors.New("worker lookup failed")
+
+						BeforeEach(func() {
+							fakeWorkerClient.GetWorkerReturns(nil, workerErr)
+						})
+
+						It("exits with the error", func() {
+							Eventually(process.Wait()).Should(Receive(Equal(workerErr)))
+						})
+					})
 				})
 			})
 		})
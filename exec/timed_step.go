@@ -0,0 +1,61 @@
+package exec
+
+import (
+	"os"
+
+	"github.com/concourse/atc/worker"
+)
+
+//go:generate counterfeiter . TimingDelegate
+
+// TimingDelegate is used to record when a step starts and finishes running.
+// StepStarted is called again when the step's build is resumed.
+type TimingDelegate interface {
+	StepStarted()
+	StepFinished(Success, error)
+}
+
+// TimedStep wraps another step, reporting to its delegate when the nested
+// step starts and finishes.
+type TimedStep struct {
+	step     StepFactory
+	delegate TimingDelegate
+
+	runStep Step
+}
+
+// Timed constructs a TimedStep factory.
+func Timed(step StepFactory, delegate TimingDelegate) TimedStep {
+	return TimedStep{
+		step:     step,
+		delegate: delegate,
+	}
+}
+
+// Using constructs a *TimedStep.
+func (ts TimedStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
+	ts.runStep = ts.step.Using(prev, repo)
+	return &ts
+}
+
+// Run runs the nested step, reporting its start beforehand and its outcome
+// afterwards. The nested step's error is returned as-is.
+func (ts *TimedStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	ts.delegate.StepStarted()
+
+	err := ts.runStep.Run(signals, ready)
+
+	var succeeded Success
+	if err == nil {
+		ts.runStep.Result(&succeeded)
+	}
+
+	ts.delegate.StepFinished(succeeded, err)
+
+	return err
+}
+
+// Result delegates to the nested step.
+func (ts *TimedStep) Result(x interface{}) bool {
+	return ts.runStep.Result(x)
+}
@@ -0,0 +1,126 @@
+package exec_test
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/concourse/atc/exec"
+
+	"github.com/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Timed Step", func() {
+	var (
+		fakeStepFactoryStep *execfakes.FakeStepFactory
+		fakeTimingDelegate  *execfakes.FakeTimingDelegate
+
+		runStep *execfakes.FakeStep
+
+		timed StepFactory
+		step  Step
+	)
+
+	BeforeEach(func() {
+		fakeStepFactoryStep = new(execfakes.FakeStepFactory)
+		fakeTimingDelegate = new(execfakes.FakeTimingDelegate)
+
+		runStep = new(execfakes.FakeStep)
+		fakeStepFactoryStep.UsingReturns(runStep)
+
+		timed = Timed(fakeStepFactoryStep, fakeTimingDelegate)
+		step = timed.Using(nil, nil)
+	})
+
+	Describe("Run", func() {
+		var runErr error
+
+		JustBeforeEach(func() {
+			runErr = step.Run(nil, nil)
+		})
+
+		Context("while the inner step is running", func() {
+			var startedCalls, finishedCalls int
+
+			BeforeEach(func() {
+				runStep.RunStub = func(<-chan os.Signal, chan<- struct{}) error {
+					startedCalls = fakeTimingDelegate.StepStartedCallCount()
+					finishedCalls = fakeTimingDelegate.StepFinishedCallCount()
+					return nil
+				}
+			})
+
+			It("has reported that the step started, but not that it finished", func() {
+				Expect(startedCalls).To(Equal(1))
+				Expect(finishedCalls).To(BeZero())
+			})
+		})
+
+		Context("when the inner step succeeds", func() {
+			BeforeEach(func() {
+				runStep.ResultStub = successResult(true)
+			})
+
+			It("reports that the step succeeded", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(fakeTimingDelegate.StepFinishedCallCount()).To(Equal(1))
+
+				succeeded, err := fakeTimingDelegate.StepFinishedArgsForCall(0)
+				Expect(succeeded).To(Equal(Success(true)))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the inner step fails", func() {
+			BeforeEach(func() {
+				runStep.ResultStub = successResult(false)
+			})
+
+			It("reports that the step failed", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+				Expect(fakeTimingDelegate.StepFinishedCallCount()).To(Equal(1))
+
+				succeeded, err := fakeTimingDelegate.StepFinishedArgsForCall(0)
+				Expect(succeeded).To(Equal(Success(false)))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the inner step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				runStep.RunReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(runErr).To(Equal(disaster))
+			})
+
+			It("reports the error", func() {
+				Expect(fakeTimingDelegate.StepFinishedCallCount()).To(Equal(1))
+
+				succeeded, err := fakeTimingDelegate.StepFinishedArgsForCall(0)
+				Expect(succeeded).To(Equal(Success(false)))
+				Expect(err).To(Equal(disaster))
+			})
+
+			It("does not ask the inner step for its result", func() {
+				Expect(runStep.ResultCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("Result", func() {
+		BeforeEach(func() {
+			runStep.ResultStub = successResult(true)
+		})
+
+		It("delegates to the inner step", func() {
+			var succeeded Success
+			Expect(step.Result(&succeeded)).To(BeTrue())
+			Expect(succeeded).To(Equal(Success(true)))
+		})
+	})
+})
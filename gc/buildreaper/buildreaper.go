@@ -1,7 +1,11 @@
 package buildreaper
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactstore"
 	"github.com/concourse/atc/db"
 )
 
@@ -10,6 +14,8 @@ import (
 type BuildReaperDB interface {
 	GetAllPipelines() ([]db.SavedPipeline, error)
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
+	DeleteClusterEventsOlderThan(retention time.Duration) error
+	FindJobConfigsForBuilds(buildIDs []int) (map[int]atc.JobConfig, error)
 }
 
 type BuildReaper interface {
@@ -20,24 +26,43 @@ type buildReaper struct {
 	logger            lager.Logger
 	db                BuildReaperDB
 	pipelineDBFactory db.PipelineDBFactory
+	artifactStore     artifactstore.Store
 	batchSize         int
+
+	artifactsToRetain     int
+	clusterEventRetention time.Duration
 }
 
 func NewBuildReaper(
 	logger lager.Logger,
 	db BuildReaperDB,
 	pipelineDBFactory db.PipelineDBFactory,
+	artifactStore artifactstore.Store,
 	batchSize int,
+	artifactsToRetain int,
+	clusterEventRetention time.Duration,
 ) BuildReaper {
 	return &buildReaper{
 		logger:            logger,
 		db:                db,
 		pipelineDBFactory: pipelineDBFactory,
+		artifactStore:     artifactStore,
 		batchSize:         batchSize,
+
+		artifactsToRetain:     artifactsToRetain,
+		clusterEventRetention: clusterEventRetention,
 	}
 }
 
 func (br *buildReaper) Run() error {
+	if br.clusterEventRetention != 0 {
+		err := br.db.DeleteClusterEventsOlderThan(br.clusterEventRetention)
+		if err != nil {
+			br.logger.Error("could-not-delete-cluster-events", err)
+			return err
+		}
+	}
+
 	pipelines, err := br.db.GetAllPipelines()
 	if err != nil {
 		br.logger.Error("could-not-get-active-pipelines", err)
@@ -59,6 +84,11 @@ func (br *buildReaper) Run() error {
 
 		for _, job := range jobs {
 			if job.Config.BuildLogsToRetain == 0 {
+				err := br.reapArtifacts(pipelineDB, job)
+				if err != nil {
+					return err
+				}
+
 				continue
 			}
 
@@ -143,6 +173,13 @@ func (br *buildReaper) Run() error {
 				return err
 			}
 
+			// artifacts are best-effort; failing to remove them should not keep
+			// the build logs from being reaped
+			err = br.artifactStore.DeleteForBuilds(buildIDsToDelete)
+			if err != nil {
+				br.logger.Error("could-not-delete-build-artifacts", err)
+			}
+
 			err = pipelineDB.UpdateFirstLoggedBuildID(job.Job.Name, buildIDsToDelete[len(buildIDsToDelete)-1]+1)
 			if err != nil {
 				br.logger.Error("could-not-update-first-logged-build-id", err)
@@ -151,5 +188,90 @@ func (br *buildReaper) Run() error {
 		}
 	}
 
+	return br.reapOrphanedArtifacts()
+}
+
+// reapArtifacts removes the artifacts of the builds of a job that keeps all of
+// its build logs once they are older than its artifactsToRetain most recent
+// builds, as otherwise nothing would ever remove them.
+func (br *buildReaper) reapArtifacts(pipelineDB db.PipelineDB, job db.SavedJob) error {
+	if len(job.Config.Artifacts) == 0 || br.artifactsToRetain <= 0 {
+		return nil
+	}
+
+	buildsToRetain, _, err := pipelineDB.GetJobBuilds(
+		job.Job.Name,
+		db.Page{Limit: br.artifactsToRetain},
+	)
+	if err != nil {
+		br.logger.Error("could-not-get-job-builds-to-retain-artifacts-of", err)
+		return err
+	}
+
+	if len(buildsToRetain) < br.artifactsToRetain {
+		return nil
+	}
+
+	buildsToReap, _, err := pipelineDB.GetJobBuilds(
+		job.Job.Name,
+		db.Page{Since: buildsToRetain[len(buildsToRetain)-1].ID(), Limit: br.batchSize},
+	)
+	if err != nil {
+		br.logger.Error("could-not-get-job-builds-to-delete-artifacts-of", err)
+		return err
+	}
+
+	buildIDsToReap := []int{}
+	for _, build := range buildsToReap {
+		buildIDsToReap = append(buildIDsToReap, build.ID())
+	}
+
+	err = br.artifactStore.DeleteForBuilds(buildIDsToReap)
+	if err != nil {
+		br.logger.Error("could-not-delete-build-artifacts", err)
+	}
+
+	return nil
+}
+
+// reapOrphanedArtifacts removes the artifacts of builds whose job no longer
+// saves artifacts, as the job or its pipeline has been removed or the job has
+// been reconfigured, which reaping by job would never come across.
+func (br *buildReaper) reapOrphanedArtifacts() error {
+	buildIDs, err := br.artifactStore.BuildIDs()
+	if err != nil {
+		br.logger.Error("could-not-get-builds-with-artifacts", err)
+		return nil
+	}
+
+	if len(buildIDs) == 0 {
+		return nil
+	}
+
+	jobConfigs, err := br.db.FindJobConfigsForBuilds(buildIDs)
+	if err != nil {
+		br.logger.Error("could-not-get-jobs-of-builds-with-artifacts", err)
+		return err
+	}
+
+	buildIDsToReap := []int{}
+	for _, buildID := range buildIDs {
+		jobConfig, found := jobConfigs[buildID]
+		if found && len(jobConfig.Artifacts) > 0 {
+			continue
+		}
+
+		buildIDsToReap = append(buildIDsToReap, buildID)
+	}
+
+	if len(buildIDsToReap) == 0 {
+		return nil
+	}
+
+	err = br.artifactStore.DeleteForBuilds(buildIDsToReap)
+	if err != nil {
+		br.logger.Error("could-not-delete-orphaned-build-artifacts", err)
+	}
+
 	return nil
 }
@@ -3,9 +3,11 @@ package buildreaper_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/artifactstore/artifactstorefakes"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
 	. "github.com/concourse/atc/gc/buildreaper"
@@ -20,13 +22,19 @@ var _ = Describe("BuildReaper", func() {
 		buildReaper           BuildReaper
 		fakeBuildReaperDB     *buildreaperfakes.FakeBuildReaperDB
 		fakePipelineDBFactory *dbfakes.FakePipelineDBFactory
+		fakeArtifactStore     *artifactstorefakes.FakeStore
 		batchSize             int
+		artifactsToRetain     int
+		clusterEventRetention time.Duration
 	)
 
 	BeforeEach(func() {
 		fakeBuildReaperDB = new(buildreaperfakes.FakeBuildReaperDB)
 		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
+		fakeArtifactStore = new(artifactstorefakes.FakeStore)
 		batchSize = 5
+		artifactsToRetain = 2
+		clusterEventRetention = 0
 	})
 
 	JustBeforeEach(func() {
@@ -35,10 +43,49 @@ var _ = Describe("BuildReaper", func() {
 			buildReaperLogger,
 			fakeBuildReaperDB,
 			fakePipelineDBFactory,
+			fakeArtifactStore,
 			batchSize,
+			artifactsToRetain,
+			clusterEventRetention,
 		)
 	})
 
+	Context("when cluster events are kept forever", func() {
+		It("does not delete any", func() {
+			err := buildReaper.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBuildReaperDB.DeleteClusterEventsOlderThanCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when cluster events have a retention period", func() {
+		BeforeEach(func() {
+			clusterEventRetention = time.Hour
+		})
+
+		It("deletes the events older than it", func() {
+			err := buildReaper.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBuildReaperDB.DeleteClusterEventsOlderThanCallCount()).To(Equal(1))
+			Expect(fakeBuildReaperDB.DeleteClusterEventsOlderThanArgsForCall(0)).To(Equal(time.Hour))
+		})
+
+		Context("when deleting them fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuildReaperDB.DeleteClusterEventsOlderThanReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				err := buildReaper.Run()
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+
 	Context("when there is a pipeline", func() {
 		var fakePipelineDB *dbfakes.FakePipelineDB
 
@@ -113,6 +160,27 @@ var _ = Describe("BuildReaper", func() {
 						Expect(actualBuildIDs).To(ConsistOf(6, 7, 8, 9, 10))
 					})
 
+					It("deletes the artifacts of the reaped builds", func() {
+						err := buildReaper.Run()
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeArtifactStore.DeleteForBuildsCallCount()).To(Equal(1))
+						Expect(fakeArtifactStore.DeleteForBuildsArgsForCall(0)).To(ConsistOf(6, 7, 8, 9, 10))
+					})
+
+					Context("when deleting the artifacts fails", func() {
+						BeforeEach(func() {
+							fakeArtifactStore.DeleteForBuildsReturns(errors.New("nope"))
+						})
+
+						It("still updates FirstLoggedBuildID", func() {
+							err := buildReaper.Run()
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(Equal(1))
+						})
+					})
+
 					It("updates FirstLoggedBuildID to n+1, n = latest reaped build ID", func() {
 						err := buildReaper.Run()
 						Expect(err).NotTo(HaveOccurred())
@@ -509,6 +577,59 @@ var _ = Describe("BuildReaper", func() {
 				Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
 			})
 		})
+
+		Context("when a job that retains all of its build logs saves artifacts", func() {
+			BeforeEach(func() {
+				fakePipelineDB.GetJobsReturns([]db.SavedJob{
+					{
+						Job: db.Job{Name: "job-1"},
+						Config: atc.JobConfig{
+							Artifacts: []string{"some-output"},
+						},
+					},
+				}, nil)
+			})
+
+			Context("when it has more builds than it retains the artifacts of", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetJobBuildsStub = func(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+						if job == "job-1" && page == (db.Page{Limit: 2}) {
+							return []db.Build{sb(12), sb(11)}, db.Pagination{}, nil
+						} else if job == "job-1" && page == (db.Page{Since: 11, Limit: 5}) {
+							return []db.Build{sb(10), sb(9)}, db.Pagination{}, nil
+						} else {
+							Fail(fmt.Sprintf("GetJobBuilds called with unexpected arguments: job=%s, page=%#v", job, page))
+						}
+						return nil, db.Pagination{}, nil
+					}
+				})
+
+				It("deletes the artifacts of the older builds, but not their logs", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeArtifactStore.DeleteForBuildsCallCount()).To(Equal(1))
+					Expect(fakeArtifactStore.DeleteForBuildsArgsForCall(0)).To(Equal([]int{10, 9}))
+
+					Expect(fakeBuildReaperDB.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+					Expect(fakePipelineDB.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when it has no more builds than it retains the artifacts of", func() {
+				BeforeEach(func() {
+					fakePipelineDB.GetJobBuildsReturns([]db.Build{sb(1)}, db.Pagination{}, nil)
+				})
+
+				It("deletes nothing", func() {
+					err := buildReaper.Run()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakePipelineDB.GetJobBuildsCallCount()).To(Equal(1))
+					Expect(fakeArtifactStore.DeleteForBuildsCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Context("when there is a paused pipeline", func() {
@@ -527,6 +648,52 @@ var _ = Describe("BuildReaper", func() {
 		})
 	})
 
+	Context("when there are artifacts of builds whose jobs no longer save them", func() {
+		BeforeEach(func() {
+			fakeArtifactStore.BuildIDsReturns([]int{1, 2, 3, 4}, nil)
+
+			fakeBuildReaperDB.FindJobConfigsForBuildsReturns(map[int]atc.JobConfig{
+				2: {Name: "some-job", Artifacts: []string{"some-output"}},
+				3: {Name: "some-reconfigured-job"},
+			}, nil)
+		})
+
+		It("deletes the artifacts of builds of removed or reconfigured jobs", func() {
+			err := buildReaper.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBuildReaperDB.FindJobConfigsForBuildsCallCount()).To(Equal(1))
+			Expect(fakeBuildReaperDB.FindJobConfigsForBuildsArgsForCall(0)).To(Equal([]int{1, 2, 3, 4}))
+
+			Expect(fakeArtifactStore.DeleteForBuildsCallCount()).To(Equal(1))
+			Expect(fakeArtifactStore.DeleteForBuildsArgsForCall(0)).To(Equal([]int{1, 3, 4}))
+		})
+
+		Context("when finding the builds' jobs fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuildReaperDB.FindJobConfigsForBuildsReturns(nil, disaster)
+			})
+
+			It("returns the error without deleting anything", func() {
+				err := buildReaper.Run()
+				Expect(err).To(Equal(disaster))
+
+				Expect(fakeArtifactStore.DeleteForBuildsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when there are no artifacts", func() {
+		It("does not look for their jobs", func() {
+			err := buildReaper.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeBuildReaperDB.FindJobConfigsForBuildsCallCount()).To(BeZero())
+		})
+	})
+
 	Context("when getting the pipelines fails", func() {
 		var disaster error
 
@@ -3,7 +3,9 @@ package buildreaperfakes
 
 import (
 	"sync"
+	"time"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/gc/buildreaper"
 )
@@ -24,6 +26,23 @@ type FakeBuildReaperDB struct {
 	deleteBuildEventsByBuildIDsReturns struct {
 		result1 error
 	}
+	DeleteClusterEventsOlderThanStub        func(retention time.Duration) error
+	deleteClusterEventsOlderThanMutex       sync.RWMutex
+	deleteClusterEventsOlderThanArgsForCall []struct {
+		retention time.Duration
+	}
+	deleteClusterEventsOlderThanReturns struct {
+		result1 error
+	}
+	FindJobConfigsForBuildsStub        func(buildIDs []int) (map[int]atc.JobConfig, error)
+	findJobConfigsForBuildsMutex       sync.RWMutex
+	findJobConfigsForBuildsArgsForCall []struct {
+		buildIDs []int
+	}
+	findJobConfigsForBuildsReturns struct {
+		result1 map[int]atc.JobConfig
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -92,6 +111,78 @@ func (fake *FakeBuildReaperDB) DeleteBuildEventsByBuildIDsReturns(result1 error)
 	}{result1}
 }
 
+func (fake *FakeBuildReaperDB) DeleteClusterEventsOlderThan(retention time.Duration) error {
+	fake.deleteClusterEventsOlderThanMutex.Lock()
+	fake.deleteClusterEventsOlderThanArgsForCall = append(fake.deleteClusterEventsOlderThanArgsForCall, struct {
+		retention time.Duration
+	}{retention})
+	fake.recordInvocation("DeleteClusterEventsOlderThan", []interface{}{retention})
+	fake.deleteClusterEventsOlderThanMutex.Unlock()
+	if fake.DeleteClusterEventsOlderThanStub != nil {
+		return fake.DeleteClusterEventsOlderThanStub(retention)
+	} else {
+		return fake.deleteClusterEventsOlderThanReturns.result1
+	}
+}
+
+func (fake *FakeBuildReaperDB) DeleteClusterEventsOlderThanCallCount() int {
+	fake.deleteClusterEventsOlderThanMutex.RLock()
+	defer fake.deleteClusterEventsOlderThanMutex.RUnlock()
+	return len(fake.deleteClusterEventsOlderThanArgsForCall)
+}
+
+func (fake *FakeBuildReaperDB) DeleteClusterEventsOlderThanArgsForCall(i int) time.Duration {
+	fake.deleteClusterEventsOlderThanMutex.RLock()
+	defer fake.deleteClusterEventsOlderThanMutex.RUnlock()
+	return fake.deleteClusterEventsOlderThanArgsForCall[i].retention
+}
+
+func (fake *FakeBuildReaperDB) DeleteClusterEventsOlderThanReturns(result1 error) {
+	fake.DeleteClusterEventsOlderThanStub = nil
+	fake.deleteClusterEventsOlderThanReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildReaperDB) FindJobConfigsForBuilds(buildIDs []int) (map[int]atc.JobConfig, error) {
+	var buildIDsCopy []int
+	if buildIDs != nil {
+		buildIDsCopy = make([]int, len(buildIDs))
+		copy(buildIDsCopy, buildIDs)
+	}
+	fake.findJobConfigsForBuildsMutex.Lock()
+	fake.findJobConfigsForBuildsArgsForCall = append(fake.findJobConfigsForBuildsArgsForCall, struct {
+		buildIDs []int
+	}{buildIDsCopy})
+	fake.recordInvocation("FindJobConfigsForBuilds", []interface{}{buildIDsCopy})
+	fake.findJobConfigsForBuildsMutex.Unlock()
+	if fake.FindJobConfigsForBuildsStub != nil {
+		return fake.FindJobConfigsForBuildsStub(buildIDs)
+	} else {
+		return fake.findJobConfigsForBuildsReturns.result1, fake.findJobConfigsForBuildsReturns.result2
+	}
+}
+
+func (fake *FakeBuildReaperDB) FindJobConfigsForBuildsCallCount() int {
+	fake.findJobConfigsForBuildsMutex.RLock()
+	defer fake.findJobConfigsForBuildsMutex.RUnlock()
+	return len(fake.findJobConfigsForBuildsArgsForCall)
+}
+
+func (fake *FakeBuildReaperDB) FindJobConfigsForBuildsArgsForCall(i int) []int {
+	fake.findJobConfigsForBuildsMutex.RLock()
+	defer fake.findJobConfigsForBuildsMutex.RUnlock()
+	return fake.findJobConfigsForBuildsArgsForCall[i].buildIDs
+}
+
+func (fake *FakeBuildReaperDB) FindJobConfigsForBuildsReturns(result1 map[int]atc.JobConfig, result2 error) {
+	fake.FindJobConfigsForBuildsStub = nil
+	fake.findJobConfigsForBuildsReturns = struct {
+		result1 map[int]atc.JobConfig
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildReaperDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -99,6 +190,10 @@ func (fake *FakeBuildReaperDB) Invocations() map[string][][]interface{} {
 	defer fake.getAllPipelinesMutex.RUnlock()
 	fake.deleteBuildEventsByBuildIDsMutex.RLock()
 	defer fake.deleteBuildEventsByBuildIDsMutex.RUnlock()
+	fake.deleteClusterEventsOlderThanMutex.RLock()
+	defer fake.deleteClusterEventsOlderThanMutex.RUnlock()
+	fake.findJobConfigsForBuildsMutex.RLock()
+	defer fake.findJobConfigsForBuildsMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -98,8 +98,8 @@ var _ = BeforeEach(func() {
 
 	logger = lagertest.NewTestLogger("gcng-test")
 
-	resourceCacheFactory = dbng.NewResourceCacheFactory(dbConn, lockFactory)
-	resourceConfigFactory = dbng.NewResourceConfigFactory(dbConn, lockFactory)
+	resourceCacheFactory = dbng.NewResourceCacheFactory(dbConn, lockFactory, nil)
+	resourceConfigFactory = dbng.NewResourceConfigFactory(dbConn, lockFactory, nil)
 })
 
 var _ = AfterSuite(func() {
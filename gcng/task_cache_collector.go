@@ -0,0 +1,37 @@
+package gcng
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+)
+
+type taskCacheCollector struct {
+	logger           lager.Logger
+	taskCacheFactory dbng.TaskCacheFactory
+}
+
+func NewTaskCacheCollector(
+	logger lager.Logger,
+	taskCacheFactory dbng.TaskCacheFactory,
+) Collector {
+	return &taskCacheCollector{
+		logger:           logger.Session("task-cache-collector"),
+		taskCacheFactory: taskCacheFactory,
+	}
+}
+
+func (tcc *taskCacheCollector) Run() error {
+	err := tcc.taskCacheFactory.CleanUsesForFinishedBuilds()
+	if err != nil {
+		tcc.logger.Error("unable-to-clean-up-for-builds", err)
+		return err
+	}
+
+	err = tcc.taskCacheFactory.CleanUpInvalidCaches()
+	if err != nil {
+		tcc.logger.Error("unable-to-clean-up-caches", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package gcng_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/gcng"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskCacheCollector", func() {
+	var (
+		collector gcng.Collector
+
+		fakeTaskCacheFactory *dbngfakes.FakeTaskCacheFactory
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("task-cache-collector")
+		fakeTaskCacheFactory = new(dbngfakes.FakeTaskCacheFactory)
+
+		collector = gcng.NewTaskCacheCollector(logger, fakeTaskCacheFactory)
+	})
+
+	Describe("Run", func() {
+		It("cleans up uses by finished builds and then unused caches", func() {
+			err := collector.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTaskCacheFactory.CleanUsesForFinishedBuildsCallCount()).To(Equal(1))
+			Expect(fakeTaskCacheFactory.CleanUpInvalidCachesCallCount()).To(Equal(1))
+		})
+
+		Context("when cleaning up uses fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeTaskCacheFactory.CleanUsesForFinishedBuildsReturns(disaster)
+			})
+
+			It("returns the error without cleaning up caches", func() {
+				err := collector.Run()
+				Expect(err).To(Equal(disaster))
+
+				Expect(fakeTaskCacheFactory.CleanUpInvalidCachesCallCount()).To(BeZero())
+			})
+		})
+	})
+})
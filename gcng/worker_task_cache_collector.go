@@ -0,0 +1,31 @@
+package gcng
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+)
+
+type workerTaskCacheCollector struct {
+	logger                 lager.Logger
+	workerTaskCacheFactory dbng.WorkerTaskCacheFactory
+}
+
+func NewWorkerTaskCacheCollector(
+	logger lager.Logger,
+	workerTaskCacheFactory dbng.WorkerTaskCacheFactory,
+) Collector {
+	return &workerTaskCacheCollector{
+		logger:                 logger.Session("worker-task-cache-collector"),
+		workerTaskCacheFactory: workerTaskCacheFactory,
+	}
+}
+
+func (wtcc *workerTaskCacheCollector) Run() error {
+	err := wtcc.workerTaskCacheFactory.CleanUpForInactiveJobs()
+	if err != nil {
+		wtcc.logger.Error("unable-to-clean-up-for-inactive-jobs", err)
+		return err
+	}
+
+	return nil
+}
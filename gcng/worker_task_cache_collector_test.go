@@ -0,0 +1,49 @@
+package gcng_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/gcng"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerTaskCacheCollector", func() {
+	var (
+		collector gcng.Collector
+
+		fakeWorkerTaskCacheFactory *dbngfakes.FakeWorkerTaskCacheFactory
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("worker-task-cache-collector")
+		fakeWorkerTaskCacheFactory = new(dbngfakes.FakeWorkerTaskCacheFactory)
+
+		collector = gcng.NewWorkerTaskCacheCollector(logger, fakeWorkerTaskCacheFactory)
+	})
+
+	Describe("Run", func() {
+		It("cleans up caches for jobs no longer in their pipeline", func() {
+			err := collector.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeWorkerTaskCacheFactory.CleanUpForInactiveJobsCallCount()).To(Equal(1))
+		})
+
+		Context("when cleaning up fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeWorkerTaskCacheFactory.CleanUpForInactiveJobsReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				err := collector.Run()
+				Expect(err).To(Equal(disaster))
+			})
+		})
+	})
+})
@@ -27,6 +27,27 @@ type JobOutput struct {
 	Resource string `json:"resource"`
 }
 
+type JobExplanation struct {
+	InputsSatisfied  bool               `json:"inputs_satisfied"`
+	Inputs           []InputExplanation `json:"inputs"`
+	NextPendingBuild *Build             `json:"next_pending_build,omitempty"`
+	Blocker          string             `json:"blocker,omitempty"`
+}
+
+type InputExplanation struct {
+	Name           string                 `json:"name"`
+	Resource       string                 `json:"resource"`
+	ResourcePaused bool                   `json:"resource_paused,omitempty"`
+	VersionID      int                    `json:"version_id,omitempty"`
+	Candidates     []CandidateExplanation `json:"candidates"`
+}
+
+type CandidateExplanation struct {
+	VersionID    int    `json:"version_id"`
+	EliminatedBy string `json:"eliminated_by,omitempty"`
+	Job          string `json:"job,omitempty"`
+}
+
 type BuildInput struct {
 	Name     string   `json:"name"`
 	Resource string   `json:"resource"`
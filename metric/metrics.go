@@ -135,6 +135,57 @@ func (event WorkerContainers) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerHealthProbe struct {
+	WorkerName      string
+	Duration        time.Duration
+	UnhealthyReason string
+}
+
+func (event WorkerHealthProbe) Emit(logger lager.Logger) {
+	state := "ok"
+	if event.UnhealthyReason != "" {
+		state = "critical"
+	}
+
+	emit(
+		logger.Session("worker-health-probe", lager.Data{
+			"worker":   event.WorkerName,
+			"duration": event.Duration.String(),
+			"reason":   event.UnhealthyReason,
+		}),
+		goryman.Event{
+			Service: "worker health probe duration (ms)",
+			Metric:  ms(event.Duration),
+			State:   state,
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+			},
+		},
+	)
+}
+
+type UnhealthyWorkers struct {
+	Count int
+}
+
+func (event UnhealthyWorkers) Emit(logger lager.Logger) {
+	state := "ok"
+	if event.Count > 0 {
+		state = "warning"
+	}
+
+	emit(
+		logger.Session("unhealthy-workers", lager.Data{
+			"count": event.Count,
+		}),
+		goryman.Event{
+			Service: "unhealthy workers",
+			Metric:  event.Count,
+			State:   state,
+		},
+	)
+}
+
 type BuildStarted struct {
 	PipelineName string
 	JobName      string
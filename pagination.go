@@ -12,3 +12,11 @@ const (
 	PaginationWebLimit        = 100
 	PaginationAPIDefaultLimit = 100
 )
+
+const (
+	VersionQueryFilter   = "filter"
+	VersionQueryMetadata = "metadata"
+	VersionQueryEnabled  = "enabled"
+	VersionQueryAfter    = "after"
+	VersionQueryBefore   = "before"
+)
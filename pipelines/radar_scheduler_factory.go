@@ -27,23 +27,37 @@ type RadarSchedulerFactory interface {
 type radarSchedulerFactory struct {
 	resourceFactory resource.ResourceFactory
 	interval        time.Duration
+	maxBackoff      time.Duration
 	engine          engine.Engine
+	workerPool      scheduler.WorkerPool
 }
 
 func NewRadarSchedulerFactory(
 	resourceFactory resource.ResourceFactory,
 	interval time.Duration,
+	maxBackoff time.Duration,
 	engine engine.Engine,
+	workerPool scheduler.WorkerPool,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
 		resourceFactory: resourceFactory,
 		interval:        interval,
+		maxBackoff:      maxBackoff,
 		engine:          engine,
+		workerPool:      workerPool,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(pipelineDB db.PipelineDB, dbPipeline dbng.Pipeline, externalURL string) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.resourceFactory, rsf.interval, pipelineDB, dbPipeline, clock.NewClock(), externalURL)
+	return radar.NewScanRunnerFactory(
+		rsf.resourceFactory,
+		rsf.interval,
+		radar.Backoff{Max: rsf.maxBackoff},
+		pipelineDB,
+		dbPipeline,
+		clock.NewClock(),
+		externalURL,
+	)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, dbPipeline dbng.Pipeline, externalURL string) scheduler.BuildScheduler {
@@ -51,6 +65,7 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, dbPip
 		clock.NewClock(),
 		rsf.resourceFactory,
 		rsf.interval,
+		radar.Backoff{Max: rsf.maxBackoff},
 		pipelineDB,
 		dbPipeline,
 		externalURL,
@@ -72,6 +87,7 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB, dbPip
 			scanner,
 			inputMapper,
 			rsf.engine,
+			rsf.workerPool,
 		),
 		Scanner: scanner,
 	}
@@ -110,6 +110,11 @@ func (syncer *Syncer) Sync() {
 
 func (syncer *Syncer) removePipeline(pipelineID int) {
 	delete(syncer.runningPipelines, pipelineID)
+
+	err := syncer.pipelineDBFactory.Release(pipelineID)
+	if err != nil {
+		syncer.logger.Error("failed-to-release-pipeline", err, lager.Data{"pipeline-id": pipelineID})
+	}
 }
 
 func (syncer *Syncer) isPipelineRunning(pipelineID int) bool {
@@ -140,6 +140,22 @@ var _ = Describe("Pipelines Syncer", func() {
 			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
 		})
 
+		It("releases the pipeline", func() {
+			syncherDB.GetAllPipelinesReturns([]db.SavedPipeline{
+				{
+					ID: 2,
+					Pipeline: db.Pipeline{
+						Name: "other-pipeline",
+					},
+				},
+			}, nil)
+
+			syncer.Sync()
+
+			Expect(pipelineDBFactory.ReleaseCallCount()).To(Equal(1))
+			Expect(pipelineDBFactory.ReleaseArgsForCall(0)).To(Equal(1))
+		})
+
 		Context("when another is configured with the same name", func() {
 			It("stops the process", func() {
 				Eventually(fakeRunner.RunCallCount).Should(Equal(1))
@@ -16,6 +16,12 @@ type Plan struct {
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
 	Timeout      *TimeoutPlan      `json:"timeout,omitempty"`
 	Retry        *RetryPlan        `json:"retry,omitempty"`
+
+	SaveArtifacts *SaveArtifactsPlan `json:"save_artifacts,omitempty"`
+
+	// configures the attempts of Retry; absent if every outcome is retried
+	// immediately
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
 }
 
 type PlanID string
@@ -108,14 +114,51 @@ type TaskPlan struct {
 	ConfigPath string      `json:"config_path,omitempty"`
 	Config     *TaskConfig `json:"config,omitempty"`
 
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty"`
+
 	Params            Params            `json:"params,omitempty"`
 	InputMapping      map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 
+	CacheOutputs bool         `json:"cache_outputs,omitempty"`
+	Reports      *TaskReports `json:"reports,omitempty"`
+
+	AbortGracePeriod string `json:"abort_grace_period,omitempty"`
+
 	Pipeline      string        `json:"pipeline"`
 	PipelineID    int           `json:"pipeline_id"`
 	ResourceTypes ResourceTypes `json:"resource_types,omitempty"`
 }
 
 type RetryPlan []Plan
+
+type SaveArtifactsPlan struct {
+	Names []string `json:"names"`
+}
+
+type RetryPolicy struct {
+	Backoff string   `json:"backoff,omitempty"`
+	On      []string `json:"on,omitempty"`
+}
+
+// RetriesOn returns true if an attempt finishing with the given status should
+// be followed by another attempt. Only errored and failed attempts are ever
+// retried; if no statuses are configured, both are.
+func (policy RetryPolicy) RetriesOn(status BuildStatus) bool {
+	if status != StatusErrored && status != StatusFailed {
+		return false
+	}
+
+	if len(policy.On) == 0 {
+		return true
+	}
+
+	for _, on := range policy.On {
+		if on == string(status) {
+			return true
+		}
+	}
+
+	return false
+}
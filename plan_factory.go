@@ -49,6 +49,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Timeout = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case SaveArtifactsPlan:
+		plan.SaveArtifacts = &t
 	default:
 		panic(fmt.Sprintf("don't know how to construct plan from %T", step))
 	}
@@ -244,6 +244,13 @@ var _ = Describe("Plan", func() {
 						},
 					},
 				},
+
+				atc.Plan{
+					ID: "26",
+					SaveArtifacts: &atc.SaveArtifactsPlan{
+						Names: []string{"some-output"},
+					},
+				},
 			},
 		}
 
@@ -417,6 +424,12 @@ var _ = Describe("Plan", func() {
           }
         }
       ]
+    },
+    {
+      "id": "26",
+      "save_artifacts": {
+        "names": ["some-output"]
+      }
     }
   ]
 }
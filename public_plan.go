@@ -18,6 +18,8 @@ func (plan Plan) Public() *json.RawMessage {
 		DependentGet *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout      *json.RawMessage `json:"timeout,omitempty"`
 		Retry        *json.RawMessage `json:"retry,omitempty"`
+
+		SaveArtifacts *json.RawMessage `json:"save_artifacts,omitempty"`
 	}
 
 	public.ID = plan.ID
@@ -70,6 +72,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.SaveArtifacts != nil {
+		public.SaveArtifacts = plan.SaveArtifacts.Public()
+	}
+
 	return enc(public)
 }
 
@@ -199,6 +205,14 @@ func (plan RetryPlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan SaveArtifactsPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Names []string `json:"names"`
+	}{
+		Names: plan.Names,
+	})
+}
+
 func enc(public interface{}) *json.RawMessage {
 	enc, _ := json.Marshal(public)
 	return (*json.RawMessage)(&enc)
@@ -0,0 +1,26 @@
+package radar
+
+import "time"
+
+// A Backoff determines how long to wait before checking again after a number
+// of consecutive failed checks. The wait doubles with each failure, starting
+// from the regular check interval, and never exceeds Max.
+type Backoff struct {
+	Max time.Duration
+}
+
+func (backoff Backoff) Interval(interval time.Duration, failures int) time.Duration {
+	if backoff.Max <= interval {
+		return interval
+	}
+
+	for i := 0; i < failures; i++ {
+		interval *= 2
+
+		if interval >= backoff.Max {
+			return backoff.Max
+		}
+	}
+
+	return interval
+}
@@ -0,0 +1,90 @@
+package radar
+
+import (
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/resource"
+)
+
+// ErrCheckTimedOut is returned when a check script does not exit within the
+// configured check_timeout.
+type ErrCheckTimedOut struct {
+	Timeout time.Duration
+}
+
+func (err ErrCheckTimedOut) Error() string {
+	return fmt.Sprintf("check timed out after %s", err.Timeout)
+}
+
+func isCheckFailure(err error) bool {
+	switch err.(type) {
+	case resource.ErrResourceScriptFailed, ErrCheckTimedOut:
+		return true
+	default:
+		return false
+	}
+}
+
+func checkTimeout(rawTimeout string) (time.Duration, error) {
+	if rawTimeout == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(rawTimeout)
+}
+
+type checkResult struct {
+	versions []atc.Version
+	err      error
+}
+
+func checkWithTimeout(
+	logger lager.Logger,
+	clock clock.Clock,
+	res resource.Resource,
+	timeout time.Duration,
+	source atc.Source,
+	fromVersion atc.Version,
+) ([]atc.Version, error) {
+	if timeout == 0 {
+		return res.Check(source, fromVersion)
+	}
+
+	checked := make(chan checkResult, 1)
+
+	go func() {
+		versions, err := res.Check(source, fromVersion)
+		checked <- checkResult{versions, err}
+	}()
+
+	timer := clock.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-checked:
+		return result.versions, result.err
+
+	case <-timer.C():
+		logger.Info("check-timed-out", lager.Data{"timeout": timeout.String()})
+
+		// kill the check script, or failing that the whole container, and wait
+		// for the goroutine above to see it exit so that it does not leak
+		err := res.Container().Stop(true)
+		if err != nil {
+			logger.Error("failed-to-stop-check", err)
+
+			err = res.Container().Destroy()
+			if err != nil {
+				logger.Error("failed-to-destroy-check-container", err)
+			}
+		}
+
+		<-checked
+
+		return nil, ErrCheckTimedOut{Timeout: timeout}
+	}
+}
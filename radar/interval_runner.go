@@ -28,6 +28,7 @@ func NewIntervalRunner(
 		scanner: scanner,
 	}
 }
+
 func (r *IntervalRunner) RunFunc(signals <-chan os.Signal, ready chan<- struct{}) error {
 	// do an immediate initial check
 	var interval time.Duration = 0
@@ -49,6 +50,12 @@ func (r *IntervalRunner) RunFunc(signals <-chan os.Signal, ready chan<- struct{}
 				if err == ErrFailedToAcquireLock {
 					break
 				}
+
+				// the scanner has backed off already
+				if isCheckFailure(err) {
+					break
+				}
+
 				return err
 			}
 		}
@@ -11,6 +11,7 @@ import (
 
 	. "github.com/concourse/atc/radar"
 	"github.com/concourse/atc/radar/radarfakes"
+	"github.com/concourse/atc/resource"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -113,6 +114,48 @@ var _ = Describe("IntervalRunner", func() {
 			})
 		})
 
+		Context("when scanner.Run() returns a check failure", func() {
+			BeforeEach(func() {
+				fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+					times <- fakeClock.Now()
+					return 4 * interval, resource.ErrResourceScriptFailed{ExitStatus: 1}
+				}
+			})
+
+			AfterEach(func() {
+				signalCh <- os.Interrupt
+				<-errCh
+			})
+
+			It("waits for the interval the scanner backed off to", func() {
+				Expect(<-times).To(Equal(epoch))
+
+				fakeClock.WaitForWatcherAndIncrement(4 * interval)
+				Expect(<-times).To(Equal(epoch.Add(4 * interval)))
+			})
+		})
+
+		Context("when scanner.Run() returns a check timeout", func() {
+			BeforeEach(func() {
+				fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+					times <- fakeClock.Now()
+					return 2 * interval, ErrCheckTimedOut{Timeout: time.Second}
+				}
+			})
+
+			AfterEach(func() {
+				signalCh <- os.Interrupt
+				<-errCh
+			})
+
+			It("waits for the interval the scanner backed off to instead of exiting", func() {
+				Expect(<-times).To(Equal(epoch))
+
+				fakeClock.WaitForWatcherAndIncrement(2 * interval)
+				Expect(<-times).To(Equal(epoch.Add(2 * interval)))
+			})
+		})
+
 		Context("when scanner.Run() returns ErrFailedToAcquireLock error", func() {
 			BeforeEach(func() {
 				fakeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
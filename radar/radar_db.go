@@ -31,5 +31,6 @@ type RadarDB interface {
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
 	SaveResourceTypeVersion(atc.ResourceType, atc.Version) error
 	SetResourceCheckError(resource db.SavedResource, err error) error
+	SetResourceCheckBackoffInterval(resource db.SavedResource, interval time.Duration) error
 	AcquireResourceTypeCheckingLock(logger lager.Logger, resourceType db.SavedResourceType, interval time.Duration, immediate bool) (lock.Lock, bool, error)
 }
@@ -145,6 +145,15 @@ type FakeRadarDB struct {
 		result2 bool
 		result3 error
 	}
+	SetResourceCheckBackoffIntervalStub        func(resource db.SavedResource, interval time.Duration) error
+	setResourceCheckBackoffIntervalMutex       sync.RWMutex
+	setResourceCheckBackoffIntervalArgsForCall []struct {
+		resource db.SavedResource
+		interval time.Duration
+	}
+	setResourceCheckBackoffIntervalReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -650,6 +659,40 @@ func (fake *FakeRadarDB) AcquireResourceTypeCheckingLockReturns(result1 lock.Loc
 	}{result1, result2, result3}
 }
 
+func (fake *FakeRadarDB) SetResourceCheckBackoffInterval(resource db.SavedResource, interval time.Duration) error {
+	fake.setResourceCheckBackoffIntervalMutex.Lock()
+	fake.setResourceCheckBackoffIntervalArgsForCall = append(fake.setResourceCheckBackoffIntervalArgsForCall, struct {
+		resource db.SavedResource
+		interval time.Duration
+	}{resource, interval})
+	fake.recordInvocation("SetResourceCheckBackoffInterval", []interface{}{resource, interval})
+	fake.setResourceCheckBackoffIntervalMutex.Unlock()
+	if fake.SetResourceCheckBackoffIntervalStub != nil {
+		return fake.SetResourceCheckBackoffIntervalStub(resource, interval)
+	} else {
+		return fake.setResourceCheckBackoffIntervalReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) SetResourceCheckBackoffIntervalCallCount() int {
+	fake.setResourceCheckBackoffIntervalMutex.RLock()
+	defer fake.setResourceCheckBackoffIntervalMutex.RUnlock()
+	return len(fake.setResourceCheckBackoffIntervalArgsForCall)
+}
+
+func (fake *FakeRadarDB) SetResourceCheckBackoffIntervalArgsForCall(i int) (db.SavedResource, time.Duration) {
+	fake.setResourceCheckBackoffIntervalMutex.RLock()
+	defer fake.setResourceCheckBackoffIntervalMutex.RUnlock()
+	return fake.setResourceCheckBackoffIntervalArgsForCall[i].resource, fake.setResourceCheckBackoffIntervalArgsForCall[i].interval
+}
+
+func (fake *FakeRadarDB) SetResourceCheckBackoffIntervalReturns(result1 error) {
+	fake.SetResourceCheckBackoffIntervalStub = nil
+	fake.setResourceCheckBackoffIntervalReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRadarDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -685,6 +728,8 @@ func (fake *FakeRadarDB) Invocations() map[string][][]interface{} {
 	defer fake.setResourceCheckErrorMutex.RUnlock()
 	fake.acquireResourceTypeCheckingLockMutex.RLock()
 	defer fake.acquireResourceTypeCheckingLockMutex.RUnlock()
+	fake.setResourceCheckBackoffIntervalMutex.RLock()
+	defer fake.setResourceCheckBackoffIntervalMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -18,6 +18,7 @@ type resourceScanner struct {
 	clock           clock.Clock
 	resourceFactory resource.ResourceFactory
 	defaultInterval time.Duration
+	backoff         Backoff
 	db              RadarDB
 	dbPipeline      dbng.Pipeline
 	externalURL     string
@@ -27,6 +28,7 @@ func NewResourceScanner(
 	clock clock.Clock,
 	resourceFactory resource.ResourceFactory,
 	defaultInterval time.Duration,
+	backoff Backoff,
 	db RadarDB,
 	dbPipeline dbng.Pipeline,
 	externalURL string,
@@ -35,6 +37,7 @@ func NewResourceScanner(
 		clock:           clock,
 		resourceFactory: resourceFactory,
 		defaultInterval: defaultInterval,
+		backoff:         backoff,
 		db:              db,
 		dbPipeline:      dbPipeline,
 		externalURL:     externalURL,
@@ -63,6 +66,13 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 		return 0, err
 	}
 
+	// the backoff is shared with the other ATCs through the lease, and is
+	// what the API reports as the time of the next check
+	leaseInterval := interval
+	if savedResource.CheckBackoffInterval > leaseInterval {
+		leaseInterval = savedResource.CheckBackoffInterval
+	}
+
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource": resourceName,
 	})
@@ -76,7 +86,7 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 			Source: savedResource.Config.Source,
 		},
 		scanner.db.Config().ResourceTypes,
-		interval,
+		leaseInterval,
 		false,
 	)
 
@@ -84,12 +94,12 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 		lockLogger.Error("failed-to-get-lock", err, lager.Data{
 			"resource": resourceName,
 		})
-		return interval, ErrFailedToAcquireLock
+		return leaseInterval, ErrFailedToAcquireLock
 	}
 
 	if !acquired {
 		lockLogger.Debug("did-not-get-lock")
-		return interval, ErrFailedToAcquireLock
+		return leaseInterval, ErrFailedToAcquireLock
 	}
 
 	defer lock.Release()
@@ -97,19 +107,40 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 	vr, _, err := scanner.db.GetLatestVersionedResource(resourceName)
 	if err != nil {
 		logger.Error("failed-to-get-current-version", err)
-		return interval, err
+		return leaseInterval, err
 	}
 
-	err = swallowErrResourceScriptFailed(
-		scanner.scan(logger.Session("tick"), savedResource, atc.Version(vr.Version)),
-	)
+	err = scanner.scan(logger.Session("tick"), savedResource, atc.Version(vr.Version))
 	if err != nil {
-		return interval, err
+		if isCheckFailure(err) {
+			return scanner.backOff(logger, savedResource, interval), err
+		}
+
+		return leaseInterval, err
 	}
 
 	return interval, nil
 }
 
+// backOff records how long to wait before checking the resource again after
+// its check failed, counting the failure that was just saved.
+func (scanner *resourceScanner) backOff(logger lager.Logger, savedResource db.SavedResource, interval time.Duration) time.Duration {
+	failures := savedResource.CheckFailures + 1
+	backoffInterval := scanner.backoff.Interval(interval, failures)
+
+	logger.Info("backing-off", lager.Data{
+		"failures": failures,
+		"interval": backoffInterval.String(),
+	})
+
+	err := scanner.db.SetResourceCheckBackoffInterval(savedResource, backoffInterval)
+	if err != nil {
+		logger.Error("failed-to-set-check-backoff-interval", err)
+	}
+
+	return backoffInterval
+}
+
 func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceName string, fromVersion atc.Version) error {
 	// if fromVersion is nil then force a check without specifying a version
 	// otherwise specify fromVersion to underlying call to resource.Check()
@@ -179,7 +210,7 @@ func (scanner *resourceScanner) Scan(logger lager.Logger, resourceName string) e
 		return err
 	}
 
-	return swallowErrResourceScriptFailed(
+	return swallowCheckFailure(
 		scanner.ScanFromVersion(logger, resourceName, atc.Version(vr.Version)),
 	)
 }
@@ -205,6 +236,16 @@ func (scanner *resourceScanner) scan(
 		return nil
 	}
 
+	timeout, err := checkTimeout(savedResource.Config.CheckTimeout)
+	if err != nil {
+		setErr := scanner.db.SetResourceCheckError(savedResource, err)
+		if setErr != nil {
+			logger.Error("failed-to-set-check-error", err)
+		}
+
+		return err
+	}
+
 	pipelineID := scanner.db.GetPipelineID()
 
 	var resourceTypeVersion atc.Version
@@ -270,7 +311,14 @@ func (scanner *resourceScanner) scan(
 		"from": fromVersion,
 	})
 
-	newVersions, err := res.Check(savedResource.Config.Source, fromVersion)
+	newVersions, err := checkWithTimeout(
+		logger,
+		scanner.clock,
+		res,
+		timeout,
+		savedResource.Config.Source,
+		fromVersion,
+	)
 
 	setErr := scanner.db.SetResourceCheckError(savedResource, err)
 	if setErr != nil {
@@ -283,6 +331,10 @@ func (scanner *resourceScanner) scan(
 			return rErr
 		}
 
+		if _, ok := err.(ErrCheckTimedOut); ok {
+			return err
+		}
+
 		logger.Error("failed-to-check", err)
 		return err
 	}
@@ -307,25 +359,25 @@ func (scanner *resourceScanner) scan(
 	return nil
 }
 
-func swallowErrResourceScriptFailed(err error) error {
-	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
+func swallowCheckFailure(err error) error {
+	if isCheckFailure(err) {
 		return nil
 	}
 	return err
 }
 
 func (scanner *resourceScanner) checkInterval(resourceConfig atc.ResourceConfig) (time.Duration, error) {
-	interval := scanner.defaultInterval
-	if resourceConfig.CheckEvery != "" {
-		configuredInterval, err := time.ParseDuration(resourceConfig.CheckEvery)
-		if err != nil {
-			return 0, err
-		}
+	return CheckInterval(scanner.defaultInterval, resourceConfig)
+}
 
-		interval = configuredInterval
+// CheckInterval is the interval a resource is checked on when its checks are
+// succeeding: its check_every, or the default interval if it has none.
+func CheckInterval(defaultInterval time.Duration, resourceConfig atc.ResourceConfig) (time.Duration, error) {
+	if resourceConfig.CheckEvery == "" {
+		return defaultInterval, nil
 	}
 
-	return interval, nil
+	return time.ParseDuration(resourceConfig.CheckEvery)
 }
 
 var errPipelineRemoved = errors.New("pipeline removed")
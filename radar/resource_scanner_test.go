@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
 
 	. "github.com/concourse/atc/radar"
 	"github.com/concourse/atc/radar/radarfakes"
@@ -55,6 +56,7 @@ var _ = Describe("ResourceScanner", func() {
 			fakeClock,
 			fakeResourceFactory,
 			interval,
+			Backoff{Max: 5 * time.Minute},
 			fakeRadarDB,
 			fakeDBPipeline,
 			"https://www.example.com",
@@ -128,6 +130,18 @@ var _ = Describe("ResourceScanner", func() {
 				Expect(runErr).To(Equal(ErrFailedToAcquireLock))
 				Expect(actualInterval).To(Equal(interval))
 			})
+
+			Context("when the resource's checks have backed off", func() {
+				BeforeEach(func() {
+					savedResource.CheckBackoffInterval = 4 * interval
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+				})
+
+				It("returns the backoff interval", func() {
+					Expect(runErr).To(Equal(ErrFailedToAcquireLock))
+					Expect(actualInterval).To(Equal(4 * interval))
+				})
+			})
 		})
 
 		Context("when the lock can be acquired", func() {
@@ -347,8 +361,135 @@ var _ = Describe("ResourceScanner", func() {
 					fakeResource.CheckReturns(nil, scriptFail)
 				})
 
-				It("returns no error", func() {
-					Expect(runErr).NotTo(HaveOccurred())
+				It("returns the failure with the interval backed off to", func() {
+					Expect(runErr).To(Equal(scriptFail))
+					Expect(actualInterval).To(Equal(2 * interval))
+				})
+
+				It("saves the interval backed off to", func() {
+					Expect(fakeRadarDB.SetResourceCheckBackoffIntervalCallCount()).To(Equal(1))
+
+					resource, backoffInterval := fakeRadarDB.SetResourceCheckBackoffIntervalArgsForCall(0)
+					Expect(resource).To(Equal(savedResource))
+					Expect(backoffInterval).To(Equal(2 * interval))
+				})
+
+				Context("when the resource's checks have failed before", func() {
+					BeforeEach(func() {
+						savedResource.CheckFailures = 2
+						savedResource.CheckBackoffInterval = 4 * interval
+						fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+					})
+
+					It("leases for the interval backed off to", func() {
+						_, _, _, leaseInterval, _ := fakeDBPipeline.AcquireResourceCheckingLockArgsForCall(0)
+						Expect(leaseInterval).To(Equal(4 * interval))
+					})
+
+					It("backs off further, up to the maximum", func() {
+						Expect(actualInterval).To(Equal(5 * time.Minute))
+
+						_, backoffInterval := fakeRadarDB.SetResourceCheckBackoffIntervalArgsForCall(0)
+						Expect(backoffInterval).To(Equal(5 * time.Minute))
+					})
+				})
+			})
+
+			Context("when the resource config has a check timeout", func() {
+				var fakeContainer *workerfakes.FakeContainer
+
+				BeforeEach(func() {
+					resourceConfig.CheckTimeout = "10s"
+					savedResource.Config = resourceConfig
+					fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+
+					fakeContainer = new(workerfakes.FakeContainer)
+					fakeResource.ContainerReturns(fakeContainer)
+				})
+
+				Context("when the check exceeds the timeout", func() {
+					BeforeEach(func() {
+						stopped := make(chan struct{})
+
+						fakeContainer.StopStub = func(bool) error {
+							close(stopped)
+							return nil
+						}
+
+						fakeResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+							fakeClock.WaitForWatcherAndIncrement(10 * time.Second)
+							<-stopped
+							return nil, errors.New("killed")
+						}
+					})
+
+					It("kills the check", func() {
+						Expect(fakeContainer.StopCallCount()).To(Equal(1))
+						Expect(fakeContainer.StopArgsForCall(0)).To(BeTrue())
+					})
+
+					It("sets the check error", func() {
+						Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+
+						_, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+						Expect(err).To(Equal(ErrCheckTimedOut{Timeout: 10 * time.Second}))
+					})
+
+					It("returns the timeout error", func() {
+						Expect(runErr).To(Equal(ErrCheckTimedOut{Timeout: 10 * time.Second}))
+					})
+
+					Context("when the check cannot be stopped", func() {
+						BeforeEach(func() {
+							destroyed := make(chan struct{})
+
+							fakeContainer.StopReturns(errors.New("nope"))
+							fakeContainer.DestroyStub = func() error {
+								close(destroyed)
+								return nil
+							}
+
+							fakeResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+								fakeClock.WaitForWatcherAndIncrement(10 * time.Second)
+								<-destroyed
+								return nil, errors.New("destroyed")
+							}
+						})
+
+						It("destroys the container and waits for the check to exit", func() {
+							Expect(fakeContainer.DestroyCallCount()).To(Equal(1))
+							Expect(fakeResource.CheckCallCount()).To(Equal(1))
+							Expect(runErr).To(Equal(ErrCheckTimedOut{Timeout: 10 * time.Second}))
+						})
+					})
+				})
+
+				Context("when the check completes in time", func() {
+					BeforeEach(func() {
+						fakeResource.CheckReturns([]atc.Version{{"version": "1"}}, nil)
+					})
+
+					It("does not kill the check", func() {
+						Expect(fakeContainer.StopCallCount()).To(BeZero())
+					})
+
+					It("saves the versions", func() {
+						Expect(fakeRadarDB.SaveResourceVersionsCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the timeout cannot be parsed", func() {
+					BeforeEach(func() {
+						resourceConfig.CheckTimeout = "bogus"
+						savedResource.Config = resourceConfig
+						fakeRadarDB.GetResourceReturns(savedResource, true, nil)
+					})
+
+					It("sets the check error and does not check", func() {
+						Expect(fakeRadarDB.SetResourceCheckErrorCallCount()).To(Equal(1))
+						Expect(fakeResource.CheckCallCount()).To(BeZero())
+						Expect(runErr).To(HaveOccurred())
+					})
 				})
 			})
 
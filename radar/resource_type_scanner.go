@@ -1,8 +1,10 @@
 package radar
 
 import (
+	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -11,23 +13,36 @@ import (
 )
 
 type resourceTypeScanner struct {
+	clock           clock.Clock
 	resourceFactory resource.ResourceFactory
 	defaultInterval time.Duration
+	backoff         Backoff
 	db              RadarDB
 	externalURL     string
+
+	// consecutive check failures by resource type name; unlike resources',
+	// they aren't saved
+	failures     map[string]int
+	failuresLock sync.Mutex
 }
 
 func NewResourceTypeScanner(
+	clock clock.Clock,
 	resourceFactory resource.ResourceFactory,
 	defaultInterval time.Duration,
+	backoff Backoff,
 	db RadarDB,
 	externalURL string,
 ) Scanner {
 	return &resourceTypeScanner{
+		clock:           clock,
 		resourceFactory: resourceFactory,
 		defaultInterval: defaultInterval,
+		backoff:         backoff,
 		db:              db,
 		externalURL:     externalURL,
+
+		failures: map[string]int{},
 	}
 }
 
@@ -53,33 +68,66 @@ func (scanner *resourceTypeScanner) Run(logger lager.Logger, resourceTypeName st
 		return 0, db.ResourceTypeNotFoundError{Name: resourceTypeName}
 	}
 
+	interval := scanner.interval(resourceTypeName)
+
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource-type": resourceTypeName,
 	})
 
-	lock, acquired, err := scanner.db.AcquireResourceTypeCheckingLock(logger, savedResourceType, scanner.defaultInterval, false)
+	lock, acquired, err := scanner.db.AcquireResourceTypeCheckingLock(logger, savedResourceType, interval, false)
 	if err != nil {
 		lockLogger.Error("failed-to-get-lock", err, lager.Data{
 			"resource-type": resourceTypeName,
 		})
-		return scanner.defaultInterval, ErrFailedToAcquireLock
+		return interval, ErrFailedToAcquireLock
 	}
 
 	if !acquired {
 		lockLogger.Debug("did-not-get-lock")
-		return scanner.defaultInterval, ErrFailedToAcquireLock
+		return interval, ErrFailedToAcquireLock
 	}
 
 	defer lock.Release()
 
 	err = scanner.resourceTypeScan(logger.Session("tick"), savedResourceType.Config, savedResourceType.Version)
 	if err != nil {
+		if isCheckFailure(err) {
+			return scanner.backOff(logger, resourceTypeName), err
+		}
+
 		return 0, err
 	}
 
+	scanner.failuresLock.Lock()
+	delete(scanner.failures, resourceTypeName)
+	scanner.failuresLock.Unlock()
+
 	return scanner.defaultInterval, nil
 }
 
+func (scanner *resourceTypeScanner) interval(resourceTypeName string) time.Duration {
+	scanner.failuresLock.Lock()
+	defer scanner.failuresLock.Unlock()
+
+	return scanner.backoff.Interval(scanner.defaultInterval, scanner.failures[resourceTypeName])
+}
+
+func (scanner *resourceTypeScanner) backOff(logger lager.Logger, resourceTypeName string) time.Duration {
+	scanner.failuresLock.Lock()
+	scanner.failures[resourceTypeName]++
+	failures := scanner.failures[resourceTypeName]
+	scanner.failuresLock.Unlock()
+
+	interval := scanner.backoff.Interval(scanner.defaultInterval, failures)
+
+	logger.Info("backing-off", lager.Data{
+		"failures": failures,
+		"interval": interval.String(),
+	})
+
+	return interval
+}
+
 func (scanner *resourceTypeScanner) Scan(logger lager.Logger, resourceTypeName string) error {
 	return nil
 }
@@ -89,6 +137,12 @@ func (scanner *resourceTypeScanner) ScanFromVersion(logger lager.Logger, resourc
 }
 
 func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resourceType atc.ResourceType, fromVersion db.Version) error {
+	timeout, err := checkTimeout(resourceType.CheckTimeout)
+	if err != nil {
+		logger.Error("failed-to-parse-check-timeout", err)
+		return err
+	}
+
 	pipelineID := scanner.db.GetPipelineID()
 
 	resourceSpec := worker.ContainerSpec{
@@ -124,11 +178,22 @@ func (scanner *resourceTypeScanner) resourceTypeScan(logger lager.Logger, resour
 		return err
 	}
 
-	newVersions, err := res.Check(resourceType.Source, atc.Version(fromVersion))
+	newVersions, err := checkWithTimeout(
+		logger,
+		scanner.clock,
+		res,
+		timeout,
+		resourceType.Source,
+		atc.Version(fromVersion),
+	)
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
-			return nil
+			return rErr
+		}
+
+		if _, ok := err.(ErrCheckTimedOut); ok {
+			return err
 		}
 
 		logger.Error("failed-to-check", err)
@@ -4,13 +4,16 @@ import (
 	"errors"
 	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock/lockfakes"
 	. "github.com/concourse/atc/radar"
 	"github.com/concourse/atc/radar/radarfakes"
+	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
 
 	rfakes "github.com/concourse/atc/resource/resourcefakes"
 	. "github.com/onsi/ginkgo"
@@ -21,6 +24,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 	var (
 		fakeResourceFactory *rfakes.FakeResourceFactory
 		fakeRadarDB         *radarfakes.FakeRadarDB
+		fakeClock           *fakeclock.FakeClock
 		interval            time.Duration
 
 		scanner Scanner
@@ -34,12 +38,15 @@ var _ = Describe("ResourceTypeScanner", func() {
 	BeforeEach(func() {
 		fakeResourceFactory = new(rfakes.FakeResourceFactory)
 		fakeRadarDB = new(radarfakes.FakeRadarDB)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 		interval = 1 * time.Minute
 
 		fakeRadarDB.GetPipelineIDReturns(42)
 		scanner = NewResourceTypeScanner(
+			fakeClock,
 			fakeResourceFactory,
 			interval,
+			Backoff{Max: 5 * time.Minute},
 			fakeRadarDB,
 			"https://www.example.com",
 		)
@@ -240,6 +247,79 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 			})
 
+			Context("when checking fails with ErrResourceScriptFailed", func() {
+				scriptFail := resource.ErrResourceScriptFailed{}
+
+				BeforeEach(func() {
+					fakeResource.CheckReturns(nil, scriptFail)
+				})
+
+				It("returns the failure with the interval backed off to", func() {
+					Expect(runErr).To(Equal(scriptFail))
+					Expect(actualInterval).To(Equal(2 * interval))
+				})
+
+				Context("when the resource type is checked again", func() {
+					JustBeforeEach(func() {
+						actualInterval, runErr = scanner.Run(lagertest.NewTestLogger("test"), "some-resource-type")
+					})
+
+					It("leases for the interval backed off to, and backs off further", func() {
+						_, _, leaseInterval, _ := fakeRadarDB.AcquireResourceTypeCheckingLockArgsForCall(1)
+						Expect(leaseInterval).To(Equal(2 * interval))
+
+						Expect(actualInterval).To(Equal(4 * interval))
+					})
+
+					Context("when the check succeeds the second time", func() {
+						BeforeEach(func() {
+							fakeResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+								if fakeResource.CheckCallCount() == 1 {
+									return nil, scriptFail
+								}
+
+								return nil, nil
+							}
+						})
+
+						It("stops backing off", func() {
+							Expect(runErr).NotTo(HaveOccurred())
+							Expect(actualInterval).To(Equal(interval))
+						})
+					})
+				})
+			})
+
+			Context("when the check exceeds the resource type's check timeout", func() {
+				var fakeContainer *workerfakes.FakeContainer
+
+				BeforeEach(func() {
+					savedResourceType.Config.CheckTimeout = "10s"
+					fakeRadarDB.GetResourceTypeReturns(savedResourceType, true, nil)
+
+					fakeContainer = new(workerfakes.FakeContainer)
+					fakeResource.ContainerReturns(fakeContainer)
+
+					stopped := make(chan struct{})
+					fakeContainer.StopStub = func(bool) error {
+						close(stopped)
+						return nil
+					}
+
+					fakeResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+						fakeClock.WaitForWatcherAndIncrement(10 * time.Second)
+						<-stopped
+						return nil, errors.New("killed")
+					}
+				})
+
+				It("kills the check and returns the timeout error", func() {
+					Expect(fakeContainer.StopCallCount()).To(Equal(1))
+					Expect(runErr).To(Equal(ErrCheckTimedOut{Timeout: 10 * time.Second}))
+					Expect(actualInterval).To(Equal(2 * interval))
+				})
+			})
+
 			Context("when the pipeline is paused", func() {
 				BeforeEach(func() {
 					fakeRadarDB.IsPausedReturns(true, nil)
@@ -34,6 +34,7 @@ type scanRunnerFactory struct {
 func NewScanRunnerFactory(
 	resourceFactory resource.ResourceFactory,
 	defaultInterval time.Duration,
+	backoff Backoff,
 	db RadarDB,
 	dbPipeline dbng.Pipeline,
 	clock clock.Clock,
@@ -43,13 +44,16 @@ func NewScanRunnerFactory(
 		clock,
 		resourceFactory,
 		defaultInterval,
+		backoff,
 		db,
 		dbPipeline,
 		externalURL,
 	)
 	resourceTypeScanner := NewResourceTypeScanner(
+		clock,
 		resourceFactory,
 		defaultInterval,
+		backoff,
 		db,
 		externalURL,
 	)
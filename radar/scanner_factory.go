@@ -15,21 +15,24 @@ type ScannerFactory interface {
 type scannerFactory struct {
 	resourceFactory resource.ResourceFactory
 	defaultInterval time.Duration
+	backoff         Backoff
 	externalURL     string
 }
 
 func NewScannerFactory(
 	resourceFactory resource.ResourceFactory,
 	defaultInterval time.Duration,
+	backoff Backoff,
 	externalURL string,
 ) ScannerFactory {
 	return &scannerFactory{
 		resourceFactory: resourceFactory,
 		defaultInterval: defaultInterval,
+		backoff:         backoff,
 		externalURL:     externalURL,
 	}
 }
 
 func (f *scannerFactory) NewResourceScanner(db RadarDB, dbPipeline dbng.Pipeline) Scanner {
-	return NewResourceScanner(clock.NewClock(), f.resourceFactory, f.defaultInterval, db, dbPipeline, f.externalURL)
+	return NewResourceScanner(clock.NewClock(), f.resourceFactory, f.defaultInterval, f.backoff, db, dbPipeline, f.externalURL)
 }
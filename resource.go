@@ -10,4 +10,11 @@ type Resource struct {
 
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
+	CheckFailures  int    `json:"check_failures,omitempty"`
+
+	// CheckBackoff is the interval, in seconds, that the resource's checks
+	// have backed off to after failing; NextCheck is when the next check is
+	// due, as a Unix timestamp.
+	CheckBackoff int64 `json:"check_backoff,omitempty"`
+	NextCheck    int64 `json:"next_check,omitempty"`
 }
@@ -94,6 +94,16 @@ func (f *fetchSourceProvider) Get() (FetchSource, error) {
 		return nil, err
 	}
 
+	if found {
+		released, err := worker.ReleaseContainerOnDrainingWorker(f.logger, f.workerClient, container, resourceProcessIDPropertyName)
+		if err != nil {
+			f.logger.Error("failed-to-release-container-on-draining-worker", err)
+			return nil, err
+		}
+
+		found = !released
+	}
+
 	if found {
 		cacheVolume, cacheVolumeFound := findCacheVolumeForContainer(container)
 		if cacheVolumeFound {
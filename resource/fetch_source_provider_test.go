@@ -63,6 +63,7 @@ var _ = Describe("FetchSourceProvider", func() {
 		Context("when container for session exists", func() {
 			var fakeContainer *workerfakes.FakeContainer
 			var fakeVolume *workerfakes.FakeVolume
+			var fakeContainerWorker *workerfakes.FakeWorker
 
 			BeforeEach(func() {
 				fakeContainer = new(workerfakes.FakeContainer)
@@ -74,6 +75,11 @@ var _ = Describe("FetchSourceProvider", func() {
 					},
 				})
 
+				fakeContainer.WorkerNameReturns("some-worker")
+
+				fakeContainerWorker = new(workerfakes.FakeWorker)
+				fakeWorkerClient.GetWorkerReturns(fakeContainerWorker, nil)
+
 				fakeWorkerClient.FindContainerForIdentifierReturns(fakeContainer, true, nil)
 			})
 
@@ -84,6 +90,64 @@ var _ = Describe("FetchSourceProvider", func() {
 				expectedSource := NewContainerFetchSource(logger, fakeContainer, fakeVolume, resourceOptions)
 				Expect(source).To(Equal(expectedSource))
 			})
+
+			Context("when the container's worker is draining", func() {
+				BeforeEach(func() {
+					fakeContainerWorker.IsDrainingReturns(true)
+				})
+
+				Context("when the script has not been started", func() {
+					var fakeWorker *workerfakes.FakeWorker
+
+					BeforeEach(func() {
+						fakeContainer.PropertyReturns("", errors.New("nope"))
+
+						fakeWorker = new(workerfakes.FakeWorker)
+						fakeWorkerClient.SatisfyingReturns(fakeWorker, nil)
+						resourceInstance.FindOrCreateOnReturns(fakeVolume, nil)
+					})
+
+					It("destroys the container and picks another worker", func() {
+						source, err := fetchSourceProvider.Get()
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeWorkerClient.GetWorkerArgsForCall(0)).To(Equal("some-worker"))
+						Expect(fakeContainer.DestroyCallCount()).To(Equal(1))
+						Expect(fakeWorkerClient.SatisfyingCallCount()).To(Equal(1))
+
+						expectedSource := NewVolumeFetchSource(
+							logger,
+							fakeVolume,
+							fakeWorker,
+							resourceOptions,
+							resourceTypes,
+							tags,
+							teamID,
+							session,
+							metadata,
+							fakeImageFetchingDelegate,
+						)
+						Expect(source).To(Equal(expectedSource))
+					})
+				})
+
+				Context("when the script has been started", func() {
+					BeforeEach(func() {
+						fakeContainer.PropertyReturns("some-process-id", nil)
+					})
+
+					It("keeps using the container", func() {
+						source, err := fetchSourceProvider.Get()
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeContainer.DestroyCallCount()).To(BeZero())
+						Expect(fakeWorkerClient.SatisfyingCallCount()).To(BeZero())
+
+						expectedSource := NewContainerFetchSource(logger, fakeContainer, fakeVolume, resourceOptions)
+						Expect(source).To(Equal(expectedSource))
+					})
+				})
+			})
 		})
 
 		Context("when container for session does not exist", func() {
@@ -1,6 +1,8 @@
 package resource
 
 import (
+	"os"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/worker"
@@ -39,6 +41,7 @@ type ResourceFactory interface {
 
 	NewBuildResource(
 		logger lager.Logger,
+		signals <-chan os.Signal,
 		id worker.Identifier,
 		metadata worker.Metadata,
 		containerSpec worker.ContainerSpec,
@@ -104,6 +107,7 @@ func (f *resourceFactory) NewResource(
 
 func (f *resourceFactory) NewBuildResource(
 	logger lager.Logger,
+	signals <-chan os.Signal,
 	id worker.Identifier,
 	metadata worker.Metadata,
 	containerSpec worker.ContainerSpec,
@@ -112,7 +116,36 @@ func (f *resourceFactory) NewBuildResource(
 	inputSources []InputSource,
 	outputPaths map[string]string,
 ) (Resource, []InputSource, error) {
-	compatibleWorkers, err := f.workerClient.AllSatisfying(containerSpec.WorkerSpec(), resourceTypes)
+	// a build resumed after a restart should re-attach to the container its
+	// step was already running in, wherever it was placed. there's no telling
+	// which inputs were mounted into it, so they're all treated as missing;
+	// they're only streamed in if the script hadn't been started yet.
+	existingContainer, found, err := f.workerClient.FindContainerForIdentifier(logger, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if found {
+		released, err := worker.ReleaseContainerOnDrainingWorker(logger, f.workerClient, existingContainer, resourceProcessIDPropertyName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		found = !released
+	}
+
+	if found {
+		logger.Info("found-existing-container", lager.Data{"container": existingContainer.Handle()})
+		return NewResourceForContainer(existingContainer), inputSources, nil
+	}
+
+	compatibleWorkers, err := f.workerClient.AwaitAllSatisfying(
+		logger,
+		signals,
+		imageFetchingDelegate,
+		containerSpec.WorkerSpec(),
+		resourceTypes,
+	)
 	if err != nil {
 		return nil, nil, err
 	}
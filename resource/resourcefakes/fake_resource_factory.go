@@ -2,6 +2,7 @@
 package resourcefakes
 
 import (
+	"os"
 	"sync"
 
 	"code.cloudfoundry.org/lager"
@@ -27,10 +28,11 @@ type FakeResourceFactory struct {
 		result2 []string
 		result3 error
 	}
-	NewBuildResourceStub        func(logger lager.Logger, id worker.Identifier, metadata worker.Metadata, containerSpec worker.ContainerSpec, resourceTypes atc.ResourceTypes, imageFetchingDelegate worker.ImageFetchingDelegate, inputSources []resource.InputSource, outputPaths map[string]string) (resource.Resource, []resource.InputSource, error)
+	NewBuildResourceStub        func(logger lager.Logger, signals <-chan os.Signal, id worker.Identifier, metadata worker.Metadata, containerSpec worker.ContainerSpec, resourceTypes atc.ResourceTypes, imageFetchingDelegate worker.ImageFetchingDelegate, inputSources []resource.InputSource, outputPaths map[string]string) (resource.Resource, []resource.InputSource, error)
 	newBuildResourceMutex       sync.RWMutex
 	newBuildResourceArgsForCall []struct {
 		logger                lager.Logger
+		signals               <-chan os.Signal
 		id                    worker.Identifier
 		metadata              worker.Metadata
 		containerSpec         worker.ContainerSpec
@@ -115,7 +117,7 @@ func (fake *FakeResourceFactory) NewResourceReturns(result1 resource.Resource, r
 	}{result1, result2, result3}
 }
 
-func (fake *FakeResourceFactory) NewBuildResource(logger lager.Logger, id worker.Identifier, metadata worker.Metadata, containerSpec worker.ContainerSpec, resourceTypes atc.ResourceTypes, imageFetchingDelegate worker.ImageFetchingDelegate, inputSources []resource.InputSource, outputPaths map[string]string) (resource.Resource, []resource.InputSource, error) {
+func (fake *FakeResourceFactory) NewBuildResource(logger lager.Logger, signals <-chan os.Signal, id worker.Identifier, metadata worker.Metadata, containerSpec worker.ContainerSpec, resourceTypes atc.ResourceTypes, imageFetchingDelegate worker.ImageFetchingDelegate, inputSources []resource.InputSource, outputPaths map[string]string) (resource.Resource, []resource.InputSource, error) {
 	var inputSourcesCopy []resource.InputSource
 	if inputSources != nil {
 		inputSourcesCopy = make([]resource.InputSource, len(inputSources))
@@ -124,6 +126,7 @@ func (fake *FakeResourceFactory) NewBuildResource(logger lager.Logger, id worker
 	fake.newBuildResourceMutex.Lock()
 	fake.newBuildResourceArgsForCall = append(fake.newBuildResourceArgsForCall, struct {
 		logger                lager.Logger
+		signals               <-chan os.Signal
 		id                    worker.Identifier
 		metadata              worker.Metadata
 		containerSpec         worker.ContainerSpec
@@ -131,11 +134,11 @@ func (fake *FakeResourceFactory) NewBuildResource(logger lager.Logger, id worker
 		imageFetchingDelegate worker.ImageFetchingDelegate
 		inputSources          []resource.InputSource
 		outputPaths           map[string]string
-	}{logger, id, metadata, containerSpec, resourceTypes, imageFetchingDelegate, inputSourcesCopy, outputPaths})
-	fake.recordInvocation("NewBuildResource", []interface{}{logger, id, metadata, containerSpec, resourceTypes, imageFetchingDelegate, inputSourcesCopy, outputPaths})
+	}{logger, signals, id, metadata, containerSpec, resourceTypes, imageFetchingDelegate, inputSourcesCopy, outputPaths})
+	fake.recordInvocation("NewBuildResource", []interface{}{logger, signals, id, metadata, containerSpec, resourceTypes, imageFetchingDelegate, inputSourcesCopy, outputPaths})
 	fake.newBuildResourceMutex.Unlock()
 	if fake.NewBuildResourceStub != nil {
-		return fake.NewBuildResourceStub(logger, id, metadata, containerSpec, resourceTypes, imageFetchingDelegate, inputSources, outputPaths)
+		return fake.NewBuildResourceStub(logger, signals, id, metadata, containerSpec, resourceTypes, imageFetchingDelegate, inputSources, outputPaths)
 	} else {
 		return fake.newBuildResourceReturns.result1, fake.newBuildResourceReturns.result2, fake.newBuildResourceReturns.result3
 	}
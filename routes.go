@@ -14,12 +14,23 @@ const (
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
 	GetBuildPreparation = "GetBuildPreparation"
+	ListBuildArtifacts  = "ListBuildArtifacts"
+	GetBuildArtifact    = "GetBuildArtifact"
+	GetBuildTimings     = "GetBuildTimings"
+	GetBuildLog         = "GetBuildLog"
+
+	ListBuildComments    = "ListBuildComments"
+	CreateBuildComment   = "CreateBuildComment"
+	ListBuildAnnotations = "ListBuildAnnotations"
+	ListBuildTestResults = "ListBuildTestResults"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
 	ListJobs       = "ListJobs"
 	ListJobBuilds  = "ListJobBuilds"
 	ListJobInputs  = "ListJobInputs"
+	ExplainJob     = "ExplainJob"
+	GetJobTimings  = "GetJobTimings"
 	GetJobBuild    = "GetJobBuild"
 	PauseJob       = "PauseJob"
 	UnpauseJob     = "UnpauseJob"
@@ -27,6 +38,9 @@ const (
 	JobBadge       = "JobBadge"
 	MainJobBadge   = "MainJobBadge"
 
+	SearchJobBuildLogs = "SearchJobBuildLogs"
+	GetJobFlakyTests   = "GetJobFlakyTests"
+
 	ListResources   = "ListResources"
 	GetResource     = "GetResource"
 	PauseResource   = "PauseResource"
@@ -57,6 +71,7 @@ const (
 	RegisterWorker  = "RegisterWorker"
 	LandWorker      = "LandWorker"
 	RetireWorker    = "RetireWorker"
+	ListWorkerPins  = "ListWorkerPins"
 	PruneWorker     = "PruneWorker"
 	HeartbeatWorker = "HeartbeatWorker"
 	ListWorkers     = "ListWorkers"
@@ -74,6 +89,8 @@ const (
 
 	ListVolumes = "ListVolumes"
 
+	StreamEvents = "StreamEvents"
+
 	ListAuthMethods = "ListAuthMethods"
 	GetAuthToken    = "GetAuthToken"
 	GetUser         = "GetUser"
@@ -95,13 +112,25 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "POST", Name: AbortBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/artifacts/:artifact_name", Method: "GET", Name: GetBuildArtifact},
+	{Path: "/api/v1/builds/:build_id/timings", Method: "GET", Name: GetBuildTimings},
+	{Path: "/api/v1/builds/:build_id/log", Method: "GET", Name: GetBuildLog},
+	{Path: "/api/v1/builds/:build_id/comments", Method: "GET", Name: ListBuildComments},
+	{Path: "/api/v1/builds/:build_id/comments", Method: "POST", Name: CreateBuildComment},
+	{Path: "/api/v1/builds/:build_id/annotations", Method: "GET", Name: ListBuildAnnotations},
+	{Path: "/api/v1/builds/:build_id/tests", Method: "GET", Name: ListBuildTestResults},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/explain", Method: "GET", Name: ExplainJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/timings", Method: "GET", Name: GetJobTimings},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/logs/search", Method: "GET", Name: SearchJobBuildLogs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/flaky_tests", Method: "GET", Name: GetJobFlakyTests},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
@@ -139,6 +168,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/workers", Method: "POST", Name: RegisterWorker},
 	{Path: "/api/v1/workers/:worker_name/land", Method: "PUT", Name: LandWorker},
 	{Path: "/api/v1/workers/:worker_name/retire", Method: "PUT", Name: RetireWorker},
+	{Path: "/api/v1/workers/:worker_name/pins", Method: "GET", Name: ListWorkerPins},
 	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
@@ -155,6 +185,8 @@ var Routes = rata.Routes([]rata.Route{
 
 	{Path: "/api/v1/volumes", Method: "GET", Name: ListVolumes},
 
+	{Path: "/api/v1/events", Method: "GET", Name: StreamEvents},
+
 	{Path: "/api/v1/teams/:team_name/auth/methods", Method: "GET", Name: ListAuthMethods},
 	{Path: "/api/v1/teams/:team_name/auth/token", Method: "GET", Name: GetAuthToken},
 	{Path: "/api/v1/user", Method: "GET", Name: GetUser},
@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/scheduler/inputmapper"
 	"github.com/concourse/atc/scheduler/maxinflight"
+	"github.com/concourse/atc/worker"
 )
 
 //go:generate counterfeiter . BuildStarter
@@ -21,8 +22,28 @@ type BuildStarter interface {
 		resourceTypes atc.ResourceTypes,
 		nextPendingBuilds []db.Build,
 	) error
+
+	Blocker(
+		logger lager.Logger,
+		jobConfig atc.JobConfig,
+		resourceConfigs atc.ResourceConfigs,
+		resourceTypes atc.ResourceTypes,
+		nextPendingBuild db.Build,
+	) (Blocker, error)
 }
 
+// Blocker describes why a pending build would not be started right now.
+type Blocker string
+
+const (
+	BlockerNone               Blocker = ""
+	BlockerMaxInFlightReached Blocker = "max-in-flight-reached"
+	BlockerInputsNotSatisfied Blocker = "inputs-not-satisfied"
+	BlockerPipelinePaused     Blocker = "pipeline-paused"
+	BlockerJobPaused          Blocker = "job-paused"
+	BlockerNoSatisfyingWorker Blocker = "no-satisfying-worker"
+)
+
 //go:generate counterfeiter . BuildStarterDB
 
 type BuildStarterDB interface {
@@ -40,6 +61,12 @@ type BuildStarterBuildsDB interface {
 	FinishBuild(buildID int, pipelineID int, status db.Status) error
 }
 
+//go:generate counterfeiter . WorkerPool
+
+type WorkerPool interface {
+	AllSatisfying(worker.WorkerSpec, atc.ResourceTypes) ([]worker.Worker, error)
+}
+
 //go:generate counterfeiter . BuildFactory
 
 type BuildFactory interface {
@@ -53,6 +80,7 @@ func NewBuildStarter(
 	scanner Scanner,
 	inputMapper inputmapper.InputMapper,
 	execEngine engine.Engine,
+	workerPool WorkerPool,
 ) BuildStarter {
 	return &buildStarter{
 		db:                 db,
@@ -61,6 +89,7 @@ func NewBuildStarter(
 		scanner:            scanner,
 		inputMapper:        inputMapper,
 		execEngine:         execEngine,
+		workerPool:         workerPool,
 	}
 }
 
@@ -71,6 +100,7 @@ type buildStarter struct {
 	execEngine         engine.Engine
 	scanner            Scanner
 	inputMapper        inputmapper.InputMapper
+	workerPool         WorkerPool
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
@@ -94,6 +124,147 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 	return nil
 }
 
+// Blocker performs the same checks as starting the pending build would, in
+// the same order, without changing anything. Once the build could be started,
+// it also checks that some worker could run each of the steps the build can't
+// do without, as otherwise the build would start only to error. Workers that
+// are merely full don't block it; its steps wait for them to have room.
+func (s *buildStarter) Blocker(
+	logger lager.Logger,
+	jobConfig atc.JobConfig,
+	resourceConfigs atc.ResourceConfigs,
+	resourceTypes atc.ResourceTypes,
+	nextPendingBuild db.Build,
+) (Blocker, error) {
+	logger = logger.Session("blocker", lager.Data{
+		"build-id":   nextPendingBuild.ID(),
+		"build-name": nextPendingBuild.Name(),
+	})
+
+	reachedMaxInFlight, err := s.maxInFlightUpdater.IsMaxInFlightReached(logger, jobConfig, nextPendingBuild.ID())
+	if err != nil {
+		return BlockerNone, err
+	}
+	if reachedMaxInFlight {
+		return BlockerMaxInFlightReached, nil
+	}
+
+	_, found, err := s.db.GetNextBuildInputs(nextPendingBuild.JobName())
+	if err != nil {
+		logger.Error("failed-to-get-next-build-inputs", err)
+		return BlockerNone, err
+	}
+	if !found {
+		return BlockerInputsNotSatisfied, nil
+	}
+
+	pipelinePaused, err := s.db.IsPaused()
+	if err != nil {
+		logger.Error("failed-to-check-if-pipeline-is-paused", err)
+		return BlockerNone, err
+	}
+	if pipelinePaused {
+		return BlockerPipelinePaused, nil
+	}
+
+	job, found, err := s.db.GetJob(nextPendingBuild.JobName())
+	if err != nil {
+		logger.Error("failed-to-check-if-job-is-paused", err)
+		return BlockerNone, err
+	}
+	if found && job.Paused {
+		return BlockerJobPaused, nil
+	}
+
+	for _, spec := range stepWorkerSpecs(jobConfig, resourceConfigs, nextPendingBuild.TeamID()) {
+		_, err := s.workerPool.AllSatisfying(spec, resourceTypes)
+		if err == nil || err == worker.ErrWorkersFull {
+			continue
+		}
+
+		if _, ok := err.(worker.NoCompatibleWorkersError); ok || err == worker.ErrNoWorkers {
+			return BlockerNoSatisfyingWorker, nil
+		}
+
+		logger.Error("failed-to-find-satisfying-workers", err)
+		return BlockerNone, err
+	}
+
+	return BlockerNone, nil
+}
+
+// stepWorkerSpecs returns the worker requirements of each of the job's get,
+// put, and task steps that every build runs. Tasks whose config is loaded from
+// a file at runtime are only constrained by their tags.
+func stepWorkerSpecs(jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, teamID int) []worker.WorkerSpec {
+	var specs []worker.WorkerSpec
+
+	steps := requiredSteps(atc.PlanConfig{
+		Do:     &jobConfig.Plan,
+		Ensure: jobConfig.Ensure,
+	})
+
+	for _, plan := range steps {
+		spec := worker.WorkerSpec{
+			Tags:   plan.Tags,
+			TeamID: teamID,
+		}
+
+		switch {
+		case plan.Get != "" || plan.Put != "":
+			resourceName := plan.Get + plan.Put
+			if plan.Resource != "" {
+				resourceName = plan.Resource
+			}
+
+			resourceConfig, found := resourceConfigs.Lookup(resourceName)
+			if !found {
+				continue
+			}
+
+			spec.ResourceType = resourceConfig.Type
+
+		case plan.Task != "":
+			if plan.TaskConfig != nil {
+				spec.Platform = plan.TaskConfig.Platform
+			}
+
+		default:
+			continue
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
+
+// requiredSteps returns the steps of the plan that run whatever happens.
+// Steps in a try are left out, as the build can do without them, and so are
+// steps in on_success and on_failure hooks, which only run depending on how
+// their step went.
+func requiredSteps(plan atc.PlanConfig) []atc.PlanConfig {
+	steps := []atc.PlanConfig{plan}
+
+	if plan.Do != nil {
+		for _, p := range *plan.Do {
+			steps = append(steps, requiredSteps(p)...)
+		}
+	}
+
+	if plan.Aggregate != nil {
+		for _, p := range *plan.Aggregate {
+			steps = append(steps, requiredSteps(p)...)
+		}
+	}
+
+	if plan.Ensure != nil {
+		steps = append(steps, requiredSteps(*plan.Ensure)...)
+	}
+
+	return steps
+}
+
 func (s *buildStarter) tryStartNextPendingBuild(
 	logger lager.Logger,
 	nextPendingBuild db.Build,
@@ -16,6 +16,8 @@ import (
 	"github.com/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/atc/scheduler/maxinflight/maxinflightfakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -31,6 +33,7 @@ var _ = Describe("I'm a BuildStarter", func() {
 		fakeScanner      *schedulerfakes.FakeScanner
 		fakeInputMapper  *inputmapperfakes.FakeInputMapper
 		fakeBuildStarter *schedulerfakes.FakeBuildStarter
+		fakeWorkerPool   *schedulerfakes.FakeWorkerPool
 
 		buildStarter scheduler.BuildStarter
 
@@ -45,8 +48,9 @@ var _ = Describe("I'm a BuildStarter", func() {
 		fakeScanner = new(schedulerfakes.FakeScanner)
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
+		fakeWorkerPool = new(schedulerfakes.FakeWorkerPool)
 
-		buildStarter = scheduler.NewBuildStarter(fakeDB, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
+		buildStarter = scheduler.NewBuildStarter(fakeDB, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine, fakeWorkerPool)
 
 		disaster = errors.New("bad thing")
 	})
@@ -556,4 +560,214 @@ var _ = Describe("I'm a BuildStarter", func() {
 		})
 	})
 
+	Describe("Blocker", func() {
+		var pendingBuild *dbfakes.FakeBuild
+		var jobConfig atc.JobConfig
+		var resourceConfigs atc.ResourceConfigs
+		var resourceTypes atc.ResourceTypes
+		var blocker scheduler.Blocker
+		var blockerErr error
+
+		BeforeEach(func() {
+			pendingBuild = new(dbfakes.FakeBuild)
+			pendingBuild.IDReturns(66)
+			pendingBuild.JobNameReturns("some-job")
+			pendingBuild.TeamIDReturns(7)
+
+			jobConfig = atc.JobConfig{Name: "some-job"}
+			resourceConfigs = atc.ResourceConfigs{{Name: "some-resource", Type: "some-type"}}
+			resourceTypes = atc.ResourceTypes{{Name: "some-custom-type", Type: "some-type"}}
+
+			fakeDB.GetNextBuildInputsReturns([]db.BuildInput{{Name: "some-input"}}, true, nil)
+			fakeDB.GetJobReturns(db.SavedJob{}, true, nil)
+			fakeWorkerPool.AllSatisfyingReturns([]worker.Worker{new(workerfakes.FakeWorker)}, nil)
+		})
+
+		JustBeforeEach(func() {
+			blocker, blockerErr = buildStarter.Blocker(
+				lagertest.NewTestLogger("test"),
+				jobConfig,
+				resourceConfigs,
+				resourceTypes,
+				pendingBuild,
+			)
+		})
+
+		It("does not start or update anything", func() {
+			Expect(fakeUpdater.UpdateMaxInFlightReachedCallCount()).To(BeZero())
+			Expect(fakeDB.UpdateBuildToScheduledCallCount()).To(BeZero())
+			Expect(fakeEngine.CreateBuildCallCount()).To(BeZero())
+		})
+
+		Context("when nothing is blocking the build", func() {
+			It("returns no blocker", func() {
+				Expect(blockerErr).NotTo(HaveOccurred())
+				Expect(blocker).To(Equal(scheduler.BlockerNone))
+			})
+
+			It("checked max in flight for the right build", func() {
+				Expect(fakeUpdater.IsMaxInFlightReachedCallCount()).To(Equal(1))
+				_, actualJobConfig, actualBuildID := fakeUpdater.IsMaxInFlightReachedArgsForCall(0)
+				Expect(actualJobConfig).To(Equal(atc.JobConfig{Name: "some-job"}))
+				Expect(actualBuildID).To(Equal(66))
+			})
+		})
+
+		Context("when max in flight is reached", func() {
+			BeforeEach(func() {
+				fakeUpdater.IsMaxInFlightReachedReturns(true, nil)
+			})
+
+			It("returns the max in flight blocker", func() {
+				Expect(blocker).To(Equal(scheduler.BlockerMaxInFlightReached))
+			})
+		})
+
+		Context("when checking max in flight fails", func() {
+			BeforeEach(func() {
+				fakeUpdater.IsMaxInFlightReachedReturns(false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(blockerErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when there are no next build inputs", func() {
+			BeforeEach(func() {
+				fakeDB.GetNextBuildInputsReturns(nil, false, nil)
+			})
+
+			It("returns the inputs blocker", func() {
+				Expect(blocker).To(Equal(scheduler.BlockerInputsNotSatisfied))
+			})
+		})
+
+		Context("when the pipeline is paused", func() {
+			BeforeEach(func() {
+				fakeDB.IsPausedReturns(true, nil)
+			})
+
+			It("returns the paused pipeline blocker", func() {
+				Expect(blocker).To(Equal(scheduler.BlockerPipelinePaused))
+			})
+		})
+
+		Context("when the job is paused", func() {
+			BeforeEach(func() {
+				fakeDB.GetJobReturns(db.SavedJob{Paused: true}, true, nil)
+			})
+
+			It("returns the paused job blocker", func() {
+				Expect(blocker).To(Equal(scheduler.BlockerJobPaused))
+			})
+		})
+
+		Context("when the job has steps that need workers", func() {
+			BeforeEach(func() {
+				jobConfig.Plan = atc.PlanSequence{
+					{Get: "some-input", Resource: "some-resource", Tags: atc.Tags{"some-tag"}},
+					{
+						Task: "some-task",
+						TaskConfig: &atc.TaskConfig{
+							Platform: "windows",
+						},
+					},
+				}
+			})
+
+			It("looks for workers satisfying each step", func() {
+				Expect(fakeWorkerPool.AllSatisfyingCallCount()).To(Equal(2))
+
+				spec, actualResourceTypes := fakeWorkerPool.AllSatisfyingArgsForCall(0)
+				Expect(spec).To(Equal(worker.WorkerSpec{
+					ResourceType: "some-type",
+					Tags:         []string{"some-tag"},
+					TeamID:       7,
+				}))
+				Expect(actualResourceTypes).To(Equal(resourceTypes))
+
+				spec, _ = fakeWorkerPool.AllSatisfyingArgsForCall(1)
+				Expect(spec).To(Equal(worker.WorkerSpec{
+					Platform: "windows",
+					TeamID:   7,
+				}))
+			})
+
+			It("returns no blocker when workers are found", func() {
+				Expect(blockerErr).NotTo(HaveOccurred())
+				Expect(blocker).To(Equal(scheduler.BlockerNone))
+			})
+
+			Context("when some steps only run in a try or on success or failure", func() {
+				BeforeEach(func() {
+					jobConfig.Plan = atc.PlanSequence{
+						{
+							Get:      "some-input",
+							Resource: "some-resource",
+							Failure:  &atc.PlanConfig{Task: "some-failure-task"},
+							Ensure:   &atc.PlanConfig{Task: "some-ensure-task"},
+						},
+						{
+							Try: &atc.PlanConfig{Put: "some-resource"},
+						},
+					}
+
+					jobConfig.Success = &atc.PlanConfig{Task: "some-success-task"}
+				})
+
+				It("only looks for workers for the steps that always run", func() {
+					Expect(fakeWorkerPool.AllSatisfyingCallCount()).To(Equal(2))
+
+					spec, _ := fakeWorkerPool.AllSatisfyingArgsForCall(0)
+					Expect(spec.ResourceType).To(Equal("some-type"))
+
+					spec, _ = fakeWorkerPool.AllSatisfyingArgsForCall(1)
+					Expect(spec).To(Equal(worker.WorkerSpec{TeamID: 7}))
+				})
+			})
+
+			Context("when no worker satisfies a step", func() {
+				BeforeEach(func() {
+					fakeWorkerPool.AllSatisfyingReturns(nil, worker.NoCompatibleWorkersError{})
+				})
+
+				It("returns the no satisfying worker blocker", func() {
+					Expect(blockerErr).NotTo(HaveOccurred())
+					Expect(blocker).To(Equal(scheduler.BlockerNoSatisfyingWorker))
+				})
+			})
+
+			Context("when there are no workers", func() {
+				BeforeEach(func() {
+					fakeWorkerPool.AllSatisfyingReturns(nil, worker.ErrNoWorkers)
+				})
+
+				It("returns the no satisfying worker blocker", func() {
+					Expect(blocker).To(Equal(scheduler.BlockerNoSatisfyingWorker))
+				})
+			})
+
+			Context("when the satisfying workers are full", func() {
+				BeforeEach(func() {
+					fakeWorkerPool.AllSatisfyingReturns(nil, worker.ErrWorkersFull)
+				})
+
+				It("returns no blocker, as the build's steps would wait for room", func() {
+					Expect(blockerErr).NotTo(HaveOccurred())
+					Expect(blocker).To(Equal(scheduler.BlockerNone))
+				})
+			})
+
+			Context("when looking up workers fails", func() {
+				BeforeEach(func() {
+					fakeWorkerPool.AllSatisfyingReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(blockerErr).To(Equal(disaster))
+				})
+			})
+		})
+	})
 })
@@ -38,13 +38,40 @@ func (factory *buildFactory) Create(
 		return atc.Plan{}, err
 	}
 
-	return factory.applyHooks(constructionParams{
+	if len(job.Artifacts) > 0 {
+		plan = factory.planFactory.NewPlan(atc.OnSuccessPlan{
+			Step: plan,
+			Next: factory.planFactory.NewPlan(atc.SaveArtifactsPlan{
+				Names: job.Artifacts,
+			}),
+		})
+	}
+
+	plan, err = factory.applyHooks(constructionParams{
 		plan:          plan,
 		hooks:         job.Hooks(),
 		resources:     resources,
 		resourceTypes: resourceTypes,
 		inputs:        inputs,
 	})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	if job.AbortGracePeriod != "" {
+		err = atc.NewPlanTraversal(func(plan *atc.Plan) error {
+			if plan.Task != nil && plan.Task.AbortGracePeriod == "" {
+				plan.Task.AbortGracePeriod = job.AbortGracePeriod
+			}
+
+			return nil
+		}).Traverse(&plan)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+	}
+
+	return plan, nil
 }
 
 func (factory *buildFactory) constructPlanFromJob(
@@ -102,15 +129,15 @@ func (factory *buildFactory) constructPlanFromConfig(
 	var plan atc.Plan
 	var err error
 
-	if planConfig.Attempts == 0 {
+	if planConfig.Attempts == nil || planConfig.Attempts.Count == 0 {
 		plan, err = factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
 		if err != nil {
 			return atc.Plan{}, err
 		}
 	} else {
-		retryStep := make(atc.RetryPlan, planConfig.Attempts)
+		retryStep := make(atc.RetryPlan, planConfig.Attempts.Count)
 
-		for i := 0; i < planConfig.Attempts; i++ {
+		for i := 0; i < planConfig.Attempts.Count; i++ {
 			attempt, err := factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
 			if err != nil {
 				return atc.Plan{}, err
@@ -120,6 +147,13 @@ func (factory *buildFactory) constructPlanFromConfig(
 		}
 
 		plan = factory.planFactory.NewPlan(retryStep)
+
+		if planConfig.Attempts.Backoff != "" || len(planConfig.Attempts.On) != 0 {
+			plan.RetryPolicy = &atc.RetryPolicy{
+				Backoff: planConfig.Attempts.Backoff,
+				On:      planConfig.Attempts.On,
+			}
+		}
 	}
 
 	return factory.applyHooks(constructionParams{
@@ -231,12 +265,16 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Privileged:        planConfig.Privileged,
 			Config:            planConfig.TaskConfig,
 			ConfigPath:        planConfig.TaskConfigPath,
+			ContainerLimits:   planConfig.ContainerLimits,
 			Tags:              planConfig.Tags,
 			ResourceTypes:     resourceTypes,
 			Params:            planConfig.Params,
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
+			CacheOutputs:      planConfig.CacheOutputs,
+			Reports:           planConfig.Reports,
+			AbortGracePeriod:  planConfig.AbortGracePeriod,
 		})
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
@@ -0,0 +1,92 @@
+package factory_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler/factory"
+	"github.com/concourse/atc/testhelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Artifacts", func() {
+	var (
+		resourceTypes atc.ResourceTypes
+
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resourceTypes = atc.ResourceTypes{
+			{
+				Name:   "some-custom-resource",
+				Type:   "docker-image",
+				Source: atc.Source{"some": "custom-source"},
+			},
+		}
+	})
+
+	Context("when the job has artifacts", func() {
+		It("saves them once the plan succeeds, before the job's hooks", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "some-task",
+					},
+				},
+				Artifacts: []string{"some-output"},
+				Failure: &atc.PlanConfig{
+					Task: "job failure",
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+				Step: expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:          "some-task",
+						PipelineID:    42,
+						ResourceTypes: resourceTypes,
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.SaveArtifactsPlan{
+						Names: []string{"some-output"},
+					}),
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "job failure",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				}),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
+	Context("when the job has no artifacts", func() {
+		It("does not save any", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "some-task",
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.TaskPlan{
+				Name:          "some-task",
+				PipelineID:    42,
+				ResourceTypes: resourceTypes,
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+})
@@ -38,7 +38,7 @@ var _ = Describe("Factory Retry Step", func() {
 				Plan: atc.PlanSequence{
 					{
 						Task:     "second task",
-						Attempts: 3,
+						Attempts: &atc.AttemptsConfig{Count: 3},
 					},
 				},
 			}, nil, resourceTypes, nil)
@@ -66,13 +66,50 @@ var _ = Describe("Factory Retry Step", func() {
 		})
 	})
 
+	Context("when there is a task annotated with 'attempts' with a backoff and outcomes", func() {
+		It("builds a retry plan with a retry policy", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "second task",
+						Attempts: &atc.AttemptsConfig{
+							Count:   2,
+							Backoff: "30s",
+							On:      []string{"errored"},
+						},
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.RetryPlan{
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "second task",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				}),
+				expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "second task",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+				}),
+			})
+			expected.RetryPolicy = &atc.RetryPolicy{
+				Backoff: "30s",
+				On:      []string{"errored"},
+			}
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("when there is a task annotated with 'attempts' and 'on_success'", func() {
 		It("builds correctly", func() {
 			actual, err := buildFactory.Create(atc.JobConfig{
 				Plan: atc.PlanSequence{
 					{
 						Task:     "second task",
-						Attempts: 3,
+						Attempts: &atc.AttemptsConfig{Count: 3},
 						Success: &atc.PlanConfig{
 							Task: "second task",
 						},
@@ -74,6 +74,34 @@ var _ = Describe("Factory Task", func() {
 			})
 		})
 
+		Context("when container limits are specified", func() {
+			BeforeEach(func() {
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:            "some-task",
+							TaskConfigPath:  "some-input/task.yml",
+							ContainerLimits: &atc.ContainerLimits{CPU: 512, Memory: 1024},
+						},
+					},
+				}
+			})
+
+			It("includes them in the task plan", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:            "some-task",
+					PipelineID:      42,
+					ConfigPath:      "some-input/task.yml",
+					ContainerLimits: &atc.ContainerLimits{CPU: 512, Memory: 1024},
+					ResourceTypes:   resourceTypes,
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+
 		Context("when input mapping is specified", func() {
 			BeforeEach(func() {
 				input = atc.JobConfig{
@@ -181,5 +209,116 @@ var _ = Describe("Factory Task", func() {
 				Expect(actual).To(testhelpers.MatchPlan(expected))
 			})
 		})
+
+		Context("when cache_outputs is specified", func() {
+			BeforeEach(func() {
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:           "some-task",
+							TaskConfigPath: "some-input/build.yml",
+							CacheOutputs:   true,
+						},
+					},
+				}
+			})
+
+			It("creates a build plan that caches the task's outputs", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some-task",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+					ConfigPath:    "some-input/build.yml",
+					CacheOutputs:  true,
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+
+		Context("when reports are specified", func() {
+			BeforeEach(func() {
+				input = atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:           "some-task",
+							TaskConfigPath: "some-input/build.yml",
+							Reports:        &atc.TaskReports{JUnit: "some-output/reports/*.xml"},
+						},
+					},
+				}
+			})
+
+			It("creates a build plan that collects the task's reports", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:          "some-task",
+					PipelineID:    42,
+					ResourceTypes: resourceTypes,
+					ConfigPath:    "some-input/build.yml",
+					Reports:       &atc.TaskReports{JUnit: "some-output/reports/*.xml"},
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+
+		Context("when an abort grace period is specified", func() {
+			BeforeEach(func() {
+				input = atc.JobConfig{
+					AbortGracePeriod: "1m",
+					Plan: atc.PlanSequence{
+						{
+							Task:             "some-task",
+							TaskConfigPath:   "some-input/build.yml",
+							AbortGracePeriod: "5m",
+						},
+						{
+							Task:           "some-other-task",
+							TaskConfigPath: "some-input/build.yml",
+						},
+					},
+					Ensure: &atc.PlanConfig{
+						Task:           "some-cleanup-task",
+						TaskConfigPath: "some-input/cleanup.yml",
+					},
+				}
+			})
+
+			It("gives the tasks the step's grace period, or else the job's", func() {
+				actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.EnsurePlan{
+					Step: expectedPlanFactory.NewPlan(atc.DoPlan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:             "some-task",
+							PipelineID:       42,
+							ResourceTypes:    resourceTypes,
+							ConfigPath:       "some-input/build.yml",
+							AbortGracePeriod: "5m",
+						}),
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:             "some-other-task",
+							PipelineID:       42,
+							ResourceTypes:    resourceTypes,
+							ConfigPath:       "some-input/build.yml",
+							AbortGracePeriod: "1m",
+						}),
+					}),
+					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:             "some-cleanup-task",
+						PipelineID:       42,
+						ResourceTypes:    resourceTypes,
+						ConfigPath:       "some-input/cleanup.yml",
+						AbortGracePeriod: "1m",
+					}),
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
 	})
 })
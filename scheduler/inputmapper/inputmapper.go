@@ -16,6 +16,12 @@ type InputMapper interface {
 		versions *algorithm.VersionsDB,
 		job atc.JobConfig,
 	) (algorithm.InputMapping, error)
+
+	ExplainNextInputMapping(
+		logger lager.Logger,
+		versions *algorithm.VersionsDB,
+		job atc.JobConfig,
+	) (algorithm.Explanation, error)
 }
 
 //go:generate counterfeiter . InputMapperDB
@@ -92,3 +98,19 @@ func (i *inputMapper) SaveNextInputMapping(
 
 	return resolvedMapping, nil
 }
+
+func (i *inputMapper) ExplainNextInputMapping(
+	logger lager.Logger,
+	versions *algorithm.VersionsDB,
+	job atc.JobConfig,
+) (algorithm.Explanation, error) {
+	logger = logger.Session("explain-next-input-mapping")
+
+	algorithmInputConfigs, err := i.transformer.TransformInputConfigs(versions, job.Name, config.JobInputs(job))
+	if err != nil {
+		logger.Error("failed-to-get-algorithm-input-configs", err)
+		return algorithm.Explanation{}, err
+	}
+
+	return algorithmInputConfigs.Explain(versions), nil
+}
@@ -384,4 +384,82 @@ var _ = Describe("Inputmapper", func() {
 			})
 		})
 	})
+
+	Describe("ExplainNextInputMapping", func() {
+		var (
+			versionsDB  *algorithm.VersionsDB
+			jobConfig   atc.JobConfig
+			explanation algorithm.Explanation
+			explainErr  error
+		)
+
+		BeforeEach(func() {
+			versionsDB = &algorithm.VersionsDB{
+				JobIDs:      map[string]int{"some-job": 1},
+				ResourceIDs: map[string]int{"a": 11},
+				ResourceVersions: []algorithm.ResourceVersion{
+					{VersionID: 1, ResourceID: 11, CheckOrder: 1},
+					{VersionID: 2, ResourceID: 11, CheckOrder: 2},
+				},
+			}
+
+			jobConfig = atc.JobConfig{
+				Name: "some-job",
+				Plan: atc.PlanSequence{
+					{Get: "a"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			explanation, explainErr = inputMapper.ExplainNextInputMapping(
+				lagertest.NewTestLogger("test"),
+				versionsDB,
+				jobConfig,
+			)
+		})
+
+		Context("when transforming the input configs fails", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when transforming the input configs succeeds", func() {
+			BeforeEach(func() {
+				fakeTransformer.TransformInputConfigsReturns(algorithm.InputConfigs{
+					{
+						Name:       "a",
+						ResourceID: 11,
+						Passed:     algorithm.JobSet{},
+						JobID:      1,
+					},
+				}, nil)
+			})
+
+			It("explains the resolution of the inputs", func() {
+				Expect(explainErr).NotTo(HaveOccurred())
+				Expect(explanation.Resolved).To(BeTrue())
+				Expect(explanation.Inputs).To(Equal([]algorithm.InputExplanation{
+					{
+						Input:      "a",
+						ResourceID: 11,
+						Candidates: []algorithm.CandidateExplanation{
+							{VersionID: 2},
+						},
+					},
+				}))
+			})
+
+			It("does not save any input mapping", func() {
+				Expect(fakeDB.SaveIndependentInputMappingCallCount()).To(BeZero())
+				Expect(fakeDB.SaveNextInputMappingCallCount()).To(BeZero())
+				Expect(fakeDB.DeleteNextInputMappingCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -22,6 +22,17 @@ type FakeInputMapper struct {
 		result1 algorithm.InputMapping
 		result2 error
 	}
+	ExplainNextInputMappingStub        func(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig) (algorithm.Explanation, error)
+	explainNextInputMappingMutex       sync.RWMutex
+	explainNextInputMappingArgsForCall []struct {
+		logger   lager.Logger
+		versions *algorithm.VersionsDB
+		job      atc.JobConfig
+	}
+	explainNextInputMappingReturns struct {
+		result1 algorithm.Explanation
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -62,11 +73,49 @@ func (fake *FakeInputMapper) SaveNextInputMappingReturns(result1 algorithm.Input
 	}{result1, result2}
 }
 
+func (fake *FakeInputMapper) ExplainNextInputMapping(logger lager.Logger, versions *algorithm.VersionsDB, job atc.JobConfig) (algorithm.Explanation, error) {
+	fake.explainNextInputMappingMutex.Lock()
+	fake.explainNextInputMappingArgsForCall = append(fake.explainNextInputMappingArgsForCall, struct {
+		logger   lager.Logger
+		versions *algorithm.VersionsDB
+		job      atc.JobConfig
+	}{logger, versions, job})
+	fake.recordInvocation("ExplainNextInputMapping", []interface{}{logger, versions, job})
+	fake.explainNextInputMappingMutex.Unlock()
+	if fake.ExplainNextInputMappingStub != nil {
+		return fake.ExplainNextInputMappingStub(logger, versions, job)
+	} else {
+		return fake.explainNextInputMappingReturns.result1, fake.explainNextInputMappingReturns.result2
+	}
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingCallCount() int {
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
+	return len(fake.explainNextInputMappingArgsForCall)
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, atc.JobConfig) {
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
+	return fake.explainNextInputMappingArgsForCall[i].logger, fake.explainNextInputMappingArgsForCall[i].versions, fake.explainNextInputMappingArgsForCall[i].job
+}
+
+func (fake *FakeInputMapper) ExplainNextInputMappingReturns(result1 algorithm.Explanation, result2 error) {
+	fake.ExplainNextInputMappingStub = nil
+	fake.explainNextInputMappingReturns = struct {
+		result1 algorithm.Explanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeInputMapper) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.explainNextInputMappingMutex.RLock()
+	defer fake.explainNextInputMappingMutex.RUnlock()
 	return fake.invocations
 }
 
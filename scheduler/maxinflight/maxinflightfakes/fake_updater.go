@@ -21,6 +21,17 @@ type FakeUpdater struct {
 		result1 bool
 		result2 error
 	}
+	IsMaxInFlightReachedStub        func(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error)
+	isMaxInFlightReachedMutex       sync.RWMutex
+	isMaxInFlightReachedArgsForCall []struct {
+		logger    lager.Logger
+		jobConfig atc.JobConfig
+		buildID   int
+	}
+	isMaxInFlightReachedReturns struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -61,11 +72,49 @@ func (fake *FakeUpdater) UpdateMaxInFlightReachedReturns(result1 bool, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeUpdater) IsMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error) {
+	fake.isMaxInFlightReachedMutex.Lock()
+	fake.isMaxInFlightReachedArgsForCall = append(fake.isMaxInFlightReachedArgsForCall, struct {
+		logger    lager.Logger
+		jobConfig atc.JobConfig
+		buildID   int
+	}{logger, jobConfig, buildID})
+	fake.recordInvocation("IsMaxInFlightReached", []interface{}{logger, jobConfig, buildID})
+	fake.isMaxInFlightReachedMutex.Unlock()
+	if fake.IsMaxInFlightReachedStub != nil {
+		return fake.IsMaxInFlightReachedStub(logger, jobConfig, buildID)
+	} else {
+		return fake.isMaxInFlightReachedReturns.result1, fake.isMaxInFlightReachedReturns.result2
+	}
+}
+
+func (fake *FakeUpdater) IsMaxInFlightReachedCallCount() int {
+	fake.isMaxInFlightReachedMutex.RLock()
+	defer fake.isMaxInFlightReachedMutex.RUnlock()
+	return len(fake.isMaxInFlightReachedArgsForCall)
+}
+
+func (fake *FakeUpdater) IsMaxInFlightReachedArgsForCall(i int) (lager.Logger, atc.JobConfig, int) {
+	fake.isMaxInFlightReachedMutex.RLock()
+	defer fake.isMaxInFlightReachedMutex.RUnlock()
+	return fake.isMaxInFlightReachedArgsForCall[i].logger, fake.isMaxInFlightReachedArgsForCall[i].jobConfig, fake.isMaxInFlightReachedArgsForCall[i].buildID
+}
+
+func (fake *FakeUpdater) IsMaxInFlightReachedReturns(result1 bool, result2 error) {
+	fake.IsMaxInFlightReachedStub = nil
+	fake.isMaxInFlightReachedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeUpdater) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.updateMaxInFlightReachedMutex.RLock()
 	defer fake.updateMaxInFlightReachedMutex.RUnlock()
+	fake.isMaxInFlightReachedMutex.RLock()
+	defer fake.isMaxInFlightReachedMutex.RUnlock()
 	return fake.invocations
 }
 
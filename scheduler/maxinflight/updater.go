@@ -10,6 +10,7 @@ import (
 
 type Updater interface {
 	UpdateMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error)
+	IsMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error)
 }
 
 //go:generate counterfeiter . UpdaterDB
@@ -44,6 +45,10 @@ func (u *updater) UpdateMaxInFlightReached(logger lager.Logger, jobConfig atc.Jo
 	return reached, nil
 }
 
+func (u *updater) IsMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error) {
+	return u.isMaxInFlightReached(logger.Session("is-max-in-flight-reached"), jobConfig, buildID)
+}
+
 func (u *updater) isMaxInFlightReached(logger lager.Logger, jobConfig atc.JobConfig, buildID int) (bool, error) {
 	maxInFlight := jobConfig.MaxInFlight()
 
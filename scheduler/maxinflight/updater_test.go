@@ -246,4 +246,58 @@ var _ = Describe("Updater", func() {
 			})
 		})
 	})
+
+	Describe("IsMaxInFlightReached", func() {
+		var reached bool
+		var checkErr error
+
+		JustBeforeEach(func() {
+			reached, checkErr = updater.IsMaxInFlightReached(
+				lagertest.NewTestLogger("test"),
+				atc.JobConfig{
+					Name:           "some-job",
+					RawMaxInFlight: 2,
+				},
+				57,
+			)
+		})
+
+		Context("when there are 2 builds of the job running", func() {
+			BeforeEach(func() {
+				fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{new(dbfakes.FakeBuild), new(dbfakes.FakeBuild)}, nil)
+			})
+
+			It("returns true without recording it", func() {
+				Expect(checkErr).NotTo(HaveOccurred())
+				Expect(reached).To(BeTrue())
+				Expect(fakeDB.SetMaxInFlightReachedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when looking up the running builds fails", func() {
+			BeforeEach(func() {
+				fakeDB.GetRunningBuildsBySerialGroupReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(checkErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when the build is first in line", func() {
+			BeforeEach(func() {
+				fakeDB.GetRunningBuildsBySerialGroupReturns([]db.Build{new(dbfakes.FakeBuild)}, nil)
+
+				pendingBuild := new(dbfakes.FakeBuild)
+				pendingBuild.IDReturns(57)
+				fakeDB.GetNextPendingBuildBySerialGroupReturns(pendingBuild, true, nil)
+			})
+
+			It("returns false without recording it", func() {
+				Expect(checkErr).NotTo(HaveOccurred())
+				Expect(reached).To(BeFalse())
+				Expect(fakeDB.SetMaxInFlightReachedCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -29,6 +29,7 @@ type BuildScheduler interface {
 		resourceTypes atc.ResourceTypes,
 	) (db.Build, Waiter, error)
 	SaveNextInputMapping(logger lager.Logger, job atc.JobConfig) error
+	Explain(logger lager.Logger, job atc.JobConfig) (Explanation, error)
 }
 
 var errPipelineRemoved = errors.New("pipeline removed")
@@ -160,3 +160,54 @@ func (s *Scheduler) SaveNextInputMapping(logger lager.Logger, job atc.JobConfig)
 	_, err = s.InputMapper.SaveNextInputMapping(logger, versions, job)
 	return err
 }
+
+// Explanation describes why a job's next build is or is not running.
+type Explanation struct {
+	Inputs           algorithm.Explanation
+	NextPendingBuild db.Build
+	Blocker          Blocker
+}
+
+func (s *Scheduler) Explain(logger lager.Logger, job atc.JobConfig) (Explanation, error) {
+	logger = logger.Session("explain", lager.Data{"job_name": job.Name})
+
+	versions, err := s.DB.LoadVersionsDB()
+	if err != nil {
+		logger.Error("failed-to-load-versions-db", err)
+		return Explanation{}, err
+	}
+
+	inputs, err := s.InputMapper.ExplainNextInputMapping(logger, versions, job)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	explanation := Explanation{Inputs: inputs}
+
+	nextPendingBuilds, err := s.DB.GetPendingBuildsForJob(job.Name)
+	if err != nil {
+		logger.Error("failed-to-get-next-pending-build-for-job", err)
+		return Explanation{}, err
+	}
+
+	if len(nextPendingBuilds) == 0 {
+		return explanation, nil
+	}
+
+	explanation.NextPendingBuild = nextPendingBuilds[0]
+
+	config := s.DB.Config()
+
+	explanation.Blocker, err = s.BuildStarter.Blocker(
+		logger,
+		job,
+		config.Resources,
+		config.ResourceTypes,
+		explanation.NextPendingBuild,
+	)
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	return explanation, nil
+}
@@ -371,4 +371,122 @@ var _ = Describe("Scheduler", func() {
 			})
 		})
 	})
+
+	Describe("Explain", func() {
+		var explanation Explanation
+		var explainErr error
+
+		JustBeforeEach(func() {
+			explanation, explainErr = scheduler.Explain(lagertest.NewTestLogger("test"), atc.JobConfig{Name: "some-job"})
+		})
+
+		Context("when loading the versions DB fails", func() {
+			BeforeEach(func() {
+				fakeDB.LoadVersionsDBReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(explainErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when loading the versions DB succeeds", func() {
+			var versionsDB *algorithm.VersionsDB
+			var inputsExplanation algorithm.Explanation
+
+			BeforeEach(func() {
+				versionsDB = &algorithm.VersionsDB{JobIDs: map[string]int{"j1": 1}}
+				fakeDB.LoadVersionsDBReturns(versionsDB, nil)
+
+				inputsExplanation = algorithm.Explanation{
+					Inputs: []algorithm.InputExplanation{{Input: "some-input", ResourceID: 11}},
+				}
+				fakeInputMapper.ExplainNextInputMappingReturns(inputsExplanation, nil)
+			})
+
+			It("explains the input mapping for the right job and versions", func() {
+				Expect(fakeInputMapper.ExplainNextInputMappingCallCount()).To(Equal(1))
+				_, actualVersionsDB, actualJobConfig := fakeInputMapper.ExplainNextInputMappingArgsForCall(0)
+				Expect(actualVersionsDB).To(Equal(versionsDB))
+				Expect(actualJobConfig).To(Equal(atc.JobConfig{Name: "some-job"}))
+			})
+
+			Context("when explaining the input mapping fails", func() {
+				BeforeEach(func() {
+					fakeInputMapper.ExplainNextInputMappingReturns(algorithm.Explanation{}, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(explainErr).To(Equal(disaster))
+				})
+			})
+
+			Context("when there is no pending build", func() {
+				BeforeEach(func() {
+					fakeDB.GetPendingBuildsForJobReturns([]db.Build{}, nil)
+				})
+
+				It("returns the inputs explanation without a blocker", func() {
+					Expect(explainErr).NotTo(HaveOccurred())
+					Expect(explanation.Inputs).To(Equal(inputsExplanation))
+					Expect(explanation.NextPendingBuild).To(BeNil())
+					Expect(explanation.Blocker).To(Equal(BlockerNone))
+					Expect(fakeBuildStarter.BlockerCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when there is a pending build", func() {
+				var pendingBuild *dbfakes.FakeBuild
+
+				BeforeEach(func() {
+					pendingBuild = new(dbfakes.FakeBuild)
+					fakeDB.GetPendingBuildsForJobReturns([]db.Build{pendingBuild, new(dbfakes.FakeBuild)}, nil)
+
+					fakeDB.ConfigReturns(atc.Config{
+						Resources:     atc.ResourceConfigs{{Name: "some-resource"}},
+						ResourceTypes: atc.ResourceTypes{{Name: "some-resource-type"}},
+					})
+				})
+
+				Context("when the build starter reports a blocker", func() {
+					BeforeEach(func() {
+						fakeBuildStarter.BlockerReturns(BlockerMaxInFlightReached, nil)
+					})
+
+					It("returns the blocker of the next pending build", func() {
+						Expect(explainErr).NotTo(HaveOccurred())
+						Expect(explanation.NextPendingBuild).To(Equal(pendingBuild))
+						Expect(explanation.Blocker).To(Equal(BlockerMaxInFlightReached))
+
+						Expect(fakeBuildStarter.BlockerCallCount()).To(Equal(1))
+						_, actualJobConfig, actualResourceConfigs, actualResourceTypes, actualBuild := fakeBuildStarter.BlockerArgsForCall(0)
+						Expect(actualJobConfig).To(Equal(atc.JobConfig{Name: "some-job"}))
+						Expect(actualResourceConfigs).To(Equal(atc.ResourceConfigs{{Name: "some-resource"}}))
+						Expect(actualResourceTypes).To(Equal(atc.ResourceTypes{{Name: "some-resource-type"}}))
+						Expect(actualBuild).To(Equal(pendingBuild))
+					})
+				})
+
+				Context("when determining the blocker fails", func() {
+					BeforeEach(func() {
+						fakeBuildStarter.BlockerReturns(BlockerNone, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(explainErr).To(Equal(disaster))
+					})
+				})
+			})
+
+			Context("when getting the pending builds fails", func() {
+				BeforeEach(func() {
+					fakeDB.GetPendingBuildsForJobReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(explainErr).To(Equal(disaster))
+				})
+			})
+		})
+	})
 })
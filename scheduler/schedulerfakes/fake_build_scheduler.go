@@ -48,6 +48,16 @@ type FakeBuildScheduler struct {
 	saveNextInputMappingReturns struct {
 		result1 error
 	}
+	ExplainStub        func(logger lager.Logger, job atc.JobConfig) (scheduler.Explanation, error)
+	explainMutex       sync.RWMutex
+	explainArgsForCall []struct {
+		logger lager.Logger
+		job    atc.JobConfig
+	}
+	explainReturns struct {
+		result1 scheduler.Explanation
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -162,6 +172,41 @@ func (fake *FakeBuildScheduler) SaveNextInputMappingReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuildScheduler) Explain(logger lager.Logger, job atc.JobConfig) (scheduler.Explanation, error) {
+	fake.explainMutex.Lock()
+	fake.explainArgsForCall = append(fake.explainArgsForCall, struct {
+		logger lager.Logger
+		job    atc.JobConfig
+	}{logger, job})
+	fake.recordInvocation("Explain", []interface{}{logger, job})
+	fake.explainMutex.Unlock()
+	if fake.ExplainStub != nil {
+		return fake.ExplainStub(logger, job)
+	} else {
+		return fake.explainReturns.result1, fake.explainReturns.result2
+	}
+}
+
+func (fake *FakeBuildScheduler) ExplainCallCount() int {
+	fake.explainMutex.RLock()
+	defer fake.explainMutex.RUnlock()
+	return len(fake.explainArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) ExplainArgsForCall(i int) (lager.Logger, atc.JobConfig) {
+	fake.explainMutex.RLock()
+	defer fake.explainMutex.RUnlock()
+	return fake.explainArgsForCall[i].logger, fake.explainArgsForCall[i].job
+}
+
+func (fake *FakeBuildScheduler) ExplainReturns(result1 scheduler.Explanation, result2 error) {
+	fake.ExplainStub = nil
+	fake.explainReturns = struct {
+		result1 scheduler.Explanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -171,6 +216,8 @@ func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	defer fake.triggerImmediatelyMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.explainMutex.RLock()
+	defer fake.explainMutex.RUnlock()
 	return fake.invocations
 }
 
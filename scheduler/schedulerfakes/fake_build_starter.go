@@ -23,6 +23,19 @@ type FakeBuildStarter struct {
 	tryStartPendingBuildsForJobReturns struct {
 		result1 error
 	}
+	BlockerStub        func(logger lager.Logger, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes, nextPendingBuild db.Build) (scheduler.Blocker, error)
+	blockerMutex       sync.RWMutex
+	blockerArgsForCall []struct {
+		logger           lager.Logger
+		jobConfig        atc.JobConfig
+		resourceConfigs  atc.ResourceConfigs
+		resourceTypes    atc.ResourceTypes
+		nextPendingBuild db.Build
+	}
+	blockerReturns struct {
+		result1 scheduler.Blocker
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -69,11 +82,51 @@ func (fake *FakeBuildStarter) TryStartPendingBuildsForJobReturns(result1 error)
 	}{result1}
 }
 
+func (fake *FakeBuildStarter) Blocker(logger lager.Logger, jobConfig atc.JobConfig, resourceConfigs atc.ResourceConfigs, resourceTypes atc.ResourceTypes, nextPendingBuild db.Build) (scheduler.Blocker, error) {
+	fake.blockerMutex.Lock()
+	fake.blockerArgsForCall = append(fake.blockerArgsForCall, struct {
+		logger           lager.Logger
+		jobConfig        atc.JobConfig
+		resourceConfigs  atc.ResourceConfigs
+		resourceTypes    atc.ResourceTypes
+		nextPendingBuild db.Build
+	}{logger, jobConfig, resourceConfigs, resourceTypes, nextPendingBuild})
+	fake.recordInvocation("Blocker", []interface{}{logger, jobConfig, resourceConfigs, resourceTypes, nextPendingBuild})
+	fake.blockerMutex.Unlock()
+	if fake.BlockerStub != nil {
+		return fake.BlockerStub(logger, jobConfig, resourceConfigs, resourceTypes, nextPendingBuild)
+	} else {
+		return fake.blockerReturns.result1, fake.blockerReturns.result2
+	}
+}
+
+func (fake *FakeBuildStarter) BlockerCallCount() int {
+	fake.blockerMutex.RLock()
+	defer fake.blockerMutex.RUnlock()
+	return len(fake.blockerArgsForCall)
+}
+
+func (fake *FakeBuildStarter) BlockerArgsForCall(i int) (lager.Logger, atc.JobConfig, atc.ResourceConfigs, atc.ResourceTypes, db.Build) {
+	fake.blockerMutex.RLock()
+	defer fake.blockerMutex.RUnlock()
+	return fake.blockerArgsForCall[i].logger, fake.blockerArgsForCall[i].jobConfig, fake.blockerArgsForCall[i].resourceConfigs, fake.blockerArgsForCall[i].resourceTypes, fake.blockerArgsForCall[i].nextPendingBuild
+}
+
+func (fake *FakeBuildStarter) BlockerReturns(result1 scheduler.Blocker, result2 error) {
+	fake.BlockerStub = nil
+	fake.blockerReturns = struct {
+		result1 scheduler.Blocker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildStarter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.tryStartPendingBuildsForJobMutex.RLock()
 	defer fake.tryStartPendingBuildsForJobMutex.RUnlock()
+	fake.blockerMutex.RLock()
+	defer fake.blockerMutex.RUnlock()
 	return fake.invocations
 }
 
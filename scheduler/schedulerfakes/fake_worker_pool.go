@@ -0,0 +1,82 @@
+// This file was generated by counterfeiter
+package schedulerfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/worker"
+)
+
+type FakeWorkerPool struct {
+	AllSatisfyingStub        func(worker.WorkerSpec, atc.ResourceTypes) ([]worker.Worker, error)
+	allSatisfyingMutex       sync.RWMutex
+	allSatisfyingArgsForCall []struct {
+		arg1 worker.WorkerSpec
+		arg2 atc.ResourceTypes
+	}
+	allSatisfyingReturns struct {
+		result1 []worker.Worker
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWorkerPool) AllSatisfying(arg1 worker.WorkerSpec, arg2 atc.ResourceTypes) ([]worker.Worker, error) {
+	fake.allSatisfyingMutex.Lock()
+	fake.allSatisfyingArgsForCall = append(fake.allSatisfyingArgsForCall, struct {
+		arg1 worker.WorkerSpec
+		arg2 atc.ResourceTypes
+	}{arg1, arg2})
+	fake.recordInvocation("AllSatisfying", []interface{}{arg1, arg2})
+	fake.allSatisfyingMutex.Unlock()
+	if fake.AllSatisfyingStub != nil {
+		return fake.AllSatisfyingStub(arg1, arg2)
+	} else {
+		return fake.allSatisfyingReturns.result1, fake.allSatisfyingReturns.result2
+	}
+}
+
+func (fake *FakeWorkerPool) AllSatisfyingCallCount() int {
+	fake.allSatisfyingMutex.RLock()
+	defer fake.allSatisfyingMutex.RUnlock()
+	return len(fake.allSatisfyingArgsForCall)
+}
+
+func (fake *FakeWorkerPool) AllSatisfyingArgsForCall(i int) (worker.WorkerSpec, atc.ResourceTypes) {
+	fake.allSatisfyingMutex.RLock()
+	defer fake.allSatisfyingMutex.RUnlock()
+	return fake.allSatisfyingArgsForCall[i].arg1, fake.allSatisfyingArgsForCall[i].arg2
+}
+
+func (fake *FakeWorkerPool) AllSatisfyingReturns(result1 []worker.Worker, result2 error) {
+	fake.AllSatisfyingStub = nil
+	fake.allSatisfyingReturns = struct {
+		result1 []worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerPool) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.allSatisfyingMutex.RLock()
+	defer fake.allSatisfyingMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeWorkerPool) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ scheduler.WorkerPool = new(FakeWorkerPool)
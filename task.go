@@ -31,6 +31,34 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+
+	// Directories to persist across builds of the task's step on each worker.
+	Caches []CacheConfig `json:"caches,omitempty" yaml:"caches,omitempty" mapstructure:"caches"`
+
+	// Resources the task's container may consume. Unset limits fall back to
+	// the ATC's defaults.
+	ContainerLimits ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+}
+
+type ContainerLimits struct {
+	// Relative CPU weight of the container.
+	CPU uint64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// Maximum memory of the container, in bytes.
+	Memory uint64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
+}
+
+// Merge returns the limits with any limit set in other taking precedence.
+func (limits ContainerLimits) Merge(other ContainerLimits) ContainerLimits {
+	if other.CPU != 0 {
+		limits.CPU = other.CPU
+	}
+
+	if other.Memory != 0 {
+		limits.Memory = other.Memory
+	}
+
+	return limits
 }
 
 type ImageResource struct {
@@ -106,10 +134,16 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Inputs = other.Inputs
 	}
 
+	if len(other.Caches) != 0 {
+		config.Caches = other.Caches
+	}
+
 	if other.Run.Path != "" {
 		config.Run = other.Run
 	}
 
+	config.ContainerLimits = config.ContainerLimits.Merge(other.ContainerLimits)
+
 	return config
 }
 
@@ -125,6 +159,7 @@ func (config TaskConfig) Validate() error {
 	}
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
+	messages = append(messages, config.validateCacheContainsPaths()...)
 
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
@@ -315,6 +350,18 @@ func (config TaskConfig) validateOutputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateCacheContainsPaths() []string {
+	messages := []string{}
+
+	for i, cache := range config.Caches {
+		if cache.Path == "" {
+			messages = append(messages, fmt.Sprintf("  cache in position %d is missing a path", i))
+		}
+	}
+
+	return messages
+}
+
 func (config TaskConfig) validateInputContainsNames() []string {
 	messages := []string{}
 
@@ -360,6 +407,10 @@ func (output TaskOutputConfig) resolvePath() string {
 	return output.Name
 }
 
+type CacheConfig struct {
+	Path string `json:"path,omitempty" yaml:"path,omitempty" mapstructure:"path"`
+}
+
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
@@ -43,6 +43,20 @@ run: {path: a/file}
 					Expect(task.Run.Path).To(Equal("a/file"))
 				})
 
+				It("loads caches", func() {
+					data := []byte(`
+platform: beos
+
+caches:
+- path: node_modules
+
+run: {path: a/file}
+`)
+					task, err := LoadTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(task.Caches).To(Equal([]CacheConfig{{Path: "node_modules"}}))
+				})
+
 				It("converts yaml booleans to strings in params", func() {
 					data := []byte(`
 platform: beos
@@ -118,6 +132,26 @@ run: {path: a/file}
 				})
 			})
 
+			Context("given a valid task config with container limits", func() {
+				It("works", func() {
+					data := []byte(`
+platform: beos
+
+container_limits:
+  cpu: 512
+  memory: 1073741824
+
+run: {path: a/file}
+`)
+					task, err := LoadTaskConfig(data)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(task.ContainerLimits).To(Equal(ContainerLimits{
+						CPU:    512,
+						Memory: 1073741824,
+					}))
+				})
+			})
+
 			Context("given a valid task config with extra keys", func() {
 				It("returns an error", func() {
 					data := []byte(`
@@ -234,6 +268,26 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has caches", func() {
+			BeforeEach(func() {
+				validConfig.Caches = append(validConfig.Caches, CacheConfig{Path: "some-cache"})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when cache.path is missing", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, CacheConfig{Path: "some-cache"}, CacheConfig{})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  cache in position 1 is missing a path")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
@@ -705,6 +759,19 @@ run: {path: a/file}
 
 		})
 
+		It("overrides the container limits that are set", func() {
+			Expect(TaskConfig{
+				ContainerLimits: ContainerLimits{CPU: 512, Memory: 1024},
+			}.Merge(TaskConfig{
+				ContainerLimits: ContainerLimits{Memory: 2048},
+			})).To(
+
+				Equal(TaskConfig{
+					ContainerLimits: ContainerLimits{CPU: 512, Memory: 2048},
+				}))
+
+		})
+
 		It("overrides input configuration", func() {
 			Expect(TaskConfig{
 				Inputs: []TaskInputConfig{
@@ -0,0 +1,43 @@
+package atc
+
+// TestStatus is the outcome of a single test case reported by a task.
+type TestStatus string
+
+const (
+	TestStatusPassed  TestStatus = "passed"
+	TestStatusFailed  TestStatus = "failed"
+	TestStatusErrored TestStatus = "errored"
+	TestStatusSkipped TestStatus = "skipped"
+)
+
+// TestResult is the outcome of a test case, as parsed from the reports a task
+// writes.
+type TestResult struct {
+	Suite     string     `json:"suite"`
+	ClassName string     `json:"class_name,omitempty"`
+	Name      string     `json:"name"`
+	Status    TestStatus `json:"status"`
+	Duration  float64    `json:"duration"`
+	Message   string     `json:"message,omitempty"`
+}
+
+// BuildTestResult is a test result reported by one of a build's steps.
+type BuildTestResult struct {
+	PlanID PlanID `json:"plan_id"`
+	Step   string `json:"step"`
+
+	TestResult
+}
+
+// FlakyTest is a test which both passed and failed across a job's recent
+// builds.
+type FlakyTest struct {
+	Suite     string `json:"suite"`
+	ClassName string `json:"class_name,omitempty"`
+	Name      string `json:"name"`
+
+	Passes   int `json:"passes"`
+	Failures int `json:"failures"`
+
+	LastFailedBuild string `json:"last_failed_build"`
+}
@@ -3,6 +3,7 @@ package atc
 import (
 	"errors"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 	"time"
@@ -112,6 +113,13 @@ func validateResources(c Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.CheckTimeout != "" {
+			_, err := time.ParseDuration(resource.CheckTimeout)
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has a check_timeout that could not be parsed ('%s')", resource.CheckTimeout))
+			}
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -148,6 +156,13 @@ func validateResourceTypes(c Config) error {
 		if resourceType.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resourceType.CheckTimeout != "" {
+			_, err := time.ParseDuration(resourceType.CheckTimeout)
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has a check_timeout that could not be parsed ('%s')", resourceType.CheckTimeout))
+			}
+		}
 	}
 
 	return compositeErr(errorMessages)
@@ -216,6 +231,15 @@ func validateJobs(c Config) ([]Warning, error) {
 			)
 		}
 
+		if job.AbortGracePeriod != "" {
+			_, err := time.ParseDuration(job.AbortGracePeriod)
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an abort_grace_period that could not be parsed ('%s')", job.AbortGracePeriod))
+			}
+		}
+
+		errorMessages = append(errorMessages, validateArtifacts(identifier, job)...)
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -265,6 +289,111 @@ func (ft foundTypes) IsValid() (bool, string) {
 	return true, ""
 }
 
+// ValidArtifactName returns whether name can be used for an artifact saved
+// by a job. Artifacts are kept as files named after them, so they can't be
+// paths or hidden files.
+func ValidArtifactName(name string) bool {
+	return name != "" &&
+		!strings.HasPrefix(name, ".") &&
+		!strings.ContainsAny(name, `/\`)
+}
+
+func validatePassedJobInOtherPipeline(identifier string, job string) []string {
+	errorMessages := []string{}
+
+	passedJob := ParsePassedJob(job)
+
+	if passedJob.PipelineName == "" || strings.TrimSpace(passedJob.PipelineName) != passedJob.PipelineName {
+		errorMessages = append(
+			errorMessages,
+			fmt.Sprintf(
+				"%s.passed references a job in another pipeline with an invalid pipeline name ('%s'); expected 'pipeline/job'",
+				identifier,
+				job,
+			),
+		)
+	}
+
+	if passedJob.JobName == "" || strings.TrimSpace(passedJob.JobName) != passedJob.JobName || strings.Contains(passedJob.JobName, "/") {
+		errorMessages = append(
+			errorMessages,
+			fmt.Sprintf(
+				"%s.passed references a job in another pipeline with an invalid job name ('%s'); expected 'pipeline/job'",
+				identifier,
+				job,
+			),
+		)
+	}
+
+	return errorMessages
+}
+
+func validateArtifacts(identifier string, job JobConfig) []string {
+	errorMessages := []string{}
+
+	outputs, allOutputsKnown := artifactOutputs(job)
+
+	artifacts := map[string]bool{}
+	for _, artifact := range job.Artifacts {
+		switch {
+		case artifact == "":
+			errorMessages = append(errorMessages, identifier+" has an artifact with no name")
+		case !ValidArtifactName(artifact):
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s has an artifact with an invalid name (must not contain slashes or start with '.'): %s", identifier, artifact),
+			)
+		case artifacts[artifact]:
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s has the same artifact more than once: %s", identifier, artifact),
+			)
+		case allOutputsKnown && !outputs[artifact]:
+			errorMessages = append(
+				errorMessages,
+				fmt.Sprintf("%s has an artifact that is not an output of its plan: %s", identifier, artifact),
+			)
+		}
+
+		artifacts[artifact] = true
+	}
+
+	return errorMessages
+}
+
+// artifactOutputs returns the names of the artifacts produced by the job's
+// plan. The outputs of tasks configured by file aren't known until they run,
+// in which case the names returned are incomplete.
+func artifactOutputs(job JobConfig) (map[string]bool, bool) {
+	outputs := map[string]bool{}
+	complete := true
+
+	for _, plan := range job.Plans() {
+		switch {
+		case plan.Get != "", plan.Put != "":
+			// puts produce the artifact of their implicit get
+			outputs[plan.Name()] = true
+
+		case plan.Task != "":
+			if plan.TaskConfigPath != "" || plan.TaskConfig == nil {
+				complete = false
+				continue
+			}
+
+			for _, output := range plan.TaskConfig.Outputs {
+				name := output.Name
+				if mapped, found := plan.OutputMapping[name]; found {
+					name = mapped
+				}
+
+				outputs[name] = true
+			}
+		}
+	}
+
+	return outputs, complete
+}
+
 func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []string) {
 	foundTypes := foundTypes{
 		identifier: identifier,
@@ -323,7 +452,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "container_limits", "cache_outputs", "reports", "abort_grace_period"},
 			plan, identifier)...,
 		)
 
@@ -353,6 +482,12 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		}
 
 		for _, job := range plan.Passed {
+			if _, found := c.Jobs.Lookup(job); !found && strings.Contains(job, "/") {
+				// jobs in other pipelines can only be looked up when scheduling
+				errorMessages = append(errorMessages, validatePassedJobInOtherPipeline(identifier, job)...)
+				continue
+			}
+
 			jobConfig, found := c.Jobs.Lookup(job)
 			if !found {
 				errorMessages = append(
@@ -398,7 +533,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "privileged", "config", "file", "container_limits", "cache_outputs", "reports", "abort_grace_period"},
 			plan, identifier)...,
 		)
 
@@ -445,6 +580,23 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			warnings = append(warnings, newDeprecationWarning(identifier+" specifies both `file` and `config` in a task step"))
 		}
 
+		if plan.Reports != nil {
+			junitDir := plan.Reports.JUnitDir()
+
+			if plan.Reports.JUnit == "" {
+				errorMessages = append(errorMessages, identifier+" specifies reports without a junit path")
+			} else if junitDir == "." || junitDir == ".." || strings.HasPrefix(junitDir, "../") || path.IsAbs(junitDir) {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(" specifies a junit path which is not within a directory of the task's working directory ('%s')", plan.Reports.JUnit))
+			}
+		}
+
+		if plan.AbortGracePeriod != "" {
+			_, err := time.ParseDuration(plan.AbortGracePeriod)
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an abort_grace_period that could not be parsed ('%s')", plan.AbortGracePeriod))
+			}
+		}
+
 		errorMessages = append(errorMessages, validateInapplicableFields(
 			[]string{"resource", "passed", "trigger"},
 			plan, identifier)...,
@@ -486,9 +638,25 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		}
 	}
 
-	if plan.Attempts < 0 {
+	if plan.Attempts != nil {
 		subIdentifier := fmt.Sprintf("%s.attempts", identifier)
-		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
+
+		if plan.Attempts.Count < 0 {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts.Count))
+		}
+
+		if plan.Attempts.Backoff != "" {
+			_, err := time.ParseDuration(plan.Attempts.Backoff)
+			if err != nil {
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" refers to a backoff that could not be parsed ('%s')", plan.Attempts.Backoff))
+			}
+		}
+
+		for _, outcome := range plan.Attempts.On {
+			if outcome != string(StatusErrored) && outcome != string(StatusFailed) {
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" can only retry on 'errored' or 'failed', not '%s'", outcome))
+			}
+		}
 	}
 
 	return warnings, errorMessages
@@ -524,6 +692,22 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "container_limits":
+			if plan.ContainerLimits != nil {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "cache_outputs":
+			if plan.CacheOutputs {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "reports":
+			if plan.Reports != nil {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "abort_grace_period":
+			if plan.AbortGracePeriod != "" {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
@@ -168,6 +168,18 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has an invalid check timeout", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckTimeout = "bogus"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has a check_timeout that could not be parsed ('bogus')"))
+			})
+		})
+
 		Context("when two resources have the same name", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, config.Resources...)
@@ -377,6 +389,18 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource type has an invalid check timeout", func() {
+			BeforeEach(func() {
+				config.ResourceTypes[0].CheckTimeout = "bogus"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resource types:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resource_types.some-resource-type has a check_timeout that could not be parsed ('bogus')"))
+			})
+		})
+
 		Context("when two resource types have the same name", func() {
 			BeforeEach(func() {
 				config.ResourceTypes = append(config.ResourceTypes, config.ResourceTypes...)
@@ -425,6 +449,92 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has an abort_grace_period that cannot be parsed", func() {
+			BeforeEach(func() {
+				job.AbortGracePeriod = "a while"
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has an abort_grace_period that could not be parsed ('a while')"))
+			})
+		})
+
+		Context("when a job has the same artifact more than once", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{Get: "some-output", Resource: "some-resource"})
+				job.Artifacts = []string{"some-output", "some-output"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has the same artifact more than once: some-output"))
+			})
+		})
+
+		Context("when a job has an artifact whose name is a path", func() {
+			BeforeEach(func() {
+				job.Artifacts = []string{"../some-output"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has an artifact with an invalid name (must not contain slashes or start with '.'): ../some-output"))
+			})
+		})
+
+		Context("when a job has an artifact that is not an output of its plan", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					Task: "some-task",
+					TaskConfig: &TaskConfig{
+						Platform: "linux",
+						Run:      TaskRunConfig{Path: "ls"},
+						Outputs:  []TaskOutputConfig{{Name: "some-output"}},
+					},
+					OutputMapping: map[string]string{"some-output": "mapped-output"},
+				})
+				job.Artifacts = []string{"mapped-output", "some-output"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has an artifact that is not an output of its plan: some-output"))
+				Expect(errorMessages[0]).NotTo(ContainSubstring("mapped-output"))
+			})
+
+			Context("when the job also has a task configured by file", func() {
+				BeforeEach(func() {
+					config.Jobs[len(config.Jobs)-1].Plan = append(job.Plan, PlanConfig{
+						Task:           "some-other-task",
+						TaskConfigPath: "some/task.yml",
+					})
+				})
+
+				It("can't tell, so doesn't return an error", func() {
+					Expect(errorMessages).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when a job has an artifact with no name", func() {
+			BeforeEach(func() {
+				job.Artifacts = []string{""}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job has an artifact with no name"))
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.Plan = append(job.Plan, PlanConfig{
@@ -582,6 +692,157 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a put plan has container limits specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:             "lol",
+						ContainerLimits: &ContainerLimits{CPU: 512},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.lol has invalid fields specified (container_limits)"))
+				})
+			})
+
+			Context("when a get plan has cache_outputs specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:          "lol",
+						CacheOutputs: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.lol has invalid fields specified (cache_outputs)"))
+				})
+			})
+
+			Context("when a get plan has reports specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:     "lol",
+						Reports: &TaskReports{JUnit: "reports/*.xml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.lol has invalid fields specified (reports)"))
+				})
+			})
+
+			Context("when a task plan has reports without a junit path", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "some/config.yml",
+						Reports:        &TaskReports{},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol specifies reports without a junit path"))
+				})
+			})
+
+			Context("when a task plan has a junit path at the root of its working directory", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "some/config.yml",
+						Reports:        &TaskReports{JUnit: "*.xml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol specifies a junit path which is not within a directory of the task's working directory ('*.xml')"))
+				})
+			})
+
+			Context("when a task plan has a junit path outside of its working directory", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "some/config.yml",
+						Reports:        &TaskReports{JUnit: "../reports/*.xml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("specifies a junit path which is not within a directory of the task's working directory ('../reports/*.xml')"))
+				})
+			})
+
+			Context("when a task plan has a junit path within a directory", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "some/config.yml",
+						Reports:        &TaskReports{JUnit: "output/reports/*/*.xml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a get plan has an abort_grace_period specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:              "lol",
+						AbortGracePeriod: "5m",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.lol has invalid fields specified (abort_grace_period)"))
+				})
+			})
+
+			Context("when a task plan has an abort_grace_period that cannot be parsed", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:             "lol",
+						TaskConfigPath:   "some/config.yml",
+						AbortGracePeriod: "a while",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol has an abort_grace_period that could not be parsed ('a while')"))
+				})
+			})
+
 			Context("when a task plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -980,7 +1241,7 @@ var _ = Describe("ValidateConfig", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
 						Put:      "some-resource",
-						Attempts: -1,
+						Attempts: &AttemptsConfig{Count: -1},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -992,6 +1253,53 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a retry plan has zero attempts", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "some-resource",
+						Attempts: &AttemptsConfig{Count: 0},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error, as the step is run once", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a retry plan has an invalid backoff", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "some-resource",
+						Attempts: &AttemptsConfig{Count: 3, Backoff: "nope"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.attempts refers to a backoff that could not be parsed ('nope')"))
+				})
+			})
+
+			Context("when a retry plan retries on an unknown outcome", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "some-resource",
+						Attempts: &AttemptsConfig{Count: 3, On: []string{"errored", "succeeded"}},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.attempts can only retry on 'errored' or 'failed', not 'succeeded'"))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1024,6 +1332,55 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a job's input's passed constraints reference a job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"staging/integration"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a malformed job in another pipeline", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"staging/", "/integration", "a/b/c"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job in another pipeline with an invalid job name ('staging/')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job in another pipeline with an invalid pipeline name ('/integration')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job in another pipeline with an invalid job name ('a/b/c')"))
+				})
+			})
+
+			Context("when a job's input's passed constraints reference a job of the same pipeline whose name contains a slash", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Passed: []string{"some/job"},
+					})
+
+					config.Jobs = append(config.Jobs, job, JobConfig{Name: "some/job"})
+				})
+
+				It("validates it as a job of the same pipeline", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.passed references a job ('some/job') which doesn't interact with the resource ('some-resource')"))
+				})
+			})
+
 			Context("when a job's input's passed constraints references a valid job that has the resource as an output", func() {
 				BeforeEach(func() {
 					config.Jobs[0].Plan = append(config.Jobs[0].Plan, PlanConfig{
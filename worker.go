@@ -10,6 +10,15 @@ type Worker struct {
 	NoProxy       string `json:"no_proxy,omitempty"`
 
 	ActiveContainers int `json:"active_containers"`
+	MaxContainers    int `json:"max_containers,omitempty"`
+
+	// set by the ATC when the worker fails its health probes; such workers are
+	// not given any new containers
+	UnhealthyReason string `json:"unhealthy_reason,omitempty"`
+
+	// base resource types which the worker provides at a version other than
+	// the one required by the ATC
+	DriftedResourceTypes []string `json:"drifted_resource_types,omitempty"`
 
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
@@ -27,6 +36,42 @@ type WorkerResourceType struct {
 	Version string `json:"version"`
 }
 
+type WorkerPin struct {
+	BuildID      int    `json:"build_id"`
+	BuildName    string `json:"build_name"`
+	JobName      string `json:"job_name,omitempty"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	TeamName     string `json:"team_name"`
+
+	Containers []string `json:"containers"`
+}
+
+// BaseResourceTypeVersions maps the names of base resource types to the
+// version that every worker is expected to provide.
+type BaseResourceTypeVersions map[string]string
+
+// Drift returns the names of the given resource types which are provided at a
+// version other than the required one. Types with no required version never
+// drift.
+func (versions BaseResourceTypeVersions) Drift(resourceTypes []WorkerResourceType) []string {
+	drifted := []string{}
+	for _, resourceType := range resourceTypes {
+		required, found := versions[resourceType.Type]
+		if found && resourceType.Version != required {
+			drifted = append(drifted, resourceType.Type)
+		}
+	}
+
+	return drifted
+}
+
+// Satisfied returns true if the given resource type is provided at the
+// required version, or if no version is required for it.
+func (versions BaseResourceTypeVersions) Satisfied(resourceType WorkerResourceType) bool {
+	required, found := versions[resourceType.Type]
+	return !found || resourceType.Version == required
+}
+
 type PruneWorkerResponseBody struct {
 	Stderr string `json:"stderr"`
 }
@@ -25,3 +25,16 @@ type ArtifactSource interface {
 	// `StreamTo` will be used to copy the data to the destination instead.
 	VolumeOn(Worker) (Volume, bool, error)
 }
+
+//go:generate counterfeiter . VersionedArtifactSource
+
+// VersionedArtifactSource is an ArtifactSource whose content is determined by
+// a version, such as a fetched resource or a cached task output. Two sources
+// with the same version are expected to have the same content.
+type VersionedArtifactSource interface {
+	ArtifactSource
+
+	// ArtifactVersion returns a string identifying the source's content, or
+	// false if it is not known.
+	ArtifactVersion() (string, bool)
+}
@@ -94,6 +94,7 @@ type Client interface {
 
 	Satisfying(WorkerSpec, atc.ResourceTypes) (Worker, error)
 	AllSatisfying(WorkerSpec, atc.ResourceTypes) ([]Worker, error)
+	AwaitAllSatisfying(lager.Logger, <-chan os.Signal, ImageFetchingDelegate, WorkerSpec, atc.ResourceTypes) ([]Worker, error)
 	RunningWorkers() ([]Worker, error)
 	GetWorker(workerName string) (Worker, error)
 }
@@ -134,6 +135,12 @@ func (OutputStrategy) baggageclaimStrategy() baggageclaim.Strategy {
 	return baggageclaim.EmptyStrategy{}
 }
 
+type TaskCacheStrategy struct{}
+
+func (TaskCacheStrategy) baggageclaimStrategy() baggageclaim.Strategy {
+	return baggageclaim.EmptyStrategy{}
+}
+
 type ImageArtifactReplicationStrategy struct {
 	Name string
 }
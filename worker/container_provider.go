@@ -685,6 +685,48 @@ func (p *containerProvider) createGardenContainer(
 		})
 	}
 
+	for _, cache := range spec.TaskCaches {
+		var cacheVolume Volume
+		var volumeErr error
+
+		cacheVolumeSpec := VolumeSpec{
+			Strategy:   TaskCacheStrategy{},
+			Privileged: spec.ImageSpec.Privileged,
+		}
+
+		if metadata.JobName != "" {
+			cacheVolume, volumeErr = p.volumeClient.FindOrCreateVolumeForTaskCache(
+				logger,
+				cacheVolumeSpec,
+				creatingContainer,
+				spec.TeamID,
+				dbng.WorkerTaskCache{
+					PipelineID: metadata.PipelineID,
+					JobName:    metadata.JobName,
+					StepName:   metadata.StepName,
+					Path:       cache.Path,
+				},
+				cache.MountPath,
+			)
+		} else {
+			cacheVolume, volumeErr = p.volumeClient.FindOrCreateVolumeForContainer(
+				logger,
+				cacheVolumeSpec,
+				creatingContainer,
+				spec.TeamID,
+				cache.MountPath,
+			)
+		}
+		if volumeErr != nil {
+			return nil, volumeErr
+		}
+
+		volumeMounts = append(volumeMounts, VolumeMount{
+			Volume:    cacheVolume,
+			MountPath: cache.MountPath,
+		})
+	}
+
 	for _, mount := range spec.Mounts {
 		volumeMounts = append(volumeMounts, mount)
 	}
@@ -754,6 +796,10 @@ func (p *containerProvider) createGardenContainer(
 		RootFSPath: imageURL,
 		Env:        env,
 		Handle:     creatingContainer.Handle(),
+		Limits: garden.Limits{
+			CPU:    garden.CPULimits{LimitInShares: spec.Limits.CPU},
+			Memory: garden.MemoryLimits{LimitInBytes: spec.Limits.Memory},
+		},
 	}
 
 	return p.gardenClient.Create(gardenSpec)
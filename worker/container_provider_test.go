@@ -259,7 +259,15 @@ var _ = Describe("ContainerProvider", func() {
 	}
 
 	Describe("FindOrCreateBuildContainer", func() {
+		var (
+			metadata   Metadata
+			taskCaches []TaskCacheMount
+		)
+
 		BeforeEach(func() {
+			metadata = Metadata{}
+			taskCaches = nil
+
 			fakeDBTeam.CreateBuildContainerReturns(fakeCreatingContainer, nil)
 			fakeGardenWorkerDB.AcquireContainerCreatingLockReturns(new(lockfakes.FakeLock), true, nil)
 		})
@@ -269,10 +277,13 @@ var _ = Describe("ContainerProvider", func() {
 				logger, nil,
 				fakeImageFetchingDelegate,
 				Identifier{},
-				Metadata{},
+				metadata,
 				ContainerSpec{
-					ImageSpec: ImageSpec{},
-					Inputs:    inputs,
+					ImageSpec:  ImageSpec{},
+					Inputs:     inputs,
+					Limits:     atc.ContainerLimits{CPU: 512, Memory: 1024},
+					TeamID:     73,
+					TaskCaches: taskCaches,
 				},
 				atc.ResourceTypes{
 					{
@@ -291,6 +302,20 @@ var _ = Describe("ContainerProvider", func() {
 			})
 
 			ItHandlesContainerInCreatingState()
+
+			Context("when container does not exist in garden", func() {
+				BeforeEach(func() {
+					fakeGardenClient.LookupReturns(nil, garden.ContainerNotFoundError{})
+				})
+
+				It("creates the container with the spec's limits", func() {
+					gardenSpec := fakeGardenClient.CreateArgsForCall(0)
+					Expect(gardenSpec.Limits).To(Equal(garden.Limits{
+						CPU:    garden.CPULimits{LimitInShares: 512},
+						Memory: garden.MemoryLimits{LimitInBytes: 1024},
+					}))
+				})
+			})
 		})
 
 		Context("when container exists in database in created state", func() {
@@ -309,6 +334,80 @@ var _ = Describe("ContainerProvider", func() {
 			ItHandlesNonExistentContainer(func() int {
 				return fakeDBTeam.CreateBuildContainerCallCount()
 			})
+
+			Context("when the spec has task caches", func() {
+				var fakeCacheVolume *wfakes.FakeVolume
+
+				BeforeEach(func() {
+					taskCaches = []TaskCacheMount{
+						{Path: "some-cache", MountPath: "/tmp/build/some-dir/some-cache"},
+					}
+
+					fakeCacheVolume = new(wfakes.FakeVolume)
+					fakeCacheVolume.HandleReturns("cache-handle")
+					fakeCacheVolume.PathReturns("/some/cache/path")
+					fakeVolumeClient.FindOrCreateVolumeForTaskCacheReturns(fakeCacheVolume, nil)
+					fakeVolumeClient.FindOrCreateVolumeForContainerReturns(fakeCacheVolume, nil)
+				})
+
+				Context("when the build is for a job", func() {
+					BeforeEach(func() {
+						metadata = Metadata{
+							PipelineID: 42,
+							JobName:    "some-job",
+							StepName:   "some-step",
+						}
+					})
+
+					It("finds or creates the job's cache volume", func() {
+						Expect(fakeVolumeClient.FindOrCreateVolumeForTaskCacheCallCount()).To(Equal(1))
+						_, _, container, teamID, cache, mountPath := fakeVolumeClient.FindOrCreateVolumeForTaskCacheArgsForCall(0)
+						Expect(container).To(Equal(fakeCreatingContainer))
+						Expect(teamID).To(Equal(73))
+						Expect(cache).To(Equal(dbng.WorkerTaskCache{
+							PipelineID: 42,
+							JobName:    "some-job",
+							StepName:   "some-step",
+							Path:       "some-cache",
+						}))
+						Expect(mountPath).To(Equal("/tmp/build/some-dir/some-cache"))
+					})
+
+					It("mounts the cache volume into the container", func() {
+						gardenSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(gardenSpec.BindMounts).To(ContainElement(garden.BindMount{
+							SrcPath: "/some/cache/path",
+							DstPath: "/tmp/build/some-dir/some-cache",
+							Mode:    garden.BindMountModeRW,
+						}))
+					})
+
+					Context("when the cache volume can not be found or created", func() {
+						BeforeEach(func() {
+							fakeVolumeClient.FindOrCreateVolumeForTaskCacheReturns(nil, disasterErr)
+						})
+
+						It("returns the error", func() {
+							Expect(findOrCreateErr).To(Equal(disasterErr))
+						})
+
+						It("does not create container in garden", func() {
+							Expect(fakeGardenClient.CreateCallCount()).To(Equal(0))
+						})
+					})
+				})
+
+				Context("when the build is a one-off build", func() {
+					It("mounts an empty volume for the container instead", func() {
+						Expect(fakeVolumeClient.FindOrCreateVolumeForTaskCacheCallCount()).To(Equal(0))
+						Expect(fakeVolumeClient.FindOrCreateVolumeForContainerCallCount()).To(Equal(1))
+						_, _, container, teamID, mountPath := fakeVolumeClient.FindOrCreateVolumeForContainerArgsForCall(0)
+						Expect(container).To(Equal(fakeCreatingContainer))
+						Expect(teamID).To(Equal(73))
+						Expect(mountPath).To(Equal("/tmp/build/some-dir/some-cache"))
+					})
+				})
+			})
 		})
 	})
 
@@ -30,8 +30,21 @@ type ContainerSpec struct {
 	// volumes that need to be mounted to container
 	Mounts []VolumeMount
 
+	// Directories kept on the worker and reused by later builds of the job's
+	// step. One-off builds, and builds running while another build of the
+	// step holds the cache, get an empty directory instead.
+	TaskCaches []TaskCacheMount
+
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Optional resource limits for the container. Zero values mean unlimited.
+	Limits atc.ContainerLimits
+}
+
+type TaskCacheMount struct {
+	Path      string // Relative to the task's working directory.
+	MountPath string
 }
 
 type ImageSpec struct {
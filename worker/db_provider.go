@@ -33,6 +33,7 @@ type WorkerDB interface {
 	ReapVolume(handle string) error
 	AcquireVolumeCreatingLock(lager.Logger, int) (lock.Lock, bool, error)
 	AcquireContainerCreatingLock(lager.Logger, int) (lock.Lock, bool, error)
+	CountContainersOnWorker(workerName string) (int, error)
 }
 
 var ErrDesiredWorkerNotRunning = errors.New("desired-garden-worker-is-not-known-to-be-running")
@@ -47,6 +48,7 @@ type dbProvider struct {
 	dbResourceTypeFactory     dbng.ResourceTypeFactory
 	dbResourceConfigFactory   dbng.ResourceConfigFactory
 	dbBaseResourceTypeFactory dbng.BaseResourceTypeFactory
+	dbWorkerTaskCacheFactory  dbng.WorkerTaskCacheFactory
 	dbVolumeFactory           dbng.VolumeFactory
 	dbTeamFactory             dbng.TeamFactory
 	pipelineDBFactory         db.PipelineDBFactory
@@ -62,6 +64,7 @@ func NewDBWorkerProvider(
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
 	dbResourceConfigFactory dbng.ResourceConfigFactory,
 	dbBaseResourceTypeFactory dbng.BaseResourceTypeFactory,
+	dbWorkerTaskCacheFactory dbng.WorkerTaskCacheFactory,
 	dbVolumeFactory dbng.VolumeFactory,
 	dbTeamFactory dbng.TeamFactory,
 	pipelineDBFactory db.PipelineDBFactory,
@@ -76,6 +79,7 @@ func NewDBWorkerProvider(
 		dbResourceCacheFactory:    dbResourceCacheFactory,
 		dbResourceConfigFactory:   dbResourceConfigFactory,
 		dbBaseResourceTypeFactory: dbBaseResourceTypeFactory,
+		dbWorkerTaskCacheFactory:  dbWorkerTaskCacheFactory,
 		dbVolumeFactory:           dbVolumeFactory,
 		dbTeamFactory:             dbTeamFactory,
 		dbWorkerFactory:           workerFactory,
@@ -94,9 +98,19 @@ func (provider *dbProvider) RunningWorkers() ([]Worker, error) {
 	workers := []Worker{}
 
 	for _, savedWorker := range savedWorkers {
-		if savedWorker.State == dbng.WorkerStateRunning {
-			workers = append(workers, provider.newGardenWorker(tikTok, savedWorker))
+		if savedWorker.State != dbng.WorkerStateRunning {
+			continue
 		}
+
+		if savedWorker.UnhealthyReason != "" {
+			provider.logger.Debug("skipping-unhealthy-worker", lager.Data{
+				"worker-name": savedWorker.Name,
+				"reason":      savedWorker.UnhealthyReason,
+			})
+			continue
+		}
+
+		workers = append(workers, provider.newGardenWorker(tikTok, savedWorker))
 	}
 
 	return workers, nil
@@ -163,6 +177,7 @@ func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker *dbn
 		provider.db,
 		provider.dbVolumeFactory,
 		provider.dbBaseResourceTypeFactory,
+		provider.dbWorkerTaskCacheFactory,
 		clock.NewClock(),
 		&dbng.Worker{
 			Name:       savedWorker.Name,
@@ -193,7 +208,7 @@ func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker *dbn
 		provider.db,
 		provider,
 		tikTok,
-		savedWorker.ActiveContainers,
+		savedWorker.MaxContainers,
 		savedWorker.ResourceTypes,
 		savedWorker.Platform,
 		savedWorker.Tags,
@@ -201,5 +216,6 @@ func (provider *dbProvider) newGardenWorker(tikTok clock.Clock, savedWorker *dbn
 		savedWorker.Name,
 		*savedWorker.GardenAddr,
 		savedWorker.StartTime,
+		savedWorker.State,
 	)
 }
@@ -115,6 +115,7 @@ var _ = Describe("DBProvider", func() {
 			fakeDBResourceCacheFactory,
 			nil,
 			fakeDBBaseResourceTypeFactory,
+			nil,
 			fakeDBVolumeFactory,
 			fakeDBTeamFactory,
 			fakePipelineDBFactory,
@@ -218,6 +219,31 @@ var _ = Describe("DBProvider", func() {
 				})
 			})
 
+			Context("when one of the workers failed its health probe", func() {
+				BeforeEach(func() {
+					fakeDBWorkerFactory.WorkersReturns([]*dbng.Worker{
+						{
+							Name:            "some-worker",
+							GardenAddr:      &gardenAddr,
+							BaggageclaimURL: &baggageclaimURL,
+							State:           dbng.WorkerStateRunning,
+						},
+						{
+							Name:            "unhealthy-worker",
+							GardenAddr:      &gardenAddr,
+							BaggageclaimURL: &baggageclaimURL,
+							State:           dbng.WorkerStateRunning,
+							UnhealthyReason: "garden: connection refused",
+						},
+					}, nil)
+				})
+
+				It("does not return it", func() {
+					Expect(workers).To(HaveLen(1))
+					Expect(workers[0].Name()).To(Equal("some-worker"))
+				})
+			})
+
 			Context("creating the connection to garden", func() {
 				var id Identifier
 				var spec ContainerSpec
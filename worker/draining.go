@@ -0,0 +1,37 @@
+package worker
+
+import "code.cloudfoundry.org/lager"
+
+// ReleaseContainerOnDrainingWorker destroys a container found for a resumed
+// step if its worker is landing or retiring and the step's process never
+// started in it, as recorded by the container property with the given name.
+// The step can then be placed on another worker rather than holding the
+// draining one up. Containers whose process did start are left to be
+// re-attached to.
+func ReleaseContainerOnDrainingWorker(logger lager.Logger, client Client, container Container, processIDPropertyName string) (bool, error) {
+	containerWorker, err := client.GetWorker(container.WorkerName())
+	if err != nil {
+		return false, err
+	}
+
+	if !containerWorker.IsDraining() {
+		return false, nil
+	}
+
+	_, err = container.Property(processIDPropertyName)
+	if err == nil {
+		return false, nil
+	}
+
+	logger.Info("releasing-container-on-draining-worker", lager.Data{
+		"container-handle": container.Handle(),
+		"worker-name":      container.WorkerName(),
+	})
+
+	err = container.Destroy()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
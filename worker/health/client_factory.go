@@ -0,0 +1,78 @@
+package health
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	gclient "code.cloudfoundry.org/garden/client"
+	gconn "code.cloudfoundry.org/garden/client/connection"
+	"code.cloudfoundry.org/garden/routes"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/worker/transport"
+	"github.com/concourse/baggageclaim"
+	bclient "github.com/concourse/baggageclaim/client"
+	"github.com/concourse/retryhttp"
+	"github.com/tedsuo/rata"
+)
+
+//go:generate counterfeiter . ClientFactory
+
+type ClientFactory interface {
+	GardenClient(worker *dbng.Worker) garden.Client
+	BaggageclaimClient(worker *dbng.Worker) baggageclaim.Client
+}
+
+type clientFactory struct {
+	logger  lager.Logger
+	db      transport.TransportDB
+	timeout time.Duration
+}
+
+// NewClientFactory returns a ClientFactory whose clients give up after the
+// given timeout and never retry, so that a hanging worker is reported as
+// unhealthy rather than holding up the probe.
+func NewClientFactory(logger lager.Logger, db transport.TransportDB, timeout time.Duration) ClientFactory {
+	return &clientFactory{
+		logger:  logger,
+		db:      db,
+		timeout: timeout,
+	}
+}
+
+func (f *clientFactory) GardenClient(worker *dbng.Worker) garden.Client {
+	httpClient := &http.Client{
+		Transport: transport.NewGardenRoundTripper(worker.Name, worker.GardenAddr, f.db, f.httpTransport()),
+		Timeout:   f.timeout,
+	}
+
+	// the request generator's address doesn't matter because it's overwritten by the worker lookup clients
+	hijackStreamer := &transport.WorkerHijackStreamer{
+		HttpClient:       httpClient,
+		HijackableClient: transport.NewHijackableClient(worker.Name, f.db, retryhttp.DefaultHijackableClient),
+		Req:              rata.NewRequestGenerator("http://127.0.0.1:8080", routes.Routes),
+	}
+
+	return gclient.New(gconn.NewWithHijacker(hijackStreamer, f.logger.Session("garden-connection")))
+}
+
+func (f *clientFactory) BaggageclaimClient(worker *dbng.Worker) baggageclaim.Client {
+	roundTripper := transport.NewBaggageclaimRoundTripper(
+		worker.Name,
+		worker.BaggageclaimURL,
+		f.db,
+		f.httpTransport(),
+	)
+
+	return bclient.New(*worker.BaggageclaimURL, roundTripper)
+}
+
+func (f *clientFactory) httpTransport() *http.Transport {
+	return &http.Transport{
+		DisableKeepAlives:     true,
+		Dial:                  (&net.Dialer{Timeout: f.timeout}).Dial,
+		ResponseHeaderTimeout: f.timeout,
+	}
+}
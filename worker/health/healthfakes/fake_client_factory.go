@@ -0,0 +1,122 @@
+// This file was generated by counterfeiter
+package healthfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/worker/health"
+	"github.com/concourse/baggageclaim"
+)
+
+type FakeClientFactory struct {
+	GardenClientStub        func(worker *dbng.Worker) garden.Client
+	gardenClientMutex       sync.RWMutex
+	gardenClientArgsForCall []struct {
+		worker *dbng.Worker
+	}
+	gardenClientReturns struct {
+		result1 garden.Client
+	}
+	BaggageclaimClientStub        func(worker *dbng.Worker) baggageclaim.Client
+	baggageclaimClientMutex       sync.RWMutex
+	baggageclaimClientArgsForCall []struct {
+		worker *dbng.Worker
+	}
+	baggageclaimClientReturns struct {
+		result1 baggageclaim.Client
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClientFactory) GardenClient(worker *dbng.Worker) garden.Client {
+	fake.gardenClientMutex.Lock()
+	fake.gardenClientArgsForCall = append(fake.gardenClientArgsForCall, struct {
+		worker *dbng.Worker
+	}{worker})
+	fake.recordInvocation("GardenClient", []interface{}{worker})
+	fake.gardenClientMutex.Unlock()
+	if fake.GardenClientStub != nil {
+		return fake.GardenClientStub(worker)
+	} else {
+		return fake.gardenClientReturns.result1
+	}
+}
+
+func (fake *FakeClientFactory) GardenClientCallCount() int {
+	fake.gardenClientMutex.RLock()
+	defer fake.gardenClientMutex.RUnlock()
+	return len(fake.gardenClientArgsForCall)
+}
+
+func (fake *FakeClientFactory) GardenClientArgsForCall(i int) *dbng.Worker {
+	fake.gardenClientMutex.RLock()
+	defer fake.gardenClientMutex.RUnlock()
+	return fake.gardenClientArgsForCall[i].worker
+}
+
+func (fake *FakeClientFactory) GardenClientReturns(result1 garden.Client) {
+	fake.GardenClientStub = nil
+	fake.gardenClientReturns = struct {
+		result1 garden.Client
+	}{result1}
+}
+
+func (fake *FakeClientFactory) BaggageclaimClient(worker *dbng.Worker) baggageclaim.Client {
+	fake.baggageclaimClientMutex.Lock()
+	fake.baggageclaimClientArgsForCall = append(fake.baggageclaimClientArgsForCall, struct {
+		worker *dbng.Worker
+	}{worker})
+	fake.recordInvocation("BaggageclaimClient", []interface{}{worker})
+	fake.baggageclaimClientMutex.Unlock()
+	if fake.BaggageclaimClientStub != nil {
+		return fake.BaggageclaimClientStub(worker)
+	} else {
+		return fake.baggageclaimClientReturns.result1
+	}
+}
+
+func (fake *FakeClientFactory) BaggageclaimClientCallCount() int {
+	fake.baggageclaimClientMutex.RLock()
+	defer fake.baggageclaimClientMutex.RUnlock()
+	return len(fake.baggageclaimClientArgsForCall)
+}
+
+func (fake *FakeClientFactory) BaggageclaimClientArgsForCall(i int) *dbng.Worker {
+	fake.baggageclaimClientMutex.RLock()
+	defer fake.baggageclaimClientMutex.RUnlock()
+	return fake.baggageclaimClientArgsForCall[i].worker
+}
+
+func (fake *FakeClientFactory) BaggageclaimClientReturns(result1 baggageclaim.Client) {
+	fake.BaggageclaimClientStub = nil
+	fake.baggageclaimClientReturns = struct {
+		result1 baggageclaim.Client
+	}{result1}
+}
+
+func (fake *FakeClientFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.gardenClientMutex.RLock()
+	defer fake.gardenClientMutex.RUnlock()
+	fake.baggageclaimClientMutex.RLock()
+	defer fake.baggageclaimClientMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeClientFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ health.ClientFactory = new(FakeClientFactory)
@@ -0,0 +1,136 @@
+package health
+
+import (
+	"fmt"
+	"sync"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/baggageclaim"
+)
+
+// no volume ever has this property, so listing volumes by it exercises
+// baggageclaim without making it send back every volume on the worker
+const probeVolumeProperty = "concourse:health-probe"
+
+type Prober interface {
+	Run() error
+}
+
+type prober struct {
+	logger        lager.Logger
+	workerFactory dbng.WorkerFactory
+	clientFactory ClientFactory
+	clock         clock.Clock
+}
+
+// NewProber returns a Prober which pings Garden and Baggageclaim on every
+// running worker. Workers that fail to respond are marked as unhealthy, which
+// keeps new containers off of them until they respond again, regardless of
+// whether their beacon is still heartbeating.
+func NewProber(
+	logger lager.Logger,
+	workerFactory dbng.WorkerFactory,
+	clientFactory ClientFactory,
+	clock clock.Clock,
+) Prober {
+	return &prober{
+		logger:        logger,
+		workerFactory: workerFactory,
+		clientFactory: clientFactory,
+		clock:         clock,
+	}
+}
+
+func (p *prober) Run() error {
+	logger := p.logger.Session("probe")
+
+	workers, err := p.workerFactory.Workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return err
+	}
+
+	unhealthy := make([]bool, len(workers))
+
+	wg := new(sync.WaitGroup)
+	for i, worker := range workers {
+		if worker.State != dbng.WorkerStateRunning || worker.GardenAddr == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, worker *dbng.Worker) {
+			defer wg.Done()
+			unhealthy[i] = p.probeWorker(logger, worker)
+		}(i, worker)
+	}
+
+	wg.Wait()
+
+	unhealthyCount := 0
+	for _, u := range unhealthy {
+		if u {
+			unhealthyCount++
+		}
+	}
+
+	metric.UnhealthyWorkers{
+		Count: unhealthyCount,
+	}.Emit(logger)
+
+	return nil
+}
+
+func (p *prober) probeWorker(logger lager.Logger, worker *dbng.Worker) bool {
+	logger = logger.WithData(lager.Data{"worker-name": worker.Name})
+
+	started := p.clock.Now()
+	reason := p.unhealthyReason(logger, worker)
+
+	metric.WorkerHealthProbe{
+		WorkerName:      worker.Name,
+		Duration:        p.clock.Since(started),
+		UnhealthyReason: reason,
+	}.Emit(logger)
+
+	if reason == worker.UnhealthyReason {
+		return reason != ""
+	}
+
+	var err error
+	if reason == "" {
+		logger.Info("worker-recovered", lager.Data{"previous-reason": worker.UnhealthyReason})
+		err = p.workerFactory.MarkWorkerHealthy(worker.Name)
+	} else {
+		logger.Info("worker-unhealthy", lager.Data{"reason": reason})
+		err = p.workerFactory.MarkWorkerUnhealthy(worker.Name, reason)
+	}
+
+	if err != nil {
+		logger.Error("failed-to-save-worker-health", err)
+	}
+
+	return reason != ""
+}
+
+func (p *prober) unhealthyReason(logger lager.Logger, worker *dbng.Worker) string {
+	err := p.clientFactory.GardenClient(worker).Ping()
+	if err != nil {
+		return fmt.Sprintf("garden: %s", err)
+	}
+
+	if worker.BaggageclaimURL != nil {
+		_, err := p.clientFactory.BaggageclaimClient(worker).ListVolumes(
+			logger,
+			baggageclaim.VolumeProperties{probeVolumeProperty: "true"},
+		)
+		if err != nil {
+			return fmt.Sprintf("baggageclaim: %s", err)
+		}
+	}
+
+	return ""
+}
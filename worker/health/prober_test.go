@@ -0,0 +1,184 @@
+package health_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	. "github.com/concourse/atc/worker/health"
+	"github.com/concourse/atc/worker/health/healthfakes"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Prober", func() {
+	var (
+		fakeWorkerFactory      *dbngfakes.FakeWorkerFactory
+		fakeClientFactory      *healthfakes.FakeClientFactory
+		fakeGardenClient       *gardenfakes.FakeClient
+		fakeBaggageclaimClient *baggageclaimfakes.FakeClient
+
+		gardenAddr      string
+		baggageclaimURL string
+		worker          *dbng.Worker
+
+		prober Prober
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeWorkerFactory = new(dbngfakes.FakeWorkerFactory)
+		fakeClientFactory = new(healthfakes.FakeClientFactory)
+
+		fakeGardenClient = new(gardenfakes.FakeClient)
+		fakeClientFactory.GardenClientReturns(fakeGardenClient)
+
+		fakeBaggageclaimClient = new(baggageclaimfakes.FakeClient)
+		fakeClientFactory.BaggageclaimClientReturns(fakeBaggageclaimClient)
+
+		gardenAddr = "1.2.3.4:7777"
+		baggageclaimURL = "http://1.2.3.4:7788"
+		worker = &dbng.Worker{
+			Name:            "some-worker",
+			GardenAddr:      &gardenAddr,
+			BaggageclaimURL: &baggageclaimURL,
+			State:           dbng.WorkerStateRunning,
+		}
+
+		fakeWorkerFactory.WorkersReturns([]*dbng.Worker{worker}, nil)
+
+		prober = NewProber(
+			lagertest.NewTestLogger("test"),
+			fakeWorkerFactory,
+			fakeClientFactory,
+			fakeclock.NewFakeClock(time.Unix(123, 456)),
+		)
+	})
+
+	JustBeforeEach(func() {
+		runErr = prober.Run()
+	})
+
+	It("pings garden on the worker", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeClientFactory.GardenClientCallCount()).To(Equal(1))
+		Expect(fakeClientFactory.GardenClientArgsForCall(0)).To(Equal(worker))
+		Expect(fakeGardenClient.PingCallCount()).To(Equal(1))
+	})
+
+	It("lists volumes by a property no volume has", func() {
+		Expect(fakeClientFactory.BaggageclaimClientCallCount()).To(Equal(1))
+		Expect(fakeClientFactory.BaggageclaimClientArgsForCall(0)).To(Equal(worker))
+		Expect(fakeBaggageclaimClient.ListVolumesCallCount()).To(Equal(1))
+
+		_, properties := fakeBaggageclaimClient.ListVolumesArgsForCall(0)
+		Expect(properties).To(Equal(baggageclaim.VolumeProperties{"concourse:health-probe": "true"}))
+	})
+
+	It("does not change a healthy worker", func() {
+		Expect(fakeWorkerFactory.MarkWorkerUnhealthyCallCount()).To(BeZero())
+		Expect(fakeWorkerFactory.MarkWorkerHealthyCallCount()).To(BeZero())
+	})
+
+	Context("when garden does not respond", func() {
+		BeforeEach(func() {
+			fakeGardenClient.PingReturns(errors.New("connection refused"))
+		})
+
+		It("marks the worker as unhealthy", func() {
+			Expect(fakeWorkerFactory.MarkWorkerUnhealthyCallCount()).To(Equal(1))
+			name, reason := fakeWorkerFactory.MarkWorkerUnhealthyArgsForCall(0)
+			Expect(name).To(Equal("some-worker"))
+			Expect(reason).To(Equal("garden: connection refused"))
+		})
+
+		It("does not bother probing baggageclaim", func() {
+			Expect(fakeBaggageclaimClient.ListVolumesCallCount()).To(BeZero())
+		})
+
+		Context("when the worker is already marked as unhealthy for that reason", func() {
+			BeforeEach(func() {
+				worker.UnhealthyReason = "garden: connection refused"
+			})
+
+			It("does not save it again", func() {
+				Expect(fakeWorkerFactory.MarkWorkerUnhealthyCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when saving the worker's health fails", func() {
+			BeforeEach(func() {
+				fakeWorkerFactory.MarkWorkerUnhealthyReturns(errors.New("nope"))
+			})
+
+			It("keeps going", func() {
+				Expect(runErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Context("when baggageclaim does not respond", func() {
+		BeforeEach(func() {
+			fakeBaggageclaimClient.ListVolumesReturns(nil, errors.New("timeout"))
+		})
+
+		It("marks the worker as unhealthy", func() {
+			Expect(fakeWorkerFactory.MarkWorkerUnhealthyCallCount()).To(Equal(1))
+			name, reason := fakeWorkerFactory.MarkWorkerUnhealthyArgsForCall(0)
+			Expect(name).To(Equal("some-worker"))
+			Expect(reason).To(Equal("baggageclaim: timeout"))
+		})
+	})
+
+	Context("when an unhealthy worker responds again", func() {
+		BeforeEach(func() {
+			worker.UnhealthyReason = "garden: connection refused"
+		})
+
+		It("marks the worker as healthy", func() {
+			Expect(fakeWorkerFactory.MarkWorkerHealthyCallCount()).To(Equal(1))
+			Expect(fakeWorkerFactory.MarkWorkerHealthyArgsForCall(0)).To(Equal("some-worker"))
+		})
+	})
+
+	Context("when the worker has no baggageclaim", func() {
+		BeforeEach(func() {
+			worker.BaggageclaimURL = nil
+		})
+
+		It("only probes garden", func() {
+			Expect(fakeGardenClient.PingCallCount()).To(Equal(1))
+			Expect(fakeClientFactory.BaggageclaimClientCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the worker is not running", func() {
+		BeforeEach(func() {
+			worker.State = dbng.WorkerStateStalled
+		})
+
+		It("does not probe it", func() {
+			Expect(fakeClientFactory.GardenClientCallCount()).To(BeZero())
+			Expect(fakeClientFactory.BaggageclaimClientCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the workers fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeWorkerFactory.WorkersReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(Equal(disaster))
+		})
+	})
+})
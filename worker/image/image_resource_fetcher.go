@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -53,17 +55,20 @@ type imageResourceFetcherFactory struct {
 	resourceFetcherFactory resource.FetcherFactory
 	resourceFactoryFactory resource.ResourceFactoryFactory
 	dbResourceCacheFactory dbng.ResourceCacheFactory
+	clock                  clock.Clock
 }
 
 func NewImageResourceFetcherFactory(
 	resourceFetcherFactory resource.FetcherFactory,
 	resourceFactoryFactory resource.ResourceFactoryFactory,
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
+	clock clock.Clock,
 ) ImageResourceFetcherFactory {
 	return &imageResourceFetcherFactory{
 		resourceFetcherFactory: resourceFetcherFactory,
 		resourceFactoryFactory: resourceFactoryFactory,
 		dbResourceCacheFactory: dbResourceCacheFactory,
+		clock:                  clock,
 	}
 }
 
@@ -72,6 +77,7 @@ func (f *imageResourceFetcherFactory) ImageResourceFetcherFor(worker worker.Work
 		resourceFetcher:        f.resourceFetcherFactory.FetcherFor(worker),
 		resourceFactory:        f.resourceFactoryFactory.FactoryFor(worker),
 		dbResourceCacheFactory: f.dbResourceCacheFactory,
+		clock:                  f.clock,
 	}
 }
 
@@ -79,6 +85,7 @@ type imageResourceFetcher struct {
 	resourceFetcher        resource.Fetcher
 	resourceFactory        resource.ResourceFactory
 	dbResourceCacheFactory dbng.ResourceCacheFactory
+	clock                  clock.Clock
 }
 
 func (i *imageResourceFetcher) Fetch(
@@ -94,7 +101,11 @@ func (i *imageResourceFetcher) Fetch(
 	imageFetchingDelegate worker.ImageFetchingDelegate,
 	privileged bool,
 ) (worker.Volume, io.ReadCloser, atc.Version, error) {
-	version, err := i.getLatestVersion(logger, id, metadata, imageResourceType, imageResourceSource, tags, teamID, customTypes, imageFetchingDelegate)
+	timeouts := imageFetchingDelegate.ImageFetchTimeouts()
+
+	checkStart := i.clock.Now()
+	version, err := i.getLatestVersion(logger, id, metadata, imageResourceType, imageResourceSource, tags, teamID, customTypes, imageFetchingDelegate, timeouts.Check)
+	imageFetchingDelegate.ImageFetchPhaseFinished("check", checkStart, i.clock.Now())
 	if err != nil {
 		logger.Error("failed-to-get-latest-image-version", err)
 		return nil, nil, nil, err
@@ -165,20 +176,42 @@ func (i *imageResourceFetcher) Fetch(
 		resourceType:          resourceType,
 	}
 
+	fetchSignals := make(chan os.Signal, 1)
+
 	// we need resource cache for build
-	fetchSource, err := i.resourceFetcher.Fetch(
-		logger.Session("init-image"),
-		getSess,
-		tags,
-		teamID,
-		customTypes,
-		resourceInstance,
-		resource.EmptyMetadata{},
-		imageFetchingDelegate,
-		resourceOptions,
+	var fetchSource resource.FetchSource
+	getStart := i.clock.Now()
+	err = i.runWithTimeout(
+		logger.Session("get-image"),
+		"get",
+		timeouts.Get,
 		signals,
-		make(chan struct{}),
+		imageFetchingDelegate,
+		func() error {
+			var fetchErr error
+			fetchSource, fetchErr = i.resourceFetcher.Fetch(
+				logger.Session("init-image"),
+				getSess,
+				tags,
+				teamID,
+				customTypes,
+				resourceInstance,
+				resource.EmptyMetadata{},
+				imageFetchingDelegate,
+				resourceOptions,
+				fetchSignals,
+				make(chan struct{}),
+			)
+			return fetchErr
+		},
+		func(sig os.Signal) {
+			select {
+			case fetchSignals <- sig:
+			default:
+			}
+		},
 	)
+	imageFetchingDelegate.ImageFetchPhaseFinished("get", getStart, i.clock.Now())
 	if err != nil {
 		logger.Error("failed-to-fetch-image", err)
 		return nil, nil, nil, err
@@ -220,6 +253,7 @@ func (i *imageResourceFetcher) getLatestVersion(
 	teamID int,
 	customTypes atc.ResourceTypes,
 	imageFetchingDelegate worker.ImageFetchingDelegate,
+	timeout time.Duration,
 ) (atc.Version, error) {
 	id.Stage = db.ContainerStageCheck
 	id.ImageResourceType = imageResourceType
@@ -252,7 +286,32 @@ func (i *imageResourceFetcher) getLatestVersion(
 		return nil, err
 	}
 
-	versions, err := checkingResource.Check(imageResourceSource, nil)
+	var versions []atc.Version
+	err = i.runWithTimeout(
+		logger.Session("check-image"),
+		"check",
+		timeout,
+		nil,
+		imageFetchingDelegate,
+		func() error {
+			var checkErr error
+			versions, checkErr = checkingResource.Check(imageResourceSource, nil)
+			return checkErr
+		},
+		func(os.Signal) {
+			// checks cannot be interrupted, so kill the check process instead,
+			// or failing that its container
+			err := checkingResource.Container().Stop(true)
+			if err != nil {
+				logger.Error("failed-to-stop-check", err)
+
+				err = checkingResource.Container().Destroy()
+				if err != nil {
+					logger.Error("failed-to-destroy-check-container", err)
+				}
+			}
+		},
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +323,63 @@ func (i *imageResourceFetcher) getLatestVersion(
 	return versions[0], nil
 }
 
+// runWithTimeout calls run, forwarding signals to interrupt as they arrive. If
+// run has not returned within the timeout it is interrupted, the timeout is
+// reported to the delegate, and a worker.ImageFetchTimedOutError is returned
+// once run has exited, so that its container and lease are not left behind.
+// A zero timeout never interrupts.
+func (i *imageResourceFetcher) runWithTimeout(
+	logger lager.Logger,
+	stage string,
+	timeout time.Duration,
+	signals <-chan os.Signal,
+	imageFetchingDelegate worker.ImageFetchingDelegate,
+	run func() error,
+	interrupt func(os.Signal),
+) error {
+	var timeoutC <-chan time.Time
+	if timeout != 0 {
+		timer := i.clock.NewTimer(timeout)
+		defer timer.Stop()
+
+		timeoutC = timer.C()
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- run()
+	}()
+
+	var timedOut bool
+
+	for {
+		select {
+		case err := <-errs:
+			if timedOut {
+				logger.Info("exited-after-timing-out", lager.Data{"error": fmt.Sprintf("%v", err)})
+
+				return worker.ImageFetchTimedOutError{
+					Stage:   stage,
+					Timeout: timeout,
+				}
+			}
+
+			return err
+		case <-timeoutC:
+			logger.Info("timed-out", lager.Data{"timeout": timeout.String()})
+
+			timedOut = true
+			timeoutC = nil
+
+			imageFetchingDelegate.ImageFetchTimedOut(stage, timeout)
+
+			interrupt(os.Interrupt)
+		case sig := <-signals:
+			interrupt(sig)
+		}
+	}
+}
+
 type leaseID struct {
 	Type       resource.ResourceType `json:"type"`
 	Version    atc.Version           `json:"version"`
@@ -8,7 +8,9 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
@@ -32,6 +34,7 @@ var _ = Describe("Image", func() {
 	var fakeResourceFetcher *rfakes.FakeFetcher
 	var fakeResourceFactoryFactory *rfakes.FakeResourceFactoryFactory
 	var fakeResourceCacheFactory *dbngfakes.FakeResourceCacheFactory
+	var fakeClock *fakeclock.FakeClock
 
 	var imageResourceFetcher image.ImageResourceFetcher
 
@@ -99,11 +102,13 @@ var _ = Describe("Image", func() {
 		}
 
 		fakeResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 
 		imageResourceFetcher = image.NewImageResourceFetcherFactory(
 			fakeResourceFetcherFactory,
 			fakeResourceFactoryFactory,
 			fakeResourceCacheFactory,
+			fakeClock,
 		).ImageResourceFetcherFor(fakeWorker)
 	})
 
@@ -155,6 +160,55 @@ var _ = Describe("Image", func() {
 					})
 				})
 
+				Context("when fetching resource does not finish within the get timeout", func() {
+					var fetchExited bool
+
+					BeforeEach(func() {
+						fetchExited = false
+
+						fakeImageFetchingDelegate.ImageFetchTimeoutsReturns(worker.ImageFetchTimeouts{
+							Get: time.Hour,
+						})
+
+						fakeResourceFetcher.FetchStub = func(
+							_ lager.Logger,
+							_ resource.Session,
+							_ atc.Tags,
+							_ int,
+							_ atc.ResourceTypes,
+							_ resource.ResourceInstance,
+							_ resource.Metadata,
+							_ worker.ImageFetchingDelegate,
+							_ resource.ResourceOptions,
+							signals <-chan os.Signal,
+							_ chan<- struct{},
+						) (resource.FetchSource, error) {
+							<-signals
+							fetchExited = true
+							return nil, resource.ErrInterrupted
+						}
+
+						go fakeClock.WaitForWatcherAndIncrement(time.Hour)
+					})
+
+					It("interrupts the fetch and returns an image fetch timed out error once it has exited", func() {
+						Expect(fetchErr).To(Equal(worker.ImageFetchTimedOutError{
+							Stage:   "get",
+							Timeout: time.Hour,
+						}))
+						Expect(fetchErr.Error()).To(ContainSubstring("image fetch timed out"))
+						Expect(fetchExited).To(BeTrue())
+					})
+
+					It("reports the timeout to the delegate", func() {
+						Expect(fakeImageFetchingDelegate.ImageFetchTimedOutCallCount()).To(Equal(1))
+
+						phase, timeout := fakeImageFetchingDelegate.ImageFetchTimedOutArgsForCall(0)
+						Expect(phase).To(Equal("get"))
+						Expect(timeout).To(Equal(time.Hour))
+					})
+				})
+
 				Context("when fetching resource succeeds", func() {
 					var (
 						fakeFetchSource     *rfakes.FakeFetchSource
@@ -338,6 +392,18 @@ var _ = Describe("Image", func() {
 							Expect(fakeImageFetchingDelegate.ImageVersionDeterminedArgsForCall(0)).To(Equal(expectedIdentifier))
 						})
 
+						It("reports the timings of the 'check' and 'get' phases", func() {
+							Expect(fakeImageFetchingDelegate.ImageFetchPhaseFinishedCallCount()).To(Equal(2))
+
+							phase, start, end := fakeImageFetchingDelegate.ImageFetchPhaseFinishedArgsForCall(0)
+							Expect(phase).To(Equal("check"))
+							Expect(start).To(Equal(fakeClock.Now()))
+							Expect(end).To(Equal(fakeClock.Now()))
+
+							phase, _, _ = fakeImageFetchingDelegate.ImageFetchPhaseFinishedArgsForCall(1)
+							Expect(phase).To(Equal("get"))
+						})
+
 						// TODO It doesn't seem that all cases were being tested because they besically do the same
 						// They all create a resource and that resource calls the Check() function.
 						// Do we want to test the same things
@@ -464,6 +530,80 @@ var _ = Describe("Image", func() {
 				Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(0))
 			})
 		})
+
+		Context("when check does not finish within the check timeout", func() {
+			var fakeContainer *wfakes.FakeContainer
+			var stopped chan struct{}
+			var checkExited bool
+
+			BeforeEach(func() {
+				fakeImageFetchingDelegate.ImageFetchTimeoutsReturns(worker.ImageFetchTimeouts{
+					Check: time.Minute,
+				})
+
+				stopped = make(chan struct{})
+				checkExited = false
+
+				fakeContainer = new(wfakes.FakeContainer)
+				fakeContainer.StopStub = func(bool) error {
+					close(stopped)
+					return nil
+				}
+				fakeCheckResource.ContainerReturns(fakeContainer)
+
+				fakeCheckResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+					<-stopped
+					checkExited = true
+					return nil, errors.New("killed")
+				}
+
+				go fakeClock.WaitForWatcherAndIncrement(time.Minute)
+			})
+
+			It("kills the check process", func() {
+				Expect(fakeContainer.StopCallCount()).To(Equal(1))
+				Expect(fakeContainer.StopArgsForCall(0)).To(BeTrue())
+			})
+
+			It("returns an image fetch timed out error", func() {
+				Expect(fetchErr).To(Equal(worker.ImageFetchTimedOutError{
+					Stage:   "check",
+					Timeout: time.Minute,
+				}))
+			})
+
+			It("waits for the check to exit before returning", func() {
+				Expect(checkExited).To(BeTrue())
+			})
+
+			It("reports the timeout to the delegate", func() {
+				Expect(fakeImageFetchingDelegate.ImageFetchTimedOutCallCount()).To(Equal(1))
+
+				phase, timeout := fakeImageFetchingDelegate.ImageFetchTimedOutArgsForCall(0)
+				Expect(phase).To(Equal("check"))
+				Expect(timeout).To(Equal(time.Minute))
+			})
+
+			Context("when stopping the check process fails", func() {
+				BeforeEach(func() {
+					fakeContainer.StopStub = nil
+					fakeContainer.StopReturns(errors.New("nope"))
+					fakeContainer.DestroyStub = func() error {
+						close(stopped)
+						return nil
+					}
+				})
+
+				It("destroys the check container", func() {
+					Expect(fakeContainer.DestroyCallCount()).To(Equal(1))
+					Expect(checkExited).To(BeTrue())
+				})
+			})
+
+			It("does not construct the 'get' resource", func() {
+				Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Context("when initializing the Check resource fails", func() {
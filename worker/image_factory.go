@@ -1,9 +1,11 @@
 package worker
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
@@ -47,6 +49,28 @@ type Image interface {
 type ImageFetchingDelegate interface {
 	Stderr() io.Writer
 	ImageVersionDetermined(ResourceCacheIdentifier) error
+	WaitingForWorker()
+	ImageFetchTimeouts() ImageFetchTimeouts
+	ImageFetchPhaseFinished(phase string, start time.Time, end time.Time)
+	ImageFetchTimedOut(phase string, timeout time.Duration)
+}
+
+// ImageFetchTimeouts bounds how long the check and get of an image resource
+// may take. A zero duration means no timeout.
+type ImageFetchTimeouts struct {
+	Check time.Duration
+	Get   time.Duration
+}
+
+// ImageFetchTimedOutError is returned when checking or getting an image
+// resource does not finish within its timeout.
+type ImageFetchTimedOutError struct {
+	Stage   string
+	Timeout time.Duration
+}
+
+func (err ImageFetchTimedOutError) Error() string {
+	return fmt.Sprintf("image fetch timed out: %s did not finish within %s", err.Stage, err.Timeout)
 }
 
 type ImageMetadata struct {
@@ -58,3 +82,7 @@ type NoopImageFetchingDelegate struct{}
 
 func (NoopImageFetchingDelegate) Stderr() io.Writer                                    { return ioutil.Discard }
 func (NoopImageFetchingDelegate) ImageVersionDetermined(ResourceCacheIdentifier) error { return nil }
+func (NoopImageFetchingDelegate) WaitingForWorker()                                    {}
+func (NoopImageFetchingDelegate) ImageFetchTimeouts() ImageFetchTimeouts               { return ImageFetchTimeouts{} }
+func (NoopImageFetchingDelegate) ImageFetchPhaseFinished(string, time.Time, time.Time) {}
+func (NoopImageFetchingDelegate) ImageFetchTimedOut(string, time.Duration)             {}
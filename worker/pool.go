@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -27,6 +28,8 @@ type WorkerProvider interface {
 var (
 	ErrNoWorkers     = errors.New("no workers")
 	ErrMissingWorker = errors.New("worker for container is missing")
+	ErrWorkersFull   = errors.New("all compatible workers are running their maximum number of containers")
+	ErrInterrupted   = errors.New("interrupted")
 )
 
 type NoCompatibleWorkersError struct {
@@ -49,14 +52,28 @@ func (err NoCompatibleWorkersError) Error() string {
 
 type pool struct {
 	provider WorkerProvider
+	clock    clock.Clock
+
+	baseResourceTypeVersions atc.BaseResourceTypeVersions
+	capacityPollingInterval  time.Duration
 
 	rand *rand.Rand
 }
 
-func NewPool(provider WorkerProvider) Client {
+func NewPool(
+	provider WorkerProvider,
+	clock clock.Clock,
+	baseResourceTypeVersions atc.BaseResourceTypeVersions,
+	capacityPollingInterval time.Duration,
+) Client {
 	return &pool{
 		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:    clock,
+
+		baseResourceTypeVersions: baseResourceTypeVersions,
+		capacityPollingInterval:  capacityPollingInterval,
+
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
@@ -96,9 +113,24 @@ func (pool *pool) AllSatisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes
 
 	compatibleTeamWorkers := []Worker{}
 	compatibleGeneralWorkers := []Worker{}
+	fullWorkers := 0
 	for _, worker := range workers {
+		if !pool.providesRequiredVersion(spec, resourceTypes, worker) {
+			continue
+		}
+
 		satisfyingWorker, err := worker.Satisfying(spec, resourceTypes)
 		if err == nil {
+			full, err := isFull(satisfyingWorker)
+			if err != nil {
+				return nil, err
+			}
+
+			if full {
+				fullWorkers++
+				continue
+			}
+
 			if worker.IsOwnedByTeam() {
 				compatibleTeamWorkers = append(compatibleTeamWorkers, satisfyingWorker)
 			} else {
@@ -117,12 +149,29 @@ func (pool *pool) AllSatisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes
 		return compatibleGeneralWorkers, nil
 	}
 
+	if fullWorkers != 0 {
+		return nil, ErrWorkersFull
+	}
+
 	return nil, NoCompatibleWorkersError{
 		Spec:    spec,
 		Workers: workers,
 	}
 }
 
+func isFull(worker Worker) (bool, error) {
+	if worker.MaxContainers() == 0 {
+		return false, nil
+	}
+
+	activeContainers, err := worker.ActiveContainers()
+	if err != nil {
+		return false, err
+	}
+
+	return activeContainers >= worker.MaxContainers(), nil
+}
+
 func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, error) {
 	compatibleWorkers, err := pool.AllSatisfying(spec, resourceTypes)
 	if err != nil {
@@ -132,6 +181,53 @@ func (pool *pool) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (
 	return randomWorker, nil
 }
 
+// AwaitAllSatisfying returns the same workers as AllSatisfying. If every
+// compatible worker is full, it tells the delegate it is waiting and polls
+// until one of them has room, or until it is signalled.
+func (pool *pool) AwaitAllSatisfying(
+	logger lager.Logger,
+	signals <-chan os.Signal,
+	delegate ImageFetchingDelegate,
+	spec WorkerSpec,
+	resourceTypes atc.ResourceTypes,
+) ([]Worker, error) {
+	waiting := false
+
+	for {
+		workers, err := pool.AllSatisfying(spec, resourceTypes)
+		if err != ErrWorkersFull {
+			return workers, err
+		}
+
+		if !waiting {
+			logger.Info("waiting-for-worker")
+			delegate.WaitingForWorker()
+			waiting = true
+		}
+
+		select {
+		case <-signals:
+			return nil, ErrInterrupted
+		case <-pool.clock.After(pool.capacityPollingInterval):
+		}
+	}
+}
+
+func (pool *pool) awaitSatisfying(
+	logger lager.Logger,
+	signals <-chan os.Signal,
+	delegate ImageFetchingDelegate,
+	spec WorkerSpec,
+	resourceTypes atc.ResourceTypes,
+) (Worker, error) {
+	compatibleWorkers, err := pool.AwaitAllSatisfying(logger, signals, delegate, spec, resourceTypes)
+	if err != nil {
+		return nil, err
+	}
+	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
+	return randomWorker, nil
+}
+
 func (pool *pool) FindOrCreateBuildContainer(logger lager.Logger, signals <-chan os.Signal, delegate ImageFetchingDelegate, id Identifier, metadata Metadata, spec ContainerSpec, resourceTypes atc.ResourceTypes, outputPaths map[string]string) (Container, error) {
 	container, found, err := pool.FindContainerForIdentifier(logger, id)
 	if err != nil {
@@ -141,7 +237,7 @@ func (pool *pool) FindOrCreateBuildContainer(logger lager.Logger, signals <-chan
 		return container, nil
 	}
 
-	worker, err := pool.Satisfying(spec.WorkerSpec(), resourceTypes)
+	worker, err := pool.awaitSatisfying(logger, signals, delegate, spec.WorkerSpec(), resourceTypes)
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +302,7 @@ func (pool *pool) FindOrCreateResourceGetContainer(
 		return container, nil
 	}
 
-	worker, err := pool.Satisfying(spec.WorkerSpec(), resourceTypes)
+	worker, err := pool.awaitSatisfying(logger, cancel, delegate, spec.WorkerSpec(), resourceTypes)
 	if err != nil {
 		return nil, err
 	}
@@ -479,3 +575,26 @@ func (pool *pool) findCompatibleWorker(
 func resourcesDir(suffix string) string {
 	return filepath.Join("/tmp", "build", suffix)
 }
+
+// providesRequiredVersion returns true if the worker provides the spec's base
+// resource type at the version required by the ATC, or if no version is
+// required for it. Drifted workers are never chosen, as their output would
+// otherwise be cached under the required version.
+func (pool *pool) providesRequiredVersion(spec WorkerSpec, resourceTypes atc.ResourceTypes, worker Worker) bool {
+	if spec.ResourceType == "" {
+		return true
+	}
+
+	baseType := determineUnderlyingTypeName(spec.ResourceType, resourceTypes)
+	if _, found := pool.baseResourceTypeVersions[baseType]; !found {
+		return true
+	}
+
+	for _, resourceType := range worker.ResourceTypes() {
+		if resourceType.Type == baseType && pool.baseResourceTypeVersions.Satisfied(resourceType) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,7 +2,10 @@ package worker_test
 
 import (
 	"errors"
+	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
@@ -17,6 +20,7 @@ var _ = Describe("Pool", func() {
 	var (
 		logger       *lagertest.TestLogger
 		fakeProvider *workerfakes.FakeWorkerProvider
+		fakeClock    *fakeclock.FakeClock
 
 		pool Client
 	)
@@ -25,7 +29,10 @@ var _ = Describe("Pool", func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		pool = NewPool(fakeProvider, fakeClock, atc.BaseResourceTypeVersions{
+			"some-underlying-type": "required-version",
+		}, 5*time.Second)
 	})
 
 	Describe("GetWorker", func() {
@@ -268,6 +275,39 @@ var _ = Describe("Pool", func() {
 				Expect(firstCount[workerA]).To(BeNumerically("~", firstCount[workerB], 50))
 			})
 
+			Context("when the spec's base resource type has a required version", func() {
+				BeforeEach(func() {
+					spec.ResourceType = "some-resource-type"
+
+					workerA.ResourceTypesReturns([]atc.WorkerResourceType{
+						{Type: "some-underlying-type", Version: "drifted-version"},
+					})
+					workerB.ResourceTypesReturns([]atc.WorkerResourceType{
+						{Type: "some-underlying-type", Version: "required-version"},
+					})
+				})
+
+				It("only returns the workers providing the required version", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorkers).To(ConsistOf(workerB))
+				})
+
+				Context("when no worker provides the required version", func() {
+					BeforeEach(func() {
+						workerB.ResourceTypesReturns([]atc.WorkerResourceType{
+							{Type: "some-underlying-type", Version: "other-drifted-version"},
+						})
+					})
+
+					It("returns a NoCompatibleWorkersError", func() {
+						Expect(satisfyingErr).To(Equal(NoCompatibleWorkersError{
+							Spec:    spec,
+							Workers: []Worker{workerA, workerB, workerC},
+						}))
+					})
+				})
+			})
+
 			Context("when no workers satisfy the spec", func() {
 				BeforeEach(func() {
 					workerA.SatisfyingReturns(nil, errors.New("nope"))
@@ -337,6 +377,76 @@ var _ = Describe("Pool", func() {
 			})
 		})
 
+		Context("when some compatible workers are full", func() {
+			var (
+				fullWorker      *workerfakes.FakeWorker
+				availableWorker *workerfakes.FakeWorker
+				unlimitedWorker *workerfakes.FakeWorker
+			)
+
+			BeforeEach(func() {
+				fullWorker = new(workerfakes.FakeWorker)
+				fullWorker.SatisfyingReturns(fullWorker, nil)
+				fullWorker.MaxContainersReturns(2)
+				fullWorker.ActiveContainersReturns(2, nil)
+
+				availableWorker = new(workerfakes.FakeWorker)
+				availableWorker.SatisfyingReturns(availableWorker, nil)
+				availableWorker.MaxContainersReturns(2)
+				availableWorker.ActiveContainersReturns(1, nil)
+
+				unlimitedWorker = new(workerfakes.FakeWorker)
+				unlimitedWorker.SatisfyingReturns(unlimitedWorker, nil)
+				unlimitedWorker.ActiveContainersReturns(1000, nil)
+
+				fakeProvider.RunningWorkersReturns([]Worker{fullWorker, availableWorker, unlimitedWorker}, nil)
+			})
+
+			It("returns only the workers with capacity", func() {
+				Expect(satisfyingErr).NotTo(HaveOccurred())
+				Expect(satisfyingWorkers).To(ConsistOf(availableWorker, unlimitedWorker))
+			})
+
+			It("doesn't count the containers of workers without a limit", func() {
+				Expect(unlimitedWorker.ActiveContainersCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when counting a limited worker's containers fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				limitedWorker := new(workerfakes.FakeWorker)
+				limitedWorker.SatisfyingReturns(limitedWorker, nil)
+				limitedWorker.MaxContainersReturns(2)
+				limitedWorker.ActiveContainersReturns(0, disaster)
+
+				fakeProvider.RunningWorkersReturns([]Worker{limitedWorker}, nil)
+			})
+
+			It("returns the error", func() {
+				Expect(satisfyingErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when every compatible worker is full", func() {
+			BeforeEach(func() {
+				fullWorker := new(workerfakes.FakeWorker)
+				fullWorker.SatisfyingReturns(fullWorker, nil)
+				fullWorker.MaxContainersReturns(2)
+				fullWorker.ActiveContainersReturns(3, nil)
+
+				incompatibleWorker := new(workerfakes.FakeWorker)
+				incompatibleWorker.SatisfyingReturns(nil, errors.New("nope"))
+
+				fakeProvider.RunningWorkersReturns([]Worker{fullWorker, incompatibleWorker}, nil)
+			})
+
+			It("returns ErrWorkersFull", func() {
+				Expect(satisfyingErr).To(Equal(ErrWorkersFull))
+			})
+		})
+
 		Context("with no workers", func() {
 			BeforeEach(func() {
 				fakeProvider.RunningWorkersReturns([]Worker{}, nil)
@@ -360,6 +470,96 @@ var _ = Describe("Pool", func() {
 		})
 	})
 
+	Describe("AwaitAllSatisfying", func() {
+		var (
+			spec         WorkerSpec
+			fakeDelegate *workerfakes.FakeImageFetchingDelegate
+			signals      chan os.Signal
+
+			fullWorker *workerfakes.FakeWorker
+
+			awaitWorkers chan []Worker
+			awaitErr     chan error
+		)
+
+		BeforeEach(func() {
+			spec = WorkerSpec{Platform: "some-platform"}
+			fakeDelegate = new(workerfakes.FakeImageFetchingDelegate)
+			signals = make(chan os.Signal, 1)
+
+			fullWorker = new(workerfakes.FakeWorker)
+			fullWorker.SatisfyingReturns(fullWorker, nil)
+			fullWorker.MaxContainersReturns(1)
+			fullWorker.ActiveContainersReturns(1, nil)
+
+			fakeProvider.RunningWorkersReturns([]Worker{fullWorker}, nil)
+
+			awaitWorkers = make(chan []Worker, 1)
+			awaitErr = make(chan error, 1)
+		})
+
+		JustBeforeEach(func() {
+			go func() {
+				workers, err := pool.AwaitAllSatisfying(logger, signals, fakeDelegate, spec, atc.ResourceTypes{})
+				awaitWorkers <- workers
+				awaitErr <- err
+			}()
+		})
+
+		Context("when a compatible worker has capacity", func() {
+			BeforeEach(func() {
+				fullWorker.ActiveContainersReturns(0, nil)
+			})
+
+			It("returns immediately without notifying the delegate", func() {
+				Eventually(awaitWorkers).Should(Receive(ConsistOf(fullWorker)))
+				Expect(<-awaitErr).NotTo(HaveOccurred())
+				Expect(fakeDelegate.WaitingForWorkerCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when every compatible worker is full", func() {
+			It("notifies the delegate once", func() {
+				Eventually(fakeDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+
+				fakeClock.WaitForWatcherAndIncrement(5 * time.Second)
+				fakeClock.WaitForWatcherAndIncrement(5 * time.Second)
+
+				Consistently(fakeDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+			})
+
+			It("returns the workers once capacity frees up", func() {
+				fakeClock.WaitForWatcherAndIncrement(5 * time.Second)
+				Consistently(awaitWorkers).ShouldNot(Receive())
+
+				fullWorker.ActiveContainersReturns(0, nil)
+				fakeClock.WaitForWatcherAndIncrement(5 * time.Second)
+
+				Eventually(awaitWorkers).Should(Receive(ConsistOf(fullWorker)))
+				Expect(<-awaitErr).NotTo(HaveOccurred())
+			})
+
+			It("returns ErrInterrupted when signalled", func() {
+				Eventually(fakeDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+
+				signals <- os.Interrupt
+
+				Eventually(awaitErr).Should(Receive(Equal(ErrInterrupted)))
+			})
+		})
+
+		Context("when no workers satisfy the spec", func() {
+			BeforeEach(func() {
+				fullWorker.SatisfyingReturns(nil, errors.New("nope"))
+			})
+
+			It("fails without waiting", func() {
+				Eventually(awaitErr).Should(Receive(BeAssignableToTypeOf(NoCompatibleWorkersError{})))
+				Expect(fakeDelegate.WaitingForWorkerCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("CreateContainer", func() {
 		var (
 			fakeImageFetchingDelegate *workerfakes.FakeImageFetchingDelegate
@@ -425,8 +625,8 @@ var _ = Describe("Pool", func() {
 				workerB = new(workerfakes.FakeWorker)
 				workerC = new(workerfakes.FakeWorker)
 
-				workerA.ActiveContainersReturns(3)
-				workerB.ActiveContainersReturns(2)
+				workerA.ActiveContainersReturns(3, nil)
+				workerB.ActiveContainersReturns(2, nil)
 
 				workerA.SatisfyingReturns(workerA, nil)
 				workerB.SatisfyingReturns(workerB, nil)
@@ -33,6 +33,14 @@ type VolumeClient interface {
 		int,
 		string,
 	) (Volume, error)
+	FindOrCreateVolumeForTaskCache(
+		lager.Logger,
+		VolumeSpec,
+		dbng.CreatingContainer,
+		int,
+		dbng.WorkerTaskCache,
+		string,
+	) (Volume, error)
 	FindInitializedVolumeForResourceCache(
 		lager.Logger,
 		*dbng.UsedResourceCache,
@@ -49,6 +57,7 @@ type volumeClient struct {
 	db                        GardenWorkerDB
 	dbVolumeFactory           dbng.VolumeFactory
 	dbBaseResourceTypeFactory dbng.BaseResourceTypeFactory
+	dbWorkerTaskCacheFactory  dbng.WorkerTaskCacheFactory
 	clock                     clock.Clock
 	dbWorker                  *dbng.Worker
 }
@@ -58,6 +67,7 @@ func NewVolumeClient(
 	db GardenWorkerDB,
 	dbVolumeFactory dbng.VolumeFactory,
 	dbBaseResourceTypeFactory dbng.BaseResourceTypeFactory,
+	dbWorkerTaskCacheFactory dbng.WorkerTaskCacheFactory,
 	clock clock.Clock,
 	dbWorker *dbng.Worker,
 ) VolumeClient {
@@ -66,6 +76,7 @@ func NewVolumeClient(
 		db:                        db,
 		dbVolumeFactory:           dbVolumeFactory,
 		dbBaseResourceTypeFactory: dbBaseResourceTypeFactory,
+		dbWorkerTaskCacheFactory:  dbWorkerTaskCacheFactory,
 		clock:    clock,
 		dbWorker: dbWorker,
 	}
@@ -158,6 +169,54 @@ func (c *volumeClient) FindOrCreateVolumeForResourceCache(
 	)
 }
 
+// FindOrCreateVolumeForTaskCache returns the task cache's volume if the
+// container can claim it. While another build of the step is using the cache,
+// the container gets an empty volume of its own instead.
+func (c *volumeClient) FindOrCreateVolumeForTaskCache(
+	logger lager.Logger,
+	volumeSpec VolumeSpec,
+	container dbng.CreatingContainer,
+	teamID int,
+	workerTaskCache dbng.WorkerTaskCache,
+	mountPath string,
+) (Volume, error) {
+	workerTaskCache.WorkerName = c.dbWorker.Name
+
+	usedWorkerTaskCache, err := c.dbWorkerTaskCacheFactory.FindOrCreate(workerTaskCache)
+	if err != nil {
+		logger.Error("failed-to-find-or-create-task-cache-in-db", err)
+		return nil, err
+	}
+
+	claimed, err := c.dbWorkerTaskCacheFactory.Claim(usedWorkerTaskCache, container)
+	if err != nil {
+		logger.Error("failed-to-claim-task-cache", err)
+		return nil, err
+	}
+
+	if !claimed {
+		logger.Info("task-cache-in-use", lager.Data{"path": workerTaskCache.Path})
+		return c.FindOrCreateVolumeForContainer(logger, volumeSpec, container, teamID, mountPath)
+	}
+
+	return c.findOrCreateVolume(
+		logger,
+		volumeSpec,
+		func() (dbng.CreatingVolume, dbng.CreatedVolume, error) {
+			return c.dbVolumeFactory.FindWorkerTaskCacheVolume(teamID, c.dbWorker, usedWorkerTaskCache)
+		},
+		func() (dbng.CreatingVolume, error) {
+			v, err := c.dbVolumeFactory.CreateWorkerTaskCacheVolume(teamID, c.dbWorker, usedWorkerTaskCache)
+			if err != nil {
+				return nil, err
+			}
+
+			logger.Debug("created-volume-for-task-cache", lager.Data{"handle": v.Handle()})
+			return v, nil
+		},
+	)
+}
+
 func (c *volumeClient) FindInitializedVolumeForResourceCache(
 	logger lager.Logger,
 	usedResourceCache *dbng.UsedResourceCache,
@@ -29,6 +29,7 @@ var _ = Describe("VolumeClient", func() {
 		fakeGardenWorkerDB          *workerfakes.FakeGardenWorkerDB
 		fakeDBVolumeFactory         *dbngfakes.FakeVolumeFactory
 		fakeBaseResourceTypeFactory *dbngfakes.FakeBaseResourceTypeFactory
+		fakeWorkerTaskCacheFactory  *dbngfakes.FakeWorkerTaskCacheFactory
 		fakeClock                   *fakeclock.FakeClock
 		dbWorker                    *dbng.Worker
 
@@ -45,6 +46,7 @@ var _ = Describe("VolumeClient", func() {
 
 		fakeDBVolumeFactory = new(dbngfakes.FakeVolumeFactory)
 		fakeBaseResourceTypeFactory = new(dbngfakes.FakeBaseResourceTypeFactory)
+		fakeWorkerTaskCacheFactory = new(dbngfakes.FakeWorkerTaskCacheFactory)
 		fakeLock = new(lockfakes.FakeLock)
 
 		volumeClient = worker.NewVolumeClient(
@@ -52,6 +54,7 @@ var _ = Describe("VolumeClient", func() {
 			fakeGardenWorkerDB,
 			fakeDBVolumeFactory,
 			fakeBaseResourceTypeFactory,
+			fakeWorkerTaskCacheFactory,
 			fakeClock,
 			dbWorker,
 		)
@@ -417,6 +420,158 @@ var _ = Describe("VolumeClient", func() {
 		})
 	})
 
+	Describe("FindOrCreateVolumeForTaskCache", func() {
+		var foundOrCreatedVolume worker.Volume
+		var foundOrCreatedErr error
+
+		var fakeBaggageclaimVolume *baggageclaimfakes.FakeVolume
+		var fakeCreatingVolume *dbngfakes.FakeCreatingVolume
+		var fakeCreatingContainer *dbngfakes.FakeCreatingContainer
+		var usedWorkerTaskCache *dbng.UsedWorkerTaskCache
+
+		BeforeEach(func() {
+			fakeBaggageclaimVolume = new(baggageclaimfakes.FakeVolume)
+			fakeBaggageclaimVolume.HandleReturns("created-volume")
+
+			fakeBaggageclaimClient.CreateVolumeReturns(fakeBaggageclaimVolume, nil)
+
+			fakeCreatingVolume = new(dbngfakes.FakeCreatingVolume)
+			fakeCreatingContainer = new(dbngfakes.FakeCreatingContainer)
+
+			usedWorkerTaskCache = &dbng.UsedWorkerTaskCache{ID: 42, WorkerName: "some-worker"}
+			fakeWorkerTaskCacheFactory.FindOrCreateReturns(usedWorkerTaskCache, nil)
+			fakeWorkerTaskCacheFactory.ClaimReturns(true, nil)
+		})
+
+		JustBeforeEach(func() {
+			foundOrCreatedVolume, foundOrCreatedErr = volumeClient.FindOrCreateVolumeForTaskCache(
+				testLogger,
+				worker.VolumeSpec{
+					Strategy: worker.TaskCacheStrategy{},
+				},
+				fakeCreatingContainer,
+				42,
+				dbng.WorkerTaskCache{
+					PipelineID: 1,
+					JobName:    "some-job",
+					StepName:   "some-step",
+					Path:       "some-cache",
+				},
+				"some-mount-path",
+			)
+		})
+
+		It("finds or creates the task cache on the worker", func() {
+			Expect(fakeWorkerTaskCacheFactory.FindOrCreateCallCount()).To(Equal(1))
+			Expect(fakeWorkerTaskCacheFactory.FindOrCreateArgsForCall(0)).To(Equal(dbng.WorkerTaskCache{
+				WorkerName: "some-worker",
+				PipelineID: 1,
+				JobName:    "some-job",
+				StepName:   "some-step",
+				Path:       "some-cache",
+			}))
+		})
+
+		Context("when finding or creating the task cache fails", func() {
+			var disaster = errors.New("disaster")
+
+			BeforeEach(func() {
+				fakeWorkerTaskCacheFactory.FindOrCreateReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(foundOrCreatedErr).To(Equal(disaster))
+			})
+		})
+
+		It("claims the task cache for the container", func() {
+			Expect(fakeWorkerTaskCacheFactory.ClaimCallCount()).To(Equal(1))
+			cache, container := fakeWorkerTaskCacheFactory.ClaimArgsForCall(0)
+			Expect(cache).To(Equal(usedWorkerTaskCache))
+			Expect(container).To(Equal(fakeCreatingContainer))
+		})
+
+		Context("when claiming the task cache fails", func() {
+			var disaster = errors.New("disaster")
+
+			BeforeEach(func() {
+				fakeWorkerTaskCacheFactory.ClaimReturns(false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(foundOrCreatedErr).To(Equal(disaster))
+			})
+		})
+
+		Context("when the task cache is in use by another build", func() {
+			var fakeCreatedVolume *dbngfakes.FakeCreatedVolume
+
+			BeforeEach(func() {
+				fakeWorkerTaskCacheFactory.ClaimReturns(false, nil)
+
+				fakeCreatedVolume = new(dbngfakes.FakeCreatedVolume)
+				fakeDBVolumeFactory.FindContainerVolumeReturns(nil, nil, nil)
+				fakeDBVolumeFactory.CreateContainerVolumeReturns(fakeCreatingVolume, nil)
+				fakeGardenWorkerDB.AcquireVolumeCreatingLockReturns(fakeLock, true, nil)
+				fakeCreatingVolume.CreatedReturns(fakeCreatedVolume, nil)
+			})
+
+			It("creates a volume for the container instead", func() {
+				Expect(foundOrCreatedErr).NotTo(HaveOccurred())
+				Expect(fakeDBVolumeFactory.FindWorkerTaskCacheVolumeCallCount()).To(Equal(0))
+				Expect(fakeDBVolumeFactory.CreateContainerVolumeCallCount()).To(Equal(1))
+				teamID, actualWorker, container, mountPath := fakeDBVolumeFactory.CreateContainerVolumeArgsForCall(0)
+				Expect(teamID).To(Equal(42))
+				Expect(actualWorker).To(Equal(dbWorker))
+				Expect(container).To(Equal(fakeCreatingContainer))
+				Expect(mountPath).To(Equal("some-mount-path"))
+			})
+		})
+
+		Context("when the volume exists in created state", func() {
+			var fakeCreatedVolume *dbngfakes.FakeCreatedVolume
+
+			BeforeEach(func() {
+				fakeCreatedVolume = new(dbngfakes.FakeCreatedVolume)
+				fakeDBVolumeFactory.FindWorkerTaskCacheVolumeReturns(nil, fakeCreatedVolume, nil)
+				fakeBaggageclaimClient.LookupVolumeReturns(fakeBaggageclaimVolume, true, nil)
+			})
+
+			It("reuses the volume", func() {
+				Expect(foundOrCreatedErr).NotTo(HaveOccurred())
+				Expect(foundOrCreatedVolume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume)))
+				Expect(fakeDBVolumeFactory.CreateWorkerTaskCacheVolumeCallCount()).To(Equal(0))
+				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the volume does not exist in db", func() {
+			var fakeCreatedVolume *dbngfakes.FakeCreatedVolume
+
+			BeforeEach(func() {
+				fakeCreatedVolume = new(dbngfakes.FakeCreatedVolume)
+				fakeDBVolumeFactory.FindWorkerTaskCacheVolumeReturns(nil, nil, nil)
+				fakeDBVolumeFactory.CreateWorkerTaskCacheVolumeReturns(fakeCreatingVolume, nil)
+				fakeGardenWorkerDB.AcquireVolumeCreatingLockReturns(fakeLock, true, nil)
+				fakeCreatingVolume.CreatedReturns(fakeCreatedVolume, nil)
+			})
+
+			It("creates the volume for the task cache", func() {
+				Expect(fakeDBVolumeFactory.CreateWorkerTaskCacheVolumeCallCount()).To(Equal(1))
+				teamID, actualWorker, actualTaskCache := fakeDBVolumeFactory.CreateWorkerTaskCacheVolumeArgsForCall(0)
+				Expect(teamID).To(Equal(42))
+				Expect(actualWorker).To(Equal(dbWorker))
+				Expect(actualTaskCache).To(Equal(usedWorkerTaskCache))
+			})
+
+			It("creates volume in baggageclaim", func() {
+				Expect(foundOrCreatedErr).NotTo(HaveOccurred())
+				Expect(foundOrCreatedVolume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume)))
+				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(1))
+			})
+		})
+	})
+
 	Describe("LookupVolume", func() {
 		var handle string
 
@@ -436,6 +591,7 @@ var _ = Describe("VolumeClient", func() {
 				fakeGardenWorkerDB,
 				fakeDBVolumeFactory,
 				fakeBaseResourceTypeFactory,
+				fakeWorkerTaskCacheFactory,
 				fakeClock,
 				dbWorker,
 			).LookupVolume(testLogger, handle)
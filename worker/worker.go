@@ -43,7 +43,10 @@ const ImageMetadataFile = "metadata.json"
 type Worker interface {
 	Client
 
-	ActiveContainers() int
+	// ActiveContainers counts the worker's containers at the time it's called,
+	// rather than when the worker last heartbeated.
+	ActiveContainers() (int, error)
+	MaxContainers() int
 
 	Description() string
 	Name() string
@@ -52,6 +55,7 @@ type Worker interface {
 	Tags() atc.Tags
 	Uptime() time.Duration
 	IsOwnedByTeam() bool
+	IsDraining() bool
 }
 
 //go:generate counterfeiter . GardenWorkerDB
@@ -64,6 +68,7 @@ type GardenWorkerDB interface {
 	GetPipelineByID(pipelineID int) (db.SavedPipeline, error)
 	AcquireVolumeCreatingLock(lager.Logger, int) (lock.Lock, bool, error)
 	AcquireContainerCreatingLock(lager.Logger, int) (lock.Lock, bool, error)
+	CountContainersOnWorker(workerName string) (int, error)
 }
 
 type gardenWorker struct {
@@ -77,14 +82,15 @@ type gardenWorker struct {
 
 	clock clock.Clock
 
-	activeContainers int
-	resourceTypes    []atc.WorkerResourceType
-	platform         string
-	tags             atc.Tags
-	teamID           int
-	name             string
-	addr             string
-	startTime        int64
+	maxContainers int
+	resourceTypes []atc.WorkerResourceType
+	platform      string
+	tags          atc.Tags
+	teamID        int
+	name          string
+	addr          string
+	startTime     int64
+	state         dbng.WorkerState
 }
 
 func NewGardenWorker(
@@ -94,7 +100,7 @@ func NewGardenWorker(
 	db GardenWorkerDB,
 	provider WorkerProvider,
 	clock clock.Clock,
-	activeContainers int,
+	maxContainers int,
 	resourceTypes []atc.WorkerResourceType,
 	platform string,
 	tags atc.Tags,
@@ -102,6 +108,7 @@ func NewGardenWorker(
 	name string,
 	addr string,
 	startTime int64,
+	state dbng.WorkerState,
 ) Worker {
 	return &gardenWorker{
 		containerProviderFactory: containerProviderFactory,
@@ -112,7 +119,7 @@ func NewGardenWorker(
 		provider:          provider,
 		clock:             clock,
 		pipelineDBFactory: pipelineDBFactory,
-		activeContainers:  activeContainers,
+		maxContainers:     maxContainers,
 		resourceTypes:     resourceTypes,
 		platform:          platform,
 		tags:              tags,
@@ -120,6 +127,7 @@ func NewGardenWorker(
 		name:              name,
 		addr:              addr,
 		startTime:         startTime,
+		state:             state,
 	}
 }
 
@@ -361,8 +369,12 @@ func (worker *gardenWorker) FindContainerByHandle(logger lager.Logger, handle st
 	return containerProvider.FindContainerByHandle(logger, handle, teamID)
 }
 
-func (worker *gardenWorker) ActiveContainers() int {
-	return worker.activeContainers
+func (worker *gardenWorker) ActiveContainers() (int, error) {
+	return worker.db.CountContainersOnWorker(worker.name)
+}
+
+func (worker *gardenWorker) MaxContainers() int {
+	return worker.maxContainers
 }
 
 func (worker *gardenWorker) Satisfying(spec WorkerSpec, resourceTypes atc.ResourceTypes) (Worker, error) {
@@ -418,6 +430,10 @@ func (worker *gardenWorker) AllSatisfying(spec WorkerSpec, resourceTypes atc.Res
 	return nil, ErrNotImplemented
 }
 
+func (worker *gardenWorker) AwaitAllSatisfying(lager.Logger, <-chan os.Signal, ImageFetchingDelegate, WorkerSpec, atc.ResourceTypes) ([]Worker, error) {
+	return nil, ErrNotImplemented
+}
+
 func (worker *gardenWorker) RunningWorkers() ([]Worker, error) {
 	return nil, ErrNotImplemented
 }
@@ -458,6 +474,13 @@ func (worker *gardenWorker) IsOwnedByTeam() bool {
 	return worker.teamID != 0
 }
 
+// IsDraining returns true if the worker is landing or retiring. Draining
+// workers keep running the containers they have, but no new ones should be
+// placed on them.
+func (worker *gardenWorker) IsDraining() bool {
+	return worker.state == dbng.WorkerStateLanding || worker.state == dbng.WorkerStateRetiring
+}
+
 func (worker *gardenWorker) Uptime() time.Duration {
 	return worker.clock.Since(time.Unix(worker.startTime, 0))
 }
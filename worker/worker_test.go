@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/dbfakes"
+	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/concourse/atc/worker"
 	wfakes "github.com/concourse/atc/worker/workerfakes"
@@ -32,13 +33,14 @@ var _ = Describe("Worker", func() {
 		fakeResourceConfigFactory    *dbngfakes.FakeResourceConfigFactory
 		fakeContainerProviderFactory *wfakes.FakeContainerProviderFactory
 		fakeContainerProvider        *wfakes.FakeContainerProvider
-		activeContainers             int
+		maxContainers                int
 		resourceTypes                []atc.WorkerResourceType
 		platform                     string
 		tags                         atc.Tags
 		teamID                       int
 		workerName                   string
 		workerStartTime              int64
+		workerState                  dbng.WorkerState
 		workerUptime                 uint64
 		gardenWorker                 Worker
 	)
@@ -50,7 +52,7 @@ var _ = Describe("Worker", func() {
 		fakeGardenWorkerDB = new(wfakes.FakeGardenWorkerDB)
 		fakePipelineDBFactory = new(dbfakes.FakePipelineDBFactory)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
-		activeContainers = 42
+		maxContainers = 250
 		resourceTypes = []atc.WorkerResourceType{
 			{
 				Type:    "some-resource",
@@ -63,6 +65,7 @@ var _ = Describe("Worker", func() {
 		teamID = 17
 		workerName = "some-worker"
 		workerStartTime = fakeClock.Now().Unix()
+		workerState = dbng.WorkerStateRunning
 		workerUptime = 0
 
 		fakeDBResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
@@ -82,7 +85,7 @@ var _ = Describe("Worker", func() {
 			fakeGardenWorkerDB,
 			fakeWorkerProvider,
 			fakeClock,
-			activeContainers,
+			maxContainers,
 			resourceTypes,
 			platform,
 			tags,
@@ -90,11 +93,53 @@ var _ = Describe("Worker", func() {
 			workerName,
 			"1.2.3.4",
 			workerStartTime,
+			workerState,
 		)
 
 		fakeClock.IncrementBySeconds(workerUptime)
 	})
 
+	Describe("IsDraining", func() {
+		It("is false for a running worker", func() {
+			Expect(gardenWorker.IsDraining()).To(BeFalse())
+		})
+
+		Context("when the worker is landing", func() {
+			BeforeEach(func() {
+				workerState = dbng.WorkerStateLanding
+			})
+
+			It("is true", func() {
+				Expect(gardenWorker.IsDraining()).To(BeTrue())
+			})
+		})
+
+		Context("when the worker is retiring", func() {
+			BeforeEach(func() {
+				workerState = dbng.WorkerStateRetiring
+			})
+
+			It("is true", func() {
+				Expect(gardenWorker.IsDraining()).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("ActiveContainers", func() {
+		BeforeEach(func() {
+			fakeGardenWorkerDB.CountContainersOnWorkerReturns(42, nil)
+		})
+
+		It("counts the worker's containers when asked", func() {
+			activeContainers, err := gardenWorker.ActiveContainers()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(activeContainers).To(Equal(42))
+
+			Expect(fakeGardenWorkerDB.CountContainersOnWorkerCallCount()).To(Equal(1))
+			Expect(fakeGardenWorkerDB.CountContainersOnWorkerArgsForCall(0)).To(Equal(workerName))
+		})
+	})
+
 	Describe("FindContainerByHandle", func() {
 		var (
 			handle            string
@@ -209,6 +209,19 @@ type FakeClient struct {
 		result1 worker.Worker
 		result2 error
 	}
+	AwaitAllSatisfyingStub        func(lager.Logger, <-chan os.Signal, worker.ImageFetchingDelegate, worker.WorkerSpec, atc.ResourceTypes) ([]worker.Worker, error)
+	awaitAllSatisfyingMutex       sync.RWMutex
+	awaitAllSatisfyingArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 <-chan os.Signal
+		arg3 worker.ImageFetchingDelegate
+		arg4 worker.WorkerSpec
+		arg5 atc.ResourceTypes
+	}
+	awaitAllSatisfyingReturns struct {
+		result1 []worker.Worker
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -803,6 +816,44 @@ func (fake *FakeClient) GetWorkerReturns(result1 worker.Worker, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeClient) AwaitAllSatisfying(arg1 lager.Logger, arg2 <-chan os.Signal, arg3 worker.ImageFetchingDelegate, arg4 worker.WorkerSpec, arg5 atc.ResourceTypes) ([]worker.Worker, error) {
+	fake.awaitAllSatisfyingMutex.Lock()
+	fake.awaitAllSatisfyingArgsForCall = append(fake.awaitAllSatisfyingArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 <-chan os.Signal
+		arg3 worker.ImageFetchingDelegate
+		arg4 worker.WorkerSpec
+		arg5 atc.ResourceTypes
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("AwaitAllSatisfying", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.awaitAllSatisfyingMutex.Unlock()
+	if fake.AwaitAllSatisfyingStub != nil {
+		return fake.AwaitAllSatisfyingStub(arg1, arg2, arg3, arg4, arg5)
+	} else {
+		return fake.awaitAllSatisfyingReturns.result1, fake.awaitAllSatisfyingReturns.result2
+	}
+}
+
+func (fake *FakeClient) AwaitAllSatisfyingCallCount() int {
+	fake.awaitAllSatisfyingMutex.RLock()
+	defer fake.awaitAllSatisfyingMutex.RUnlock()
+	return len(fake.awaitAllSatisfyingArgsForCall)
+}
+
+func (fake *FakeClient) AwaitAllSatisfyingArgsForCall(i int) (lager.Logger, <-chan os.Signal, worker.ImageFetchingDelegate, worker.WorkerSpec, atc.ResourceTypes) {
+	fake.awaitAllSatisfyingMutex.RLock()
+	defer fake.awaitAllSatisfyingMutex.RUnlock()
+	return fake.awaitAllSatisfyingArgsForCall[i].arg1, fake.awaitAllSatisfyingArgsForCall[i].arg2, fake.awaitAllSatisfyingArgsForCall[i].arg3, fake.awaitAllSatisfyingArgsForCall[i].arg4, fake.awaitAllSatisfyingArgsForCall[i].arg5
+}
+
+func (fake *FakeClient) AwaitAllSatisfyingReturns(result1 []worker.Worker, result2 error) {
+	fake.AwaitAllSatisfyingStub = nil
+	fake.awaitAllSatisfyingReturns = struct {
+		result1 []worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -838,6 +889,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.runningWorkersMutex.RUnlock()
 	fake.getWorkerMutex.RLock()
 	defer fake.getWorkerMutex.RUnlock()
+	fake.awaitAllSatisfyingMutex.RLock()
+	defer fake.awaitAllSatisfyingMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -82,6 +82,15 @@ type FakeGardenWorkerDB struct {
 		result2 bool
 		result3 error
 	}
+	CountContainersOnWorkerStub        func(workerName string) (int, error)
+	countContainersOnWorkerMutex       sync.RWMutex
+	countContainersOnWorkerArgsForCall []struct {
+		workerName string
+	}
+	countContainersOnWorkerReturns struct {
+		result1 int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -336,6 +345,40 @@ func (fake *FakeGardenWorkerDB) AcquireContainerCreatingLockReturns(result1 lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakeGardenWorkerDB) CountContainersOnWorker(workerName string) (int, error) {
+	fake.countContainersOnWorkerMutex.Lock()
+	fake.countContainersOnWorkerArgsForCall = append(fake.countContainersOnWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("CountContainersOnWorker", []interface{}{workerName})
+	fake.countContainersOnWorkerMutex.Unlock()
+	if fake.CountContainersOnWorkerStub != nil {
+		return fake.CountContainersOnWorkerStub(workerName)
+	} else {
+		return fake.countContainersOnWorkerReturns.result1, fake.countContainersOnWorkerReturns.result2
+	}
+}
+
+func (fake *FakeGardenWorkerDB) CountContainersOnWorkerCallCount() int {
+	fake.countContainersOnWorkerMutex.RLock()
+	defer fake.countContainersOnWorkerMutex.RUnlock()
+	return len(fake.countContainersOnWorkerArgsForCall)
+}
+
+func (fake *FakeGardenWorkerDB) CountContainersOnWorkerArgsForCall(i int) string {
+	fake.countContainersOnWorkerMutex.RLock()
+	defer fake.countContainersOnWorkerMutex.RUnlock()
+	return fake.countContainersOnWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeGardenWorkerDB) CountContainersOnWorkerReturns(result1 int, result2 error) {
+	fake.CountContainersOnWorkerStub = nil
+	fake.countContainersOnWorkerReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeGardenWorkerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -353,6 +396,8 @@ func (fake *FakeGardenWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.acquireVolumeCreatingLockMutex.RUnlock()
 	fake.acquireContainerCreatingLockMutex.RLock()
 	defer fake.acquireContainerCreatingLockMutex.RUnlock()
+	fake.countContainersOnWorkerMutex.RLock()
+	defer fake.countContainersOnWorkerMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -4,6 +4,7 @@ package workerfakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/worker"
 )
@@ -23,8 +24,11 @@ type FakeImageFetchingDelegate struct {
 	imageVersionDeterminedReturns struct {
 		result1 error
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	WaitingForWorkerStub        func()
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct{}
+	invocations                 map[string][][]interface{}
+	invocationsMutex            sync.RWMutex
 }
 
 func (fake *FakeImageFetchingDelegate) Stderr() io.Writer {
@@ -85,6 +89,98 @@ func (fake *FakeImageFetchingDelegate) ImageVersionDeterminedReturns(result1 err
 	}{result1}
 }
 
+func (fake *FakeImageFetchingDelegate) WaitingForWorker() {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct{}{})
+	fake.recordInvocation("WaitingForWorker", []interface{}{})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub()
+	}
+}
+
+func (fake *FakeImageFetchingDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchTimeouts() worker.ImageFetchTimeouts {
+	fake.imageFetchTimeoutsMutex.Lock()
+	fake.imageFetchTimeoutsArgsForCall = append(fake.imageFetchTimeoutsArgsForCall, struct{}{})
+	fake.recordInvocation("ImageFetchTimeouts", []interface{}{})
+	fake.imageFetchTimeoutsMutex.Unlock()
+	if fake.ImageFetchTimeoutsStub != nil {
+		return fake.ImageFetchTimeoutsStub()
+	} else {
+		return fake.imageFetchTimeoutsReturns.result1
+	}
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchTimeoutsCallCount() int {
+	fake.imageFetchTimeoutsMutex.RLock()
+	defer fake.imageFetchTimeoutsMutex.RUnlock()
+	return len(fake.imageFetchTimeoutsArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchTimeoutsReturns(result1 worker.ImageFetchTimeouts) {
+	fake.ImageFetchTimeoutsStub = nil
+	fake.imageFetchTimeoutsReturns = struct {
+		result1 worker.ImageFetchTimeouts
+	}{result1}
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchPhaseFinished(phase string, start time.Time, end time.Time) {
+	fake.imageFetchPhaseFinishedMutex.Lock()
+	fake.imageFetchPhaseFinishedArgsForCall = append(fake.imageFetchPhaseFinishedArgsForCall, struct {
+		phase string
+		start time.Time
+		end   time.Time
+	}{phase, start, end})
+	fake.recordInvocation("ImageFetchPhaseFinished", []interface{}{phase, start, end})
+	fake.imageFetchPhaseFinishedMutex.Unlock()
+	if fake.ImageFetchPhaseFinishedStub != nil {
+		fake.ImageFetchPhaseFinishedStub(phase, start, end)
+	}
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchPhaseFinishedCallCount() int {
+	fake.imageFetchPhaseFinishedMutex.RLock()
+	defer fake.imageFetchPhaseFinishedMutex.RUnlock()
+	return len(fake.imageFetchPhaseFinishedArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchPhaseFinishedArgsForCall(i int) (string, time.Time, time.Time) {
+	fake.imageFetchPhaseFinishedMutex.RLock()
+	defer fake.imageFetchPhaseFinishedMutex.RUnlock()
+	return fake.imageFetchPhaseFinishedArgsForCall[i].phase, fake.imageFetchPhaseFinishedArgsForCall[i].start, fake.imageFetchPhaseFinishedArgsForCall[i].end
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchTimedOut(phase string, timeout time.Duration) {
+	fake.imageFetchTimedOutMutex.Lock()
+	fake.imageFetchTimedOutArgsForCall = append(fake.imageFetchTimedOutArgsForCall, struct {
+		phase   string
+		timeout time.Duration
+	}{phase, timeout})
+	fake.recordInvocation("ImageFetchTimedOut", []interface{}{phase, timeout})
+	fake.imageFetchTimedOutMutex.Unlock()
+	if fake.ImageFetchTimedOutStub != nil {
+		fake.ImageFetchTimedOutStub(phase, timeout)
+	}
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchTimedOutCallCount() int {
+	fake.imageFetchTimedOutMutex.RLock()
+	defer fake.imageFetchTimedOutMutex.RUnlock()
+	return len(fake.imageFetchTimedOutArgsForCall)
+}
+
+func (fake *FakeImageFetchingDelegate) ImageFetchTimedOutArgsForCall(i int) (string, time.Duration) {
+	fake.imageFetchTimedOutMutex.RLock()
+	defer fake.imageFetchTimedOutMutex.RUnlock()
+	return fake.imageFetchTimedOutArgsForCall[i].phase, fake.imageFetchTimedOutArgsForCall[i].timeout
+}
+
 func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -92,6 +188,14 @@ func (fake *FakeImageFetchingDelegate) Invocations() map[string][][]interface{}
 	defer fake.stderrMutex.RUnlock()
 	fake.imageVersionDeterminedMutex.RLock()
 	defer fake.imageVersionDeterminedMutex.RUnlock()
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	fake.imageFetchTimeoutsMutex.RLock()
+	defer fake.imageFetchTimeoutsMutex.RUnlock()
+	fake.imageFetchPhaseFinishedMutex.RLock()
+	defer fake.imageFetchPhaseFinishedMutex.RUnlock()
+	fake.imageFetchTimedOutMutex.RLock()
+	defer fake.imageFetchTimedOutMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,204 @@
+// This file was generated by counterfeiter
+package workerfakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/concourse/atc/worker"
+)
+
+type FakeVersionedArtifactSource struct {
+	StreamToStub        func(worker.ArtifactDestination) error
+	streamToMutex       sync.RWMutex
+	streamToArgsForCall []struct {
+		arg1 worker.ArtifactDestination
+	}
+	streamToReturns struct {
+		result1 error
+	}
+	StreamFileStub        func(path string) (io.ReadCloser, error)
+	streamFileMutex       sync.RWMutex
+	streamFileArgsForCall []struct {
+		path string
+	}
+	streamFileReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	VolumeOnStub        func(worker.Worker) (worker.Volume, bool, error)
+	volumeOnMutex       sync.RWMutex
+	volumeOnArgsForCall []struct {
+		arg1 worker.Worker
+	}
+	volumeOnReturns struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}
+	ArtifactVersionStub        func() (string, bool)
+	artifactVersionMutex       sync.RWMutex
+	artifactVersionArgsForCall []struct{}
+	artifactVersionReturns     struct {
+		result1 string
+		result2 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeVersionedArtifactSource) StreamTo(arg1 worker.ArtifactDestination) error {
+	fake.streamToMutex.Lock()
+	fake.streamToArgsForCall = append(fake.streamToArgsForCall, struct {
+		arg1 worker.ArtifactDestination
+	}{arg1})
+	fake.recordInvocation("StreamTo", []interface{}{arg1})
+	fake.streamToMutex.Unlock()
+	if fake.StreamToStub != nil {
+		return fake.StreamToStub(arg1)
+	} else {
+		return fake.streamToReturns.result1
+	}
+}
+
+func (fake *FakeVersionedArtifactSource) StreamToCallCount() int {
+	fake.streamToMutex.RLock()
+	defer fake.streamToMutex.RUnlock()
+	return len(fake.streamToArgsForCall)
+}
+
+func (fake *FakeVersionedArtifactSource) StreamToArgsForCall(i int) worker.ArtifactDestination {
+	fake.streamToMutex.RLock()
+	defer fake.streamToMutex.RUnlock()
+	return fake.streamToArgsForCall[i].arg1
+}
+
+func (fake *FakeVersionedArtifactSource) StreamToReturns(result1 error) {
+	fake.StreamToStub = nil
+	fake.streamToReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVersionedArtifactSource) StreamFile(path string) (io.ReadCloser, error) {
+	fake.streamFileMutex.Lock()
+	fake.streamFileArgsForCall = append(fake.streamFileArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("StreamFile", []interface{}{path})
+	fake.streamFileMutex.Unlock()
+	if fake.StreamFileStub != nil {
+		return fake.StreamFileStub(path)
+	} else {
+		return fake.streamFileReturns.result1, fake.streamFileReturns.result2
+	}
+}
+
+func (fake *FakeVersionedArtifactSource) StreamFileCallCount() int {
+	fake.streamFileMutex.RLock()
+	defer fake.streamFileMutex.RUnlock()
+	return len(fake.streamFileArgsForCall)
+}
+
+func (fake *FakeVersionedArtifactSource) StreamFileArgsForCall(i int) string {
+	fake.streamFileMutex.RLock()
+	defer fake.streamFileMutex.RUnlock()
+	return fake.streamFileArgsForCall[i].path
+}
+
+func (fake *FakeVersionedArtifactSource) StreamFileReturns(result1 io.ReadCloser, result2 error) {
+	fake.StreamFileStub = nil
+	fake.streamFileReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVersionedArtifactSource) VolumeOn(arg1 worker.Worker) (worker.Volume, bool, error) {
+	fake.volumeOnMutex.Lock()
+	fake.volumeOnArgsForCall = append(fake.volumeOnArgsForCall, struct {
+		arg1 worker.Worker
+	}{arg1})
+	fake.recordInvocation("VolumeOn", []interface{}{arg1})
+	fake.volumeOnMutex.Unlock()
+	if fake.VolumeOnStub != nil {
+		return fake.VolumeOnStub(arg1)
+	} else {
+		return fake.volumeOnReturns.result1, fake.volumeOnReturns.result2, fake.volumeOnReturns.result3
+	}
+}
+
+func (fake *FakeVersionedArtifactSource) VolumeOnCallCount() int {
+	fake.volumeOnMutex.RLock()
+	defer fake.volumeOnMutex.RUnlock()
+	return len(fake.volumeOnArgsForCall)
+}
+
+func (fake *FakeVersionedArtifactSource) VolumeOnArgsForCall(i int) worker.Worker {
+	fake.volumeOnMutex.RLock()
+	defer fake.volumeOnMutex.RUnlock()
+	return fake.volumeOnArgsForCall[i].arg1
+}
+
+func (fake *FakeVersionedArtifactSource) VolumeOnReturns(result1 worker.Volume, result2 bool, result3 error) {
+	fake.VolumeOnStub = nil
+	fake.volumeOnReturns = struct {
+		result1 worker.Volume
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVersionedArtifactSource) ArtifactVersion() (string, bool) {
+	fake.artifactVersionMutex.Lock()
+	fake.artifactVersionArgsForCall = append(fake.artifactVersionArgsForCall, struct{}{})
+	fake.recordInvocation("ArtifactVersion", []interface{}{})
+	fake.artifactVersionMutex.Unlock()
+	if fake.ArtifactVersionStub != nil {
+		return fake.ArtifactVersionStub()
+	} else {
+		return fake.artifactVersionReturns.result1, fake.artifactVersionReturns.result2
+	}
+}
+
+func (fake *FakeVersionedArtifactSource) ArtifactVersionCallCount() int {
+	fake.artifactVersionMutex.RLock()
+	defer fake.artifactVersionMutex.RUnlock()
+	return len(fake.artifactVersionArgsForCall)
+}
+
+func (fake *FakeVersionedArtifactSource) ArtifactVersionReturns(result1 string, result2 bool) {
+	fake.ArtifactVersionStub = nil
+	fake.artifactVersionReturns = struct {
+		result1 string
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeVersionedArtifactSource) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.streamToMutex.RLock()
+	defer fake.streamToMutex.RUnlock()
+	fake.streamFileMutex.RLock()
+	defer fake.streamFileMutex.RUnlock()
+	fake.volumeOnMutex.RLock()
+	defer fake.volumeOnMutex.RUnlock()
+	fake.artifactVersionMutex.RLock()
+	defer fake.artifactVersionMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeVersionedArtifactSource) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ worker.VersionedArtifactSource = new(FakeVersionedArtifactSource)
@@ -68,6 +68,20 @@ type FakeVolumeClient struct {
 		result2 bool
 		result3 error
 	}
+	FindOrCreateVolumeForTaskCacheStub        func(lager.Logger, worker.VolumeSpec, dbng.CreatingContainer, int, dbng.WorkerTaskCache, string) (worker.Volume, error)
+	findOrCreateVolumeForTaskCacheMutex       sync.RWMutex
+	findOrCreateVolumeForTaskCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 dbng.CreatingContainer
+		arg4 int
+		arg5 dbng.WorkerTaskCache
+		arg6 string
+	}
+	findOrCreateVolumeForTaskCacheReturns struct {
+		result1 worker.Volume
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -255,6 +269,45 @@ func (fake *FakeVolumeClient) LookupVolumeReturns(result1 worker.Volume, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeClient) FindOrCreateVolumeForTaskCache(arg1 lager.Logger, arg2 worker.VolumeSpec, arg3 dbng.CreatingContainer, arg4 int, arg5 dbng.WorkerTaskCache, arg6 string) (worker.Volume, error) {
+	fake.findOrCreateVolumeForTaskCacheMutex.Lock()
+	fake.findOrCreateVolumeForTaskCacheArgsForCall = append(fake.findOrCreateVolumeForTaskCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.VolumeSpec
+		arg3 dbng.CreatingContainer
+		arg4 int
+		arg5 dbng.WorkerTaskCache
+		arg6 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("FindOrCreateVolumeForTaskCache", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.findOrCreateVolumeForTaskCacheMutex.Unlock()
+	if fake.FindOrCreateVolumeForTaskCacheStub != nil {
+		return fake.FindOrCreateVolumeForTaskCacheStub(arg1, arg2, arg3, arg4, arg5, arg6)
+	} else {
+		return fake.findOrCreateVolumeForTaskCacheReturns.result1, fake.findOrCreateVolumeForTaskCacheReturns.result2
+	}
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForTaskCacheCallCount() int {
+	fake.findOrCreateVolumeForTaskCacheMutex.RLock()
+	defer fake.findOrCreateVolumeForTaskCacheMutex.RUnlock()
+	return len(fake.findOrCreateVolumeForTaskCacheArgsForCall)
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForTaskCacheArgsForCall(i int) (lager.Logger, worker.VolumeSpec, dbng.CreatingContainer, int, dbng.WorkerTaskCache, string) {
+	fake.findOrCreateVolumeForTaskCacheMutex.RLock()
+	defer fake.findOrCreateVolumeForTaskCacheMutex.RUnlock()
+	return fake.findOrCreateVolumeForTaskCacheArgsForCall[i].arg1, fake.findOrCreateVolumeForTaskCacheArgsForCall[i].arg2, fake.findOrCreateVolumeForTaskCacheArgsForCall[i].arg3, fake.findOrCreateVolumeForTaskCacheArgsForCall[i].arg4, fake.findOrCreateVolumeForTaskCacheArgsForCall[i].arg5, fake.findOrCreateVolumeForTaskCacheArgsForCall[i].arg6
+}
+
+func (fake *FakeVolumeClient) FindOrCreateVolumeForTaskCacheReturns(result1 worker.Volume, result2 error) {
+	fake.FindOrCreateVolumeForTaskCacheStub = nil
+	fake.findOrCreateVolumeForTaskCacheReturns = struct {
+		result1 worker.Volume
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -268,6 +321,8 @@ func (fake *FakeVolumeClient) Invocations() map[string][][]interface{} {
 	defer fake.findInitializedVolumeForResourceCacheMutex.RUnlock()
 	fake.lookupVolumeMutex.RLock()
 	defer fake.lookupVolumeMutex.RUnlock()
+	fake.findOrCreateVolumeForTaskCacheMutex.RLock()
+	defer fake.findOrCreateVolumeForTaskCacheMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -210,12 +210,6 @@ type FakeWorker struct {
 		result1 worker.Worker
 		result2 error
 	}
-	ActiveContainersStub        func() int
-	activeContainersMutex       sync.RWMutex
-	activeContainersArgsForCall []struct{}
-	activeContainersReturns     struct {
-		result1 int
-	}
 	DescriptionStub        func() string
 	descriptionMutex       sync.RWMutex
 	descriptionArgsForCall []struct{}
@@ -258,6 +252,38 @@ type FakeWorker struct {
 	isOwnedByTeamReturns     struct {
 		result1 bool
 	}
+	AwaitAllSatisfyingStub        func(lager.Logger, <-chan os.Signal, worker.ImageFetchingDelegate, worker.WorkerSpec, atc.ResourceTypes) ([]worker.Worker, error)
+	awaitAllSatisfyingMutex       sync.RWMutex
+	awaitAllSatisfyingArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 <-chan os.Signal
+		arg3 worker.ImageFetchingDelegate
+		arg4 worker.WorkerSpec
+		arg5 atc.ResourceTypes
+	}
+	awaitAllSatisfyingReturns struct {
+		result1 []worker.Worker
+		result2 error
+	}
+	MaxContainersStub        func() int
+	maxContainersMutex       sync.RWMutex
+	maxContainersArgsForCall []struct{}
+	maxContainersReturns     struct {
+		result1 int
+	}
+	IsDrainingStub        func() bool
+	isDrainingMutex       sync.RWMutex
+	isDrainingArgsForCall []struct{}
+	isDrainingReturns     struct {
+		result1 bool
+	}
+	ActiveContainersStub        func() (int, error)
+	activeContainersMutex       sync.RWMutex
+	activeContainersArgsForCall []struct{}
+	activeContainersReturns     struct {
+		result1 int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -852,31 +878,6 @@ func (fake *FakeWorker) GetWorkerReturns(result1 worker.Worker, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeWorker) ActiveContainers() int {
-	fake.activeContainersMutex.Lock()
-	fake.activeContainersArgsForCall = append(fake.activeContainersArgsForCall, struct{}{})
-	fake.recordInvocation("ActiveContainers", []interface{}{})
-	fake.activeContainersMutex.Unlock()
-	if fake.ActiveContainersStub != nil {
-		return fake.ActiveContainersStub()
-	} else {
-		return fake.activeContainersReturns.result1
-	}
-}
-
-func (fake *FakeWorker) ActiveContainersCallCount() int {
-	fake.activeContainersMutex.RLock()
-	defer fake.activeContainersMutex.RUnlock()
-	return len(fake.activeContainersArgsForCall)
-}
-
-func (fake *FakeWorker) ActiveContainersReturns(result1 int) {
-	fake.ActiveContainersStub = nil
-	fake.activeContainersReturns = struct {
-		result1 int
-	}{result1}
-}
-
 func (fake *FakeWorker) Description() string {
 	fake.descriptionMutex.Lock()
 	fake.descriptionArgsForCall = append(fake.descriptionArgsForCall, struct{}{})
@@ -1052,6 +1053,120 @@ func (fake *FakeWorker) IsOwnedByTeamReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeWorker) AwaitAllSatisfying(arg1 lager.Logger, arg2 <-chan os.Signal, arg3 worker.ImageFetchingDelegate, arg4 worker.WorkerSpec, arg5 atc.ResourceTypes) ([]worker.Worker, error) {
+	fake.awaitAllSatisfyingMutex.Lock()
+	fake.awaitAllSatisfyingArgsForCall = append(fake.awaitAllSatisfyingArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 <-chan os.Signal
+		arg3 worker.ImageFetchingDelegate
+		arg4 worker.WorkerSpec
+		arg5 atc.ResourceTypes
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("AwaitAllSatisfying", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.awaitAllSatisfyingMutex.Unlock()
+	if fake.AwaitAllSatisfyingStub != nil {
+		return fake.AwaitAllSatisfyingStub(arg1, arg2, arg3, arg4, arg5)
+	} else {
+		return fake.awaitAllSatisfyingReturns.result1, fake.awaitAllSatisfyingReturns.result2
+	}
+}
+
+func (fake *FakeWorker) AwaitAllSatisfyingCallCount() int {
+	fake.awaitAllSatisfyingMutex.RLock()
+	defer fake.awaitAllSatisfyingMutex.RUnlock()
+	return len(fake.awaitAllSatisfyingArgsForCall)
+}
+
+func (fake *FakeWorker) AwaitAllSatisfyingArgsForCall(i int) (lager.Logger, <-chan os.Signal, worker.ImageFetchingDelegate, worker.WorkerSpec, atc.ResourceTypes) {
+	fake.awaitAllSatisfyingMutex.RLock()
+	defer fake.awaitAllSatisfyingMutex.RUnlock()
+	return fake.awaitAllSatisfyingArgsForCall[i].arg1, fake.awaitAllSatisfyingArgsForCall[i].arg2, fake.awaitAllSatisfyingArgsForCall[i].arg3, fake.awaitAllSatisfyingArgsForCall[i].arg4, fake.awaitAllSatisfyingArgsForCall[i].arg5
+}
+
+func (fake *FakeWorker) AwaitAllSatisfyingReturns(result1 []worker.Worker, result2 error) {
+	fake.AwaitAllSatisfyingStub = nil
+	fake.awaitAllSatisfyingReturns = struct {
+		result1 []worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) MaxContainers() int {
+	fake.maxContainersMutex.Lock()
+	fake.maxContainersArgsForCall = append(fake.maxContainersArgsForCall, struct{}{})
+	fake.recordInvocation("MaxContainers", []interface{}{})
+	fake.maxContainersMutex.Unlock()
+	if fake.MaxContainersStub != nil {
+		return fake.MaxContainersStub()
+	} else {
+		return fake.maxContainersReturns.result1
+	}
+}
+
+func (fake *FakeWorker) MaxContainersCallCount() int {
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
+	return len(fake.maxContainersArgsForCall)
+}
+
+func (fake *FakeWorker) MaxContainersReturns(result1 int) {
+	fake.MaxContainersStub = nil
+	fake.maxContainersReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) IsDraining() bool {
+	fake.isDrainingMutex.Lock()
+	fake.isDrainingArgsForCall = append(fake.isDrainingArgsForCall, struct{}{})
+	fake.recordInvocation("IsDraining", []interface{}{})
+	fake.isDrainingMutex.Unlock()
+	if fake.IsDrainingStub != nil {
+		return fake.IsDrainingStub()
+	} else {
+		return fake.isDrainingReturns.result1
+	}
+}
+
+func (fake *FakeWorker) IsDrainingCallCount() int {
+	fake.isDrainingMutex.RLock()
+	defer fake.isDrainingMutex.RUnlock()
+	return len(fake.isDrainingArgsForCall)
+}
+
+func (fake *FakeWorker) IsDrainingReturns(result1 bool) {
+	fake.IsDrainingStub = nil
+	fake.isDrainingReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) ActiveContainers() (int, error) {
+	fake.activeContainersMutex.Lock()
+	fake.activeContainersArgsForCall = append(fake.activeContainersArgsForCall, struct{}{})
+	fake.recordInvocation("ActiveContainers", []interface{}{})
+	fake.activeContainersMutex.Unlock()
+	if fake.ActiveContainersStub != nil {
+		return fake.ActiveContainersStub()
+	} else {
+		return fake.activeContainersReturns.result1, fake.activeContainersReturns.result2
+	}
+}
+
+func (fake *FakeWorker) ActiveContainersCallCount() int {
+	fake.activeContainersMutex.RLock()
+	defer fake.activeContainersMutex.RUnlock()
+	return len(fake.activeContainersArgsForCall)
+}
+
+func (fake *FakeWorker) ActiveContainersReturns(result1 int, result2 error) {
+	fake.ActiveContainersStub = nil
+	fake.activeContainersReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1087,8 +1202,6 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.runningWorkersMutex.RUnlock()
 	fake.getWorkerMutex.RLock()
 	defer fake.getWorkerMutex.RUnlock()
-	fake.activeContainersMutex.RLock()
-	defer fake.activeContainersMutex.RUnlock()
 	fake.descriptionMutex.RLock()
 	defer fake.descriptionMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -1103,6 +1216,14 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.uptimeMutex.RUnlock()
 	fake.isOwnedByTeamMutex.RLock()
 	defer fake.isOwnedByTeamMutex.RUnlock()
+	fake.awaitAllSatisfyingMutex.RLock()
+	defer fake.awaitAllSatisfyingMutex.RUnlock()
+	fake.maxContainersMutex.RLock()
+	defer fake.maxContainersMutex.RUnlock()
+	fake.isDrainingMutex.RLock()
+	defer fake.isDrainingMutex.RUnlock()
+	fake.activeContainersMutex.RLock()
+	defer fake.activeContainersMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -117,6 +117,15 @@ type FakeWorkerDB struct {
 		result2 bool
 		result3 error
 	}
+	CountContainersOnWorkerStub        func(workerName string) (int, error)
+	countContainersOnWorkerMutex       sync.RWMutex
+	countContainersOnWorkerArgsForCall []struct {
+		workerName string
+	}
+	countContainersOnWorkerReturns struct {
+		result1 int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -500,6 +509,40 @@ func (fake *FakeWorkerDB) AcquireContainerCreatingLockReturns(result1 lock.Lock,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeWorkerDB) CountContainersOnWorker(workerName string) (int, error) {
+	fake.countContainersOnWorkerMutex.Lock()
+	fake.countContainersOnWorkerArgsForCall = append(fake.countContainersOnWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("CountContainersOnWorker", []interface{}{workerName})
+	fake.countContainersOnWorkerMutex.Unlock()
+	if fake.CountContainersOnWorkerStub != nil {
+		return fake.CountContainersOnWorkerStub(workerName)
+	} else {
+		return fake.countContainersOnWorkerReturns.result1, fake.countContainersOnWorkerReturns.result2
+	}
+}
+
+func (fake *FakeWorkerDB) CountContainersOnWorkerCallCount() int {
+	fake.countContainersOnWorkerMutex.RLock()
+	defer fake.countContainersOnWorkerMutex.RUnlock()
+	return len(fake.countContainersOnWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) CountContainersOnWorkerArgsForCall(i int) string {
+	fake.countContainersOnWorkerMutex.RLock()
+	defer fake.countContainersOnWorkerMutex.RUnlock()
+	return fake.countContainersOnWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeWorkerDB) CountContainersOnWorkerReturns(result1 int, result2 error) {
+	fake.CountContainersOnWorkerStub = nil
+	fake.countContainersOnWorkerReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -525,6 +568,8 @@ func (fake *FakeWorkerDB) Invocations() map[string][][]interface{} {
 	defer fake.acquireVolumeCreatingLockMutex.RUnlock()
 	fake.acquireContainerCreatingLockMutex.RLock()
 	defer fake.acquireContainerCreatingLockMutex.RUnlock()
+	fake.countContainersOnWorkerMutex.RLock()
+	defer fake.countContainersOnWorkerMutex.RUnlock()
 	return fake.invocations
 }
 
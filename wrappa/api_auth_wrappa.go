@@ -63,17 +63,26 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 		// pipeline and job are public or authorized
 		case atc.GetBuildPreparation,
-			atc.BuildEvents:
+			atc.BuildEvents,
+			atc.ListBuildArtifacts,
+			atc.GetBuildArtifact,
+			atc.GetBuildTimings,
+			atc.GetBuildLog,
+			atc.ListBuildComments,
+			atc.ListBuildAnnotations,
+			atc.ListBuildTestResults:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 		// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.CreateBuildComment:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
 		case atc.PruneWorker,
 			atc.LandWorker,
-			atc.RetireWorker:
+			atc.RetireWorker,
+			atc.ListWorkerPins:
 			newHandler = wrappa.checkWorkerTeamAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// pipeline is public or authorized
@@ -83,6 +92,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListJobs,
 			atc.GetJob,
 			atc.ListJobBuilds,
+			atc.SearchJobBuildLogs,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
@@ -106,7 +116,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DestroyTeam,
 			atc.WritePipe,
 			atc.ListVolumes,
-			atc.GetUser:
+			atc.GetUser,
+			atc.StreamEvents:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		case atc.GetLogLevel,
@@ -122,6 +133,9 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetConfig,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.ExplainJob,
+			atc.GetJobTimings,
+			atc.GetJobFlakyTests,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.PausePipeline,
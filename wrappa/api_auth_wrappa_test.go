@@ -183,16 +183,25 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetBuildPlan:   doesNotCheckIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
 
 				// authorized or public pipeline and public job
-				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
-				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.BuildEvents:          checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
+				atc.GetBuildPreparation:  checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.ListBuildArtifacts:   checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
+				atc.GetBuildArtifact:     checksIfPrivateJob(inputHandlers[atc.GetBuildArtifact]),
+				atc.GetBuildTimings:      checksIfPrivateJob(inputHandlers[atc.GetBuildTimings]),
+				atc.GetBuildLog:          checksIfPrivateJob(inputHandlers[atc.GetBuildLog]),
+				atc.ListBuildComments:    checksIfPrivateJob(inputHandlers[atc.ListBuildComments]),
+				atc.ListBuildAnnotations: checksIfPrivateJob(inputHandlers[atc.ListBuildAnnotations]),
+				atc.ListBuildTestResults: checksIfPrivateJob(inputHandlers[atc.ListBuildTestResults]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild:         checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.CreateBuildComment: checkWritePermissionForBuild(inputHandlers[atc.CreateBuildComment]),
 
 				// resource belongs to authorized team
-				atc.PruneWorker:  checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
-				atc.LandWorker:   checkTeamAccessForWorker(inputHandlers[atc.LandWorker]),
-				atc.RetireWorker: checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
+				atc.PruneWorker:    checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
+				atc.LandWorker:     checkTeamAccessForWorker(inputHandlers[atc.LandWorker]),
+				atc.RetireWorker:   checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
+				atc.ListWorkerPins: checkTeamAccessForWorker(inputHandlers[atc.ListWorkerPins]),
 
 				// belongs to public pipeline or authorized
 				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
@@ -201,6 +210,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
 				atc.GetJob:                        openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJob]),
 				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobBuilds]),
+				atc.SearchJobBuildLogs:            openForPublicPipelineOrAuthorized(inputHandlers[atc.SearchJobBuildLogs]),
 				atc.GetResource:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResource]),
 				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
 				atc.ListBuildsWithVersionAsOutput: openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsOutput]),
@@ -226,6 +236,8 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.WritePipe:   authenticated(inputHandlers[atc.WritePipe]),
 				atc.GetUser:     authenticated(inputHandlers[atc.GetUser]),
 
+				atc.StreamEvents: authenticated(inputHandlers[atc.StreamEvents]),
+
 				// authenticated and is admin
 				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
 				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
@@ -239,6 +251,9 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
 				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
 				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
+				atc.ExplainJob:             authorized(inputHandlers[atc.ExplainJob]),
+				atc.GetJobTimings:          authorized(inputHandlers[atc.GetJobTimings]),
+				atc.GetJobFlakyTests:       authorized(inputHandlers[atc.GetJobFlakyTests]),
 				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
 				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
 				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),